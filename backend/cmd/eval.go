@@ -6,6 +6,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/goda6565/ai-consultant/backend/di"
+	llmClient "github.com/goda6565/ai-consultant/backend/internal/infrastructure/llm"
 )
 
 func newProposalJobEvalCommand() *cobra.Command {
@@ -13,18 +14,26 @@ func newProposalJobEvalCommand() *cobra.Command {
 		Use:   "proposal-job-eval",
 		Short: "Proposal Job Eval",
 	}
-	cmd.AddCommand(&cobra.Command{
+	var cassetteMode string
+	var cassetteDir string
+	runCmd := &cobra.Command{
 		Use:   "run",
 		Short: "Run the proposal job eval",
 		Run: func(cmd *cobra.Command, args []string) {
 			ctx := context.Background()
-			eval, cleanup, err := di.InitProposalJobEval(ctx)
+			eval, cleanup, err := di.InitProposalJobEval(ctx, llmClient.CassetteConfig{
+				Mode: llmClient.CassetteMode(cassetteMode),
+				Dir:  cassetteDir,
+			})
 			if err != nil {
 				panic(err)
 			}
 			defer cleanup()
 			eval.Evaluate(ctx)
 		},
-	})
+	}
+	runCmd.Flags().StringVar(&cassetteMode, "llm-cassette", string(llmClient.CassetteModeOff), "LLM cassette mode (off, record, replay)")
+	runCmd.Flags().StringVar(&cassetteDir, "llm-cassette-dir", "testdata/cassettes/proposal-job", "directory of the LLM cassette")
+	cmd.AddCommand(runCmd)
 	return cmd
 }
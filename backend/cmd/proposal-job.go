@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/goda6565/ai-consultant/backend/di"
+	llmClient "github.com/goda6565/ai-consultant/backend/internal/infrastructure/llm"
 	"github.com/spf13/cobra"
 )
 
@@ -28,5 +29,22 @@ func newProposalJobCommand() *cobra.Command {
 			job.Run(ctx)
 		},
 	})
+	var problemID string
+	replayCmd := &cobra.Command{
+		Use:   "replay",
+		Short: "Replay a past run of the proposal job from its trace",
+		Run: func(cmd *cobra.Command, args []string) {
+			ctx := context.Background()
+			job, cleanup, err := di.InitProposalJobReplay(ctx, llmClient.ReplayConfig{ProblemID: problemID})
+			if err != nil {
+				panic(err)
+			}
+			defer cleanup()
+			job.Run(ctx)
+		},
+	}
+	replayCmd.Flags().StringVar(&problemID, "problem-id", "", "problem id of the run to replay")
+	_ = replayCmd.MarkFlagRequired("problem-id")
+	cmd.AddCommand(replayCmd)
 	return cmd
 }
@@ -19,6 +19,9 @@ import (
 	problemService "github.com/goda6565/ai-consultant/backend/internal/domain/problem/service"
 	problemFieldService "github.com/goda6565/ai-consultant/backend/internal/domain/problem_field/service"
 	promptService "github.com/goda6565/ai-consultant/backend/internal/domain/prompt/service"
+	searchService "github.com/goda6565/ai-consultant/backend/internal/domain/search/service"
+	traceService "github.com/goda6565/ai-consultant/backend/internal/domain/trace/service"
+	usageService "github.com/goda6565/ai-consultant/backend/internal/domain/usage/service"
 	evaluate "github.com/goda6565/ai-consultant/backend/internal/evaluate"
 	proposaljobEval "github.com/goda6565/ai-consultant/backend/internal/evaluate/proposal-job"
 	proposaljobMemory "github.com/goda6565/ai-consultant/backend/internal/evaluate/proposal-job/memory"
@@ -28,8 +31,11 @@ import (
 	cloudtasksClient "github.com/goda6565/ai-consultant/backend/internal/infrastructure/google/cloudtasks"
 	"github.com/goda6565/ai-consultant/backend/internal/infrastructure/google/database"
 	actionRepository "github.com/goda6565/ai-consultant/backend/internal/infrastructure/google/database/repository/action"
+	approvalRepository "github.com/goda6565/ai-consultant/backend/internal/infrastructure/google/database/repository/approval"
+	checkpointRepository "github.com/goda6565/ai-consultant/backend/internal/infrastructure/google/database/repository/checkpoint"
 	chunkRepository "github.com/goda6565/ai-consultant/backend/internal/infrastructure/google/database/repository/chunk"
 	documentRepository "github.com/goda6565/ai-consultant/backend/internal/infrastructure/google/database/repository/document"
+	goalRevisionRepository "github.com/goda6565/ai-consultant/backend/internal/infrastructure/google/database/repository/goal_revision"
 	hearingRepository "github.com/goda6565/ai-consultant/backend/internal/infrastructure/google/database/repository/hearing"
 	hearingMapRepository "github.com/goda6565/ai-consultant/backend/internal/infrastructure/google/database/repository/hearing_map"
 	hearingMessageRepository "github.com/goda6565/ai-consultant/backend/internal/infrastructure/google/database/repository/hearing_message"
@@ -37,17 +43,20 @@ import (
 	problemRepository "github.com/goda6565/ai-consultant/backend/internal/infrastructure/google/database/repository/problem"
 	problemFieldRepository "github.com/goda6565/ai-consultant/backend/internal/infrastructure/google/database/repository/problem_field"
 	reportRepository "github.com/goda6565/ai-consultant/backend/internal/infrastructure/google/database/repository/report"
+	traceSpanRepository "github.com/goda6565/ai-consultant/backend/internal/infrastructure/google/database/repository/trace_span"
+	usageRepository "github.com/goda6565/ai-consultant/backend/internal/infrastructure/google/database/repository/usage"
 	documentSearchClient "github.com/goda6565/ai-consultant/backend/internal/infrastructure/google/database/search"
 	"github.com/goda6565/ai-consultant/backend/internal/infrastructure/google/database/transaction"
 	"github.com/goda6565/ai-consultant/backend/internal/infrastructure/google/firebase"
-	"github.com/goda6565/ai-consultant/backend/internal/infrastructure/google/gemini"
 	googleSearchClient "github.com/goda6565/ai-consultant/backend/internal/infrastructure/google/google_search"
 	"github.com/goda6565/ai-consultant/backend/internal/infrastructure/google/ocr"
 	storageClient "github.com/goda6565/ai-consultant/backend/internal/infrastructure/google/storage"
+	datasetClient "github.com/goda6565/ai-consultant/backend/internal/infrastructure/google/storage/dataset"
 	baseServer "github.com/goda6565/ai-consultant/backend/internal/infrastructure/http/echo"
 	adminRouter "github.com/goda6565/ai-consultant/backend/internal/infrastructure/http/echo/admin"
 	adminHandler "github.com/goda6565/ai-consultant/backend/internal/infrastructure/http/echo/admin/handler"
 	actionHandler "github.com/goda6565/ai-consultant/backend/internal/infrastructure/http/echo/admin/handler/action"
+	approvalHandler "github.com/goda6565/ai-consultant/backend/internal/infrastructure/http/echo/admin/handler/approval"
 	documentHandler "github.com/goda6565/ai-consultant/backend/internal/infrastructure/http/echo/admin/handler/document"
 	hearingHandlerAdmin "github.com/goda6565/ai-consultant/backend/internal/infrastructure/http/echo/admin/handler/hearing"
 	hearingMapHandler "github.com/goda6565/ai-consultant/backend/internal/infrastructure/http/echo/admin/handler/hearing_map"
@@ -55,6 +64,8 @@ import (
 	jobConfigHandler "github.com/goda6565/ai-consultant/backend/internal/infrastructure/http/echo/admin/handler/job_config"
 	problemHandler "github.com/goda6565/ai-consultant/backend/internal/infrastructure/http/echo/admin/handler/problem"
 	reportHandler "github.com/goda6565/ai-consultant/backend/internal/infrastructure/http/echo/admin/handler/report"
+	traceHandler "github.com/goda6565/ai-consultant/backend/internal/infrastructure/http/echo/admin/handler/trace"
+	usageHandler "github.com/goda6565/ai-consultant/backend/internal/infrastructure/http/echo/admin/handler/usage"
 	agentRouter "github.com/goda6565/ai-consultant/backend/internal/infrastructure/http/echo/agent"
 	agentHandler "github.com/goda6565/ai-consultant/backend/internal/infrastructure/http/echo/agent/handler"
 	hearingHandlerAgent "github.com/goda6565/ai-consultant/backend/internal/infrastructure/http/echo/agent/handler/hearing"
@@ -62,10 +73,14 @@ import (
 	vectorHandler "github.com/goda6565/ai-consultant/backend/internal/infrastructure/http/echo/vector/handler"
 	baseJob "github.com/goda6565/ai-consultant/backend/internal/infrastructure/job"
 	proposalJob "github.com/goda6565/ai-consultant/backend/internal/infrastructure/job/proposal"
+	llmClient "github.com/goda6565/ai-consultant/backend/internal/infrastructure/llm"
 	redis "github.com/goda6565/ai-consultant/backend/internal/infrastructure/upstash/redis"
 	eventRepository "github.com/goda6565/ai-consultant/backend/internal/infrastructure/upstash/redis/repository/event"
 	zap "github.com/goda6565/ai-consultant/backend/internal/infrastructure/zap"
+	"github.com/goda6565/ai-consultant/backend/internal/pkg/scraper"
+	proposaljobReplay "github.com/goda6565/ai-consultant/backend/internal/replay/proposal-job"
 	actionUseCase "github.com/goda6565/ai-consultant/backend/internal/usecase/action"
+	approvalUseCase "github.com/goda6565/ai-consultant/backend/internal/usecase/approval"
 	chunkUseCase "github.com/goda6565/ai-consultant/backend/internal/usecase/chunk"
 	documentUseCase "github.com/goda6565/ai-consultant/backend/internal/usecase/document"
 	eventUseCase "github.com/goda6565/ai-consultant/backend/internal/usecase/event"
@@ -76,6 +91,8 @@ import (
 	problemUseCase "github.com/goda6565/ai-consultant/backend/internal/usecase/problem"
 	proposalUseCase "github.com/goda6565/ai-consultant/backend/internal/usecase/proposal"
 	reportUseCase "github.com/goda6565/ai-consultant/backend/internal/usecase/report"
+	traceUseCase "github.com/goda6565/ai-consultant/backend/internal/usecase/trace"
+	usageUseCase "github.com/goda6565/ai-consultant/backend/internal/usecase/usage"
 )
 
 func InitAdminApplication(ctx context.Context) (*App, func(), error) {
@@ -84,7 +101,7 @@ func InitAdminApplication(ctx context.Context) (*App, func(), error) {
 		zap.Set,
 		firebase.Set,
 		redis.Set,
-		gemini.Set,
+		llmClient.Set,
 		database.Set,
 		chunkRepository.Set,
 		documentRepository.Set,
@@ -97,13 +114,19 @@ func InitAdminApplication(ctx context.Context) (*App, func(), error) {
 		actionRepository.Set,
 		jobConfigRepository.Set,
 		hearingMapRepository.Set,
+		usageRepository.Set,
+		approvalRepository.Set,
+		checkpointRepository.Set,
+		traceSpanRepository.Set,
 		transaction.Set,
 		storageClient.Set,
 		cloudtasksClient.Set,
+		jobClient.Set,
 		documentService.Set,
 		problemService.Set,
 		problemFieldService.Set,
 		hearingService.Set,
+		usageService.Set,
 		documentUseCase.Set,
 		problemUseCase.Set,
 		hearingUseCase.Set,
@@ -113,6 +136,9 @@ func InitAdminApplication(ctx context.Context) (*App, func(), error) {
 		actionUseCase.Set,
 		jobConfigUseCase.Set,
 		hearingMapUseCase.Set,
+		usageUseCase.Set,
+		approvalUseCase.Set,
+		traceUseCase.Set,
 		actionHandler.Set,
 		reportHandler.Set,
 		documentHandler.Set,
@@ -121,6 +147,9 @@ func InitAdminApplication(ctx context.Context) (*App, func(), error) {
 		hearingMessageHandler.Set,
 		jobConfigHandler.Set,
 		hearingMapHandler.Set,
+		usageHandler.Set,
+		approvalHandler.Set,
+		traceHandler.Set,
 		adminHandler.Set,
 		adminRouter.Set,
 		baseServer.Set,
@@ -132,12 +161,13 @@ func InitVectorApplication(ctx context.Context) (*App, func(), error) {
 	panic(wire.Build(
 		environment.Set,
 		zap.Set,
-		gemini.Set,
+		llmClient.Set,
 		ocr.Set,
 		database.Set,
 		transaction.Set,
 		chunkRepository.Set,
 		documentRepository.Set,
+		usageRepository.Set,
 		storageClient.Set,
 		chunkService.Set,
 		chunkUseCase.Set,
@@ -153,8 +183,9 @@ func InitAgentApplication(ctx context.Context) (*App, func(), error) {
 		environment.Set,
 		zap.Set,
 		firebase.Set,
-		gemini.Set,
+		llmClient.Set,
 		database.Set,
+		redis.Set,
 		jobClient.Set,
 		transaction.Set,
 		documentRepository.Set,
@@ -166,6 +197,8 @@ func InitAgentApplication(ctx context.Context) (*App, func(), error) {
 		problemFieldRepository.Set,
 		reportRepository.Set,
 		actionRepository.Set,
+		usageRepository.Set,
+		eventRepository.Set,
 		problemFieldService.Set,
 		hearingService.Set,
 		hearingMapService.Set,
@@ -184,7 +217,7 @@ func InitProposalJob(ctx context.Context) (*Job, func(), error) {
 	panic(wire.Build(
 		environment.Set,
 		zap.Set,
-		gemini.Set,
+		llmClient.Set,
 		database.Set,
 		redis.Set,
 		problemRepository.Set,
@@ -195,12 +228,24 @@ func InitProposalJob(ctx context.Context) (*Job, func(), error) {
 		eventRepository.Set,
 		reportRepository.Set,
 		actionRepository.Set,
+		usageRepository.Set,
+		checkpointRepository.Set,
+		approvalRepository.Set,
+		goalRevisionRepository.Set,
+		traceSpanRepository.Set,
+		documentRepository.Set,
+		usageService.Set,
+		traceService.Set,
 		promptService.Set,
 		actionService.Set,
 		actionService.ActionFactorySet,
 		agentService.Set,
 		googleSearchClient.Set,
 		documentSearchClient.Set,
+		scraper.Set,
+		searchService.Set,
+		storageClient.Set,
+		datasetClient.Set,
 		tools.Set,
 		proposalUseCase.Set,
 		proposalJob.Set,
@@ -209,17 +254,50 @@ func InitProposalJob(ctx context.Context) (*Job, func(), error) {
 	))
 }
 
-func InitProposalJobEval(ctx context.Context) (*Eval, func(), error) {
+func InitProposalJobReplay(ctx context.Context, replayConfig llmClient.ReplayConfig) (*Job, func(), error) {
+	panic(wire.Build(
+		environment.Set,
+		zap.Set,
+		llmClient.ReplaySet,
+		database.Set,
+		problemRepository.Set,
+		problemFieldRepository.Set,
+		hearingRepository.Set,
+		hearingMessageRepository.Set,
+		jobConfigRepository.Set,
+		actionRepository.Set,
+		traceSpanRepository.Set,
+		documentRepository.Set,
+		proposaljobMemory.NewMemoryReportRepository,
+		proposaljobMemory.NewMemoryUsageRepository,
+		usageService.Set,
+		promptService.Set,
+		actionService.Set,
+		actionService.ActionFactorySet,
+		agentService.Set,
+		storageClient.Set,
+		datasetClient.Set,
+		tools.Set,
+		proposaljobReplay.Set,
+		baseJob.Set,
+		wire.Struct(new(Job), "*"),
+	))
+}
+
+func InitProposalJobEval(ctx context.Context, cassetteConfig llmClient.CassetteConfig) (*Eval, func(), error) {
 	panic(wire.Build(
 		environment.Set,
 		zap.Set,
-		gemini.Set,
+		llmClient.ClientSet,
 		proposaljobMemory.Set,
+		usageService.Set,
 		promptService.Set,
 		actionService.Set,
 		actionService.ActionFactorySet,
 		agentService.Set,
 		googleSearchClient.Set,
+		scraper.Set,
+		searchService.Set,
 		proposaljobMock.Set,
 		tools.Set,
 		proposaljobEval.Set,
@@ -8,19 +8,22 @@ package di
 
 import (
 	"context"
-	service10 "github.com/goda6565/ai-consultant/backend/internal/domain/action/service"
+	service11 "github.com/goda6565/ai-consultant/backend/internal/domain/action/service"
 	"github.com/goda6565/ai-consultant/backend/internal/domain/action/tools"
-	service8 "github.com/goda6565/ai-consultant/backend/internal/domain/agent/service"
-	service5 "github.com/goda6565/ai-consultant/backend/internal/domain/chunk/service"
+	service9 "github.com/goda6565/ai-consultant/backend/internal/domain/agent/service"
+	service6 "github.com/goda6565/ai-consultant/backend/internal/domain/chunk/service"
 	"github.com/goda6565/ai-consultant/backend/internal/domain/document/service"
 	service4 "github.com/goda6565/ai-consultant/backend/internal/domain/hearing/service"
-	service7 "github.com/goda6565/ai-consultant/backend/internal/domain/hearing_map/service"
-	service6 "github.com/goda6565/ai-consultant/backend/internal/domain/hearing_message/service"
+	service8 "github.com/goda6565/ai-consultant/backend/internal/domain/hearing_map/service"
+	service7 "github.com/goda6565/ai-consultant/backend/internal/domain/hearing_message/service"
 	service2 "github.com/goda6565/ai-consultant/backend/internal/domain/problem/service"
 	service3 "github.com/goda6565/ai-consultant/backend/internal/domain/problem_field/service"
-	service9 "github.com/goda6565/ai-consultant/backend/internal/domain/prompt/service"
+	service10 "github.com/goda6565/ai-consultant/backend/internal/domain/prompt/service"
+	service12 "github.com/goda6565/ai-consultant/backend/internal/domain/search/service"
+	service13 "github.com/goda6565/ai-consultant/backend/internal/domain/trace/service"
+	service5 "github.com/goda6565/ai-consultant/backend/internal/domain/usage/service"
 	"github.com/goda6565/ai-consultant/backend/internal/evaluate"
-	"github.com/goda6565/ai-consultant/backend/internal/evaluate/proposal-job"
+	proposaljob2 "github.com/goda6565/ai-consultant/backend/internal/evaluate/proposal-job"
 	"github.com/goda6565/ai-consultant/backend/internal/evaluate/proposal-job/llm-as-a-judge"
 	"github.com/goda6565/ai-consultant/backend/internal/evaluate/proposal-job/memory"
 	"github.com/goda6565/ai-consultant/backend/internal/evaluate/proposal-job/mock"
@@ -29,8 +32,11 @@ import (
 	"github.com/goda6565/ai-consultant/backend/internal/infrastructure/google/cloudtasks"
 	"github.com/goda6565/ai-consultant/backend/internal/infrastructure/google/database"
 	"github.com/goda6565/ai-consultant/backend/internal/infrastructure/google/database/repository/action"
+	"github.com/goda6565/ai-consultant/backend/internal/infrastructure/google/database/repository/approval"
+	"github.com/goda6565/ai-consultant/backend/internal/infrastructure/google/database/repository/checkpoint"
 	"github.com/goda6565/ai-consultant/backend/internal/infrastructure/google/database/repository/chunk"
 	"github.com/goda6565/ai-consultant/backend/internal/infrastructure/google/database/repository/document"
+	"github.com/goda6565/ai-consultant/backend/internal/infrastructure/google/database/repository/goal_revision"
 	"github.com/goda6565/ai-consultant/backend/internal/infrastructure/google/database/repository/hearing"
 	"github.com/goda6565/ai-consultant/backend/internal/infrastructure/google/database/repository/hearing_map"
 	"github.com/goda6565/ai-consultant/backend/internal/infrastructure/google/database/repository/hearing_message"
@@ -38,17 +44,20 @@ import (
 	"github.com/goda6565/ai-consultant/backend/internal/infrastructure/google/database/repository/problem"
 	"github.com/goda6565/ai-consultant/backend/internal/infrastructure/google/database/repository/problem_field"
 	"github.com/goda6565/ai-consultant/backend/internal/infrastructure/google/database/repository/report"
+	"github.com/goda6565/ai-consultant/backend/internal/infrastructure/google/database/repository/trace_span"
+	"github.com/goda6565/ai-consultant/backend/internal/infrastructure/google/database/repository/usage"
 	"github.com/goda6565/ai-consultant/backend/internal/infrastructure/google/database/search"
 	"github.com/goda6565/ai-consultant/backend/internal/infrastructure/google/database/transaction"
 	"github.com/goda6565/ai-consultant/backend/internal/infrastructure/google/firebase"
-	"github.com/goda6565/ai-consultant/backend/internal/infrastructure/google/gemini"
 	"github.com/goda6565/ai-consultant/backend/internal/infrastructure/google/google_search"
 	"github.com/goda6565/ai-consultant/backend/internal/infrastructure/google/ocr"
 	"github.com/goda6565/ai-consultant/backend/internal/infrastructure/google/storage"
+	"github.com/goda6565/ai-consultant/backend/internal/infrastructure/google/storage/dataset"
 	"github.com/goda6565/ai-consultant/backend/internal/infrastructure/http/echo"
 	"github.com/goda6565/ai-consultant/backend/internal/infrastructure/http/echo/admin"
 	"github.com/goda6565/ai-consultant/backend/internal/infrastructure/http/echo/admin/handler"
 	action3 "github.com/goda6565/ai-consultant/backend/internal/infrastructure/http/echo/admin/handler/action"
+	approval3 "github.com/goda6565/ai-consultant/backend/internal/infrastructure/http/echo/admin/handler/approval"
 	document3 "github.com/goda6565/ai-consultant/backend/internal/infrastructure/http/echo/admin/handler/document"
 	event3 "github.com/goda6565/ai-consultant/backend/internal/infrastructure/http/echo/admin/handler/event"
 	hearing3 "github.com/goda6565/ai-consultant/backend/internal/infrastructure/http/echo/admin/handler/hearing"
@@ -57,6 +66,8 @@ import (
 	jobconfig3 "github.com/goda6565/ai-consultant/backend/internal/infrastructure/http/echo/admin/handler/job_config"
 	problem3 "github.com/goda6565/ai-consultant/backend/internal/infrastructure/http/echo/admin/handler/problem"
 	report3 "github.com/goda6565/ai-consultant/backend/internal/infrastructure/http/echo/admin/handler/report"
+	trace2 "github.com/goda6565/ai-consultant/backend/internal/infrastructure/http/echo/admin/handler/trace"
+	usage3 "github.com/goda6565/ai-consultant/backend/internal/infrastructure/http/echo/admin/handler/usage"
 	"github.com/goda6565/ai-consultant/backend/internal/infrastructure/http/echo/agent"
 	handler3 "github.com/goda6565/ai-consultant/backend/internal/infrastructure/http/echo/agent/handler"
 	hearing4 "github.com/goda6565/ai-consultant/backend/internal/infrastructure/http/echo/agent/handler/hearing"
@@ -65,10 +76,14 @@ import (
 	chunk3 "github.com/goda6565/ai-consultant/backend/internal/infrastructure/http/echo/vector/handler/chunk"
 	"github.com/goda6565/ai-consultant/backend/internal/infrastructure/job"
 	proposal2 "github.com/goda6565/ai-consultant/backend/internal/infrastructure/job/proposal"
+	"github.com/goda6565/ai-consultant/backend/internal/infrastructure/llm"
 	"github.com/goda6565/ai-consultant/backend/internal/infrastructure/upstash/redis"
 	"github.com/goda6565/ai-consultant/backend/internal/infrastructure/upstash/redis/repository/event"
 	"github.com/goda6565/ai-consultant/backend/internal/infrastructure/zap"
+	"github.com/goda6565/ai-consultant/backend/internal/pkg/scraper"
+	"github.com/goda6565/ai-consultant/backend/internal/replay/proposal-job"
 	action2 "github.com/goda6565/ai-consultant/backend/internal/usecase/action"
+	approval2 "github.com/goda6565/ai-consultant/backend/internal/usecase/approval"
 	chunk2 "github.com/goda6565/ai-consultant/backend/internal/usecase/chunk"
 	document2 "github.com/goda6565/ai-consultant/backend/internal/usecase/document"
 	event2 "github.com/goda6565/ai-consultant/backend/internal/usecase/event"
@@ -79,6 +94,8 @@ import (
 	problem2 "github.com/goda6565/ai-consultant/backend/internal/usecase/problem"
 	"github.com/goda6565/ai-consultant/backend/internal/usecase/proposal"
 	report2 "github.com/goda6565/ai-consultant/backend/internal/usecase/report"
+	"github.com/goda6565/ai-consultant/backend/internal/usecase/trace"
+	usage2 "github.com/goda6565/ai-consultant/backend/internal/usecase/usage"
 )
 
 // Injectors from wire.go:
@@ -102,7 +119,20 @@ func InitAdminApplication(ctx context.Context) (*App, func(), error) {
 	getDocumentHandler := document3.NewGetDocumentHandler(getDocumentInputPort)
 	listDocumentInputPort := document2.NewListDocumentUseCase(documentRepository)
 	listDocumentHandler := document3.NewListDocumentHandler(listDocumentInputPort)
-	llmClient := gemini.NewGeminiClient(ctx, environmentEnvironment)
+	cassetteConfig := llm.NewCassetteConfig(environmentEnvironment)
+	providerClient := llm.NewProviderClient(ctx, environmentEnvironment, cassetteConfig)
+	retryClient := llm.NewRetryClient(providerClient, logger)
+	cassetteClient, err := llm.NewCassetteClient(retryClient, cassetteConfig, logger)
+	if err != nil {
+		cleanup4()
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	usageRepository := usage.NewUsageRepository(appPool)
+	usageClient := llm.NewUsageClient(cassetteClient, usageRepository, logger)
+	llmClient := llm.NewTraceClient(usageClient)
 	generateTitleService := service2.NewGenerateTitleService(llmClient)
 	problemRepository := problem.NewProblemRepository(appPool)
 	problemFieldRepository := problemfield.NewProblemFieldRepository(appPool)
@@ -122,6 +152,12 @@ func InitAdminApplication(ctx context.Context) (*App, func(), error) {
 	getProblemHandler := problem3.NewGetProblemHandler(getProblemInputPort)
 	listProblemInputPort := problem2.NewListProblemUseCase(problemRepository)
 	listProblemHandler := problem3.NewListProblemHandler(listProblemInputPort)
+	approvalRepository := approval.NewApprovalRepository(appPool)
+	checkpointRepository := checkpoint.NewCheckpointRepository(appPool)
+	client, cleanup5 := redis.ProvideRedisClient(ctx, environmentEnvironment)
+	eventRepository := event.NewRedisEventRepository(client)
+	cancelProblemInputPort := problem2.NewCancelProblemUseCase(problemRepository, approvalRepository, checkpointRepository, reportRepository, eventRepository)
+	cancelProblemHandler := problem3.NewCancelProblemHandler(cancelProblemInputPort)
 	duplicateCheckerService := service4.NewDuplicateCheckerService(hearingRepository)
 	createHearingInputPort := hearing2.NewCreateHearingUseCase(hearingRepository, duplicateCheckerService, adminUnitOfWork)
 	createHearingHandler := hearing3.NewCreateHearingHandler(createHearingInputPort)
@@ -129,8 +165,6 @@ func InitAdminApplication(ctx context.Context) (*App, func(), error) {
 	getHearingHandler := hearing3.NewGetHearingHandler(getHearingInputPort)
 	listHearingMessageInputPort := hearing_message.NewListHearingMessageUseCase(hearingMessageRepository)
 	listHearingMessageHandler := hearingmessage2.NewListHearingMessageHandler(listHearingMessageInputPort)
-	client, cleanup5 := redis.ProvideRedisClient(ctx, environmentEnvironment)
-	eventRepository := event.NewRedisEventRepository(client)
 	listEventInputPort := event2.NewListEventUseCase(eventRepository)
 	listEventHandler := event3.NewListEventHandler(listEventInputPort)
 	getReportInputPort := report2.NewGetReportUseCase(reportRepository)
@@ -143,7 +177,37 @@ func InitAdminApplication(ctx context.Context) (*App, func(), error) {
 	getJobConfigHandler := jobconfig3.NewGetJobConfigHandler(getJobConfigInputPort)
 	getHearingMapInputPort := hearingmap2.NewGetHearingMapUseCase(hearingMapRepository)
 	getHearingMapHandler := hearingmap3.NewGetHearingMapHandler(getHearingMapInputPort)
-	strictServerInterface := handler.NewAdminHandlers(createDocumentHandler, deleteDocumentHandler, getDocumentHandler, listDocumentHandler, createProblemHandler, deleteProblemHandler, getProblemHandler, listProblemHandler, createHearingHandler, getHearingHandler, listHearingMessageHandler, listEventHandler, getReportHandler, listActionHandler, updateJobConfigHandler, getJobConfigHandler, getHearingMapHandler)
+	priceTable, err := llm.NewPriceTable(environmentEnvironment)
+	if err != nil {
+		cleanup5()
+		cleanup4()
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	ledgerService := service5.NewLedgerService(usageRepository, priceTable)
+	getUsageInputPort := usage2.NewGetUsageUseCase(problemRepository, ledgerService)
+	getUsageHandler := usage3.NewGetUsageHandler(getUsageInputPort)
+	listPendingApprovalInputPort := approval2.NewListPendingApprovalUseCase(approvalRepository)
+	listPendingApprovalHandler := approval3.NewListPendingApprovalHandler(listPendingApprovalInputPort)
+	job, err := cloudrunjob.NewCloudRunJobClient(ctx, environmentEnvironment)
+	if err != nil {
+		cleanup5()
+		cleanup4()
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	decideApprovalInputPort := approval2.NewDecideApprovalUseCase(approvalRepository, problemRepository, job, environmentEnvironment)
+	decideApprovalHandler := approval3.NewDecideApprovalHandler(decideApprovalInputPort)
+	spanRepository := tracespan.NewSpanRepository(appPool)
+	getTraceInputPort := trace.NewGetTraceUseCase(problemRepository, spanRepository)
+	getTraceHandler := trace2.NewGetTraceHandler(getTraceInputPort)
+	exportTraceInputPort := trace.NewExportTraceUseCase(problemRepository, spanRepository)
+	exportTraceHandler := trace2.NewExportTraceHandler(exportTraceInputPort)
+	strictServerInterface := handler.NewAdminHandlers(createDocumentHandler, deleteDocumentHandler, getDocumentHandler, listDocumentHandler, createProblemHandler, deleteProblemHandler, getProblemHandler, listProblemHandler, cancelProblemHandler, createHearingHandler, getHearingHandler, listHearingMessageHandler, listEventHandler, getReportHandler, listActionHandler, updateJobConfigHandler, getJobConfigHandler, getHearingMapHandler, getUsageHandler, listPendingApprovalHandler, decideApprovalHandler, getTraceHandler, exportTraceHandler)
 	streamEventInputPort := event2.NewStreamEventUseCase(eventRepository)
 	streamEventHandler := event3.NewStreamEventHandler(streamEventInputPort)
 	adminHandlers := &handler.AdminHandlers{
@@ -173,10 +237,22 @@ func InitVectorApplication(ctx context.Context) (*App, func(), error) {
 	appPool, cleanup3 := database.ProvideAppPool(ctx, environmentEnvironment)
 	documentRepository := document.NewDocumentRepository(appPool)
 	ocrClient := ocr.NewDocumentAIClient(ctx, environmentEnvironment)
-	pdfParser := service5.NewPdfParserService(ocrClient)
-	llmClient := gemini.NewGeminiClient(ctx, environmentEnvironment)
-	csvAnalyzer := service5.NewCsvAnalyzerService(llmClient)
-	chunker := service5.NewChunkService()
+	pdfParser := service6.NewPdfParserService(ocrClient)
+	cassetteConfig := llm.NewCassetteConfig(environmentEnvironment)
+	providerClient := llm.NewProviderClient(ctx, environmentEnvironment, cassetteConfig)
+	retryClient := llm.NewRetryClient(providerClient, logger)
+	cassetteClient, err := llm.NewCassetteClient(retryClient, cassetteConfig, logger)
+	if err != nil {
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	usageRepository := usage.NewUsageRepository(appPool)
+	usageClient := llm.NewUsageClient(cassetteClient, usageRepository, logger)
+	llmClient := llm.NewTraceClient(usageClient)
+	csvAnalyzer := service6.NewCsvAnalyzerService(llmClient)
+	chunker := service6.NewChunkService()
 	storagePort := storage.NewClient(ctx)
 	createChunkInputPort := chunk2.NewCreateChunkUseCase(vectorUnitOfWork, documentRepository, pdfParser, csvAnalyzer, chunker, storagePort, llmClient)
 	createHandler := chunk3.NewCreateChunkHandler(createChunkInputPort)
@@ -208,10 +284,23 @@ func InitAgentApplication(ctx context.Context) (*App, func(), error) {
 	problemRepository := problem.NewProblemRepository(appPool)
 	problemFieldRepository := problemfield.NewProblemFieldRepository(appPool)
 	duplicateCheckerService := service4.NewDuplicateCheckerService(hearingRepository)
-	llmClient := gemini.NewGeminiClient(ctx, environmentEnvironment)
-	generateHearingMessageService := service6.NewGenerateHearingMessageService(llmClient)
-	generateHearingMapService := service7.NewGenerateHearingMapService(llmClient)
+	cassetteConfig := llm.NewCassetteConfig(environmentEnvironment)
+	providerClient := llm.NewProviderClient(ctx, environmentEnvironment, cassetteConfig)
+	retryClient := llm.NewRetryClient(providerClient, logger)
+	cassetteClient, err := llm.NewCassetteClient(retryClient, cassetteConfig, logger)
+	if err != nil {
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	usageRepository := usage.NewUsageRepository(appPool)
+	usageClient := llm.NewUsageClient(cassetteClient, usageRepository, logger)
+	llmClient := llm.NewTraceClient(usageClient)
+	generateHearingMessageService := service7.NewGenerateHearingMessageService(llmClient)
+	generateHearingMapService := service8.NewGenerateHearingMapService(llmClient)
 	judgeProblemFieldCompletionService := service3.NewJudgeProblemFieldCompletionService(llmClient)
+	client, cleanup3 := redis.ProvideRedisClient(ctx, environmentEnvironment)
+	eventRepository := event.NewRedisEventRepository(client)
 	documentRepository := document.NewDocumentRepository(appPool)
 	actionRepository := action.NewActionRepository(appPool)
 	reportRepository := report.NewReportRepository(appPool)
@@ -220,11 +309,12 @@ func InitAgentApplication(ctx context.Context) (*App, func(), error) {
 	adminUnitOfWork := transaction.NewAdminUnitOfWork(ctx, appPool, documentRepository, problemRepository, hearingRepository, hearingMessageRepository, problemFieldRepository, actionRepository, reportRepository, jobConfigRepository, hearingMapRepository)
 	job, err := cloudrunjob.NewCloudRunJobClient(ctx, environmentEnvironment)
 	if err != nil {
+		cleanup3()
 		cleanup2()
 		cleanup()
 		return nil, nil, err
 	}
-	executeHearingInputPort := hearing2.NewExecuteHearingUseCase(hearingRepository, hearingMessageRepository, problemRepository, problemFieldRepository, duplicateCheckerService, generateHearingMessageService, generateHearingMapService, judgeProblemFieldCompletionService, adminUnitOfWork, job, environmentEnvironment)
+	executeHearingInputPort := hearing2.NewExecuteHearingUseCase(hearingRepository, hearingMessageRepository, problemRepository, problemFieldRepository, duplicateCheckerService, generateHearingMessageService, generateHearingMapService, judgeProblemFieldCompletionService, eventRepository, adminUnitOfWork, job, environmentEnvironment)
 	getProblemInputPort := problem2.NewGetProblemUseCase(problemRepository)
 	executeHearingHandler := hearing4.NewExecuteHearingHandler(executeHearingInputPort, getProblemInputPort)
 	strictServerInterface := handler3.NewAgentHandlers(executeHearingHandler)
@@ -234,6 +324,7 @@ func InitAgentApplication(ctx context.Context) (*App, func(), error) {
 		Server: server,
 	}
 	return app, func() {
+		cleanup3()
 		cleanup2()
 		cleanup()
 	}, nil
@@ -250,27 +341,63 @@ func InitProposalJob(ctx context.Context) (*Job, func(), error) {
 	actionRepository := action.NewActionRepository(appPool)
 	client, cleanup3 := redis.ProvideRedisClient(ctx, environmentEnvironment)
 	eventRepository := event.NewRedisEventRepository(client)
-	llmClient := gemini.NewGeminiClient(ctx, environmentEnvironment)
-	orchestrator := service8.NewOrchestrator(llmClient)
-	summarizeService := service8.NewSummarizeService(llmClient)
-	goalService := service8.NewGoalService(llmClient)
-	terminator := service8.NewTerminator(llmClient)
-	skipper := service8.NewSkipper(llmClient)
-	promptBuilder := service9.NewPromptBuilder()
-	planActionInterface := service10.NewPlanAction(llmClient, promptBuilder)
+	cassetteConfig := llm.NewCassetteConfig(environmentEnvironment)
+	providerClient := llm.NewProviderClient(ctx, environmentEnvironment, cassetteConfig)
+	retryClient := llm.NewRetryClient(providerClient, logger)
+	cassetteClient, err := llm.NewCassetteClient(retryClient, cassetteConfig, logger)
+	if err != nil {
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	usageRepository := usage.NewUsageRepository(appPool)
+	usageClient := llm.NewUsageClient(cassetteClient, usageRepository, logger)
+	llmClient := llm.NewTraceClient(usageClient)
+	orchestrator := service9.NewOrchestrator(llmClient)
+	summarizeService := service9.NewSummarizeService(llmClient)
+	goalService := service9.NewGoalService(llmClient)
+	terminator := service9.NewTerminator(llmClient)
+	skipper := service9.NewSkipper(llmClient)
+	reflection := service9.NewReflection(llmClient)
+	goalRevisionService := service9.NewGoalRevisionService(llmClient)
+	promptBuilder := service10.NewPromptBuilder()
+	planActionInterface := service11.NewPlanAction(llmClient, promptBuilder)
 	webSearchClient := googlesearch.NewGoogleSearchClient(environmentEnvironment)
 	vectorPool, cleanup4 := database.ProvideVectorPool(ctx, environmentEnvironment)
 	documentSearchClient := search.NewSearchClient(vectorPool, appPool)
-	searchTools := tools.NewSearchTools(llmClient, webSearchClient, documentSearchClient)
-	externalSearchActionInterface := service10.NewExternalSearchAction(llmClient, searchTools, promptBuilder)
-	internalSearchActionInterface := service10.NewInternalSearchAction(llmClient, searchTools, promptBuilder)
-	analyzeActionInterface := service10.NewAnalyzeAction(llmClient, promptBuilder)
-	writeActionInterface := service10.NewWriteAction(llmClient, promptBuilder)
-	reviewActionInterface := service10.NewReviewAction(llmClient, promptBuilder)
-	actionFactory := service10.NewActionFactory(planActionInterface, externalSearchActionInterface, internalSearchActionInterface, analyzeActionInterface, writeActionInterface, reviewActionInterface)
+	scraperClient := scraper.NewScraperClient()
+	rerankConfig := llm.NewRerankConfig(environmentEnvironment)
+	reranker := service12.NewReranker(rerankConfig, llmClient)
+	queryExpander := service12.NewLLMQueryExpander(llmClient)
+	searchTools := tools.NewSearchTools(llmClient, webSearchClient, documentSearchClient, scraperClient, reranker, queryExpander)
+	externalSearchActionInterface := service11.NewExternalSearchAction(llmClient, searchTools, promptBuilder)
+	internalSearchActionInterface := service11.NewInternalSearchAction(llmClient, searchTools, promptBuilder)
+	documentRepository := document.NewDocumentRepository(appPool)
+	storagePort := storage.NewClient(ctx)
+	datasetClient := dataset.NewDatasetClient(documentRepository, storagePort)
+	dataAnalysisActionInterface := service11.NewDataAnalysisAction(llmClient, datasetClient, promptBuilder)
+	analyzeActionInterface := service11.NewAnalyzeAction(llmClient, promptBuilder)
+	writeActionInterface := service11.NewWriteAction(llmClient, promptBuilder)
+	reviewActionInterface := service11.NewReviewAction(llmClient, promptBuilder)
+	actionFactory := service11.NewActionFactory(planActionInterface, externalSearchActionInterface, internalSearchActionInterface, dataAnalysisActionInterface, analyzeActionInterface, writeActionInterface, reviewActionInterface)
 	reportRepository := report.NewReportRepository(appPool)
 	jobConfigRepository := jobconfig.NewJobConfigRepository(appPool)
-	executeProposalInputPort := proposal.NewExecuteProposalUseCase(problemRepository, problemFieldRepository, hearingRepository, hearingMessageRepository, actionRepository, eventRepository, orchestrator, summarizeService, goalService, terminator, skipper, actionFactory, reportRepository, jobConfigRepository)
+	priceTable, err := llm.NewPriceTable(environmentEnvironment)
+	if err != nil {
+		cleanup4()
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	ledgerService := service5.NewLedgerService(usageRepository, priceTable)
+	checkpointRepository := checkpoint.NewCheckpointRepository(appPool)
+	approvalRepository := approval.NewApprovalRepository(appPool)
+	goalRevisionRepository := goalrevision.NewGoalRevisionRepository(appPool)
+	spanRepository := tracespan.NewSpanRepository(appPool)
+	tracer := service13.NewTracer(spanRepository)
+	executeProposalInputPort := proposal.NewExecuteProposalUseCase(problemRepository, problemFieldRepository, hearingRepository, hearingMessageRepository, actionRepository, eventRepository, orchestrator, summarizeService, goalService, terminator, skipper, reflection, goalRevisionService, actionFactory, reportRepository, jobConfigRepository, documentRepository, ledgerService, checkpointRepository, approvalRepository, goalRevisionRepository, tracer)
 	jobApplication := proposal2.NewExecuteProposal(ctx, executeProposalInputPort)
 	jobJob := job.NewBaseJob(ctx, logger, jobApplication)
 	diJob := &Job{
@@ -284,35 +411,130 @@ func InitProposalJob(ctx context.Context) (*Job, func(), error) {
 	}, nil
 }
 
-func InitProposalJobEval(ctx context.Context) (*Eval, func(), error) {
+func InitProposalJobReplay(ctx context.Context, replayConfig llm.ReplayConfig) (*Job, func(), error) {
 	environmentEnvironment := environment.ProvideEnvironment()
 	logger, cleanup := zap.ProvideZapLogger(environmentEnvironment)
-	llmClient := gemini.NewGeminiClient(ctx, environmentEnvironment)
-	orchestrator := service8.NewOrchestrator(llmClient)
-	summarizeService := service8.NewSummarizeService(llmClient)
-	goalService := service8.NewGoalService(llmClient)
-	terminator := service8.NewTerminator(llmClient)
-	skipper := service8.NewSkipper(llmClient)
-	promptBuilder := service9.NewPromptBuilder()
-	planActionInterface := service10.NewPlanAction(llmClient, promptBuilder)
+	appPool, cleanup2 := database.ProvideAppPool(ctx, environmentEnvironment)
+	problemRepository := problem.NewProblemRepository(appPool)
+	problemFieldRepository := problemfield.NewProblemFieldRepository(appPool)
+	hearingRepository := hearing.NewHearingRepository(appPool)
+	hearingMessageRepository := hearingmessage.NewHearingMessageRepository(appPool)
+	jobConfigRepository := jobconfig.NewJobConfigRepository(appPool)
+	documentRepository := document.NewDocumentRepository(appPool)
+	actionRepository := action.NewActionRepository(appPool)
+	spanRepository := tracespan.NewSpanRepository(appPool)
+	replayClient, err := llm.NewReplayClient(ctx, replayConfig, spanRepository)
+	if err != nil {
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	usageRepository := memory.NewMemoryUsageRepository()
+	llmClient := llm.NewReplayUsageClient(replayClient, usageRepository, logger)
+	orchestrator := service9.NewOrchestrator(llmClient)
+	summarizeService := service9.NewSummarizeService(llmClient)
+	goalService := service9.NewGoalService(llmClient)
+	terminator := service9.NewTerminator(llmClient)
+	skipper := service9.NewSkipper(llmClient)
+	reflection := service9.NewReflection(llmClient)
+	goalRevisionService := service9.NewGoalRevisionService(llmClient)
+	promptBuilder := service10.NewPromptBuilder()
+	planActionInterface := service11.NewPlanAction(llmClient, promptBuilder)
+	searchRecording, err := proposaljob.NewSearchRecording(ctx, replayConfig, spanRepository)
+	if err != nil {
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	webSearchClient := proposaljob.NewWebSearchClient(searchRecording)
+	documentSearchClient := proposaljob.NewDocumentSearchClient(searchRecording)
+	scraperClient := proposaljob.NewScraperClient(searchRecording)
+	reranker := proposaljob.NewReranker()
+	queryExpander := proposaljob.NewQueryExpander()
+	searchTools := tools.NewSearchTools(llmClient, webSearchClient, documentSearchClient, scraperClient, reranker, queryExpander)
+	externalSearchActionInterface := service11.NewExternalSearchAction(llmClient, searchTools, promptBuilder)
+	internalSearchActionInterface := service11.NewInternalSearchAction(llmClient, searchTools, promptBuilder)
+	storagePort := storage.NewClient(ctx)
+	datasetClient := dataset.NewDatasetClient(documentRepository, storagePort)
+	dataAnalysisActionInterface := service11.NewDataAnalysisAction(llmClient, datasetClient, promptBuilder)
+	analyzeActionInterface := service11.NewAnalyzeAction(llmClient, promptBuilder)
+	writeActionInterface := service11.NewWriteAction(llmClient, promptBuilder)
+	reviewActionInterface := service11.NewReviewAction(llmClient, promptBuilder)
+	actionFactory := service11.NewActionFactory(planActionInterface, externalSearchActionInterface, internalSearchActionInterface, dataAnalysisActionInterface, analyzeActionInterface, writeActionInterface, reviewActionInterface)
+	reportRepository := memory.NewMemoryReportRepository()
+	priceTable, err := llm.NewPriceTable(environmentEnvironment)
+	if err != nil {
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	ledgerService := service5.NewLedgerService(usageRepository, priceTable)
+	jobApplication := proposaljob.NewProposalJobReplay(replayConfig, problemRepository, problemFieldRepository, hearingRepository, hearingMessageRepository, jobConfigRepository, documentRepository, actionRepository, orchestrator, summarizeService, goalService, terminator, skipper, reflection, goalRevisionService, actionFactory, reportRepository, ledgerService)
+	jobJob := job.NewBaseJob(ctx, logger, jobApplication)
+	diJob := &Job{
+		Job: jobJob,
+	}
+	return diJob, func() {
+		cleanup2()
+		cleanup()
+	}, nil
+}
+
+func InitProposalJobEval(ctx context.Context, cassetteConfig llm.CassetteConfig) (*Eval, func(), error) {
+	environmentEnvironment := environment.ProvideEnvironment()
+	logger, cleanup := zap.ProvideZapLogger(environmentEnvironment)
+	providerClient := llm.NewProviderClient(ctx, environmentEnvironment, cassetteConfig)
+	retryClient := llm.NewRetryClient(providerClient, logger)
+	cassetteClient, err := llm.NewCassetteClient(retryClient, cassetteConfig, logger)
+	if err != nil {
+		cleanup()
+		return nil, nil, err
+	}
+	usageRepository := memory.NewMemoryUsageRepository()
+	usageClient := llm.NewUsageClient(cassetteClient, usageRepository, logger)
+	llmClient := llm.NewTraceClient(usageClient)
+	orchestrator := service9.NewOrchestrator(llmClient)
+	summarizeService := service9.NewSummarizeService(llmClient)
+	goalService := service9.NewGoalService(llmClient)
+	terminator := service9.NewTerminator(llmClient)
+	skipper := service9.NewSkipper(llmClient)
+	reflection := service9.NewReflection(llmClient)
+	goalRevisionService := service9.NewGoalRevisionService(llmClient)
+	promptBuilder := service10.NewPromptBuilder()
+	planActionInterface := service11.NewPlanAction(llmClient, promptBuilder)
 	webSearchClient := googlesearch.NewGoogleSearchClient(environmentEnvironment)
 	documentSearchClient, cleanup2 := mock.NewMockDocumentSearchClient()
-	searchTools := tools.NewSearchTools(llmClient, webSearchClient, documentSearchClient)
-	externalSearchActionInterface := service10.NewExternalSearchAction(llmClient, searchTools, promptBuilder)
-	internalSearchActionInterface := service10.NewInternalSearchAction(llmClient, searchTools, promptBuilder)
-	analyzeActionInterface := service10.NewAnalyzeAction(llmClient, promptBuilder)
-	writeActionInterface := service10.NewWriteAction(llmClient, promptBuilder)
-	reviewActionInterface := service10.NewReviewAction(llmClient, promptBuilder)
-	actionFactory := service10.NewActionFactory(planActionInterface, externalSearchActionInterface, internalSearchActionInterface, analyzeActionInterface, writeActionInterface, reviewActionInterface)
+	scraperClient := scraper.NewScraperClient()
+	rerankConfig := llm.NewRerankConfig(environmentEnvironment)
+	reranker := service12.NewReranker(rerankConfig, llmClient)
+	queryExpander := service12.NewLLMQueryExpander(llmClient)
+	searchTools := tools.NewSearchTools(llmClient, webSearchClient, documentSearchClient, scraperClient, reranker, queryExpander)
+	externalSearchActionInterface := service11.NewExternalSearchAction(llmClient, searchTools, promptBuilder)
+	internalSearchActionInterface := service11.NewInternalSearchAction(llmClient, searchTools, promptBuilder)
+	datasetClient, cleanup3 := mock.NewMockDatasetClient()
+	dataAnalysisActionInterface := service11.NewDataAnalysisAction(llmClient, datasetClient, promptBuilder)
+	analyzeActionInterface := service11.NewAnalyzeAction(llmClient, promptBuilder)
+	writeActionInterface := service11.NewWriteAction(llmClient, promptBuilder)
+	reviewActionInterface := service11.NewReviewAction(llmClient, promptBuilder)
+	actionFactory := service11.NewActionFactory(planActionInterface, externalSearchActionInterface, internalSearchActionInterface, dataAnalysisActionInterface, analyzeActionInterface, writeActionInterface, reviewActionInterface)
 	reportRepository := memory.NewMemoryReportRepository()
 	actionRepository := memory.NewMemoryActionRepository()
+	priceTable, err := llm.NewPriceTable(environmentEnvironment)
+	if err != nil {
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	ledgerService := service5.NewLedgerService(usageRepository, priceTable)
 	judge := llmasjudge.NewJudge(llmClient)
-	evaluator := proposaljob.NewProposalJobEval(orchestrator, summarizeService, goalService, terminator, skipper, actionFactory, reportRepository, actionRepository, judge)
+	evaluator := proposaljob2.NewProposalJobEval(orchestrator, summarizeService, goalService, terminator, skipper, reflection, goalRevisionService, actionFactory, reportRepository, actionRepository, ledgerService, judge)
 	baseEvaluator := evaluate.NewBaseEvaluator(logger, evaluator)
 	eval := &Eval{
 		Evaluator: baseEvaluator,
 	}
 	return eval, func() {
+		cleanup3()
 		cleanup2()
 		cleanup()
 	}, nil
@@ -27,7 +27,7 @@ func (a *AnalyzeAction) Execute(ctx context.Context, input ActionTemplateInput)
 	llmInput := llm.GenerateTextInput{
 		SystemPrompt: prompt.SystemPrompt,
 		UserPrompt:   prompt.UserPrompt,
-		Config:       llm.LLMConfig{Provider: llm.VertexAI, Model: llm.Gemini25Flash},
+		Config:       input.State.GetModelConfig(actionValue.ActionTypeAnalyze),
 		Temperature:  0.0,
 	}
 
@@ -0,0 +1,184 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	actionValue "github.com/goda6565/ai-consultant/backend/internal/domain/action/value"
+	"github.com/goda6565/ai-consultant/backend/internal/domain/dataset"
+	"github.com/goda6565/ai-consultant/backend/internal/domain/llm"
+	"github.com/goda6565/ai-consultant/backend/internal/domain/prompt/prompts"
+	"github.com/goda6565/ai-consultant/backend/internal/domain/prompt/service"
+	"github.com/goda6565/ai-consultant/backend/internal/pkg/logger"
+)
+
+const maxDataAnalysisDatasets = 5
+const maxDataAnalysisSamples = 3
+
+// NoDatasetMessage is the output of the data analysis when no CSV document is uploaded.
+const NoDatasetMessage = "集計できるCSVデータがありません。"
+
+type DataAnalysisAction struct {
+	llmClient     llm.LLMClient
+	datasetClient dataset.DatasetClient
+	promptBuilder *service.PromptBuilder
+}
+
+func NewDataAnalysisAction(llmClient llm.LLMClient, datasetClient dataset.DatasetClient, promptBuilder *service.PromptBuilder) DataAnalysisActionInterface {
+	return &DataAnalysisAction{llmClient: llmClient, datasetClient: datasetClient, promptBuilder: promptBuilder}
+}
+
+type DataAnalysisPlanOutput struct {
+	Queries []dataset.Query `json:"queries"`
+}
+
+// Execute lets the model choose the aggregations and computes them in Go, so that the numbers in the report come from the data.
+func (d *DataAnalysisAction) Execute(ctx context.Context, input ActionTemplateInput) (*ActionTemplateOutput, error) {
+	tables, err := d.loadTables(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load datasets: %w", err)
+	}
+	if len(tables) == 0 {
+		action, err := CreateAction(input.State, actionValue.ActionTypeDataAnalysis, "", NoDatasetMessage)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create action: %w", err)
+		}
+		return &ActionTemplateOutput{Action: *action, Content: input.State.GetContent()}, nil
+	}
+
+	// 1. plan
+	descriptions := []string{}
+	for _, table := range tables {
+		descriptions = append(descriptions, table.Describe(maxDataAnalysisSamples))
+	}
+	prompt := d.promptBuilder.Build(service.PromptBuilderInput{
+		ActionType: actionValue.ActionTypeDataAnalysis,
+		State:      input.State,
+		Input:      strings.Join(descriptions, "\n"),
+	})
+	llmOutput, err := llm.GenerateStructured[DataAnalysisPlanOutput](ctx, d.llmClient, llm.GenerateStructuredTextInput{
+		SystemPrompt: prompt.SystemPrompt,
+		UserPrompt:   prompt.UserPrompt,
+		Config:       input.State.GetModelConfig(actionValue.ActionTypeDataAnalysis),
+		Temperature:  0.0,
+		Schema:       dataAnalysisSchema,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate structured output: %w", err)
+	}
+	queries := llmOutput.Value.Queries
+	if len(queries) > prompts.DataAnalysisMaxQueries {
+		queries = queries[:prompts.DataAnalysisMaxQueries]
+	}
+	logger.GetLogger(ctx).Debug("dataAnalysis", "queries", queries)
+
+	// 2. execute
+	results := []string{}
+	for n, query := range queries {
+		results = append(results, executeQuery(n+1, query, tables))
+	}
+
+	queriesJSON, err := json.Marshal(queries)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal queries: %w", err)
+	}
+	action, err := CreateAction(input.State, actionValue.ActionTypeDataAnalysis, string(queriesJSON), strings.Join(results, "\n"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create action: %w", err)
+	}
+	return &ActionTemplateOutput{Action: *action, Content: input.State.GetContent()}, nil // data analysis does not change content
+}
+
+func (d *DataAnalysisAction) loadTables(ctx context.Context) (map[string]*dataset.Table, error) {
+	infos, err := d.datasetClient.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list datasets: %w", err)
+	}
+	if len(infos) > maxDataAnalysisDatasets {
+		infos = infos[:maxDataAnalysisDatasets]
+	}
+	tables := map[string]*dataset.Table{}
+	for _, info := range infos {
+		table, err := d.datasetClient.Load(ctx, info.ID)
+		if err != nil {
+			// 読めないデータがあっても他のデータで集計を続ける
+			logger.GetLogger(ctx).Warn("failed to load dataset", "id", info.ID, "error", err)
+			continue
+		}
+		tables[info.ID] = table
+	}
+	return tables, nil
+}
+
+// executeQuery formats the query and its result so that the write action can cite the source of the numbers.
+// Errors of a query are written into the output so that the agent can correct it next time.
+func executeQuery(n int, query dataset.Query, tables map[string]*dataset.Table) string {
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("### [データ分析%d] %s\n", n, query.Description))
+	queryJSON, _ := json.Marshal(query)
+	b.WriteString(fmt.Sprintf("集計条件: %s\n", queryJSON))
+	table, ok := tables[query.DatasetID]
+	if !ok {
+		b.WriteString(fmt.Sprintf("集計に失敗しました: データ %s が見つかりません\n", query.DatasetID))
+		return b.String()
+	}
+	b.WriteString(fmt.Sprintf("出典: %s\n", table.Title))
+	result, err := query.Execute(table)
+	if err != nil {
+		b.WriteString(fmt.Sprintf("集計に失敗しました: %v\n", err))
+		return b.String()
+	}
+	b.WriteString(result.Markdown())
+	return b.String()
+}
+
+var dataAnalysisSchema = json.RawMessage(`
+	{
+		"type": "object",
+		"properties": {
+			"queries": {
+				"type": "array",
+				"items": {
+					"type": "object",
+					"properties": {
+						"datasetId": {"type": "string"},
+						"description": {"type": "string"},
+						"filters": {
+							"type": "array",
+							"items": {
+								"type": "object",
+								"properties": {
+									"column": {"type": "string"},
+									"value": {"type": "string"}
+								},
+								"required": ["column", "value"]
+							}
+						},
+						"groupBy": {
+							"type": "array",
+							"items": {"type": "string"}
+						},
+						"metrics": {
+							"type": "array",
+							"items": {
+								"type": "object",
+								"properties": {
+									"operation": {"type": "string", "enum": ["count", "sum", "avg", "min", "max", "median", "percentile"]},
+									"column": {"type": "string"},
+									"percentile": {"type": "number"}
+								},
+								"required": ["operation"]
+							}
+						},
+						"timeColumn": {"type": "string"},
+						"interval": {"type": "string", "enum": ["day", "month", "year"]}
+					},
+					"required": ["datasetId", "description", "metrics"]
+				}
+			}
+		},
+		"required": ["queries"]
+	}
+`)
@@ -0,0 +1,90 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	actionValue "github.com/goda6565/ai-consultant/backend/internal/domain/action/value"
+	agentState "github.com/goda6565/ai-consultant/backend/internal/domain/agent/state"
+	agentValue "github.com/goda6565/ai-consultant/backend/internal/domain/agent/value"
+	"github.com/goda6565/ai-consultant/backend/internal/domain/dataset"
+	datasetMock "github.com/goda6565/ai-consultant/backend/internal/domain/dataset/mock"
+	jobConfigValue "github.com/goda6565/ai-consultant/backend/internal/domain/job_config/value"
+	llmMock "github.com/goda6565/ai-consultant/backend/internal/domain/llm/mock"
+	problemEntity "github.com/goda6565/ai-consultant/backend/internal/domain/problem/entity"
+	promptService "github.com/goda6565/ai-consultant/backend/internal/domain/prompt/service"
+	workflowValue "github.com/goda6565/ai-consultant/backend/internal/domain/workflow/value"
+	"go.uber.org/mock/gomock"
+)
+
+func TestExecuteQuery(t *testing.T) {
+	table, err := dataset.ParseCSV(dataset.DatasetInfo{ID: "sales", Title: "支店別売上.csv"}, strings.NewReader("支店,売上\n東京,100\n東京,300\n大阪,50\n"))
+	if err != nil {
+		t.Fatalf("ParseCSV() error = %v", err)
+	}
+	tables := map[string]*dataset.Table{"sales": table}
+
+	tests := []struct {
+		name     string
+		query    dataset.Query
+		expected []string
+	}{
+		{
+			name: "formats the result with the source",
+			query: dataset.Query{
+				DatasetID:   "sales",
+				Description: "支店別の売上合計",
+				GroupBy:     []string{"支店"},
+				Metrics:     []dataset.Metric{{Operation: dataset.OperationSum, Column: "売上"}},
+			},
+			expected: []string{"### [データ分析1] 支店別の売上合計", "出典: 支店別売上.csv", "| 大阪 | 50 |", "| 東京 | 400 |"},
+		},
+		{
+			name:     "reports an unknown dataset",
+			query:    dataset.Query{DatasetID: "unknown", Metrics: []dataset.Metric{{Operation: dataset.OperationCount}}},
+			expected: []string{"集計に失敗しました: データ unknown が見つかりません"},
+		},
+		{
+			name:     "reports an invalid query",
+			query:    dataset.Query{DatasetID: "sales", Metrics: []dataset.Metric{{Operation: dataset.OperationSum, Column: "利益"}}},
+			expected: []string{"集計に失敗しました", "利益"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			output := executeQuery(1, tt.query, tables)
+			for _, expected := range tt.expected {
+				if !strings.Contains(output, expected) {
+					t.Errorf("output does not contain %q:\n%s", expected, output)
+				}
+			}
+		})
+	}
+}
+
+func TestDataAnalysisAction_NoDataset(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	datasetClient := datasetMock.NewMockDatasetClient(ctrl)
+	datasetClient.EXPECT().List(gomock.Any()).Return([]dataset.DatasetInfo{}, nil).Times(1)
+	// データがない場合は LLM を呼ばない
+	llmClient := llmMock.NewMockLLMClient(ctrl)
+
+	workflow, err := workflowValue.GetWorkflow(workflowValue.DefaultName)
+	if err != nil {
+		t.Fatalf("GetWorkflow() error = %v", err)
+	}
+	state := agentState.NewState(problemEntity.Problem{}, *agentValue.NewContent(""), nil, nil, *agentValue.NewHistory(""), []actionValue.ActionType{}, true, workflow, jobConfigValue.ModelMap{})
+
+	action := NewDataAnalysisAction(llmClient, datasetClient, promptService.NewPromptBuilder())
+	output, err := action.Execute(context.Background(), ActionTemplateInput{State: *state})
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	actionOutput := output.Action.GetOutput()
+	if actionOutput.Value() != NoDatasetMessage {
+		t.Errorf("output = %s, expected %s", actionOutput.Value(), NoDatasetMessage)
+	}
+}
@@ -12,10 +12,13 @@ import (
 	agentState "github.com/goda6565/ai-consultant/backend/internal/domain/agent/state"
 	"github.com/goda6565/ai-consultant/backend/internal/domain/llm"
 	"github.com/goda6565/ai-consultant/backend/internal/domain/prompt/service"
+	traceService "github.com/goda6565/ai-consultant/backend/internal/domain/trace/service"
+	traceValue "github.com/goda6565/ai-consultant/backend/internal/domain/trace/value"
 	"github.com/goda6565/ai-consultant/backend/internal/pkg/logger"
 )
 
 const maxExternalSearchDecomposeTopics = 5
+const maxExternalSearchExploreSteps = 3
 
 type ExternalSearchAction struct {
 	llmClient     llm.LLMClient
@@ -29,6 +32,7 @@ func NewExternalSearchAction(llmClient llm.LLMClient, searchTools *tools.SearchT
 
 func (s *ExternalSearchAction) Execute(ctx context.Context, input ActionTemplateInput) (*ActionTemplateOutput, error) {
 	logger := logger.GetLogger(ctx)
+	llmConfig := input.State.GetModelConfig(actionValue.ActionTypeExternalSearch)
 	// 1. decompose
 	topics, err := s.decompose(ctx, ExternalSearchDecomposeInput{
 		MaxTopics: maxExternalSearchDecomposeTopics,
@@ -40,13 +44,13 @@ func (s *ExternalSearchAction) Execute(ctx context.Context, input ActionTemplate
 	}
 
 	// 2. explore
+	// 結果はトピックの順に並べ、同じ入力なら同じ出力になるようにする
 	wg := sync.WaitGroup{}
-	results := []string{}
-	resultChannel := make(chan string, len(topics.SearchTopics))
+	exploreResults := make([]*string, len(topics.SearchTopics))
 
-	for _, topic := range topics.SearchTopics {
+	for n, topic := range topics.SearchTopics {
 		wg.Add(1)
-		go func(topic string) {
+		go func(n int, topic string) {
 			defer wg.Done()
 			defer func() {
 				if r := recover(); r != nil {
@@ -54,29 +58,31 @@ func (s *ExternalSearchAction) Execute(ctx context.Context, input ActionTemplate
 				}
 			}()
 			result, err := s.explore(ctx, ExternalSearchExploreInput{
-				Topic: topic,
+				Topic:     topic,
+				LLMConfig: llmConfig,
 			})
 			if err != nil {
 				logger.Error("failed to explore", "error", err)
+				return
 			}
-			resultChannel <- result.result
-		}(topic)
+			exploreResults[n] = &result.result
+		}(n, topic)
 	}
+	wg.Wait()
 
-	go func() {
-		wg.Wait()
-		close(resultChannel)
-	}()
-
-	for result := range resultChannel {
-		results = append(results, result)
+	results := []string{}
+	for _, result := range exploreResults {
+		if result != nil {
+			results = append(results, *result)
+		}
 	}
 
 	// 3. synthesize
 	synthesizedResults := []string{}
 	for _, result := range results {
 		synthesizedResult, err := s.synthesize(ctx, ExternalSearchSynthesizeInput{
-			Result: result,
+			Result:    result,
+			LLMConfig: llmConfig,
 		})
 		if err != nil {
 			return nil, fmt.Errorf("failed to synthesize: %w", err)
@@ -102,7 +108,11 @@ type ExternalSearchDecomposeOutput struct {
 	SearchTopics []string `json:"searchTopics"`
 }
 
-func (s *ExternalSearchAction) decompose(ctx context.Context, input ExternalSearchDecomposeInput) (*ExternalSearchDecomposeOutput, error) {
+func (s *ExternalSearchAction) decompose(ctx context.Context, input ExternalSearchDecomposeInput) (output *ExternalSearchDecomposeOutput, err error) {
+	ctx, span := traceService.Start(ctx, "decompose", traceValue.SpanKindTool)
+	defer func() {
+		span.End(err)
+	}()
 	prompt := s.promptBuilder.Build(service.PromptBuilderInput{
 		Name:       "decompose",
 		ActionType: actionValue.ActionTypeExternalSearch,
@@ -112,7 +122,7 @@ func (s *ExternalSearchAction) decompose(ctx context.Context, input ExternalSear
 	llmInput := llm.GenerateStructuredTextInput{
 		SystemPrompt: prompt.SystemPrompt,
 		UserPrompt:   prompt.UserPrompt,
-		Config:       llm.LLMConfig{Provider: llm.VertexAI, Model: llm.Gemini25Flash},
+		Config:       input.State.GetModelConfig(actionValue.ActionTypeExternalSearch),
 		Temperature:  0.0,
 		Schema: json.RawMessage(`
 			{
@@ -129,58 +139,71 @@ func (s *ExternalSearchAction) decompose(ctx context.Context, input ExternalSear
 			}
 		`),
 	}
-	llmOutput, err := s.llmClient.GenerateStructuredText(ctx, llmInput)
+	llmOutput, err := llm.GenerateStructured[ExternalSearchDecomposeOutput](ctx, s.llmClient, llmInput)
 	if err != nil {
-		return nil, fmt.Errorf("failed to generate text: %w", err)
+		return nil, fmt.Errorf("failed to generate structured output: %w", err)
 	}
-	var output ExternalSearchDecomposeOutput
-	if err := json.Unmarshal([]byte(llmOutput.Text), &output); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal output: %w", err)
-	}
-	return &output, nil
+	span.SetAttribute("searchTopics", strings.Join(llmOutput.Value.SearchTopics, "\n"))
+	return &llmOutput.Value, nil
 }
 
 type ExternalSearchExploreInput struct {
-	Topic string
+	Topic     string
+	LLMConfig llm.LLMConfig
 }
 
 type ExternalSearchExploreOutput struct {
 	result string
 }
 
-func (s *ExternalSearchAction) explore(ctx context.Context, input ExternalSearchExploreInput) (*ExternalSearchExploreOutput, error) {
+func (s *ExternalSearchAction) explore(ctx context.Context, input ExternalSearchExploreInput) (output *ExternalSearchExploreOutput, err error) {
+	ctx, span := traceService.Start(ctx, "explore", traceValue.SpanKindTool)
+	defer func() {
+		span.End(err)
+	}()
+	span.SetAttribute("topic", input.Topic)
 	prompt := s.promptBuilder.Build(service.PromptBuilderInput{
 		Name:       "explore",
 		ActionType: actionValue.ActionTypeExternalSearch,
 		Input:      input.Topic,
 	})
-	llmInput := llm.GenerateFunctionCallInput{
+	llmOutput, err := llm.RunToolLoop(ctx, s.llmClient, llm.RunToolLoopInput{
 		SystemPrompt: prompt.SystemPrompt,
 		UserPrompt:   prompt.UserPrompt,
-		Config:       llm.LLMConfig{Provider: llm.VertexAI, Model: llm.Gemini25Flash},
+		Config:       input.LLMConfig,
 		Temperature:  0.0,
-		Functions:    s.searchTools.Tools(),
-	}
-	llmOutput, err := s.llmClient.GenerateFunctionCall(ctx, llmInput)
+		Providers:    []llm.ToolProvider{s.searchTools},
+		MaxSteps:     maxExternalSearchExploreSteps,
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to generate function call: %w", err)
+		return nil, fmt.Errorf("failed to run tool loop: %w", err)
 	}
-	searchResults, err := s.searchTools.Execute(ctx, tools.ExecuteInput{Function: llmOutput.FunctionCall})
-	if err != nil {
-		return nil, fmt.Errorf("failed to execute search tools: %w", err)
+	searchResults := []string{}
+	for _, toolResult := range llmOutput.ToolResults {
+		if toolResult.Err != nil {
+			logger.GetLogger(ctx).Warn("failed to execute search tools", "function", toolResult.Call.Name, "error", toolResult.Err)
+			continue
+		}
+		searchResults = append(searchResults, toolResult.Result)
 	}
-	return &ExternalSearchExploreOutput{result: searchResults.String()}, nil
+	span.SetAttribute("results", len(searchResults))
+	return &ExternalSearchExploreOutput{result: strings.Join(searchResults, "\n")}, nil
 }
 
 type ExternalSearchSynthesizeInput struct {
-	Result string
+	Result    string
+	LLMConfig llm.LLMConfig
 }
 
 type ExternalSearchSynthesizeOutput struct {
 	result string
 }
 
-func (s *ExternalSearchAction) synthesize(ctx context.Context, input ExternalSearchSynthesizeInput) (*ExternalSearchSynthesizeOutput, error) {
+func (s *ExternalSearchAction) synthesize(ctx context.Context, input ExternalSearchSynthesizeInput) (output *ExternalSearchSynthesizeOutput, err error) {
+	ctx, span := traceService.Start(ctx, "synthesize", traceValue.SpanKindTool)
+	defer func() {
+		span.End(err)
+	}()
 	prompt := s.promptBuilder.Build(service.PromptBuilderInput{
 		Name:       "synthesize",
 		ActionType: actionValue.ActionTypeExternalSearch,
@@ -189,7 +212,7 @@ func (s *ExternalSearchAction) synthesize(ctx context.Context, input ExternalSea
 	llmInput := llm.GenerateTextInput{
 		SystemPrompt: prompt.SystemPrompt,
 		UserPrompt:   prompt.UserPrompt,
-		Config:       llm.LLMConfig{Provider: llm.VertexAI, Model: llm.Gemini25Flash},
+		Config:       input.LLMConfig,
 		Temperature:  0.0,
 	}
 	llmOutput, err := s.llmClient.GenerateText(ctx, llmInput)
@@ -12,10 +12,14 @@ import (
 	agentState "github.com/goda6565/ai-consultant/backend/internal/domain/agent/state"
 	"github.com/goda6565/ai-consultant/backend/internal/domain/llm"
 	"github.com/goda6565/ai-consultant/backend/internal/domain/prompt/service"
+	"github.com/goda6565/ai-consultant/backend/internal/domain/search"
+	traceService "github.com/goda6565/ai-consultant/backend/internal/domain/trace/service"
+	traceValue "github.com/goda6565/ai-consultant/backend/internal/domain/trace/value"
 	"github.com/goda6565/ai-consultant/backend/internal/pkg/logger"
 )
 
 const maxInternalSearchDecomposeTopics = 5
+const maxInternalSearchExploreSteps = 3
 
 type InternalSearchAction struct {
 	llmClient     llm.LLMClient
@@ -29,6 +33,7 @@ func NewInternalSearchAction(llmClient llm.LLMClient, searchTools *tools.SearchT
 
 func (s *InternalSearchAction) Execute(ctx context.Context, input ActionTemplateInput) (*ActionTemplateOutput, error) {
 	logger := logger.GetLogger(ctx)
+	llmConfig := input.State.GetModelConfig(actionValue.ActionTypeInternalSearch)
 	// 1. decompose
 	topics, err := s.decompose(ctx, InternalSearchDecomposeInput{
 		MaxTopics: maxInternalSearchDecomposeTopics,
@@ -40,13 +45,14 @@ func (s *InternalSearchAction) Execute(ctx context.Context, input ActionTemplate
 	}
 
 	// 2. explore
+	// 結果はトピックの順に並べ、同じ入力なら同じ出力になるようにする
 	wg := sync.WaitGroup{}
-	results := []string{}
-	resultChannel := make(chan string, len(topics.SearchTopics))
+	exploreResults := make([]*string, len(topics.SearchTopics))
+	exploreDocuments := make([][]tools.SearchResult, len(topics.SearchTopics))
 
-	for _, topic := range topics.SearchTopics {
+	for n, topic := range topics.SearchTopics {
 		wg.Add(1)
-		go func(topic string) {
+		go func(n int, topic string) {
 			defer wg.Done()
 			defer func() {
 				if r := recover(); r != nil {
@@ -54,22 +60,24 @@ func (s *InternalSearchAction) Execute(ctx context.Context, input ActionTemplate
 				}
 			}()
 			result, err := s.explore(ctx, InternalSearchExploreInput{
-				Topic: topic,
+				Topic:     topic,
+				LLMConfig: llmConfig,
 			})
 			if err != nil {
 				logger.Error("failed to explore", "error", err)
+				return
 			}
-			resultChannel <- result.result
-		}(topic)
+			exploreResults[n] = &result.result
+			exploreDocuments[n] = result.documents
+		}(n, topic)
 	}
+	wg.Wait()
 
-	go func() {
-		wg.Wait()
-		close(resultChannel)
-	}()
-
-	for result := range resultChannel {
-		results = append(results, result)
+	results := []string{}
+	for _, result := range exploreResults {
+		if result != nil {
+			results = append(results, *result)
+		}
 	}
 
 	logger.Debug("explore", "results", results)
@@ -78,7 +86,8 @@ func (s *InternalSearchAction) Execute(ctx context.Context, input ActionTemplate
 	synthesizedResults := []string{}
 	for _, result := range results {
 		synthesizedResult, err := s.synthesize(ctx, InternalSearchSynthesizeInput{
-			Result: result,
+			Result:    result,
+			LLMConfig: llmConfig,
 		})
 		if err != nil {
 			return nil, fmt.Errorf("failed to synthesize: %w", err)
@@ -87,6 +96,15 @@ func (s *InternalSearchAction) Execute(ctx context.Context, input ActionTemplate
 		synthesizedResults = append(synthesizedResults, synthesizedResult.result)
 	}
 
+	// どの検索クエリで見つかったドキュメントかを残す
+	documents := []tools.SearchResult{}
+	for _, results := range exploreDocuments {
+		documents = append(documents, results...)
+	}
+	if retrievals := formatRetrievals(documents); retrievals != "" {
+		synthesizedResults = append(synthesizedResults, retrievals)
+	}
+
 	action, err := CreateAction(input.State, actionValue.ActionTypeInternalSearch, "", strings.Join(synthesizedResults, "\n"))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create action: %w", err)
@@ -104,7 +122,11 @@ type InternalSearchDecomposeOutput struct {
 	SearchTopics []string `json:"searchTopics"`
 }
 
-func (s *InternalSearchAction) decompose(ctx context.Context, input InternalSearchDecomposeInput) (*InternalSearchDecomposeOutput, error) {
+func (s *InternalSearchAction) decompose(ctx context.Context, input InternalSearchDecomposeInput) (output *InternalSearchDecomposeOutput, err error) {
+	ctx, span := traceService.Start(ctx, "decompose", traceValue.SpanKindTool)
+	defer func() {
+		span.End(err)
+	}()
 	prompt := s.promptBuilder.Build(service.PromptBuilderInput{
 		Name:       "decompose",
 		ActionType: actionValue.ActionTypeInternalSearch,
@@ -114,7 +136,7 @@ func (s *InternalSearchAction) decompose(ctx context.Context, input InternalSear
 	llmInput := llm.GenerateStructuredTextInput{
 		SystemPrompt: prompt.SystemPrompt,
 		UserPrompt:   prompt.UserPrompt,
-		Config:       llm.LLMConfig{Provider: llm.VertexAI, Model: llm.Gemini25Flash},
+		Config:       input.State.GetModelConfig(actionValue.ActionTypeInternalSearch),
 		Temperature:  0.0,
 		Schema: json.RawMessage(`
 			{
@@ -131,58 +153,136 @@ func (s *InternalSearchAction) decompose(ctx context.Context, input InternalSear
 			}
 		`),
 	}
-	llmOutput, err := s.llmClient.GenerateStructuredText(ctx, llmInput)
+	llmOutput, err := llm.GenerateStructured[InternalSearchDecomposeOutput](ctx, s.llmClient, llmInput)
 	if err != nil {
-		return nil, fmt.Errorf("failed to generate text: %w", err)
-	}
-	var output InternalSearchDecomposeOutput
-	if err := json.Unmarshal([]byte(llmOutput.Text), &output); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal output: %w", err)
+		return nil, fmt.Errorf("failed to generate structured output: %w", err)
 	}
-	return &output, nil
+	span.SetAttribute("searchTopics", strings.Join(llmOutput.Value.SearchTopics, "\n"))
+	return &llmOutput.Value, nil
 }
 
 type InternalSearchExploreInput struct {
-	Topic string
+	Topic     string
+	LLMConfig llm.LLMConfig
 }
 
 type InternalSearchExploreOutput struct {
 	result string
+	// documents are the results of the document searches
+	documents []tools.SearchResult
 }
 
-func (s *InternalSearchAction) explore(ctx context.Context, input InternalSearchExploreInput) (*InternalSearchExploreOutput, error) {
+func (s *InternalSearchAction) explore(ctx context.Context, input InternalSearchExploreInput) (output *InternalSearchExploreOutput, err error) {
+	ctx, span := traceService.Start(ctx, "explore", traceValue.SpanKindTool)
+	defer func() {
+		span.End(err)
+	}()
+	span.SetAttribute("topic", input.Topic)
 	prompt := s.promptBuilder.Build(service.PromptBuilderInput{
 		Name:       "explore",
 		ActionType: actionValue.ActionTypeInternalSearch,
 		Input:      input.Topic,
 	})
-	llmInput := llm.GenerateFunctionCallInput{
+	recorder := &documentSearchRecorder{SearchTools: s.searchTools.WithLLMConfig(input.LLMConfig)}
+	llmOutput, err := llm.RunToolLoop(ctx, s.llmClient, llm.RunToolLoopInput{
 		SystemPrompt: prompt.SystemPrompt,
 		UserPrompt:   prompt.UserPrompt,
-		Config:       llm.LLMConfig{Provider: llm.VertexAI, Model: llm.Gemini25Flash},
+		Config:       input.LLMConfig,
 		Temperature:  0.0,
-		Functions:    s.searchTools.Tools(),
-	}
-	llmOutput, err := s.llmClient.GenerateFunctionCall(ctx, llmInput)
+		Providers:    []llm.ToolProvider{recorder},
+		MaxSteps:     maxInternalSearchExploreSteps,
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to generate function call: %w", err)
+		return nil, fmt.Errorf("failed to run tool loop: %w", err)
 	}
-	searchResults, err := s.searchTools.Execute(ctx, tools.ExecuteInput{Function: llmOutput.FunctionCall})
-	if err != nil {
-		return nil, fmt.Errorf("failed to execute search tools: %w", err)
+	searchResults := []string{}
+	for _, toolResult := range llmOutput.ToolResults {
+		if toolResult.Err != nil {
+			logger.GetLogger(ctx).Warn("failed to execute search tools", "function", toolResult.Call.Name, "error", toolResult.Err)
+			continue
+		}
+		searchResults = append(searchResults, toolResult.Result)
 	}
-	return &InternalSearchExploreOutput{result: searchResults.String()}, nil
+	span.SetAttribute("results", len(searchResults))
+	return &InternalSearchExploreOutput{result: strings.Join(searchResults, "\n"), documents: recorder.documents(llmOutput.ToolResults)}, nil
+}
+
+// documentSearchRecorder keeps the results of the document searches made in the tool loop.
+// The calls of a step run in parallel, so the results are keyed by the tool output and ordered by the calls afterwards.
+type documentSearchRecorder struct {
+	*tools.SearchTools
+	mu      sync.Mutex
+	results map[string][]tools.SearchResult
+}
+
+func (r *documentSearchRecorder) Call(ctx context.Context, call llm.FunctionCall) (string, error) {
+	result, searchResults, err := r.SearchTools.CallWithResults(ctx, call)
+	if err == nil && call.Name == string(tools.FunctionNameDocumentSearch) {
+		r.mu.Lock()
+		if r.results == nil {
+			r.results = map[string][]tools.SearchResult{}
+		}
+		r.results[result] = searchResults
+		r.mu.Unlock()
+	}
+	return result, err
+}
+
+// documents returns the recorded results in the order of the calls.
+func (r *documentSearchRecorder) documents(toolResults []llm.ToolResult) []tools.SearchResult {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	documents := []tools.SearchResult{}
+	for _, toolResult := range toolResults {
+		if toolResult.Err != nil || toolResult.Call.Name != string(tools.FunctionNameDocumentSearch) {
+			continue
+		}
+		documents = append(documents, r.results[toolResult.Result]...)
+	}
+	return documents
+}
+
+var queryVariantKindLabels = map[search.QueryVariantKind]string{
+	search.QueryVariantKindOriginal:     "検索クエリ",
+	search.QueryVariantKindParaphrase:   "言い換え",
+	search.QueryVariantKindHypothetical: "仮想回答",
+}
+
+// formatRetrievals lists the documents with the query variants that found them. Documents found by several topics are listed once.
+func formatRetrievals(documents []tools.SearchResult) string {
+	builder := strings.Builder{}
+	seen := map[string]bool{}
+	for _, document := range documents {
+		if len(document.QueryVariants) == 0 || seen[document.ChunkID] {
+			continue
+		}
+		seen[document.ChunkID] = true
+		variants := []string{}
+		for _, variant := range document.QueryVariants {
+			variants = append(variants, fmt.Sprintf("%s「%s」", queryVariantKindLabels[variant.Kind], variant.Text))
+		}
+		builder.WriteString(fmt.Sprintf("- %s (%s): %s\n", document.Title, document.URL, strings.Join(variants, ", ")))
+	}
+	if builder.Len() == 0 {
+		return ""
+	}
+	return "## 社内ドキュメントの取得元クエリ\n" + builder.String()
 }
 
 type InternalSearchSynthesizeInput struct {
-	Result string
+	Result    string
+	LLMConfig llm.LLMConfig
 }
 
 type InternalSearchSynthesizeOutput struct {
 	result string
 }
 
-func (s *InternalSearchAction) synthesize(ctx context.Context, input InternalSearchSynthesizeInput) (*InternalSearchSynthesizeOutput, error) {
+func (s *InternalSearchAction) synthesize(ctx context.Context, input InternalSearchSynthesizeInput) (output *InternalSearchSynthesizeOutput, err error) {
+	ctx, span := traceService.Start(ctx, "synthesize", traceValue.SpanKindTool)
+	defer func() {
+		span.End(err)
+	}()
 	prompt := s.promptBuilder.Build(service.PromptBuilderInput{
 		Name:       "synthesize",
 		ActionType: actionValue.ActionTypeInternalSearch,
@@ -191,7 +291,7 @@ func (s *InternalSearchAction) synthesize(ctx context.Context, input InternalSea
 	llmInput := llm.GenerateTextInput{
 		SystemPrompt: prompt.SystemPrompt,
 		UserPrompt:   prompt.UserPrompt,
-		Config:       llm.LLMConfig{Provider: llm.VertexAI, Model: llm.Gemini25Flash},
+		Config:       input.LLMConfig,
 		Temperature:  0.0,
 	}
 	llmOutput, err := s.llmClient.GenerateText(ctx, llmInput)
@@ -0,0 +1,47 @@
+package service
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/goda6565/ai-consultant/backend/internal/domain/action/tools"
+	"github.com/goda6565/ai-consultant/backend/internal/domain/llm"
+	"github.com/goda6565/ai-consultant/backend/internal/domain/search"
+)
+
+func TestDocumentSearchRecorder_Documents(t *testing.T) {
+	documentSearch := string(tools.FunctionNameDocumentSearch)
+	variant := func(text string) []search.QueryVariant {
+		return []search.QueryVariant{{Kind: search.QueryVariantKindOriginal, Text: text}}
+	}
+	// recorded in the order the parallel calls finished
+	recorder := &documentSearchRecorder{results: map[string][]tools.SearchResult{
+		"result b": {{ChunkID: "b", Title: "B", QueryVariants: variant("b")}},
+		"result a": {{ChunkID: "a", Title: "A", QueryVariants: variant("a")}, {ChunkID: "b", Title: "B", QueryVariants: variant("a")}},
+	}}
+	toolResults := []llm.ToolResult{
+		{Call: llm.FunctionCall{Name: documentSearch}, Result: "result a"},
+		{Call: llm.FunctionCall{Name: string(tools.FunctionNameWebSearch)}, Result: "web"},
+		{Call: llm.FunctionCall{Name: documentSearch}, Result: "result b"},
+	}
+
+	documents := recorder.documents(toolResults)
+	expected := []string{"a", "b", "b"}
+	if len(documents) != len(expected) {
+		t.Fatalf("documents = %d, expected %d", len(documents), len(expected))
+	}
+	for n, document := range documents {
+		if document.ChunkID != expected[n] {
+			t.Errorf("documents[%d] = %s, expected %s", n, document.ChunkID, expected[n])
+		}
+	}
+
+	// the first call that found the chunk is listed
+	retrievals := formatRetrievals(documents)
+	if strings.Count(retrievals, "- B") != 1 || !strings.Contains(retrievals, "- B (): 検索クエリ「a」") {
+		t.Errorf("unexpected retrievals: %s", retrievals)
+	}
+	if strings.Index(retrievals, "- A") > strings.Index(retrievals, "- B") {
+		t.Errorf("retrievals must follow the call order: %s", retrievals)
+	}
+}
@@ -26,7 +26,7 @@ func (p *PlanAction) Execute(ctx context.Context, input ActionTemplateInput) (*A
 	llmInput := llm.GenerateTextInput{
 		SystemPrompt: prompt.SystemPrompt,
 		UserPrompt:   prompt.UserPrompt,
-		Config:       llm.LLMConfig{Provider: llm.VertexAI, Model: llm.Gemini25Flash},
+		Config:       input.State.GetModelConfig(actionValue.ActionTypePlan),
 		Temperature:  0.0,
 	}
 	llmOutput, err := p.llmClient.GenerateText(ctx, llmInput)
@@ -26,7 +26,7 @@ func (r *ReviewAction) Execute(ctx context.Context, input ActionTemplateInput) (
 	llmInput := llm.GenerateTextInput{
 		SystemPrompt: prompt.SystemPrompt,
 		UserPrompt:   prompt.UserPrompt,
-		Config:       llm.LLMConfig{Provider: llm.VertexAI, Model: llm.Gemini25Flash},
+		Config:       input.State.GetModelConfig(actionValue.ActionTypeReview),
 		Temperature:  0.0,
 	}
 	llmOutput, err := r.llmClient.GenerateText(ctx, llmInput)
@@ -9,10 +9,13 @@ import (
 	agentState "github.com/goda6565/ai-consultant/backend/internal/domain/agent/state"
 	agentValue "github.com/goda6565/ai-consultant/backend/internal/domain/agent/value"
 	"github.com/goda6565/ai-consultant/backend/internal/domain/errors"
+	"github.com/goda6565/ai-consultant/backend/internal/domain/llm"
 )
 
 type ActionTemplateInput struct {
 	State agentState.State
+	// OnDelta receives the output text while it is generated. Actions that do not stream ignore it.
+	OnDelta llm.DeltaHandler
 }
 
 type ActionTemplateOutput struct {
@@ -27,6 +30,7 @@ type ActionTemplate interface {
 type PlanActionInterface ActionTemplate
 type ExternalSearchActionInterface ActionTemplate
 type InternalSearchActionInterface ActionTemplate
+type DataAnalysisActionInterface ActionTemplate
 type AnalyzeActionInterface ActionTemplate
 type WriteActionInterface ActionTemplate
 type ReviewActionInterface ActionTemplate
@@ -35,6 +39,7 @@ type ActionFactory struct {
 	planActionTemplate           PlanActionInterface
 	externalSearchActionTemplate ExternalSearchActionInterface
 	internalSearchActionTemplate InternalSearchActionInterface
+	dataAnalysisActionTemplate   DataAnalysisActionInterface
 	analyzeActionTemplate        AnalyzeActionInterface
 	writeActionTemplate          WriteActionInterface
 	reviewActionTemplate         ReviewActionInterface
@@ -44,11 +49,12 @@ func NewActionFactory(
 	planActionTemplate PlanActionInterface,
 	externalSearchActionTemplate ExternalSearchActionInterface,
 	internalSearchActionTemplate InternalSearchActionInterface,
+	dataAnalysisActionTemplate DataAnalysisActionInterface,
 	analyzeActionTemplate AnalyzeActionInterface,
 	writeActionTemplate WriteActionInterface,
 	reviewActionTemplate ReviewActionInterface,
 ) *ActionFactory {
-	return &ActionFactory{planActionTemplate: planActionTemplate, externalSearchActionTemplate: externalSearchActionTemplate, internalSearchActionTemplate: internalSearchActionTemplate, analyzeActionTemplate: analyzeActionTemplate, writeActionTemplate: writeActionTemplate, reviewActionTemplate: reviewActionTemplate}
+	return &ActionFactory{planActionTemplate: planActionTemplate, externalSearchActionTemplate: externalSearchActionTemplate, internalSearchActionTemplate: internalSearchActionTemplate, dataAnalysisActionTemplate: dataAnalysisActionTemplate, analyzeActionTemplate: analyzeActionTemplate, writeActionTemplate: writeActionTemplate, reviewActionTemplate: reviewActionTemplate}
 }
 
 func (f *ActionFactory) GetActionTemplate(actionType value.ActionType) (ActionTemplate, error) {
@@ -59,6 +65,8 @@ func (f *ActionFactory) GetActionTemplate(actionType value.ActionType) (ActionTe
 		return f.externalSearchActionTemplate, nil
 	case value.ActionTypeInternalSearch:
 		return f.internalSearchActionTemplate, nil
+	case value.ActionTypeDataAnalysis:
+		return f.dataAnalysisActionTemplate, nil
 	case value.ActionTypeAnalyze:
 		return f.analyzeActionTemplate, nil
 	case value.ActionTypeWrite:
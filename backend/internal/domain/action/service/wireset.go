@@ -6,6 +6,7 @@ var Set = wire.NewSet(
 	NewPlanAction,
 	NewExternalSearchAction,
 	NewInternalSearchAction,
+	NewDataAnalysisAction,
 	NewAnalyzeAction,
 	NewWriteAction,
 	NewReviewAction,
@@ -2,15 +2,19 @@ package service
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
+	"strings"
 
 	actionValue "github.com/goda6565/ai-consultant/backend/internal/domain/action/value"
 	agentValue "github.com/goda6565/ai-consultant/backend/internal/domain/agent/value"
 	"github.com/goda6565/ai-consultant/backend/internal/domain/llm"
+	"github.com/goda6565/ai-consultant/backend/internal/domain/prompt/prompts"
 	"github.com/goda6565/ai-consultant/backend/internal/domain/prompt/service"
 )
 
+// defaultChangeReason is used when the model omits the change reason.
+const defaultChangeReason = "提案書本文を更新"
+
 type WriteAction struct {
 	llmClient     llm.LLMClient
 	promptBuilder *service.PromptBuilder
@@ -20,50 +24,79 @@ func NewWriteAction(llmClient llm.LLMClient, promptBuilder *service.PromptBuilde
 	return &WriteAction{llmClient: llmClient, promptBuilder: promptBuilder}
 }
 
-type WriteActionOutputStruct struct {
-	Content      string `json:"content"`
-	ChangeReason string `json:"change_reason"`
-}
-
 func (w *WriteAction) Execute(ctx context.Context, input ActionTemplateInput) (*ActionTemplateOutput, error) {
 	prompt := w.promptBuilder.Build(service.PromptBuilderInput{
 		ActionType: actionValue.ActionTypeWrite,
 		State:      input.State,
 	})
-	llmInput := llm.GenerateStructuredTextInput{
+	llmInput := llm.GenerateTextInput{
 		SystemPrompt: prompt.SystemPrompt,
 		UserPrompt:   prompt.UserPrompt,
-		Config:       llm.LLMConfig{Provider: llm.VertexAI, Model: llm.Gemini25Flash},
+		Config:       input.State.GetModelConfig(actionValue.ActionTypeWrite),
 		Temperature:  0.0,
-		Schema: json.RawMessage(`
-			{
-				"type": "object",
-				"properties": {
-					"content": {
-						"type": "string"
-					},
-					"change_reason": {
-						"type": "string"
-					}
-				},
-				"required": ["content", "change_reason"]
-			}
-		`),
 	}
-	llmOutput, err := w.llmClient.GenerateStructuredText(ctx, llmInput)
+	// 本文は JSON ではなくプレーンテキストで生成し、書いている途中から配信する
+	var onDelta llm.DeltaHandler
+	if input.OnDelta != nil {
+		onDelta = newContentDeltaHandler(input.OnDelta)
+	}
+	llmOutput, err := llm.GenerateTextWithStream(ctx, w.llmClient, llmInput, onDelta)
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate text: %w", err)
 	}
+	content, changeReason := splitWriteOutput(llmOutput.Text)
 
-	var output WriteActionOutputStruct
-	if err := json.Unmarshal([]byte(llmOutput.Text), &output); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal output: %w", err)
-	}
-
-	newContent := agentValue.NewContent(output.Content)
-	action, err := CreateAction(input.State, actionValue.ActionTypeWrite, "", output.ChangeReason)
+	newContent := agentValue.NewContent(content)
+	action, err := CreateAction(input.State, actionValue.ActionTypeWrite, "", changeReason)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create action: %w", err)
 	}
 	return &ActionTemplateOutput{Action: *action, Content: *newContent}, nil
 }
+
+// splitWriteOutput splits the output into the proposal body and the change reason.
+// When the delimiter is missing, the whole output is treated as the body.
+func splitWriteOutput(text string) (string, string) {
+	content, changeReason, _ := strings.Cut(text, prompts.WriteChangeReasonDelimiter)
+	changeReason = strings.TrimSpace(changeReason)
+	if changeReason == "" {
+		changeReason = defaultChangeReason
+	}
+	return strings.TrimSpace(content), changeReason
+}
+
+// newContentDeltaHandler forwards only the proposal body.
+// The tail that may be the beginning of the delimiter is held back until the next delta decides it.
+func newContentDeltaHandler(onDelta llm.DeltaHandler) llm.DeltaHandler {
+	var text strings.Builder
+	sent := 0
+	done := false
+	return func(ctx context.Context, delta string) {
+		if done {
+			return
+		}
+		text.WriteString(delta)
+		current := text.String()
+		end := len(current)
+		if index := strings.Index(current, prompts.WriteChangeReasonDelimiter); index >= 0 {
+			end = index
+			done = true
+		} else {
+			end -= delimiterPrefixLength(current)
+		}
+		if end > sent {
+			onDelta(ctx, current[sent:end])
+			sent = end
+		}
+	}
+}
+
+// delimiterPrefixLength returns the length of the longest suffix of text that is a prefix of the delimiter.
+func delimiterPrefixLength(text string) int {
+	for n := min(len(text), len(prompts.WriteChangeReasonDelimiter)-1); n > 0; n-- {
+		if strings.HasSuffix(text, prompts.WriteChangeReasonDelimiter[:n]) {
+			return n
+		}
+	}
+	return 0
+}
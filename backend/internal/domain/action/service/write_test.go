@@ -0,0 +1,66 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/goda6565/ai-consultant/backend/internal/domain/prompt/prompts"
+)
+
+func TestContentDeltaHandler(t *testing.T) {
+	delimiter := prompts.WriteChangeReasonDelimiter
+	tests := []struct {
+		name     string
+		deltas   []string
+		expected string
+	}{
+		{
+			name:     "forwards the whole body without delimiter",
+			deltas:   []string{"## 現状", "分析\n", "本文"},
+			expected: "## 現状分析\n本文",
+		},
+		{
+			name:     "stops at the delimiter",
+			deltas:   []string{"本文\n", delimiter + "\n追加"},
+			expected: "本文\n",
+		},
+		{
+			name:     "holds back a delimiter split across deltas",
+			deltas:   []string{"本文\n" + delimiter[:5], delimiter[5:], "\n追加"},
+			expected: "本文\n",
+		},
+		{
+			name:     "releases a held tail that is not the delimiter",
+			deltas:   []string{"A ===", " B"},
+			expected: "A === B",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var b strings.Builder
+			handler := newContentDeltaHandler(func(ctx context.Context, delta string) {
+				b.WriteString(delta)
+			})
+			for _, delta := range tt.deltas {
+				handler(context.Background(), delta)
+			}
+			if b.String() != tt.expected {
+				t.Errorf("forwarded = %q, expected %q", b.String(), tt.expected)
+			}
+		})
+	}
+}
+
+func TestSplitWriteOutput(t *testing.T) {
+	content, changeReason := splitWriteOutput("## 提案\n本文\n" + prompts.WriteChangeReasonDelimiter + "\n追加: 根拠を追加した。\n")
+	if content != "## 提案\n本文" || changeReason != "追加: 根拠を追加した。" {
+		t.Errorf("content = %q, changeReason = %q", content, changeReason)
+	}
+
+	content, changeReason = splitWriteOutput("本文のみ")
+	if content != "本文のみ" || changeReason != defaultChangeReason {
+		t.Errorf("content = %q, changeReason = %q", content, changeReason)
+	}
+}
@@ -0,0 +1,139 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/goda6565/ai-consultant/backend/internal/domain/llm"
+	"github.com/goda6565/ai-consultant/backend/internal/domain/search"
+	"github.com/goda6565/ai-consultant/backend/internal/pkg/logger"
+)
+
+// 1つのトピックにつき元のクエリに加えて生成する言い換えと仮想回答の数
+const (
+	documentSearchNumParaphrases  = 2
+	documentSearchNumHypothetical = 1
+)
+
+// expandQuery returns the variants of the query to search. Only the query is searched when no QueryExpander is set
+// or the expansion fails.
+func (s *SearchTools) expandQuery(ctx context.Context, query string) []search.QueryVariant {
+	original := []search.QueryVariant{{Kind: search.QueryVariantKindOriginal, Text: query}}
+	if s.QueryExpander == nil {
+		return original
+	}
+	output, err := s.QueryExpander.Expand(ctx, search.ExpandQueryInput{
+		Query:           query,
+		NumParaphrases:  documentSearchNumParaphrases,
+		NumHypothetical: documentSearchNumHypothetical,
+		Config:          s.getLLMConfig(),
+	})
+	if err != nil {
+		logger.GetLogger(ctx).Warn("failed to expand query, searching only the query", "query", query, "error", err)
+		return original
+	}
+	return output.Variants
+}
+
+// retrieve searches the documents with every variant of the query and merges the hits.
+// The variants start with the original query.
+// A variant that fails is skipped; the search fails only when every variant fails.
+func (s *SearchTools) retrieve(ctx context.Context, variants []search.QueryVariant, input search.DocumentSearchInput) ([]search.DocumentSearchResult, error) {
+	logger := logger.GetLogger(ctx)
+	// 展開しない場合は結果をそのまま返す。リプレイでは記録済みの結果がどのクエリで見つかったかを引き継ぐ
+	if s.QueryExpander == nil {
+		return s.searchVariant(ctx, variants[0], input)
+	}
+	// 結果はクエリの順に並べ、同じ入力なら同じ出力になるようにする
+	wg := sync.WaitGroup{}
+	variantResults := make([][]search.DocumentSearchResult, len(variants))
+	variantErrors := make([]error, len(variants))
+	for n, variant := range variants {
+		wg.Add(1)
+		go func(n int, variant search.QueryVariant) {
+			defer wg.Done()
+			defer func() {
+				if r := recover(); r != nil {
+					variantErrors[n] = fmt.Errorf("panic: %v", r)
+				}
+			}()
+			variantResults[n], variantErrors[n] = s.searchVariant(ctx, variant, input)
+		}(n, variant)
+	}
+	wg.Wait()
+
+	var lastErr error
+	succeeded := 0
+	for n, err := range variantErrors {
+		if err != nil {
+			logger.Warn("failed to search document", "kind", variants[n].Kind, "query", variants[n].Text, "error", err)
+			lastErr = err
+			continue
+		}
+		succeeded++
+	}
+	if succeeded == 0 && lastErr != nil {
+		return nil, lastErr
+	}
+	return mergeVariantResults(variants, variantResults, input.MaxNumResults), nil
+}
+
+func (s *SearchTools) searchVariant(ctx context.Context, variant search.QueryVariant, input search.DocumentSearchInput) ([]search.DocumentSearchResult, error) {
+	embedding, err := s.llmClient.GenerateEmbedding(ctx, llm.GenerateEmbeddingInput{
+		Text: variant.Text,
+		Config: llm.EmbeddingConfig{
+			Provider: llm.VertexAI,
+			Model:    llm.GeminiEmbedding001,
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate embedding: %w", err)
+	}
+	input.Query = variant.Text
+	input.Embedding = &embedding.Embedding
+	// 仮想回答は文章なので語句の一致では絞れない。埋め込みの近さだけで探す
+	if variant.Kind == search.QueryVariantKindHypothetical {
+		input.Mode = search.DocumentSearchModeVector
+	}
+	output, err := s.DocumentSearchTool.Search(ctx, input)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search document: %w", err)
+	}
+	return output.Results, nil
+}
+
+// mergeVariantResults interleaves the rankings of the variants by rank and removes the duplicated chunks.
+// Each result records every variant that found it, and the highest similarity among them.
+func mergeVariantResults(variants []search.QueryVariant, variantResults [][]search.DocumentSearchResult, maxNumResults int) []search.DocumentSearchResult {
+	merged := []search.DocumentSearchResult{}
+	positions := map[string]int{}
+	for rank := 0; ; rank++ {
+		found := false
+		for n, results := range variantResults {
+			if rank >= len(results) {
+				continue
+			}
+			found = true
+			result := results[rank]
+			position, ok := positions[result.ChunkID]
+			if !ok {
+				positions[result.ChunkID] = len(merged)
+				result.QueryVariants = []search.QueryVariant{variants[n]}
+				merged = append(merged, result)
+				continue
+			}
+			merged[position].QueryVariants = append(merged[position].QueryVariants, variants[n])
+			if result.Similarity != nil && (merged[position].Similarity == nil || *result.Similarity > *merged[position].Similarity) {
+				merged[position].Similarity = result.Similarity
+			}
+		}
+		if !found {
+			break
+		}
+	}
+	if maxNumResults > 0 && maxNumResults < len(merged) {
+		merged = merged[:maxNumResults]
+	}
+	return merged
+}
@@ -0,0 +1,51 @@
+package tools
+
+import (
+	"testing"
+
+	"github.com/goda6565/ai-consultant/backend/internal/domain/search"
+)
+
+func TestMergeVariantResults(t *testing.T) {
+	original := search.QueryVariant{Kind: search.QueryVariantKindOriginal, Text: "来店数の推移"}
+	paraphrase := search.QueryVariant{Kind: search.QueryVariantKindParaphrase, Text: "来客数の変化"}
+	low, high := 0.6, 0.9
+	a := search.DocumentSearchResult{ChunkID: "a", Similarity: &low}
+	b := search.DocumentSearchResult{ChunkID: "b"}
+	c := search.DocumentSearchResult{ChunkID: "c"}
+	aFromParaphrase := search.DocumentSearchResult{ChunkID: "a", Similarity: &high}
+
+	merged := mergeVariantResults(
+		[]search.QueryVariant{original, paraphrase},
+		[][]search.DocumentSearchResult{{a, b}, {c, aFromParaphrase}},
+		0,
+	)
+
+	// interleaved by rank: a (0), c (0), b (1), a (1) is a duplicate
+	expected := []string{"a", "c", "b"}
+	if len(merged) != len(expected) {
+		t.Fatalf("merged = %d results, expected %d", len(merged), len(expected))
+	}
+	for n, result := range merged {
+		if result.ChunkID != expected[n] {
+			t.Errorf("merged[%d] = %s, expected %s", n, result.ChunkID, expected[n])
+		}
+	}
+	if len(merged[0].QueryVariants) != 2 || merged[0].QueryVariants[0] != original || merged[0].QueryVariants[1] != paraphrase {
+		t.Errorf("variants of a = %v, expected both", merged[0].QueryVariants)
+	}
+	if merged[0].Similarity == nil || *merged[0].Similarity != high {
+		t.Errorf("similarity of a = %v, expected %v", merged[0].Similarity, high)
+	}
+	if len(merged[1].QueryVariants) != 1 || merged[1].QueryVariants[0] != paraphrase {
+		t.Errorf("variants of c = %v, expected the paraphrase", merged[1].QueryVariants)
+	}
+	// the input is not modified
+	if a.QueryVariants != nil || *a.Similarity != low {
+		t.Error("input results must not be modified")
+	}
+
+	if truncated := mergeVariantResults([]search.QueryVariant{original, paraphrase}, [][]search.DocumentSearchResult{{a, b}, {c}}, 2); len(truncated) != 2 {
+		t.Errorf("truncated = %d results, expected 2", len(truncated))
+	}
+}
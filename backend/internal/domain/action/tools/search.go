@@ -8,9 +8,11 @@ import (
 	"sync"
 
 	"github.com/goda6565/ai-consultant/backend/internal/domain/errors"
+	jobConfigValue "github.com/goda6565/ai-consultant/backend/internal/domain/job_config/value"
 	"github.com/goda6565/ai-consultant/backend/internal/domain/llm"
 	"github.com/goda6565/ai-consultant/backend/internal/domain/search"
-	"github.com/goda6565/ai-consultant/backend/internal/pkg/scraper"
+	traceService "github.com/goda6565/ai-consultant/backend/internal/domain/trace/service"
+	traceValue "github.com/goda6565/ai-consultant/backend/internal/domain/trace/value"
 
 	"github.com/goda6565/ai-consultant/backend/internal/pkg/logger"
 )
@@ -18,9 +20,17 @@ import (
 type SearchTools struct {
 	// required
 	llmClient llm.LLMClient
+	// llmConfig is the model of the reranking and the query expansion. The default model is used when it is not set.
+	llmConfig *llm.LLMConfig
 	// tools
 	WebSearchTool      search.WebSearchClient
 	DocumentSearchTool search.DocumentSearchClient
+	ScraperClient      search.ScraperClient
+	// document search results are reranked when it is set. It is nil when DOCUMENT_SEARCH_RERANK is false,
+	// and in the replay, whose recorded results are already reranked.
+	Reranker search.Reranker
+	// optional: document search also searches the paraphrases and the hypothetical answers of the query when it is set
+	QueryExpander search.QueryExpander
 }
 
 type FunctionName string
@@ -33,11 +43,40 @@ const (
 	DocumentSearchDescription = "システム内に蓄積されたドキュメントを検索する。社内ナレッジ、過去の事例、内部文書、ユーザー固有の情報、組織固有のベストプラクティスなどを調べる場合に使用する。"
 )
 
+// SearchResultsAttributeKey はトレースに残す検索結果 (JSON) の属性名
+const SearchResultsAttributeKey = "results"
+
+// QueryAttributeKey はトレースに残す検索クエリの属性名
+const QueryAttributeKey = "query"
+
 const defaultWebSearchMaxNumResults = 5
 const defaultDocumentSearchMaxNumResults = 5
 
-func NewSearchTools(llmClient llm.LLMClient, webSearchTool search.WebSearchClient, documentSearchTool search.DocumentSearchClient) *SearchTools {
-	return &SearchTools{llmClient: llmClient, WebSearchTool: webSearchTool, DocumentSearchTool: documentSearchTool}
+// rerankCandidateFactor は再ランキングの前に結果の何倍の候補を取得するか
+const rerankCandidateFactor = 4
+
+// documentSearchMinSimilarity より類似度が低いチャンクは無関係とみなして返さない
+const documentSearchMinSimilarity = 0.5
+
+// DocumentURLFormat は社内ドキュメントのチャンクを出典として示す URL の形式。ストレージの URL は公開しない
+const DocumentURLFormat = "document://%s#chunk-%d"
+
+func NewSearchTools(llmClient llm.LLMClient, webSearchTool search.WebSearchClient, documentSearchTool search.DocumentSearchClient, scraperClient search.ScraperClient, reranker search.Reranker, queryExpander search.QueryExpander) *SearchTools {
+	return &SearchTools{llmClient: llmClient, WebSearchTool: webSearchTool, DocumentSearchTool: documentSearchTool, ScraperClient: scraperClient, Reranker: reranker, QueryExpander: queryExpander}
+}
+
+// WithLLMConfig returns a copy of the tools that rerank and expand the queries with the model, e.g. the model of the calling action.
+func (s *SearchTools) WithLLMConfig(config llm.LLMConfig) *SearchTools {
+	copied := *s
+	copied.llmConfig = &config
+	return &copied
+}
+
+func (s *SearchTools) getLLMConfig() llm.LLMConfig {
+	if s.llmConfig == nil {
+		return jobConfigValue.DefaultLLMConfig
+	}
+	return *s.llmConfig
 }
 
 func (s *SearchTools) Tools() []llm.Function {
@@ -72,9 +111,18 @@ type ExecuteInput struct {
 }
 
 type SearchResult struct {
-	Title   string
-	Content string
-	URL     string
+	Title   string `json:"title"`
+	Content string `json:"content"`
+	URL     string `json:"url"`
+	// Score is the relevance given by the reranker
+	Score *float64 `json:"score,omitempty"`
+	// document search only
+	DocumentID string   `json:"documentId,omitempty"`
+	ChunkID    string   `json:"chunkId,omitempty"`
+	ChunkIndex int      `json:"chunkIndex,omitempty"`
+	Similarity *float64 `json:"similarity,omitempty"`
+	// QueryVariants are the variants of the query that found the chunk
+	QueryVariants []search.QueryVariant `json:"queryVariants,omitempty"`
 }
 
 type ExecuteOutput struct {
@@ -86,8 +134,14 @@ func (e *ExecuteOutput) String() string {
 	builder.WriteString("SearchResults:\n")
 	for _, result := range e.SearchResults {
 		builder.WriteString(fmt.Sprintf("Title: %s\n", result.Title))
+		if result.Score != nil {
+			builder.WriteString(fmt.Sprintf("Score: %.2f\n", *result.Score))
+		}
 		builder.WriteString(fmt.Sprintf("Content: %s\n", result.Content))
 		builder.WriteString(fmt.Sprintf("URL: %s\n", result.URL))
+		for _, variant := range result.QueryVariants {
+			builder.WriteString(fmt.Sprintf("Query: (%s) %s\n", variant.Kind, variant.Text))
+		}
 	}
 	return builder.String()
 }
@@ -113,6 +167,34 @@ func (s *SearchTools) Execute(ctx context.Context, input ExecuteInput) (*Execute
 	}
 }
 
+// Call executes the function call as a tool of llm.RunToolLoop.
+func (s *SearchTools) Call(ctx context.Context, call llm.FunctionCall) (string, error) {
+	result, _, err := s.CallWithResults(ctx, call)
+	return result, err
+}
+
+// CallWithResults is Call that also returns the search results.
+func (s *SearchTools) CallWithResults(ctx context.Context, call llm.FunctionCall) (result string, searchResults []SearchResult, err error) {
+	ctx, span := traceService.Start(ctx, call.Name, traceValue.SpanKindTool)
+	defer func() {
+		span.End(err)
+	}()
+	query, ok := call.Arguments["query"].(string)
+	if !ok || query == "" {
+		return "", nil, errors.NewDomainError(errors.ValidationError, fmt.Sprintf("query is required for %s", call.Name))
+	}
+	span.SetAttribute(QueryAttributeKey, query)
+	output, err := s.Execute(ctx, ExecuteInput{Function: call})
+	if err != nil {
+		return "", nil, err
+	}
+	// リプレイで同じ検索結果を返せるよう結果をそのまま残す
+	if results, err := json.Marshal(output.SearchResults); err == nil {
+		span.SetAttribute(SearchResultsAttributeKey, string(results))
+	}
+	return output.String(), output.SearchResults, nil
+}
+
 func (s *SearchTools) webSearch(ctx context.Context, query string) ([]SearchResult, error) {
 	logger := logger.GetLogger(ctx)
 	output, err := s.WebSearchTool.Search(ctx, search.WebSearchInput{Query: query, MaxNumResults: defaultWebSearchMaxNumResults})
@@ -120,59 +202,85 @@ func (s *SearchTools) webSearch(ctx context.Context, query string) ([]SearchResu
 		return nil, fmt.Errorf("failed to search web: %w", err)
 	}
 
+	// 結果は検索順に並べ、同じ入力なら同じ出力になるようにする
 	wg := sync.WaitGroup{}
-	scrapeChannel := make(chan SearchResult)
-	scrapeClient := scraper.NewScraperClient()
-	for _, result := range output.Results {
+	scrapedResults := make([]*SearchResult, len(output.Results))
+	for n, result := range output.Results {
 		wg.Add(1)
-		go func(result search.WebSearchResult) {
+		go func(n int, result search.WebSearchResult) {
 			defer wg.Done()
 			defer func() {
 				if r := recover(); r != nil {
 					logger.Error("failed to scrape", "error", r)
 				}
 			}()
-			content, err := scrapeClient.Scrape(ctx, result.URL)
+			content, err := s.ScraperClient.Scrape(ctx, result.URL)
 			if err != nil {
 				return
 			}
-			searchResult := SearchResult{Title: result.Title, Content: content, URL: result.URL}
-			scrapeChannel <- searchResult
-		}(result)
+			scrapedResults[n] = &SearchResult{Title: result.Title, Content: content, URL: result.URL}
+		}(n, result)
 	}
-
-	go func() {
-		wg.Wait()
-		close(scrapeChannel)
-	}()
+	wg.Wait()
 
 	searchResults := []SearchResult{}
-	for searchResult := range scrapeChannel {
-		searchResults = append(searchResults, searchResult)
+	for _, searchResult := range scrapedResults {
+		if searchResult != nil {
+			searchResults = append(searchResults, *searchResult)
+		}
 	}
 
 	return searchResults, nil
 }
 
 func (s *SearchTools) documentSearch(ctx context.Context, query string) ([]SearchResult, error) {
-	embedding, err := s.llmClient.GenerateEmbedding(ctx, llm.GenerateEmbeddingInput{
-		Text: query,
-		Config: llm.EmbeddingConfig{
-			Provider: llm.VertexAI,
-			Model:    llm.GeminiEmbedding001,
-		},
-	})
-	if err != nil {
-		return nil, fmt.Errorf("failed to generate embedding: %w", err)
+	// 社内文書の言い回しは検索クエリと異なることが多いため、言い換えと仮想回答でも検索する
+	variants := s.expandQuery(ctx, query)
+	maxNumResults := defaultDocumentSearchMaxNumResults
+	if s.Reranker != nil {
+		maxNumResults *= rerankCandidateFactor
 	}
-	output, err := s.DocumentSearchTool.Search(ctx, search.DocumentSearchInput{Query: query, Embedding: &embedding.Embedding, MaxNumResults: defaultDocumentSearchMaxNumResults})
+	minSimilarity := documentSearchMinSimilarity
+	// 型番や支店名などの語句はベクトル検索だけでは拾えないため全文検索と組み合わせる
+	input := search.DocumentSearchInput{MaxNumResults: maxNumResults, Mode: search.DocumentSearchModeHybrid, MinSimilarity: &minSimilarity}
+	// ジョブ設定で許可されたドキュメントだけを検索する
+	if documentIDs, ok := search.AllowedDocumentIDsFromContext(ctx); ok {
+		input.AllowedDocumentIDs = &documentIDs
+	}
+	results, err := s.retrieve(ctx, variants, input)
 	if err != nil {
-		return nil, fmt.Errorf("failed to search document: %w", err)
+		return nil, err
+	}
+	if s.Reranker != nil {
+		results = s.rerank(ctx, query, results)
 	}
 	searchResults := []SearchResult{}
-	for _, result := range output.Results {
-		searchResult := SearchResult{Title: result.Title, Content: result.Content, URL: result.URL}
+	for _, result := range results {
+		searchResult := SearchResult{
+			Title:         result.Title,
+			Content:       result.Content,
+			URL:           fmt.Sprintf(DocumentURLFormat, result.DocumentID, result.ChunkIndex),
+			Score:         result.RerankScore,
+			DocumentID:    result.DocumentID,
+			ChunkID:       result.ChunkID,
+			ChunkIndex:    result.ChunkIndex,
+			Similarity:    result.Similarity,
+			QueryVariants: result.QueryVariants,
+		}
 		searchResults = append(searchResults, searchResult)
 	}
 	return searchResults, nil
 }
+
+// rerank keeps the fused order when the reranking fails, as the candidates are still relevant by similarity.
+func (s *SearchTools) rerank(ctx context.Context, query string, results []search.DocumentSearchResult) []search.DocumentSearchResult {
+	reranked, err := s.Reranker.Rerank(ctx, search.RerankInput{Query: query, Candidates: results, MaxNumResults: defaultDocumentSearchMaxNumResults, Config: s.getLLMConfig()})
+	if err != nil {
+		logger.GetLogger(ctx).Warn("failed to rerank, keeping the fused order", "query", query, "error", err)
+		if len(results) > defaultDocumentSearchMaxNumResults {
+			results = results[:defaultDocumentSearchMaxNumResults]
+		}
+		return results
+	}
+	return reranked.Results
+}
@@ -0,0 +1,109 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/goda6565/ai-consultant/backend/internal/domain/llm"
+	llmMock "github.com/goda6565/ai-consultant/backend/internal/domain/llm/mock"
+	"github.com/goda6565/ai-consultant/backend/internal/domain/search"
+	searchMock "github.com/goda6565/ai-consultant/backend/internal/domain/search/mock"
+	"github.com/goda6565/ai-consultant/backend/internal/pkg/logger"
+	"go.uber.org/mock/gomock"
+)
+
+type nopLogger struct{}
+
+func (nopLogger) Info(string, ...interface{})  {}
+func (nopLogger) Debug(string, ...interface{}) {}
+func (nopLogger) Warn(string, ...interface{})  {}
+func (nopLogger) Error(string, ...interface{}) {}
+func (nopLogger) Fatal(string, ...interface{}) {}
+func (nopLogger) Panic(string, ...interface{}) {}
+func (nopLogger) LogUsage(llm.Usage)           {}
+func (nopLogger) Sync() error                  { return nil }
+
+func testContext() context.Context {
+	return logger.WithLogger(context.Background(), nopLogger{})
+}
+
+func TestSearchTools_DocumentSearch_RerankFailure(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	llmClient := llmMock.NewMockLLMClient(ctrl)
+	documentSearchClient := searchMock.NewMockDocumentSearchClient(ctrl)
+	reranker := searchMock.NewMockReranker(ctrl)
+
+	candidates := []search.DocumentSearchResult{}
+	for n := range defaultDocumentSearchMaxNumResults * rerankCandidateFactor {
+		candidates = append(candidates, search.DocumentSearchResult{ChunkID: fmt.Sprintf("chunk-%d", n)})
+	}
+	llmClient.EXPECT().GenerateEmbedding(gomock.Any(), gomock.Any()).Return(&llm.GenerateEmbeddingOutput{Embedding: []float32{0.1}}, nil).Times(1)
+	documentSearchClient.EXPECT().Search(gomock.Any(), gomock.Any()).Return(&search.DocumentSearchOutput{Results: candidates}, nil).Times(1)
+	reranker.EXPECT().Rerank(gomock.Any(), gomock.Any()).Return(nil, errors.New("unavailable")).Times(1)
+
+	tools := NewSearchTools(llmClient, nil, documentSearchClient, nil, reranker, nil)
+	results, err := tools.documentSearch(testContext(), "AB-123")
+	if err != nil {
+		t.Fatalf("expected the fused order on a rerank failure, got %v", err)
+	}
+	if len(results) != defaultDocumentSearchMaxNumResults {
+		t.Fatalf("results = %d, expected %d", len(results), defaultDocumentSearchMaxNumResults)
+	}
+	for n, result := range results {
+		if result.ChunkID != candidates[n].ChunkID {
+			t.Errorf("results[%d] = %s, expected %s", n, result.ChunkID, candidates[n].ChunkID)
+		}
+		if result.Score != nil {
+			t.Errorf("results[%d] has a score without reranking", n)
+		}
+	}
+}
+
+func TestSearchTools_WithLLMConfig(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	reranker := searchMock.NewMockReranker(ctrl)
+	config := llm.LLMConfig{Provider: llm.OpenAI, Model: llm.GPT4o}
+	reranker.EXPECT().Rerank(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, input search.RerankInput) (*search.RerankOutput, error) {
+		if input.Config != config {
+			t.Errorf("config = %v, expected %v", input.Config, config)
+		}
+		return &search.RerankOutput{Results: input.Candidates}, nil
+	}).Times(1)
+
+	tools := NewSearchTools(nil, nil, nil, nil, reranker, nil)
+	tools.WithLLMConfig(config).rerank(testContext(), "AB-123", []search.DocumentSearchResult{{ChunkID: "a"}})
+	if tools.llmConfig != nil {
+		t.Error("the shared tools must not be changed")
+	}
+}
+
+func TestSearchTools_DocumentSearch_ExpansionFailure(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	llmClient := llmMock.NewMockLLMClient(ctrl)
+	documentSearchClient := searchMock.NewMockDocumentSearchClient(ctrl)
+	queryExpander := searchMock.NewMockQueryExpander(ctrl)
+
+	queryExpander.EXPECT().Expand(gomock.Any(), gomock.Any()).Return(nil, errors.New("unavailable")).Times(1)
+	// only the query is searched
+	llmClient.EXPECT().GenerateEmbedding(gomock.Any(), gomock.Any()).Return(&llm.GenerateEmbeddingOutput{Embedding: []float32{0.1}}, nil).Times(1)
+	documentSearchClient.EXPECT().Search(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, input search.DocumentSearchInput) (*search.DocumentSearchOutput, error) {
+		if input.Query != "AB-123" {
+			t.Errorf("query = %s, expected AB-123", input.Query)
+		}
+		return &search.DocumentSearchOutput{Results: []search.DocumentSearchResult{{ChunkID: "a"}}}, nil
+	}).Times(1)
+
+	tools := NewSearchTools(llmClient, nil, documentSearchClient, nil, nil, queryExpander)
+	results, err := tools.documentSearch(testContext(), "AB-123")
+	if err != nil {
+		t.Fatalf("expected the query to be searched on an expansion failure, got %v", err)
+	}
+	if len(results) != 1 || len(results[0].QueryVariants) != 1 || results[0].QueryVariants[0].Kind != search.QueryVariantKindOriginal {
+		t.Errorf("results = %v, expected the hit of the original query", results)
+	}
+}
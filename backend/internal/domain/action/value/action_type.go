@@ -1,8 +1,6 @@
 package value
 
 import (
-	"strings"
-
 	"github.com/goda6565/ai-consultant/backend/internal/domain/errors"
 )
 
@@ -12,6 +10,7 @@ const (
 	ActionTypePlan           ActionType = "plan"
 	ActionTypeExternalSearch ActionType = "externalSearch"
 	ActionTypeInternalSearch ActionType = "internalSearch"
+	ActionTypeDataAnalysis   ActionType = "dataAnalysis"
 	ActionTypeAnalyze        ActionType = "analyze"
 	ActionTypeWrite          ActionType = "write"
 	ActionTypeReview         ActionType = "review"
@@ -23,67 +22,13 @@ const (
 	SelfActionTypeSkipper      ActionType = "skipper"
 	SelfActionTypeReflection   ActionType = "reflection"
 	SelfActionTypeSummarize    ActionType = "summarize"
-)
-
-func ActionRoute(enableInternalSearch bool) string {
-	var b strings.Builder
-	b.WriteString("【アクションルート】\n")
-
-	if enableInternalSearch {
-		b.WriteString(`
-┌──────────────┐
-│     plan     │──▶ externalSearch ──▶ internalSearch ──▶ analyze ──▶ write ──▶ review
-└──────────────┘                                  │
-        ▲                                         │
-        └─────────────────────────────────────────┘（内容が不十分な場合は再ループ）
-
-reviewで十分な品質に達した場合のみ「完了」と判断する。
-`)
-	} else {
-		b.WriteString(`
-┌──────────────┐
-│     plan     │──▶ externalSearch ──▶ analyze ──▶ write ──▶ review
-└──────────────┘                                  │
-        ▲                                         │
-        └─────────────────────────────────────────┘（内容が不十分な場合は再ループ）
-
-reviewで十分な品質に達した場合のみ「完了」と判断する。
-`)
-	}
-
-	b.WriteString("\n【各アクションの役割と限界】\n")
-	b.WriteString("- plan: 課題を分析し、次に取るべき行動を計画する\n")
-	b.WriteString("    得意: 問題構造の把握・優先度設定\n")
-	b.WriteString("    限界: 新しい情報の取得や数値データの生成は行わない\n\n")
-
-	b.WriteString("- externalSearch: 業界全体や他社動向など、外部公開情報を検索・収集する\n")
-	b.WriteString("    得意: 公開レポート、ニュース、統計、他社事例の抽出\n")
-	b.WriteString("    限界: 自社内部データ（顧客満足度スコア、支店別指標など）は取得できない\n\n")
-
-	if enableInternalSearch {
-		b.WriteString("- internalSearch: 支店レポートや顧客アンケートなど、内部ナレッジベースから情報を検索・抽出する\n")
-		b.WriteString("    得意: 社内文書・顧客アンケート・支店レポートの参照（RAGなど）\n")
-		b.WriteString("    限界: 社内で未収集の定量データや、暗黙知（個人経験・感情など）は取得できない\n\n")
-	}
+	SelfActionTypeGoal         ActionType = "goal"
+	// 承認ゲートで人間が下した判断を履歴に残すためのアクション種別
+	SelfActionTypeApproval ActionType = "approval"
 
-	b.WriteString("- analyze: 外部・内部の情報を統合し、要約・構造化・比較評価を行う\n")
-	b.WriteString("    得意: 情報の整理・仮説構築・優先度分析\n")
-	b.WriteString("    限界: 情報源が不足している場合、新しい事実を補完することはできない\n\n")
-
-	b.WriteString("- write: 分析結果をもとに、レポートや提案文を生成する\n")
-	b.WriteString("    得意: 明確で論理的なレポート構成、改善提案の文章化\n")
-	b.WriteString("    限界: 不完全な情報や曖昧な分析を自動的に補正することはできない\n\n")
-
-	b.WriteString("- review: 生成物の品質を検証し、改善点を特定する\n")
-	b.WriteString("    得意: 内容の一貫性・論理性・KPIの妥当性の評価\n")
-	b.WriteString("    限界: 情報不足や根本的なデータ欠如は指摘できるが、自動補完はできない\n\n")
-
-	b.WriteString("【注意】\n")
-	b.WriteString("reviewは最終アクションではなく、品質が基準を満たさない場合はplanに戻って再実行します。\n")
-	b.WriteString("このサイクルにより、情報収集→分析→生成→検証の反復によってレポート精度を高めます。\n")
-
-	return b.String()
-}
+	// ヒアリング中の呼び出しをまとめて扱うためのアクション種別
+	SelfActionTypeHearing ActionType = "hearing"
+)
 
 func (a ActionType) Equals(other ActionType) bool {
 	return a == other
@@ -93,28 +38,6 @@ func (a ActionType) Value() string {
 	return string(a)
 }
 
-func (a ActionType) Proceed(enableInternalSearch bool) ActionType {
-	switch a {
-	case ActionTypePlan:
-		return ActionTypeExternalSearch
-	case ActionTypeExternalSearch:
-		if enableInternalSearch {
-			return ActionTypeInternalSearch
-		}
-		return ActionTypeAnalyze
-	case ActionTypeInternalSearch:
-		return ActionTypeAnalyze
-	case ActionTypeAnalyze:
-		return ActionTypeWrite
-	case ActionTypeWrite:
-		return ActionTypeReview
-	case ActionTypeReview:
-		return ActionTypePlan
-	default:
-		return a
-	}
-}
-
 func NewActionType(value string) (ActionType, error) {
 	switch value {
 	case string(ActionTypePlan):
@@ -123,6 +46,8 @@ func NewActionType(value string) (ActionType, error) {
 		return ActionTypeExternalSearch, nil
 	case string(ActionTypeInternalSearch):
 		return ActionTypeInternalSearch, nil
+	case string(ActionTypeDataAnalysis):
+		return ActionTypeDataAnalysis, nil
 	case string(ActionTypeAnalyze):
 		return ActionTypeAnalyze, nil
 	case string(ActionTypeWrite):
@@ -141,6 +66,12 @@ func NewActionType(value string) (ActionType, error) {
 		return SelfActionTypeReflection, nil
 	case string(SelfActionTypeSummarize):
 		return SelfActionTypeSummarize, nil
+	case string(SelfActionTypeGoal):
+		return SelfActionTypeGoal, nil
+	case string(SelfActionTypeApproval):
+		return SelfActionTypeApproval, nil
+	case string(SelfActionTypeHearing):
+		return SelfActionTypeHearing, nil
 	default:
 		return "", errors.NewDomainError(errors.ValidationError, "invalid action type")
 	}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"strings"
 
+	actionValue "github.com/goda6565/ai-consultant/backend/internal/domain/action/value"
 	agentState "github.com/goda6565/ai-consultant/backend/internal/domain/agent/state"
 	"github.com/goda6565/ai-consultant/backend/internal/domain/agent/value"
 	"github.com/goda6565/ai-consultant/backend/internal/domain/llm"
@@ -32,7 +33,7 @@ func (g *GoalService) Execute(ctx context.Context, input GoalServiceInput) (*Goa
 	llmInput := llm.GenerateTextInput{
 		SystemPrompt: systemPrompt,
 		UserPrompt:   userPrompt,
-		Config:       llm.LLMConfig{Provider: llm.VertexAI, Model: llm.Gemini25Flash},
+		Config:       input.State.GetModelConfig(actionValue.SelfActionTypeGoal),
 		Temperature:  0.0,
 	}
 	llmOutput, err := g.llmClient.GenerateText(ctx, llmInput)
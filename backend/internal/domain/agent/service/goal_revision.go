@@ -0,0 +1,121 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	actionValue "github.com/goda6565/ai-consultant/backend/internal/domain/action/value"
+	agentState "github.com/goda6565/ai-consultant/backend/internal/domain/agent/state"
+	"github.com/goda6565/ai-consultant/backend/internal/domain/agent/value"
+	"github.com/goda6565/ai-consultant/backend/internal/domain/llm"
+)
+
+type GoalRevisionService struct {
+	llmClient llm.LLMClient
+}
+
+func NewGoalRevisionService(llmClient llm.LLMClient) *GoalRevisionService {
+	return &GoalRevisionService{llmClient: llmClient}
+}
+
+type GoalRevisionServiceInput struct {
+	State agentState.State
+}
+
+type GoalRevisionServiceOutput struct {
+	ShouldRevise bool
+	Goal         value.Goal
+	Reason       string
+}
+
+type GoalRevisionOutputStruct struct {
+	ShouldRevise bool   `json:"shouldRevise"`
+	Goal         string `json:"goal"`
+	Reason       string `json:"reason"`
+}
+
+// CanReviseGoal reports whether the goal may be revised after the action.
+// Only plan and review look at the whole picture, and the number of the revisions is limited so that the goal does not keep moving.
+func CanReviseGoal(state agentState.State, actionType actionValue.ActionType) bool {
+	if !actionType.Equals(actionValue.ActionTypePlan) && !actionType.Equals(actionValue.ActionTypeReview) {
+		return false
+	}
+	goalHistory := state.GetGoalHistory()
+	return goalHistory.CountRevisions() < value.MaxGoalRevisions
+}
+
+func (g *GoalRevisionService) Execute(ctx context.Context, input GoalRevisionServiceInput) (*GoalRevisionServiceOutput, error) {
+	state := input.State
+
+	llmInput := llm.GenerateStructuredTextInput{
+		SystemPrompt: goalRevisionSystemPrompt,
+		UserPrompt:   g.createUserPrompt(state),
+		Config:       state.GetModelConfig(actionValue.SelfActionTypeGoal),
+		Schema: json.RawMessage(`
+			{
+				"type": "object",
+				"properties": {
+					"shouldRevise": {
+						"type": "boolean"
+					},
+					"goal": {
+						"type": "string"
+					},
+					"reason": {
+						"type": "string"
+					}
+				},
+				"required": ["shouldRevise", "goal", "reason"]
+			}
+		`),
+		Temperature: 0.0,
+	}
+	llmOutput, err := llm.GenerateStructured[GoalRevisionOutputStruct](ctx, g.llmClient, llmInput)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate structured output: %w", err)
+	}
+	output := llmOutput.Value
+
+	// 空のゴールには変更しない
+	shouldRevise := output.ShouldRevise && strings.TrimSpace(output.Goal) != ""
+	return &GoalRevisionServiceOutput{
+		ShouldRevise: shouldRevise,
+		Goal:         *value.NewGoal(strings.TrimSpace(output.Goal)),
+		Reason:       output.Reason,
+	}, nil
+}
+
+func (g *GoalRevisionService) createUserPrompt(state agentState.State) string {
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("=== 直前のアクション ===\n%s\n\n", state.GetCurrentAction().Value()))
+	b.WriteString("=== 現在のエージェントの状態 ===\n")
+	b.WriteString(state.ToPrompt())
+	return b.String()
+}
+
+var goalRevisionSystemPrompt = `
+あなたはエージェントの「ゴール見直し担当」です。
+計画（plan）またはレビュー（review）の直後に呼び出され、現在のゴールを見直すべきかを判断します。
+
+# 判断基準
+- 検索や分析で得られた事実が、ゴールの前提（ヒアリングで聞いた現状認識や課題の原因など）と明確に矛盾している場合のみ見直す。
+  - 例: 「顧客離反率が高い」ことを前提としたゴールだが、内部データでは離反率が業界平均より低く、実際の課題は新規獲得であると判明した
+- 情報が不足しているだけ、表現を整えたいだけ、レポートの品質が低いだけの場合は見直さない。
+- 直近に見直したばかりのゴールを、新しい根拠なしに再度変更しない。
+
+# 見直す場合のルール
+- 新しいゴールは現在のゴールと同じ形式（絶対ゴールと SMART の達成基準）で全文を書く。
+- ユーザー課題に関するレポートを完成させるという最終目的は変えない。
+- reason には、どの事実（出典やデータ）が前提とどう矛盾したかを1〜2文で具体的に書く。
+
+# 出力形式
+必ず次のJSON形式で出力してください。見直さない場合は goal を空文字にしてください。
+
+{
+  "shouldRevise": false,
+  "goal": "",
+  "reason": "見直さない理由、または見直す理由"
+}
+`
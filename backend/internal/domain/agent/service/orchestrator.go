@@ -49,7 +49,7 @@ func (o *Orchestrator) Execute(ctx context.Context, input OrchestratorInput) (*O
 	llmInput := llm.GenerateStructuredTextInput{
 		SystemPrompt: o.createSystemPrompt(state),
 		UserPrompt:   o.createUserPrompt(state),
-		Config:       llm.LLMConfig{Provider: llm.VertexAI, Model: llm.Gemini25Flash},
+		Config:       state.GetModelConfig(actionValue.SelfActionTypeOrchestrator),
 		Schema: json.RawMessage(`
 			{
 				"type": "object",
@@ -66,15 +66,11 @@ func (o *Orchestrator) Execute(ctx context.Context, input OrchestratorInput) (*O
 		`),
 		Temperature: 0.0,
 	}
-	llmOutput, err := o.llmClient.GenerateStructuredText(ctx, llmInput)
+	llmOutput, err := llm.GenerateStructured[OrchestratorOutputStruct](ctx, o.llmClient, llmInput)
 	if err != nil {
-		return nil, fmt.Errorf("failed to generate text: %w", err)
-	}
-
-	var output OrchestratorOutputStruct
-	if err := json.Unmarshal([]byte(llmOutput.Text), &output); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal output: %w", err)
+		return nil, fmt.Errorf("failed to generate structured output: %w", err)
 	}
+	output := llmOutput.Value
 
 	return &OrchestratorOutput{
 		CanProceed: output.CanProceed,
@@ -83,7 +79,7 @@ func (o *Orchestrator) Execute(ctx context.Context, input OrchestratorInput) (*O
 }
 
 func (o *Orchestrator) createSystemPrompt(state agentState.State) string {
-	return fmt.Sprintf(orchestratorSystemPrompt, actionValue.ActionRoute(state.GetEnableInternalSearch()), state.ToActionHistory())
+	return fmt.Sprintf(orchestratorSystemPrompt, state.ToActionRoute(), state.ToActionHistory())
 }
 
 func (o *Orchestrator) createUserPrompt(state agentState.State) string {
@@ -0,0 +1,149 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	actionValue "github.com/goda6565/ai-consultant/backend/internal/domain/action/value"
+	agentState "github.com/goda6565/ai-consultant/backend/internal/domain/agent/state"
+	"github.com/goda6565/ai-consultant/backend/internal/domain/llm"
+)
+
+// ReflectionStallCount は同じアクションがこの回数だけ継続と判断されたら停滞とみなして振り返る回数
+const ReflectionStallCount = 2
+
+type ReflectionTrigger string
+
+const (
+	ReflectionTriggerFailed  ReflectionTrigger = "failed"
+	ReflectionTriggerStalled ReflectionTrigger = "stalled"
+)
+
+func (r ReflectionTrigger) Value() string {
+	return string(r)
+}
+
+type Reflection struct {
+	llmClient llm.LLMClient
+}
+
+func NewReflection(llmClient llm.LLMClient) *Reflection {
+	return &Reflection{llmClient: llmClient}
+}
+
+type ReflectionInput struct {
+	State   agentState.State
+	Trigger ReflectionTrigger
+	// ActionType is the failed or stalled action. Parallel branches run while the current action is the first of them.
+	ActionType actionValue.ActionType
+	// Detail is the error of the failed action or the reason of the stall
+	Detail string
+}
+
+type ReflectionOutput struct {
+	Analysis string
+	Lessons  []string
+}
+
+type ReflectionOutputStruct struct {
+	Analysis string   `json:"analysis"`
+	Lessons  []string `json:"lessons"`
+}
+
+// IsStalled reports whether the current action has been continued long enough to reflect on it.
+// It is true only once per stall so that the same stall is not reflected repeatedly.
+func IsStalled(state agentState.State) bool {
+	return state.GetCurrentActionCount() == ReflectionStallCount
+}
+
+func (r *Reflection) Execute(ctx context.Context, input ReflectionInput) (*ReflectionOutput, error) {
+	state := input.State
+
+	llmInput := llm.GenerateStructuredTextInput{
+		SystemPrompt: reflectionSystemPrompt,
+		UserPrompt:   r.createUserPrompt(state, input.Trigger, input.ActionType, input.Detail),
+		Config:       state.GetModelConfig(actionValue.SelfActionTypeReflection),
+		Schema: json.RawMessage(`
+			{
+				"type": "object",
+				"properties": {
+					"analysis": {
+						"type": "string"
+					},
+					"lessons": {
+						"type": "array",
+						"items": {
+							"type": "string"
+						}
+					}
+				},
+				"required": ["analysis", "lessons"]
+			}
+		`),
+		Temperature: 0.0,
+	}
+	llmOutput, err := llm.GenerateStructured[ReflectionOutputStruct](ctx, r.llmClient, llmInput)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate structured output: %w", err)
+	}
+	output := llmOutput.Value
+
+	return &ReflectionOutput{
+		Analysis: output.Analysis,
+		Lessons:  output.Lessons,
+	}, nil
+}
+
+func (r *Reflection) createUserPrompt(state agentState.State, trigger ReflectionTrigger, actionType actionValue.ActionType, detail string) string {
+	var b strings.Builder
+	b.WriteString("=== 振り返りのきっかけ ===\n")
+	switch trigger {
+	case ReflectionTriggerFailed:
+		b.WriteString(fmt.Sprintf("アクション「%s」の実行が失敗しました。\n", actionType.Value()))
+	case ReflectionTriggerStalled:
+		b.WriteString(fmt.Sprintf("アクション「%s」が%d回続けて継続と判断され、停滞しています。\n", actionType.Value(), state.GetCurrentActionCount()))
+	}
+	if detail != "" {
+		b.WriteString(fmt.Sprintf("詳細: %s\n", detail))
+	}
+	b.WriteString("\n=== 現在のエージェントの状態 ===\n")
+	b.WriteString(state.ToPrompt())
+	return b.String()
+}
+
+var reflectionSystemPrompt = `
+あなたはエージェントの「振り返り担当（Reflection）」です。
+アクションが失敗した、または同じアクションが繰り返され停滞しているときに呼び出されます。
+目的は、直近の履歴からうまくいかなかった原因を特定し、以降のアクションで守るべき具体的な教訓を示すことです。
+
+# 手順
+1. 直近の履歴とアクション履歴から、失敗・停滞の直接の原因を特定する。
+   - 例: 検索クエリが抽象的すぎる、同じ観点の検索を繰り返している、情報源が存在しないデータを探している、指示が曖昧でアクションが目的を果たせていない
+2. 原因に対して、次のアクションで取るべき戦略の修正を考える。
+3. 教訓は「次に何をするか」が分かる具体的な行動指針として書く。
+
+# ルール
+- 教訓は1〜3個、各1文で簡潔に書く。
+- 一般論（「よく考える」「品質を高める」など）は書かない。
+- 利用できないアクションや手段を前提にしない。
+- すでに示されている教訓と同じ内容は繰り返さない。
+
+# 出力形式
+必ず次のJSON形式で出力してください。
+
+{
+  "analysis": "失敗・停滞の原因の分析",
+  "lessons": ["教訓1", "教訓2"]
+}
+
+# 出力例
+{
+  "analysis": "externalSearchで業界全体の市場規模を繰り返し検索しているが、課題で必要なのは地方銀行の顧客離反率であり、クエリが目的とずれている。",
+  "lessons": [
+    "externalSearchのクエリには「地方銀行」「顧客離反率」など課題固有の語を必ず含める。",
+    "2回の検索で数値が得られない場合は推定値での分析に切り替え、analyzeへ進む。"
+  ]
+}
+`
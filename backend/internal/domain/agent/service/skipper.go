@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"strings"
 
+	actionValue "github.com/goda6565/ai-consultant/backend/internal/domain/action/value"
 	agentState "github.com/goda6565/ai-consultant/backend/internal/domain/agent/state"
 	"github.com/goda6565/ai-consultant/backend/internal/domain/llm"
 )
@@ -38,7 +39,7 @@ func (t *Skipper) Execute(ctx context.Context, input SkipperInput) (*SkipperOutp
 	llmInput := llm.GenerateStructuredTextInput{
 		SystemPrompt: t.createSystemPrompt(state),
 		UserPrompt:   t.createUserPrompt(state),
-		Config:       llm.LLMConfig{Provider: llm.VertexAI, Model: llm.Gemini25Flash},
+		Config:       state.GetModelConfig(actionValue.SelfActionTypeSkipper),
 		Schema: json.RawMessage(`
 			{
 				"type": "object",
@@ -55,15 +56,11 @@ func (t *Skipper) Execute(ctx context.Context, input SkipperInput) (*SkipperOutp
 		`),
 		Temperature: 0.0,
 	}
-	llmOutput, err := t.llmClient.GenerateStructuredText(ctx, llmInput)
+	llmOutput, err := llm.GenerateStructured[SkipperOutputStruct](ctx, t.llmClient, llmInput)
 	if err != nil {
-		return nil, fmt.Errorf("failed to generate text: %w", err)
-	}
-
-	var output SkipperOutputStruct
-	if err := json.Unmarshal([]byte(llmOutput.Text), &output); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal output: %w", err)
+		return nil, fmt.Errorf("failed to generate structured output: %w", err)
 	}
+	output := llmOutput.Value
 
 	return &SkipperOutput{
 		ShouldSkip: output.ShouldSkip,
@@ -50,7 +50,7 @@ func (s *SummarizeService) Summarize(ctx context.Context, input SummarizeService
 	llmInput := llm.GenerateTextInput{
 		SystemPrompt: s.createSystemPrompt(),
 		UserPrompt:   s.createUserPrompt(input.History),
-		Config:       llm.LLMConfig{Provider: llm.VertexAI, Model: llm.Gemini25Flash},
+		Config:       input.LLMConfig,
 		Temperature:  0.0,
 	}
 	llmOutput, err := s.llmClient.GenerateText(ctx, llmInput)
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"strings"
 
+	actionValue "github.com/goda6565/ai-consultant/backend/internal/domain/action/value"
 	agentState "github.com/goda6565/ai-consultant/backend/internal/domain/agent/state"
 	"github.com/goda6565/ai-consultant/backend/internal/domain/llm"
 )
@@ -47,7 +48,7 @@ func (t *Terminator) Execute(ctx context.Context, input TerminatorInput) (*Termi
 	llmInput := llm.GenerateStructuredTextInput{
 		SystemPrompt: t.createSystemPrompt(state),
 		UserPrompt:   t.createUserPrompt(state),
-		Config:       llm.LLMConfig{Provider: llm.VertexAI, Model: llm.Gemini25Flash},
+		Config:       state.GetModelConfig(actionValue.SelfActionTypeTerminator),
 		Schema: json.RawMessage(`
 			{
 				"type": "object",
@@ -64,15 +65,11 @@ func (t *Terminator) Execute(ctx context.Context, input TerminatorInput) (*Termi
 		`),
 		Temperature: 0.0,
 	}
-	llmOutput, err := t.llmClient.GenerateStructuredText(ctx, llmInput)
+	llmOutput, err := llm.GenerateStructured[TerminatorOutputStruct](ctx, t.llmClient, llmInput)
 	if err != nil {
-		return nil, fmt.Errorf("failed to generate text: %w", err)
-	}
-
-	var output TerminatorOutputStruct
-	if err := json.Unmarshal([]byte(llmOutput.Text), &output); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal output: %w", err)
+		return nil, fmt.Errorf("failed to generate structured output: %w", err)
 	}
+	output := llmOutput.Value
 
 	return &TerminatorOutput{
 		ShouldTerminate: output.ShouldTerminate,
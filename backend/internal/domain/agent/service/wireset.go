@@ -8,4 +8,6 @@ var Set = wire.NewSet(
 	NewGoalService,
 	NewTerminator,
 	NewSkipper,
+	NewReflection,
+	NewGoalRevisionService,
 )
@@ -7,26 +7,33 @@ import (
 	actionValue "github.com/goda6565/ai-consultant/backend/internal/domain/action/value"
 	"github.com/goda6565/ai-consultant/backend/internal/domain/agent/value"
 	hearingMessageEntity "github.com/goda6565/ai-consultant/backend/internal/domain/hearing_message/entity"
+	jobConfigValue "github.com/goda6565/ai-consultant/backend/internal/domain/job_config/value"
+	"github.com/goda6565/ai-consultant/backend/internal/domain/llm"
 	problemEntity "github.com/goda6565/ai-consultant/backend/internal/domain/problem/entity"
 	problemFieldEntity "github.com/goda6565/ai-consultant/backend/internal/domain/problem_field/entity"
+	workflowValue "github.com/goda6565/ai-consultant/backend/internal/domain/workflow/value"
 )
 
 type State struct {
 	problem              problemEntity.Problem
 	goal                 value.Goal
+	goalHistory          value.GoalHistory
 	content              value.Content
 	problemFields        []problemFieldEntity.ProblemField
 	hearingMessages      []hearingMessageEntity.HearingMessage
 	history              value.History
+	lessons              value.Lessons
 	currentAction        actionValue.ActionType
 	actionHistory        []actionValue.ActionType
 	currentActionCount   int
 	actionLoopCount      int
 	enableInternalSearch bool
+	workflow             *workflowValue.Workflow
+	modelMap             jobConfigValue.ModelMap
 }
 
-func NewState(problem problemEntity.Problem, content value.Content, problemFields []problemFieldEntity.ProblemField, hearingMessages []hearingMessageEntity.HearingMessage, history value.History, actionHistory []actionValue.ActionType, enableInternalSearch bool) *State {
-	return &State{problem: problem, content: content, problemFields: problemFields, hearingMessages: hearingMessages, history: history, currentAction: actionValue.ActionTypePlan, actionHistory: actionHistory, currentActionCount: 0, actionLoopCount: 0, enableInternalSearch: enableInternalSearch}
+func NewState(problem problemEntity.Problem, content value.Content, problemFields []problemFieldEntity.ProblemField, hearingMessages []hearingMessageEntity.HearingMessage, history value.History, actionHistory []actionValue.ActionType, enableInternalSearch bool, workflow *workflowValue.Workflow, modelMap jobConfigValue.ModelMap) *State {
+	return &State{problem: problem, content: content, problemFields: problemFields, hearingMessages: hearingMessages, history: history, currentAction: workflow.GetStart(), actionHistory: actionHistory, currentActionCount: 0, actionLoopCount: 0, enableInternalSearch: enableInternalSearch, workflow: workflow, modelMap: modelMap}
 }
 
 func (s *State) GetProblem() problemEntity.Problem {
@@ -51,7 +58,7 @@ func (s *State) GetCurrentAction() actionValue.ActionType {
 
 func (s *State) ToNextAction(canProceed bool) {
 	if canProceed {
-		s.currentAction = s.currentAction.Proceed(s.enableInternalSearch)
+		s.currentAction = s.workflow.Next(s.currentAction, s.workflowFlags())
 		s.currentActionCount = 0
 	} else {
 		s.currentActionCount++
@@ -59,6 +66,31 @@ func (s *State) ToNextAction(canProceed bool) {
 	s.actionHistory = append(s.actionHistory, s.currentAction)
 }
 
+// ForceAction moves to the action regardless of the action route.
+func (s *State) ForceAction(actionType actionValue.ActionType) {
+	s.currentAction = actionType
+	s.currentActionCount = 0
+	s.actionHistory = append(s.actionHistory, s.currentAction)
+}
+
+// GetBranches returns the actions to run for the current step: the parallel group started by the current action, or the current action alone.
+func (s *State) GetBranches() []actionValue.ActionType {
+	if branches := s.workflow.Branches(s.currentAction, s.workflowFlags()); branches != nil {
+		return branches
+	}
+	return []actionValue.ActionType{s.currentAction}
+}
+
+// JoinBranches moves to the last action of the parallel group as if the actions had run one by one.
+func (s *State) JoinBranches(branches []actionValue.ActionType) {
+	if len(branches) < 2 {
+		return
+	}
+	s.currentAction = branches[len(branches)-1]
+	s.currentActionCount = 0
+	s.actionHistory = append(s.actionHistory, branches[1:]...)
+}
+
 func (s *State) Done() {
 	s.currentAction = actionValue.ActionTypeDone
 }
@@ -67,8 +99,14 @@ func (s *State) GetActionHistory() []actionValue.ActionType {
 	return s.actionHistory
 }
 
-func (s *State) SetGoal(goal value.Goal) {
+func (s *State) GetGoalHistory() value.GoalHistory {
+	return s.goalHistory
+}
+
+// ReviseGoal sets the goal as the next version of the goal history. The first call sets the initial goal.
+func (s *State) ReviseGoal(goal value.Goal, reason string, actionType actionValue.ActionType) value.GoalRevision {
 	s.goal = goal
+	return s.goalHistory.Add(goal, reason, actionType)
 }
 
 func (s *State) SetContent(content value.Content) {
@@ -95,6 +133,29 @@ func (s *State) GetEnableInternalSearch() bool {
 	return s.enableInternalSearch
 }
 
+func (s *State) GetWorkflow() *workflowValue.Workflow {
+	return s.workflow
+}
+
+// IsTerminalAction reports whether the current action is the last one of the workflow,
+// after which the run may be completed.
+func (s *State) IsTerminalAction() bool {
+	return s.workflow.IsTerminal(s.currentAction)
+}
+
+// ToActionRoute renders the action route of the workflow for the prompts.
+func (s *State) ToActionRoute() string {
+	return s.workflow.Render(s.workflowFlags())
+}
+
+func (s *State) workflowFlags() workflowValue.Flags {
+	return workflowValue.Flags{EnableInternalSearch: s.enableInternalSearch}
+}
+
+func (s *State) GetModelConfig(actionType actionValue.ActionType) llm.LLMConfig {
+	return s.modelMap.Get(actionType)
+}
+
 func (s *State) AddHistory(actionType actionValue.ActionType, content string) {
 	currentHistory := s.history.GetValue()
 	var b strings.Builder
@@ -111,6 +172,15 @@ func (s *State) SetHistory(history value.History) {
 	s.history = history
 }
 
+func (s *State) GetLessons() value.Lessons {
+	return s.lessons
+}
+
+// AddLessons keeps the lessons of a reflection so that they are included in the following prompts.
+func (s *State) AddLessons(lessons ...string) {
+	s.lessons.Add(lessons...)
+}
+
 func (s *State) ToActionHistory() string {
 	if len(s.actionHistory) == 0 {
 		return ""
@@ -149,6 +219,12 @@ func (s *State) ToPrompt() string {
 	b.WriteString("=== 最終ゴール ===\n")
 	b.WriteString(s.goal.Value())
 	b.WriteString("\n")
+	if s.goalHistory.IsRevised() {
+		b.WriteString("=== ゴールの変更履歴 ===\n")
+		for _, revision := range s.goalHistory.Values()[1:] {
+			b.WriteString(fmt.Sprintf("- 第%d版（%s）: %s\n", revision.Version, revision.ActionType.Value(), revision.Reason))
+		}
+	}
 
 	b.WriteString("=== 課題情報 ===\n")
 	b.WriteString(fmt.Sprintf("タイトル: %s\n", s.problem.GetTitle().Value()))
@@ -175,6 +251,13 @@ func (s *State) ToPrompt() string {
 	b.WriteString("=== 現在の履歴 ===\n")
 	b.WriteString(s.history.GetValue())
 
+	if !s.lessons.IsEmpty() {
+		b.WriteString("\n=== 振り返りから得た教訓（今後の行動で必ず考慮すること） ===\n")
+		for _, lesson := range s.lessons.Values() {
+			b.WriteString(fmt.Sprintf("- %s\n", lesson))
+		}
+	}
+
 	b.WriteString("\n=== 現在のアクション ===\n")
 	b.WriteString(s.currentAction.Value())
 
@@ -183,7 +266,113 @@ func (s *State) ToPrompt() string {
 
 	b.WriteString("\n=== アクションルート ===\n")
 	b.WriteString("**このアクション以外はできないので、今後の計画にこれら以外のActionは考慮しないでください**")
-	b.WriteString(actionValue.ActionRoute(s.enableInternalSearch))
+	b.WriteString(s.ToActionRoute())
 
 	return b.String()
 }
+
+// ToReport returns the content for the final report, with how the goal evolved when it was revised.
+func (s *State) ToReport() value.Content {
+	return toReport(s.content, s.goalHistory)
+}
+
+func toReport(content value.Content, goalHistory value.GoalHistory) value.Content {
+	goalReport := goalHistory.ToReport()
+	if goalReport == "" || content.Value() == "" {
+		return content
+	}
+	return *value.NewContent(strings.TrimRight(content.Value(), "\n") + "\n\n" + goalReport)
+}
+
+// Snapshot is the part of State that changes during a run.
+// The problem, hearing and job config are loaded again on restore.
+type Snapshot struct {
+	Goal               string                 `json:"goal"`
+	GoalHistory        []GoalRevisionSnapshot `json:"goalHistory,omitempty"`
+	Content            string                 `json:"content"`
+	History            string                 `json:"history"`
+	Lessons            []string               `json:"lessons,omitempty"`
+	CurrentAction      string                 `json:"currentAction"`
+	ActionHistory      []string               `json:"actionHistory"`
+	CurrentActionCount int                    `json:"currentActionCount"`
+	ActionLoopCount    int                    `json:"actionLoopCount"`
+}
+
+type GoalRevisionSnapshot struct {
+	Version    int    `json:"version"`
+	Goal       string `json:"goal"`
+	Reason     string `json:"reason"`
+	ActionType string `json:"actionType"`
+}
+
+func (s *State) Snapshot() Snapshot {
+	actionHistory := make([]string, len(s.actionHistory))
+	for i, actionType := range s.actionHistory {
+		actionHistory[i] = actionType.Value()
+	}
+	goalHistory := []GoalRevisionSnapshot{}
+	for _, revision := range s.goalHistory.Values() {
+		goalHistory = append(goalHistory, GoalRevisionSnapshot{Version: revision.Version, Goal: revision.Goal.Value(), Reason: revision.Reason, ActionType: revision.ActionType.Value()})
+	}
+	return Snapshot{
+		Goal:               s.goal.Value(),
+		GoalHistory:        goalHistory,
+		Content:            s.content.Value(),
+		History:            s.history.GetValue(),
+		Lessons:            s.lessons.Values(),
+		CurrentAction:      s.currentAction.Value(),
+		ActionHistory:      actionHistory,
+		CurrentActionCount: s.currentActionCount,
+		ActionLoopCount:    s.actionLoopCount,
+	}
+}
+
+// RestoreState rebuilds the state of a run from the snapshot.
+func RestoreState(problem problemEntity.Problem, problemFields []problemFieldEntity.ProblemField, hearingMessages []hearingMessageEntity.HearingMessage, enableInternalSearch bool, workflow *workflowValue.Workflow, modelMap jobConfigValue.ModelMap, snapshot Snapshot) (*State, error) {
+	currentAction, err := actionValue.NewActionType(snapshot.CurrentAction)
+	if err != nil {
+		return nil, fmt.Errorf("failed to restore current action: %w", err)
+	}
+	actionHistory := make([]actionValue.ActionType, len(snapshot.ActionHistory))
+	for i, value := range snapshot.ActionHistory {
+		actionType, err := actionValue.NewActionType(value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to restore action history: %w", err)
+		}
+		actionHistory[i] = actionType
+	}
+	state := NewState(problem, *value.NewContent(snapshot.Content), problemFields, hearingMessages, *value.NewHistory(snapshot.History), actionHistory, enableInternalSearch, workflow, modelMap)
+	state.goal = *value.NewGoal(snapshot.Goal)
+	goalHistory, err := snapshot.goalHistory()
+	if err != nil {
+		return nil, err
+	}
+	state.goalHistory = *goalHistory
+	state.lessons = *value.NewLessons(snapshot.Lessons)
+	state.currentAction = currentAction
+	state.currentActionCount = snapshot.CurrentActionCount
+	state.actionLoopCount = snapshot.ActionLoopCount
+	return state, nil
+}
+
+// ToReport returns the report of the snapshot in the same form as State.ToReport,
+// for a run that is finished without restoring its state.
+func (s Snapshot) ToReport() (value.Content, error) {
+	goalHistory, err := s.goalHistory()
+	if err != nil {
+		return value.Content{}, err
+	}
+	return toReport(*value.NewContent(s.Content), *goalHistory), nil
+}
+
+func (s Snapshot) goalHistory() (*value.GoalHistory, error) {
+	revisions := make([]value.GoalRevision, len(s.GoalHistory))
+	for i, revision := range s.GoalHistory {
+		actionType, err := actionValue.NewActionType(revision.ActionType)
+		if err != nil {
+			return nil, fmt.Errorf("failed to restore goal history: %w", err)
+		}
+		revisions[i] = value.GoalRevision{Version: revision.Version, Goal: *value.NewGoal(revision.Goal), Reason: revision.Reason, ActionType: actionType}
+	}
+	return value.NewGoalHistory(revisions), nil
+}
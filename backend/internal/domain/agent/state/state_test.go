@@ -0,0 +1,178 @@
+package state_test
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	actionValue "github.com/goda6565/ai-consultant/backend/internal/domain/action/value"
+	"github.com/goda6565/ai-consultant/backend/internal/domain/agent/state"
+	"github.com/goda6565/ai-consultant/backend/internal/domain/agent/value"
+	jobConfigValue "github.com/goda6565/ai-consultant/backend/internal/domain/job_config/value"
+	problemEntity "github.com/goda6565/ai-consultant/backend/internal/domain/problem/entity"
+	workflowValue "github.com/goda6565/ai-consultant/backend/internal/domain/workflow/value"
+)
+
+func newWorkflow(t *testing.T, name workflowValue.Name) *workflowValue.Workflow {
+	t.Helper()
+	workflow, err := workflowValue.GetWorkflow(name)
+	if err != nil {
+		t.Fatalf("failed to get workflow: %v", err)
+	}
+	return workflow
+}
+
+func TestRestoreState(t *testing.T) {
+	workflow := newWorkflow(t, workflowValue.DefaultName)
+	original := state.NewState(problemEntity.Problem{}, *value.NewContent(""), nil, nil, *value.NewHistory(""), []actionValue.ActionType{}, true, workflow, jobConfigValue.ModelMap{})
+	original.ReviseGoal(*value.NewGoal("goal"), "initial", actionValue.SelfActionTypeGoal)
+	original.ReviseGoal(*value.NewGoal("revised goal"), "premise was wrong", actionValue.ActionTypeReview)
+	original.ToNextAction(true)
+	original.ToNextAction(false)
+	original.IncrementActionLoopCount()
+	original.SetContent(*value.NewContent("content"))
+	original.AddHistory(actionValue.ActionTypePlan, "plan")
+	original.AddLessons("lesson")
+
+	restored, err := state.RestoreState(problemEntity.Problem{}, nil, nil, true, workflow, jobConfigValue.ModelMap{}, original.Snapshot())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(restored.Snapshot(), original.Snapshot()) {
+		t.Errorf("Snapshot = %+v, expected %+v", restored.Snapshot(), original.Snapshot())
+	}
+	if restored.GetCurrentActionCount() != 1 || restored.GetActionLoopCount() != 1 {
+		t.Errorf("counters = (%d, %d), expected (1, 1)", restored.GetCurrentActionCount(), restored.GetActionLoopCount())
+	}
+
+	invalid := original.Snapshot()
+	invalid.CurrentAction = "unknown"
+	if _, err := state.RestoreState(problemEntity.Problem{}, nil, nil, true, workflow, jobConfigValue.ModelMap{}, invalid); err == nil {
+		t.Error("expected error for unknown action")
+	}
+}
+
+func TestState_ToNextAction(t *testing.T) {
+	tests := []struct {
+		name     string
+		workflow workflowValue.Name
+		expected []actionValue.ActionType
+	}{
+		{
+			name:     "standard",
+			workflow: workflowValue.DefaultName,
+			expected: []actionValue.ActionType{actionValue.ActionTypeExternalSearch, actionValue.ActionTypeAnalyze, actionValue.ActionTypeWrite, actionValue.ActionTypeReview, actionValue.ActionTypePlan},
+		},
+		{
+			name:     "quick memo",
+			workflow: "quickMemo",
+			expected: []actionValue.ActionType{actionValue.ActionTypeExternalSearch, actionValue.ActionTypeWrite, actionValue.ActionTypePlan},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := state.NewState(problemEntity.Problem{}, *value.NewContent(""), nil, nil, *value.NewHistory(""), []actionValue.ActionType{}, false, newWorkflow(t, tt.workflow), jobConfigValue.ModelMap{})
+			if s.GetCurrentAction() != actionValue.ActionTypePlan {
+				t.Fatalf("start = %s, expected plan", s.GetCurrentAction())
+			}
+			for i, expected := range tt.expected {
+				s.ToNextAction(true)
+				if s.GetCurrentAction() != expected {
+					t.Fatalf("step %d = %s, expected %s", i+1, s.GetCurrentAction(), expected)
+				}
+				if s.IsTerminalAction() != (i == len(tt.expected)-2) {
+					t.Errorf("IsTerminalAction() at %s = %v", s.GetCurrentAction(), s.IsTerminalAction())
+				}
+			}
+		})
+	}
+}
+
+func TestState_JoinBranches(t *testing.T) {
+	s := state.NewState(problemEntity.Problem{}, *value.NewContent(""), nil, nil, *value.NewHistory(""), []actionValue.ActionType{}, true, newWorkflow(t, workflowValue.DefaultName), jobConfigValue.ModelMap{})
+	if branches := s.GetBranches(); !slices.Equal(branches, []actionValue.ActionType{actionValue.ActionTypePlan}) {
+		t.Fatalf("GetBranches() at plan = %v, expected [plan]", branches)
+	}
+
+	s.ToNextAction(true)
+	branches := s.GetBranches()
+	if !slices.Equal(branches, []actionValue.ActionType{actionValue.ActionTypeExternalSearch, actionValue.ActionTypeInternalSearch}) {
+		t.Fatalf("GetBranches() = %v, expected [externalSearch internalSearch]", branches)
+	}
+	s.JoinBranches(branches)
+	if s.GetCurrentAction() != actionValue.ActionTypeInternalSearch {
+		t.Errorf("current action = %s, expected internalSearch", s.GetCurrentAction())
+	}
+	if history := s.GetActionHistory(); !slices.Equal(history, []actionValue.ActionType{actionValue.ActionTypeExternalSearch, actionValue.ActionTypeInternalSearch}) {
+		t.Errorf("action history = %v", history)
+	}
+	s.ToNextAction(true)
+	if s.GetCurrentAction() != actionValue.ActionTypeDataAnalysis {
+		t.Errorf("next action = %s, expected dataAnalysis", s.GetCurrentAction())
+	}
+}
+
+func TestState_ReviseGoal(t *testing.T) {
+	s := state.NewState(problemEntity.Problem{}, *value.NewContent("## 提案\n本文\n"), nil, nil, *value.NewHistory(""), []actionValue.ActionType{}, false, newWorkflow(t, workflowValue.DefaultName), jobConfigValue.ModelMap{})
+	s.ReviseGoal(*value.NewGoal("離反率を下げる"), "ヒアリング内容をもとに設定", actionValue.SelfActionTypeGoal)
+	if strings.Contains(s.ToPrompt(), "ゴールの変更履歴") {
+		t.Error("prompt must not contain the goal history before a revision")
+	}
+	content := s.ToReport()
+	if content.Value() != "## 提案\n本文\n" {
+		t.Errorf("report must be the content before a revision: %q", content.Value())
+	}
+
+	revision := s.ReviseGoal(*value.NewGoal("新規顧客の獲得を増やす"), "離反率は業界平均より低いことが判明した", actionValue.ActionTypeReview)
+	if revision.Version != 2 {
+		t.Errorf("version = %d, expected 2", revision.Version)
+	}
+	goal := s.GetGoal()
+	if goal.Value() != "新規顧客の獲得を増やす" {
+		t.Errorf("goal = %s", goal.Value())
+	}
+	history := s.GetGoalHistory()
+	if history.CountRevisions() != 1 {
+		t.Errorf("CountRevisions() = %d, expected 1", history.CountRevisions())
+	}
+	if !strings.Contains(s.ToPrompt(), "- 第2版（review）: 離反率は業界平均より低いことが判明した") {
+		t.Errorf("prompt does not contain the goal history:\n%s", s.ToPrompt())
+	}
+	content = s.ToReport()
+	for _, expected := range []string{"## 提案\n本文\n\n## ゴールの変遷", "### 第1版\n離反率を下げる", "### 第2版\n新規顧客の獲得を増やす", "- 変更理由: 離反率は業界平均より低いことが判明した"} {
+		if !strings.Contains(content.Value(), expected) {
+			t.Errorf("report does not contain %q:\n%s", expected, content.Value())
+		}
+	}
+
+	// 状態を復元せずに終了する場合もチェックポイントから同じレポートを作る
+	snapshotReport, err := s.Snapshot().ToReport()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !snapshotReport.Equals(content) {
+		t.Errorf("snapshot report = %q, expected %q", snapshotReport.Value(), content.Value())
+	}
+}
+
+func TestState_AddLessons(t *testing.T) {
+	s := state.NewState(problemEntity.Problem{}, *value.NewContent(""), nil, nil, *value.NewHistory(""), []actionValue.ActionType{}, false, newWorkflow(t, workflowValue.DefaultName), jobConfigValue.ModelMap{})
+	if strings.Contains(s.ToPrompt(), "教訓") {
+		t.Error("prompt must not contain the lessons section without lessons")
+	}
+
+	for i := range value.MaxLessons + 2 {
+		s.AddLessons(fmt.Sprintf("lesson%d", i), "")
+	}
+	lessons := s.GetLessons()
+	values := lessons.Values()
+	if len(values) != value.MaxLessons || values[0] != "lesson2" {
+		t.Errorf("lessons = %v, expected the latest %d lessons", values, value.MaxLessons)
+	}
+	if !strings.Contains(s.ToPrompt(), fmt.Sprintf("- lesson%d", value.MaxLessons+1)) {
+		t.Error("prompt must contain the latest lesson")
+	}
+}
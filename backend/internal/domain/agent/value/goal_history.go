@@ -0,0 +1,70 @@
+package value
+
+import (
+	"fmt"
+	"strings"
+
+	actionValue "github.com/goda6565/ai-consultant/backend/internal/domain/action/value"
+)
+
+// MaxGoalRevisions はエージェントが初期ゴールを変更できる回数の上限。人による変更は含めない
+const MaxGoalRevisions = 3
+
+// GoalRevision は版ごとのゴールと、そのゴールにした理由
+type GoalRevision struct {
+	// Version は初期ゴールを1とする版
+	Version int
+	Goal    Goal
+	Reason  string
+	// ActionType はゴールを変更したアクション。初期ゴールは goal
+	ActionType actionValue.ActionType
+}
+
+type GoalHistory struct {
+	revisions []GoalRevision
+}
+
+func NewGoalHistory(revisions []GoalRevision) *GoalHistory {
+	return &GoalHistory{revisions: append([]GoalRevision{}, revisions...)}
+}
+
+// Add appends the goal as the next version and returns the revision.
+func (h *GoalHistory) Add(goal Goal, reason string, actionType actionValue.ActionType) GoalRevision {
+	revision := GoalRevision{Version: len(h.revisions) + 1, Goal: goal, Reason: reason, ActionType: actionType}
+	h.revisions = append(h.revisions, revision)
+	return revision
+}
+
+func (h *GoalHistory) Values() []GoalRevision {
+	return append([]GoalRevision{}, h.revisions...)
+}
+
+// CountRevisions returns the number of the revisions after the initial goal.
+func (h *GoalHistory) CountRevisions() int {
+	if len(h.revisions) == 0 {
+		return 0
+	}
+	return len(h.revisions) - 1
+}
+
+func (h *GoalHistory) IsRevised() bool {
+	return h.CountRevisions() > 0
+}
+
+// ToReport renders how and why the goal evolved for the final report. It is empty when the goal was never revised.
+func (h *GoalHistory) ToReport() string {
+	if !h.IsRevised() {
+		return ""
+	}
+	var b strings.Builder
+	b.WriteString("## ゴールの変遷\n")
+	b.WriteString("調査の過程で判明した事実に基づき、当初のゴールを次のように見直しました。\n\n")
+	for _, revision := range h.revisions {
+		b.WriteString(fmt.Sprintf("### 第%d版\n", revision.Version))
+		b.WriteString(fmt.Sprintf("%s\n\n", strings.TrimSpace(revision.Goal.Value())))
+		if revision.Version > 1 {
+			b.WriteString(fmt.Sprintf("- 変更理由: %s\n\n", revision.Reason))
+		}
+	}
+	return strings.TrimRight(b.String(), "\n") + "\n"
+}
@@ -0,0 +1,35 @@
+package value
+
+// MaxLessons はプロンプトに含める教訓の上限。古いものから捨てる
+const MaxLessons = 5
+
+type Lessons struct {
+	values []string
+}
+
+func NewLessons(values []string) *Lessons {
+	lessons := &Lessons{}
+	lessons.Add(values...)
+	return lessons
+}
+
+// Add appends the lessons, skipping empty ones, and keeps only the latest MaxLessons.
+func (l *Lessons) Add(values ...string) {
+	for _, v := range values {
+		if v == "" {
+			continue
+		}
+		l.values = append(l.values, v)
+	}
+	if len(l.values) > MaxLessons {
+		l.values = l.values[len(l.values)-MaxLessons:]
+	}
+}
+
+func (l *Lessons) Values() []string {
+	return append([]string{}, l.values...)
+}
+
+func (l *Lessons) IsEmpty() bool {
+	return len(l.values) == 0
+}
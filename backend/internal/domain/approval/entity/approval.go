@@ -0,0 +1,115 @@
+package entity
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	actionValue "github.com/goda6565/ai-consultant/backend/internal/domain/action/value"
+	agentValue "github.com/goda6565/ai-consultant/backend/internal/domain/agent/value"
+	"github.com/goda6565/ai-consultant/backend/internal/domain/approval/value"
+	"github.com/goda6565/ai-consultant/backend/internal/domain/errors"
+	sharedValue "github.com/goda6565/ai-consultant/backend/internal/domain/shared/value"
+)
+
+// Approval は承認ゲートで一時停止した提案ジョブに対する人間の判断
+type Approval struct {
+	id         sharedValue.ID
+	problemID  sharedValue.ID
+	actionType actionValue.ActionType
+	// 再開時のチェックポイントのステップ
+	step     int
+	output   string
+	decision value.Decision
+	feedback string
+	// nil の場合はゴールを変更しない
+	goal      *agentValue.Goal
+	createdAt *time.Time
+	decidedAt *time.Time
+	appliedAt *time.Time
+}
+
+func NewApproval(id sharedValue.ID, problemID sharedValue.ID, actionType actionValue.ActionType, step int, output string, decision value.Decision, feedback string, goal *agentValue.Goal, createdAt *time.Time, decidedAt *time.Time, appliedAt *time.Time) *Approval {
+	return &Approval{id: id, problemID: problemID, actionType: actionType, step: step, output: output, decision: decision, feedback: feedback, goal: goal, createdAt: createdAt, decidedAt: decidedAt, appliedAt: appliedAt}
+}
+
+func (a *Approval) GetID() sharedValue.ID {
+	return a.id
+}
+
+func (a *Approval) GetProblemID() sharedValue.ID {
+	return a.problemID
+}
+
+func (a *Approval) GetActionType() actionValue.ActionType {
+	return a.actionType
+}
+
+func (a *Approval) GetStep() int {
+	return a.step
+}
+
+func (a *Approval) GetOutput() string {
+	return a.output
+}
+
+func (a *Approval) GetDecision() value.Decision {
+	return a.decision
+}
+
+func (a *Approval) GetFeedback() string {
+	return a.feedback
+}
+
+func (a *Approval) GetGoal() *agentValue.Goal {
+	return a.goal
+}
+
+func (a *Approval) GetCreatedAt() *time.Time {
+	return a.createdAt
+}
+
+func (a *Approval) GetDecidedAt() *time.Time {
+	return a.decidedAt
+}
+
+func (a *Approval) GetAppliedAt() *time.Time {
+	return a.appliedAt
+}
+
+func (a *Approval) IsPending() bool {
+	return a.decision.Equals(value.DecisionPending)
+}
+
+// IsApplied reports whether the resumed job has already added the decision to its state.
+func (a *Approval) IsApplied() bool {
+	return a.appliedAt != nil
+}
+
+func (a *Approval) Decide(decision value.Decision, feedback string, goal *agentValue.Goal) error {
+	if !decision.Equals(value.DecisionApprove) && !decision.Equals(value.DecisionRevise) {
+		return errors.NewDomainError(errors.ValidationError, "decision must be approve or revise")
+	}
+	a.decision = decision
+	a.feedback = feedback
+	a.goal = goal
+	return nil
+}
+
+// ToHistory returns the decision as guidance for the agent.
+func (a *Approval) ToHistory() string {
+	var b strings.Builder
+	switch a.decision {
+	case value.DecisionApprove:
+		b.WriteString(fmt.Sprintf("レビュアーが%sの結果を承認しました。", a.actionType.Value()))
+	case value.DecisionRevise:
+		b.WriteString(fmt.Sprintf("レビュアーが%sの結果を差し戻しました。フィードバックを反映してやり直してください。", a.actionType.Value()))
+	}
+	if a.feedback != "" {
+		b.WriteString(fmt.Sprintf("\nフィードバック: %s", a.feedback))
+	}
+	if a.goal != nil {
+		b.WriteString(fmt.Sprintf("\nゴールを次のように変更しました: %s", a.goal.Value()))
+	}
+	return b.String()
+}
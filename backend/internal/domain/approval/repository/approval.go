@@ -0,0 +1,23 @@
+package repository
+
+import (
+	"context"
+
+	approvalEntity "github.com/goda6565/ai-consultant/backend/internal/domain/approval/entity"
+	sharedValue "github.com/goda6565/ai-consultant/backend/internal/domain/shared/value"
+)
+
+//go:generate go tool mockgen -source=$GOFILE -destination=mock/$GOFILE -package=mock
+type ApprovalRepository interface {
+	FindByID(ctx context.Context, id sharedValue.ID) (*approvalEntity.Approval, error)
+	FindLatestByProblemID(ctx context.Context, problemID sharedValue.ID) (*approvalEntity.Approval, error)
+	FindPending(ctx context.Context) ([]approvalEntity.Approval, error)
+	Create(ctx context.Context, approval *approvalEntity.Approval) error
+	// Decide saves the decision only while the approval is pending and reports whether it was saved
+	Decide(ctx context.Context, approval *approvalEntity.Approval) (bool, error)
+	MarkApplied(ctx context.Context, id sharedValue.ID) error
+	// Reopen returns a decision that could not start the job to pending
+	Reopen(ctx context.Context, id sharedValue.ID) error
+	// ExpirePendingByProblemID closes the pending approvals of a problem cancelled while waiting for them
+	ExpirePendingByProblemID(ctx context.Context, problemID sharedValue.ID) error
+}
@@ -0,0 +1,159 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: approval.go
+//
+// Generated by this command:
+//
+//	mockgen -source=approval.go -destination=mock/approval.go -package=mock
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entity "github.com/goda6565/ai-consultant/backend/internal/domain/approval/entity"
+	value "github.com/goda6565/ai-consultant/backend/internal/domain/shared/value"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockApprovalRepository is a mock of ApprovalRepository interface.
+type MockApprovalRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockApprovalRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockApprovalRepositoryMockRecorder is the mock recorder for MockApprovalRepository.
+type MockApprovalRepositoryMockRecorder struct {
+	mock *MockApprovalRepository
+}
+
+// NewMockApprovalRepository creates a new mock instance.
+func NewMockApprovalRepository(ctrl *gomock.Controller) *MockApprovalRepository {
+	mock := &MockApprovalRepository{ctrl: ctrl}
+	mock.recorder = &MockApprovalRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockApprovalRepository) EXPECT() *MockApprovalRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockApprovalRepository) Create(ctx context.Context, approval *entity.Approval) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, approval)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockApprovalRepositoryMockRecorder) Create(ctx, approval any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockApprovalRepository)(nil).Create), ctx, approval)
+}
+
+// Decide mocks base method.
+func (m *MockApprovalRepository) Decide(ctx context.Context, approval *entity.Approval) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Decide", ctx, approval)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Decide indicates an expected call of Decide.
+func (mr *MockApprovalRepositoryMockRecorder) Decide(ctx, approval any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Decide", reflect.TypeOf((*MockApprovalRepository)(nil).Decide), ctx, approval)
+}
+
+// ExpirePendingByProblemID mocks base method.
+func (m *MockApprovalRepository) ExpirePendingByProblemID(ctx context.Context, problemID value.ID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExpirePendingByProblemID", ctx, problemID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExpirePendingByProblemID indicates an expected call of ExpirePendingByProblemID.
+func (mr *MockApprovalRepositoryMockRecorder) ExpirePendingByProblemID(ctx, problemID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExpirePendingByProblemID", reflect.TypeOf((*MockApprovalRepository)(nil).ExpirePendingByProblemID), ctx, problemID)
+}
+
+// FindByID mocks base method.
+func (m *MockApprovalRepository) FindByID(ctx context.Context, id value.ID) (*entity.Approval, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", ctx, id)
+	ret0, _ := ret[0].(*entity.Approval)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByID indicates an expected call of FindByID.
+func (mr *MockApprovalRepositoryMockRecorder) FindByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockApprovalRepository)(nil).FindByID), ctx, id)
+}
+
+// FindLatestByProblemID mocks base method.
+func (m *MockApprovalRepository) FindLatestByProblemID(ctx context.Context, problemID value.ID) (*entity.Approval, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindLatestByProblemID", ctx, problemID)
+	ret0, _ := ret[0].(*entity.Approval)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindLatestByProblemID indicates an expected call of FindLatestByProblemID.
+func (mr *MockApprovalRepositoryMockRecorder) FindLatestByProblemID(ctx, problemID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindLatestByProblemID", reflect.TypeOf((*MockApprovalRepository)(nil).FindLatestByProblemID), ctx, problemID)
+}
+
+// FindPending mocks base method.
+func (m *MockApprovalRepository) FindPending(ctx context.Context) ([]entity.Approval, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindPending", ctx)
+	ret0, _ := ret[0].([]entity.Approval)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindPending indicates an expected call of FindPending.
+func (mr *MockApprovalRepositoryMockRecorder) FindPending(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindPending", reflect.TypeOf((*MockApprovalRepository)(nil).FindPending), ctx)
+}
+
+// MarkApplied mocks base method.
+func (m *MockApprovalRepository) MarkApplied(ctx context.Context, id value.ID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkApplied", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkApplied indicates an expected call of MarkApplied.
+func (mr *MockApprovalRepositoryMockRecorder) MarkApplied(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkApplied", reflect.TypeOf((*MockApprovalRepository)(nil).MarkApplied), ctx, id)
+}
+
+// Reopen mocks base method.
+func (m *MockApprovalRepository) Reopen(ctx context.Context, id value.ID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reopen", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Reopen indicates an expected call of Reopen.
+func (mr *MockApprovalRepositoryMockRecorder) Reopen(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reopen", reflect.TypeOf((*MockApprovalRepository)(nil).Reopen), ctx, id)
+}
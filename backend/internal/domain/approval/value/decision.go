@@ -0,0 +1,38 @@
+package value
+
+import "github.com/goda6565/ai-consultant/backend/internal/domain/errors"
+
+type Decision string
+
+const (
+	DecisionPending Decision = "pending"
+	// 計画・レビュー結果をそのまま承認する。フィードバックは指針として履歴に追加する
+	DecisionApprove Decision = "approve"
+	// 計画・レビュー結果を差し戻し、フィードバックを反映してやり直させる
+	DecisionRevise Decision = "revise"
+	// 承認待ちのまま問題がキャンセルされた。レビュアーは判断できない
+	DecisionExpired Decision = "expired"
+)
+
+func (d Decision) Equals(other Decision) bool {
+	return d == other
+}
+
+func (d Decision) Value() string {
+	return string(d)
+}
+
+func NewDecision(value string) (Decision, error) {
+	switch value {
+	case "pending":
+		return DecisionPending, nil
+	case "approve":
+		return DecisionApprove, nil
+	case "revise":
+		return DecisionRevise, nil
+	case "expired":
+		return DecisionExpired, nil
+	default:
+		return "", errors.NewDomainError(errors.ValidationError, "invalid approval decision")
+	}
+}
@@ -0,0 +1,58 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/goda6565/ai-consultant/backend/internal/domain/agent/state"
+	sharedValue "github.com/goda6565/ai-consultant/backend/internal/domain/shared/value"
+)
+
+// Checkpoint は提案ジョブの各ステップ開始時点の状態。ジョブが再起動された場合はここから再開する
+type Checkpoint struct {
+	id           sharedValue.ID
+	problemID    sharedValue.ID
+	step         int
+	snapshot     state.Snapshot
+	failCount    int
+	elapsed      time.Duration
+	runStartedAt time.Time
+	createdAt    *time.Time
+}
+
+func NewCheckpoint(id sharedValue.ID, problemID sharedValue.ID, step int, snapshot state.Snapshot, failCount int, elapsed time.Duration, runStartedAt time.Time, createdAt *time.Time) *Checkpoint {
+	return &Checkpoint{id: id, problemID: problemID, step: step, snapshot: snapshot, failCount: failCount, elapsed: elapsed, runStartedAt: runStartedAt, createdAt: createdAt}
+}
+
+func (c *Checkpoint) GetID() sharedValue.ID {
+	return c.id
+}
+
+func (c *Checkpoint) GetProblemID() sharedValue.ID {
+	return c.problemID
+}
+
+func (c *Checkpoint) GetStep() int {
+	return c.step
+}
+
+func (c *Checkpoint) GetSnapshot() state.Snapshot {
+	return c.snapshot
+}
+
+func (c *Checkpoint) GetFailCount() int {
+	return c.failCount
+}
+
+// GetElapsed returns the running time of the job until the checkpoint, used for the time budget.
+func (c *Checkpoint) GetElapsed() time.Duration {
+	return c.elapsed
+}
+
+// GetRunStartedAt returns when the run was first started, used to count only the usage of the run for the budget.
+func (c *Checkpoint) GetRunStartedAt() time.Time {
+	return c.runStartedAt
+}
+
+func (c *Checkpoint) GetCreatedAt() *time.Time {
+	return c.createdAt
+}
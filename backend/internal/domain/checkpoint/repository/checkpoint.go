@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"context"
+
+	checkpointEntity "github.com/goda6565/ai-consultant/backend/internal/domain/checkpoint/entity"
+	sharedValue "github.com/goda6565/ai-consultant/backend/internal/domain/shared/value"
+)
+
+//go:generate go tool mockgen -source=$GOFILE -destination=mock/$GOFILE -package=mock
+type CheckpointRepository interface {
+	FindLatestByProblemID(ctx context.Context, problemID sharedValue.ID) (*checkpointEntity.Checkpoint, error)
+	// Save overwrites the checkpoint of the same step
+	Save(ctx context.Context, checkpoint *checkpointEntity.Checkpoint) error
+	DeleteByProblemID(ctx context.Context, problemID sharedValue.ID) (numDeleted int64, err error)
+}
@@ -8,6 +8,7 @@ import (
 type Chunk struct {
 	id            sharedValue.ID
 	documentID    sharedValue.ID
+	index         int
 	content       value.Content
 	parentContent value.Content
 	embedding     value.Embedding
@@ -21,6 +22,11 @@ func (c *Chunk) GetDocumentID() sharedValue.ID {
 	return c.documentID
 }
 
+// GetIndex はドキュメント内でのチャンクの位置（0始まり）を返す
+func (c *Chunk) GetIndex() int {
+	return c.index
+}
+
 func (c *Chunk) GetContent() value.Content {
 	return c.content
 }
@@ -33,6 +39,6 @@ func (c *Chunk) GetEmbedding() value.Embedding {
 	return c.embedding
 }
 
-func NewChunk(id sharedValue.ID, documentID sharedValue.ID, content value.Content, parentContent value.Content, embedding value.Embedding) *Chunk {
-	return &Chunk{id: id, documentID: documentID, content: content, parentContent: parentContent, embedding: embedding}
+func NewChunk(id sharedValue.ID, documentID sharedValue.ID, index int, content value.Content, parentContent value.Content, embedding value.Embedding) *Chunk {
+	return &Chunk{id: id, documentID: documentID, index: index, content: content, parentContent: parentContent, embedding: embedding}
 }
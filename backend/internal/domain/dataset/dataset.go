@@ -0,0 +1,109 @@
+package dataset
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/goda6565/ai-consultant/backend/internal/domain/errors"
+)
+
+// DatasetInfo は集計の対象にできるデータ（CSVドキュメント）
+type DatasetInfo struct {
+	ID    string
+	Title string
+}
+
+// Table は CSV の内容。先頭行を列名とする
+type Table struct {
+	ID      string
+	Title   string
+	Columns []string
+	Rows    [][]string
+}
+
+//go:generate go tool mockgen -source=$GOFILE -destination=mock/$GOFILE -package=mock
+type DatasetClient interface {
+	List(ctx context.Context) ([]DatasetInfo, error)
+	Load(ctx context.Context, id string) (*Table, error)
+}
+
+// ParseCSV reads the CSV with a header row. Rows shorter than the header are padded with empty values.
+func ParseCSV(info DatasetInfo, reader io.Reader) (*Table, error) {
+	csvReader := csv.NewReader(reader)
+	csvReader.FieldsPerRecord = -1
+	records, err := csvReader.ReadAll()
+	if err != nil {
+		return nil, errors.NewDomainError(errors.ValidationError, fmt.Sprintf("invalid csv %s: %v", info.Title, err))
+	}
+	if len(records) == 0 {
+		return nil, errors.NewDomainError(errors.ValidationError, fmt.Sprintf("csv %s has no header", info.Title))
+	}
+	columns := make([]string, len(records[0]))
+	for i, column := range records[0] {
+		column = strings.TrimSpace(column)
+		if i == 0 {
+			// Excel で保存した CSV の BOM を取り除く
+			column = strings.TrimPrefix(column, "\ufeff")
+		}
+		columns[i] = column
+	}
+	rows := make([][]string, 0, len(records)-1)
+	for _, record := range records[1:] {
+		row := make([]string, len(columns))
+		for i := range columns {
+			if i < len(record) {
+				row[i] = strings.TrimSpace(record[i])
+			}
+		}
+		rows = append(rows, row)
+	}
+	return &Table{ID: info.ID, Title: info.Title, Columns: columns, Rows: rows}, nil
+}
+
+func (t *Table) columnIndex(column string) (int, error) {
+	for i, c := range t.Columns {
+		if c == column {
+			return i, nil
+		}
+	}
+	return 0, errors.NewDomainError(errors.ValidationError, fmt.Sprintf("unknown column %q in %s (columns: %s)", column, t.Title, strings.Join(t.Columns, ", ")))
+}
+
+// Describe summarizes the table for the prompt: the number of rows and the kind and samples of each column.
+func (t *Table) Describe(maxSamples int) string {
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("- datasetId: %s\n  タイトル: %s\n  行数: %d\n  列:\n", t.ID, t.Title, len(t.Rows)))
+	for i, column := range t.Columns {
+		samples := []string{}
+		seen := map[string]bool{}
+		numeric, date, filled := 0, 0, 0
+		for _, row := range t.Rows {
+			v := row[i]
+			if v == "" {
+				continue
+			}
+			filled++
+			if _, ok := parseNumber(v); ok {
+				numeric++
+			} else if _, ok := parseTime(v); ok {
+				date++
+			}
+			if len(samples) < maxSamples && !seen[v] {
+				seen[v] = true
+				samples = append(samples, v)
+			}
+		}
+		kind := "文字列"
+		switch {
+		case filled > 0 && numeric == filled:
+			kind = "数値"
+		case filled > 0 && date == filled:
+			kind = "日付"
+		}
+		b.WriteString(fmt.Sprintf("    - %s（%s）例: %s\n", column, kind, strings.Join(samples, ", ")))
+	}
+	return b.String()
+}
@@ -0,0 +1,430 @@
+package dataset
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/goda6565/ai-consultant/backend/internal/domain/errors"
+)
+
+// MaxResultRows は集計結果としてプロンプトに載せる行数の上限
+const MaxResultRows = 50
+
+type Operation string
+
+const (
+	OperationCount      Operation = "count"
+	OperationSum        Operation = "sum"
+	OperationAvg        Operation = "avg"
+	OperationMin        Operation = "min"
+	OperationMax        Operation = "max"
+	OperationMedian     Operation = "median"
+	OperationPercentile Operation = "percentile"
+)
+
+type Interval string
+
+const (
+	IntervalDay   Interval = "day"
+	IntervalMonth Interval = "month"
+	IntervalYear  Interval = "year"
+)
+
+type Metric struct {
+	Operation Operation `json:"operation"`
+	// Column is optional for count, which then counts the rows
+	Column string `json:"column,omitempty"`
+	// Percentile is 0 to 100 and used only by the percentile operation
+	Percentile float64 `json:"percentile,omitempty"`
+}
+
+type Filter struct {
+	Column string `json:"column"`
+	Value  string `json:"value"`
+}
+
+// Query is an aggregation requested by the agent. It is executed deterministically, not by the LLM.
+type Query struct {
+	DatasetID   string   `json:"datasetId"`
+	Description string   `json:"description"`
+	Filters     []Filter `json:"filters,omitempty"`
+	GroupBy     []string `json:"groupBy,omitempty"`
+	Metrics     []Metric `json:"metrics"`
+	// TimeColumn and Interval make the query a time series. The change from the previous period is added to the result.
+	TimeColumn string   `json:"timeColumn,omitempty"`
+	Interval   Interval `json:"interval,omitempty"`
+}
+
+type Result struct {
+	Columns []string
+	Rows    [][]string
+	// SkippedRows は数値・日付として解釈できず集計から除いた行数
+	SkippedRows int
+	Truncated   bool
+	// Latest は時系列を切り詰めたときに直近の期間を残したこと
+	Latest bool
+}
+
+func (m Metric) label() string {
+	switch m.Operation {
+	case OperationCount:
+		if m.Column == "" {
+			return "count"
+		}
+	case OperationPercentile:
+		return fmt.Sprintf("p%s(%s)", formatNumber(m.Percentile), m.Column)
+	}
+	return fmt.Sprintf("%s(%s)", m.Operation, m.Column)
+}
+
+// columnIndexes maps the columns used by the query to their index in the table.
+type columnIndexes map[string]int
+
+// validate checks the query against the table and returns the indexes of the columns it uses.
+func (q Query) validate(table *Table) (columnIndexes, error) {
+	indexes := columnIndexes{}
+	add := func(column string) error {
+		i, err := table.columnIndex(column)
+		if err != nil {
+			return err
+		}
+		indexes[column] = i
+		return nil
+	}
+	if len(q.Metrics) == 0 {
+		return nil, errors.NewDomainError(errors.ValidationError, "query requires at least one metric")
+	}
+	for _, metric := range q.Metrics {
+		switch metric.Operation {
+		case OperationCount:
+			if metric.Column == "" {
+				continue
+			}
+		case OperationSum, OperationAvg, OperationMin, OperationMax, OperationMedian:
+		case OperationPercentile:
+			if metric.Percentile < 0 || metric.Percentile > 100 {
+				return nil, errors.NewDomainError(errors.ValidationError, fmt.Sprintf("percentile must be between 0 and 100: %v", metric.Percentile))
+			}
+		default:
+			return nil, errors.NewDomainError(errors.ValidationError, fmt.Sprintf("unknown operation %q", metric.Operation))
+		}
+		if err := add(metric.Column); err != nil {
+			return nil, err
+		}
+	}
+	for _, column := range q.GroupBy {
+		if err := add(column); err != nil {
+			return nil, err
+		}
+	}
+	for _, filter := range q.Filters {
+		if err := add(filter.Column); err != nil {
+			return nil, err
+		}
+	}
+	if q.TimeColumn != "" {
+		if err := add(q.TimeColumn); err != nil {
+			return nil, err
+		}
+		switch q.Interval {
+		case IntervalDay, IntervalMonth, IntervalYear:
+		default:
+			return nil, errors.NewDomainError(errors.ValidationError, fmt.Sprintf("unknown interval %q", q.Interval))
+		}
+	}
+	return indexes, nil
+}
+
+// cell returns the trimmed value of the column so that spaces around a value do not split or miss groups.
+func (c columnIndexes) cell(row []string, column string) string {
+	return strings.TrimSpace(row[c[column]])
+}
+
+type group struct {
+	// keys は GroupBy の値に続けて、時系列の場合は期間を持つ
+	keys   []string
+	values [][]float64
+}
+
+// Execute aggregates the table. Groups are sorted by their keys so that the result does not depend on the row order.
+// A time series is sorted by period first, and only the latest periods are kept when the result is truncated.
+func (q Query) Execute(table *Table) (*Result, error) {
+	indexes, err := q.validate(table)
+	if err != nil {
+		return nil, err
+	}
+
+	groups := map[string]*group{}
+	skipped := 0
+	for _, row := range table.Rows {
+		if !q.matches(indexes, row) {
+			continue
+		}
+		keys := make([]string, 0, len(q.GroupBy)+1)
+		for _, column := range q.GroupBy {
+			keys = append(keys, indexes.cell(row, column))
+		}
+		if q.TimeColumn != "" {
+			t, ok := parseTime(indexes.cell(row, q.TimeColumn))
+			if !ok {
+				skipped++
+				continue
+			}
+			keys = append(keys, truncateTime(t, q.Interval))
+		}
+
+		values := make([]float64, len(q.Metrics))
+		valid := make([]bool, len(q.Metrics))
+		invalid := false
+		for m, metric := range q.Metrics {
+			if metric.Operation == OperationCount {
+				valid[m] = metric.Column == "" || indexes.cell(row, metric.Column) != ""
+				continue
+			}
+			v, ok := parseNumber(indexes.cell(row, metric.Column))
+			if !ok {
+				invalid = true
+				break
+			}
+			values[m], valid[m] = v, true
+		}
+		if invalid {
+			// 一部の指標だけ集計すると指標ごとに対象行が変わるため、行ごと除外する
+			skipped++
+			continue
+		}
+
+		key := strings.Join(keys, "\x00")
+		g, ok := groups[key]
+		if !ok {
+			g = &group{keys: keys, values: make([][]float64, len(q.Metrics))}
+			groups[key] = g
+		}
+		for m := range q.Metrics {
+			if valid[m] {
+				g.values[m] = append(g.values[m], values[m])
+			}
+		}
+	}
+
+	sorted := make([]*group, 0, len(groups))
+	for _, g := range groups {
+		sorted = append(sorted, g)
+	}
+	slices.SortFunc(sorted, func(a, b *group) int {
+		if q.TimeColumn != "" {
+			period := len(a.keys) - 1
+			if c := strings.Compare(a.keys[period], b.keys[period]); c != 0 {
+				return c
+			}
+		}
+		return slices.Compare(a.keys, b.keys)
+	})
+
+	result := &Result{Columns: q.columns(), SkippedRows: skipped}
+	previous := map[string][]float64{}
+	for _, g := range sorted {
+		row := append([]string{}, g.keys...)
+		aggregates := make([]float64, len(q.Metrics))
+		for m, metric := range q.Metrics {
+			v, ok := aggregate(metric, g.values[m])
+			aggregates[m] = v
+			if ok {
+				row = append(row, formatNumber(v))
+			} else {
+				row = append(row, "")
+			}
+		}
+		if q.TimeColumn != "" {
+			// 同じグループ（期間以外のキー）の直前の期間と比べる
+			series := strings.Join(g.keys[:len(g.keys)-1], "\x00")
+			for m := range q.Metrics {
+				row = append(row, changeRate(previous[series], m, aggregates[m]))
+			}
+			previous[series] = aggregates
+		}
+		result.Rows = append(result.Rows, row)
+	}
+	if len(result.Rows) > MaxResultRows {
+		if q.TimeColumn != "" {
+			result.Rows = result.Rows[len(result.Rows)-MaxResultRows:]
+			result.Latest = true
+		} else {
+			result.Rows = result.Rows[:MaxResultRows]
+		}
+		result.Truncated = true
+	}
+	return result, nil
+}
+
+func (q Query) matches(indexes columnIndexes, row []string) bool {
+	for _, filter := range q.Filters {
+		if indexes.cell(row, filter.Column) != strings.TrimSpace(filter.Value) {
+			return false
+		}
+	}
+	return true
+}
+
+func (q Query) columns() []string {
+	columns := append([]string{}, q.GroupBy...)
+	if q.TimeColumn != "" {
+		columns = append(columns, fmt.Sprintf("%s(%s)", q.TimeColumn, q.Interval))
+	}
+	for _, metric := range q.Metrics {
+		columns = append(columns, metric.label())
+	}
+	if q.TimeColumn != "" {
+		for _, metric := range q.Metrics {
+			columns = append(columns, fmt.Sprintf("%s 前期比", metric.label()))
+		}
+	}
+	return columns
+}
+
+func aggregate(metric Metric, values []float64) (float64, bool) {
+	if metric.Operation == OperationCount {
+		return float64(len(values)), true
+	}
+	if len(values) == 0 {
+		return 0, false
+	}
+	switch metric.Operation {
+	case OperationSum:
+		return sum(values), true
+	case OperationAvg:
+		return sum(values) / float64(len(values)), true
+	case OperationMin:
+		return slices.Min(values), true
+	case OperationMax:
+		return slices.Max(values), true
+	case OperationMedian:
+		return percentile(values, 50), true
+	case OperationPercentile:
+		return percentile(values, metric.Percentile), true
+	default:
+		return 0, false
+	}
+}
+
+func sum(values []float64) float64 {
+	total := 0.0
+	for _, v := range values {
+		total += v
+	}
+	return total
+}
+
+// percentile interpolates linearly between the closest ranks.
+func percentile(values []float64, p float64) float64 {
+	sorted := slices.Clone(values)
+	slices.Sort(sorted)
+	rank := p / 100 * float64(len(sorted)-1)
+	lower := int(math.Floor(rank))
+	upper := int(math.Ceil(rank))
+	return sorted[lower] + (sorted[upper]-sorted[lower])*(rank-float64(lower))
+}
+
+func changeRate(previous []float64, m int, current float64) string {
+	if previous == nil || previous[m] == 0 {
+		return ""
+	}
+	rate := (current - previous[m]) / math.Abs(previous[m]) * 100
+	sign := ""
+	if rate > 0 {
+		sign = "+"
+	}
+	return sign + formatNumber(rate) + "%"
+}
+
+func parseNumber(value string) (float64, bool) {
+	value = strings.TrimSpace(value)
+	value = strings.ReplaceAll(value, ",", "")
+	value = strings.TrimPrefix(value, "¥")
+	value = strings.TrimSuffix(value, "円")
+	value = strings.TrimSuffix(value, "%")
+	if value == "" {
+		return 0, false
+	}
+	v, err := strconv.ParseFloat(value, 64)
+	if err != nil || math.IsNaN(v) || math.IsInf(v, 0) {
+		return 0, false
+	}
+	return v, true
+}
+
+var timeLayouts = []string{
+	"2006-01-02",
+	"2006/01/02",
+	"2006/1/2",
+	"2006-01-02 15:04:05",
+	"2006/01/02 15:04:05",
+	time.RFC3339,
+	"2006-01",
+	"2006/01",
+	"2006/1",
+}
+
+func parseTime(value string) (time.Time, bool) {
+	for _, layout := range timeLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+func truncateTime(t time.Time, interval Interval) string {
+	switch interval {
+	case IntervalDay:
+		return t.Format("2006-01-02")
+	case IntervalYear:
+		return t.Format("2006")
+	default:
+		return t.Format("2006-01")
+	}
+}
+
+// formatNumber rounds to 2 decimal places and drops trailing zeros.
+func formatNumber(v float64) string {
+	rounded := math.Round(v*100) / 100
+	if rounded == 0 {
+		// -0 を表示しない
+		rounded = 0
+	}
+	return strconv.FormatFloat(rounded, 'f', -1, 64)
+}
+
+// Markdown renders the result as a Markdown table.
+func (r *Result) Markdown() string {
+	if len(r.Rows) == 0 {
+		return "該当するデータがありません。\n"
+	}
+	var b strings.Builder
+	b.WriteString(markdownRow(r.Columns))
+	b.WriteString("|" + strings.Repeat(" --- |", len(r.Columns)) + "\n")
+	for _, row := range r.Rows {
+		b.WriteString(markdownRow(row))
+	}
+	if r.Truncated && r.Latest {
+		b.WriteString(fmt.Sprintf("（直近%d行のみ表示）\n", MaxResultRows))
+	} else if r.Truncated {
+		b.WriteString(fmt.Sprintf("（先頭%d行のみ表示）\n", MaxResultRows))
+	}
+	if r.SkippedRows > 0 {
+		b.WriteString(fmt.Sprintf("（数値・日付として解釈できない%d行を除外）\n", r.SkippedRows))
+	}
+	return b.String()
+}
+
+func markdownRow(cells []string) string {
+	escaped := make([]string, len(cells))
+	for i, cell := range cells {
+		escaped[i] = strings.ReplaceAll(cell, "|", "\\|")
+	}
+	return "| " + strings.Join(escaped, " | ") + " |\n"
+}
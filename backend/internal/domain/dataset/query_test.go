@@ -0,0 +1,218 @@
+package dataset_test
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/goda6565/ai-consultant/backend/internal/domain/dataset"
+)
+
+const salesCSV = "\ufeff支店,月,売上,件数\n" +
+	"東京,2024-01-15,\"1,000\",10\n" +
+	"大阪,2024/01/20,400,4\n" +
+	"東京,2024-02-03,1500,12\n" +
+	"大阪,2024-02-28,200,\n" +
+	"東京,2024-02-10,500,5\n" +
+	"名古屋,不明,-,3\n"
+
+func newSalesTable(t *testing.T) *dataset.Table {
+	t.Helper()
+	table, err := dataset.ParseCSV(dataset.DatasetInfo{ID: "sales", Title: "売上"}, strings.NewReader(salesCSV))
+	if err != nil {
+		t.Fatalf("ParseCSV() error = %v", err)
+	}
+	return table
+}
+
+func TestParseCSV(t *testing.T) {
+	table := newSalesTable(t)
+	if !reflect.DeepEqual(table.Columns, []string{"支店", "月", "売上", "件数"}) {
+		t.Errorf("Columns = %v", table.Columns)
+	}
+	if len(table.Rows) != 6 {
+		t.Errorf("Rows = %d, expected 6", len(table.Rows))
+	}
+	if _, err := dataset.ParseCSV(dataset.DatasetInfo{Title: "empty"}, strings.NewReader("")); err == nil {
+		t.Error("expected error for csv without header")
+	}
+}
+
+func TestQuery_Execute(t *testing.T) {
+	tests := []struct {
+		name            string
+		query           dataset.Query
+		expectedColumns []string
+		expectedRows    [][]string
+		expectedSkipped int
+		wantErr         bool
+	}{
+		{
+			name: "group by with sum, avg and count",
+			query: dataset.Query{
+				GroupBy: []string{"支店"},
+				Metrics: []dataset.Metric{
+					{Operation: dataset.OperationSum, Column: "売上"},
+					{Operation: dataset.OperationAvg, Column: "売上"},
+					{Operation: dataset.OperationCount},
+				},
+			},
+			expectedColumns: []string{"支店", "sum(売上)", "avg(売上)", "count"},
+			// 売上を解釈できない名古屋の行は件数にも含めない
+			expectedRows: [][]string{
+				{"大阪", "600", "300", "2"},
+				{"東京", "3000", "1000", "3"},
+			},
+			expectedSkipped: 1,
+		},
+		{
+			name: "filter, min, max and percentiles",
+			query: dataset.Query{
+				Filters: []dataset.Filter{{Column: "支店", Value: "東京"}},
+				Metrics: []dataset.Metric{
+					{Operation: dataset.OperationMin, Column: "売上"},
+					{Operation: dataset.OperationMax, Column: "売上"},
+					{Operation: dataset.OperationMedian, Column: "売上"},
+					{Operation: dataset.OperationPercentile, Column: "売上", Percentile: 25},
+				},
+			},
+			expectedColumns: []string{"min(売上)", "max(売上)", "median(売上)", "p25(売上)"},
+			expectedRows:    [][]string{{"500", "1500", "1000", "750"}},
+		},
+		{
+			name: "time series with change from the previous period",
+			query: dataset.Query{
+				GroupBy:    []string{"支店"},
+				Metrics:    []dataset.Metric{{Operation: dataset.OperationSum, Column: "売上"}},
+				TimeColumn: "月",
+				Interval:   dataset.IntervalMonth,
+			},
+			expectedColumns: []string{"支店", "月(month)", "sum(売上)", "sum(売上) 前期比"},
+			expectedRows: [][]string{
+				{"大阪", "2024-01", "400", ""},
+				{"東京", "2024-01", "1000", ""},
+				{"大阪", "2024-02", "200", "-50%"},
+				{"東京", "2024-02", "2000", "+100%"},
+			},
+			expectedSkipped: 1,
+		},
+		{
+			name:    "unknown column",
+			query:   dataset.Query{Metrics: []dataset.Metric{{Operation: dataset.OperationSum, Column: "利益"}}},
+			wantErr: true,
+		},
+		{
+			name:    "unknown operation",
+			query:   dataset.Query{Metrics: []dataset.Metric{{Operation: "mode", Column: "売上"}}},
+			wantErr: true,
+		},
+		{
+			name:    "time series without interval",
+			query:   dataset.Query{Metrics: []dataset.Metric{{Operation: dataset.OperationCount}}, TimeColumn: "月"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := tt.query.Execute(newSalesTable(t))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Execute() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if !reflect.DeepEqual(result.Columns, tt.expectedColumns) {
+				t.Errorf("Columns = %v, expected %v", result.Columns, tt.expectedColumns)
+			}
+			if !reflect.DeepEqual(result.Rows, tt.expectedRows) {
+				t.Errorf("Rows = %v, expected %v", result.Rows, tt.expectedRows)
+			}
+			if result.SkippedRows != tt.expectedSkipped {
+				t.Errorf("SkippedRows = %d, expected %d", result.SkippedRows, tt.expectedSkipped)
+			}
+		})
+	}
+}
+
+func TestResult_Markdown(t *testing.T) {
+	result := &dataset.Result{Columns: []string{"支店", "sum(売上)"}, Rows: [][]string{{"A|B", "1"}}, SkippedRows: 2}
+	expected := "| 支店 | sum(売上) |\n| --- | --- |\n| A\\|B | 1 |\n（数値・日付として解釈できない2行を除外）\n"
+	if got := result.Markdown(); got != expected {
+		t.Errorf("Markdown() = %q, expected %q", got, expected)
+	}
+}
+
+func TestQuery_Execute_TrimsCells(t *testing.T) {
+	csv := "支店,売上\n東京 ,100\n 東京,200\n大阪,300\n"
+	table, err := dataset.ParseCSV(dataset.DatasetInfo{ID: "sales", Title: "売上"}, strings.NewReader(csv))
+	if err != nil {
+		t.Fatalf("ParseCSV() error = %v", err)
+	}
+
+	grouped, err := dataset.Query{
+		GroupBy: []string{"支店"},
+		Metrics: []dataset.Metric{{Operation: dataset.OperationSum, Column: "売上"}},
+	}.Execute(table)
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if expected := [][]string{{"大阪", "300"}, {"東京", "300"}}; !reflect.DeepEqual(grouped.Rows, expected) {
+		t.Errorf("Rows = %v, expected %v", grouped.Rows, expected)
+	}
+
+	filtered, err := dataset.Query{
+		Filters: []dataset.Filter{{Column: "支店", Value: "東京"}},
+		Metrics: []dataset.Metric{{Operation: dataset.OperationCount}},
+	}.Execute(table)
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if expected := [][]string{{"2"}}; !reflect.DeepEqual(filtered.Rows, expected) {
+		t.Errorf("Rows = %v, expected %v", filtered.Rows, expected)
+	}
+}
+
+func TestQuery_Execute_Truncate(t *testing.T) {
+	var b strings.Builder
+	b.WriteString("日付,売上\n")
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	for day := 0; day < dataset.MaxResultRows+10; day++ {
+		b.WriteString(fmt.Sprintf("%s,%d\n", start.AddDate(0, 0, day).Format("2006-01-02"), day))
+	}
+	table, err := dataset.ParseCSV(dataset.DatasetInfo{ID: "sales", Title: "売上"}, strings.NewReader(b.String()))
+	if err != nil {
+		t.Fatalf("ParseCSV() error = %v", err)
+	}
+
+	// 時系列は直近の期間を残す
+	series, err := dataset.Query{
+		Metrics:    []dataset.Metric{{Operation: dataset.OperationSum, Column: "売上"}},
+		TimeColumn: "日付",
+		Interval:   dataset.IntervalDay,
+	}.Execute(table)
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	last := start.AddDate(0, 0, dataset.MaxResultRows+9).Format("2006-01-02")
+	if len(series.Rows) != dataset.MaxResultRows || series.Rows[len(series.Rows)-1][0] != last || !series.Truncated || !series.Latest {
+		t.Errorf("Rows = %d ending with %v, expected %d ending with %s", len(series.Rows), series.Rows[len(series.Rows)-1], dataset.MaxResultRows, last)
+	}
+	if !strings.Contains(series.Markdown(), fmt.Sprintf("（直近%d行のみ表示）", dataset.MaxResultRows)) {
+		t.Errorf("Markdown() does not tell that the latest rows are kept")
+	}
+
+	grouped, err := dataset.Query{
+		GroupBy: []string{"日付"},
+		Metrics: []dataset.Metric{{Operation: dataset.OperationCount}},
+	}.Execute(table)
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	first := start.Format("2006-01-02")
+	if len(grouped.Rows) != dataset.MaxResultRows || grouped.Rows[0][0] != first || !grouped.Truncated || grouped.Latest {
+		t.Errorf("Rows = %d starting with %v, expected %d starting with %s", len(grouped.Rows), grouped.Rows[0], dataset.MaxResultRows, first)
+	}
+}
@@ -14,6 +14,7 @@ type Document struct {
 	storageInfo  value.StorageInfo
 	status       value.DocumentStatus
 	retryCount   value.RetryCount
+	collection   value.Collection
 	createdAt    *time.Time
 	updatedAt    *time.Time
 }
@@ -63,6 +64,14 @@ func (d *Document) GetRetryCount() value.RetryCount {
 	return d.retryCount
 }
 
+func (d *Document) GetCollection() value.Collection {
+	return d.collection
+}
+
+func (d *Document) SetCollection(collection value.Collection) {
+	d.collection = collection
+}
+
 func (d *Document) GetCreatedAt() *time.Time {
 	return d.createdAt
 }
@@ -78,6 +87,7 @@ func NewDocument(
 	storageInfo value.StorageInfo,
 	status value.DocumentStatus,
 	retryCount value.RetryCount,
+	collection value.Collection,
 	createdAt *time.Time,
 	updatedAt *time.Time,
 ) *Document {
@@ -88,6 +98,7 @@ func NewDocument(
 		storageInfo:  storageInfo,
 		status:       status,
 		retryCount:   retryCount,
+		collection:   collection,
 		createdAt:    createdAt,
 		updatedAt:    updatedAt,
 	}
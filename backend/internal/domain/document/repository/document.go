@@ -13,6 +13,7 @@ type DocumentRepository interface {
 	FindAll(ctx context.Context) ([]entity.Document, error)
 	FindById(ctx context.Context, id sharedValue.ID) (*entity.Document, error)
 	FindByTitle(ctx context.Context, title value.Title) (*entity.Document, error)
+	FindIDsByCollections(ctx context.Context, collections []value.Collection) ([]sharedValue.ID, error)
 	Create(ctx context.Context, document *entity.Document) error
 	Update(ctx context.Context, document *entity.Document) (numUpdated int64, err error)
 	Delete(ctx context.Context, id sharedValue.ID) (numDeleted int64, err error)
@@ -117,6 +117,21 @@ func (mr *MockDocumentRepositoryMockRecorder) FindByTitle(ctx, title any) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByTitle", reflect.TypeOf((*MockDocumentRepository)(nil).FindByTitle), ctx, title)
 }
 
+// FindIDsByCollections mocks base method.
+func (m *MockDocumentRepository) FindIDsByCollections(ctx context.Context, collections []value.Collection) ([]value0.ID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindIDsByCollections", ctx, collections)
+	ret0, _ := ret[0].([]value0.ID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindIDsByCollections indicates an expected call of FindIDsByCollections.
+func (mr *MockDocumentRepositoryMockRecorder) FindIDsByCollections(ctx, collections any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindIDsByCollections", reflect.TypeOf((*MockDocumentRepository)(nil).FindIDsByCollections), ctx, collections)
+}
+
 // Update mocks base method.
 func (m *MockDocumentRepository) Update(ctx context.Context, document *entity.Document) (int64, error) {
 	m.ctrl.T.Helper()
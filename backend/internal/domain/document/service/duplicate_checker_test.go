@@ -30,6 +30,7 @@ func TestDuplicateChecker_CheckDuplicateByTitle(t *testing.T) {
 		testStoragePath,
 		value.DocumentStatusProcessing,
 		value.NewRetryCount(0),
+		value.Collection(""),
 		nil,
 		nil,
 	)
@@ -0,0 +1,28 @@
+package value
+
+import (
+	"github.com/goda6565/ai-consultant/backend/internal/domain/errors"
+	"unicode/utf8"
+)
+
+const (
+	maxCollectionLength = 50
+)
+
+// Collection はドキュメントをまとめるグループ名。空の場合はどのコレクションにも属さない
+type Collection string
+
+func (c Collection) Equals(other Collection) bool {
+	return c == other
+}
+
+func (c Collection) Value() string {
+	return string(c)
+}
+
+func NewCollection(value string) (Collection, error) {
+	if utf8.RuneCountInString(value) > maxCollectionLength {
+		return "", errors.NewDomainError(errors.ValidationError, "collection must be less than 50 characters")
+	}
+	return Collection(value), nil
+}
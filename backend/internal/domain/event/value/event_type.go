@@ -8,6 +8,10 @@ const (
 	EventTypeAction EventType = "action"
 	EventTypeInput  EventType = "input"
 	EventTypeOutput EventType = "output"
+	// EventTypeDelta は生成途中のテキストの差分。生成完了後は output などで全文が送られる
+	EventTypeDelta EventType = "delta"
+	// EventTypeGoalRevision はゴールの変更。メッセージに変更後のゴールと理由を含む
+	EventTypeGoalRevision EventType = "goalRevision"
 )
 
 func (e EventType) Equals(other EventType) bool {
@@ -26,6 +30,10 @@ func NewEventType(value string) (EventType, error) {
 		return EventTypeInput, nil
 	case "output":
 		return EventTypeOutput, nil
+	case "delta":
+		return EventTypeDelta, nil
+	case "goalRevision":
+		return EventTypeGoalRevision, nil
 	default:
 		return "", errors.NewDomainError(errors.ValidationError, "invalid event type")
 	}
@@ -20,3 +20,6 @@ func NewMessage(value string) (*Message, error) {
 	}
 	return &Message{value: value}, nil
 }
+
+// CancelledMessage はキャンセルで終了した実行のイベント。提案ジョブと一時停止中のキャンセルで共通
+const CancelledMessage = "提案作成がキャンセルされました。現在までの内容をレポートとして保存しました。"
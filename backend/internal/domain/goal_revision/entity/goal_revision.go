@@ -0,0 +1,52 @@
+package entity
+
+import (
+	"time"
+
+	actionValue "github.com/goda6565/ai-consultant/backend/internal/domain/action/value"
+	agentValue "github.com/goda6565/ai-consultant/backend/internal/domain/agent/value"
+	sharedValue "github.com/goda6565/ai-consultant/backend/internal/domain/shared/value"
+)
+
+// GoalRevision は提案ジョブのゴールの各版。ゴールがどのように、なぜ変わったかを残す
+type GoalRevision struct {
+	id         sharedValue.ID
+	problemID  sharedValue.ID
+	version    int
+	goal       agentValue.Goal
+	reason     string
+	actionType actionValue.ActionType
+	createdAt  *time.Time
+}
+
+func NewGoalRevision(id sharedValue.ID, problemID sharedValue.ID, version int, goal agentValue.Goal, reason string, actionType actionValue.ActionType, createdAt *time.Time) *GoalRevision {
+	return &GoalRevision{id: id, problemID: problemID, version: version, goal: goal, reason: reason, actionType: actionType, createdAt: createdAt}
+}
+
+func (g *GoalRevision) GetID() sharedValue.ID {
+	return g.id
+}
+
+func (g *GoalRevision) GetProblemID() sharedValue.ID {
+	return g.problemID
+}
+
+func (g *GoalRevision) GetVersion() int {
+	return g.version
+}
+
+func (g *GoalRevision) GetGoal() agentValue.Goal {
+	return g.goal
+}
+
+func (g *GoalRevision) GetReason() string {
+	return g.reason
+}
+
+func (g *GoalRevision) GetActionType() actionValue.ActionType {
+	return g.actionType
+}
+
+func (g *GoalRevision) GetCreatedAt() *time.Time {
+	return g.createdAt
+}
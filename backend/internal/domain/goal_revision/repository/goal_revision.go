@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"context"
+
+	goalRevisionEntity "github.com/goda6565/ai-consultant/backend/internal/domain/goal_revision/entity"
+	sharedValue "github.com/goda6565/ai-consultant/backend/internal/domain/shared/value"
+)
+
+//go:generate go tool mockgen -source=$GOFILE -destination=mock/$GOFILE -package=mock
+type GoalRevisionRepository interface {
+	// FindByProblemID returns the revisions in the order of the versions
+	FindByProblemID(ctx context.Context, problemID sharedValue.ID) ([]goalRevisionEntity.GoalRevision, error)
+	// Save overwrites the revision of the same version
+	Save(ctx context.Context, goalRevision *goalRevisionEntity.GoalRevision) error
+}
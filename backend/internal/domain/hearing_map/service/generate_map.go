@@ -3,7 +3,6 @@ package service
 import (
 	"context"
 	"fmt"
-	"strings"
 
 	hearingMessageEntity "github.com/goda6565/ai-consultant/backend/internal/domain/hearing_message/entity"
 	"github.com/goda6565/ai-consultant/backend/internal/domain/llm"
@@ -28,7 +27,8 @@ func NewGenerateHearingMapService(llmClient llm.LLMClient) *GenerateHearingMapSe
 func (s *GenerateHearingMapService) Execute(ctx context.Context, input GenerateHearingMapServiceInput) (*GenerateHearingMapServiceOutput, error) {
 	llmInput := llm.GenerateTextInput{
 		SystemPrompt: s.createSystemPrompt(),
-		UserPrompt:   s.createUserPrompt(input),
+		Messages:     hearingMessageEntity.ToLLMMessages(input.HearingMessages),
+		UserPrompt:   generateHearingMapUserPrompt,
 		Config:       llm.LLMConfig{Provider: llm.VertexAI, Model: llm.Gemini25Flash},
 	}
 
@@ -44,15 +44,7 @@ func (s *GenerateHearingMapService) createSystemPrompt() string {
 	return generateHearingMapSystemPrompt
 }
 
-func (s *GenerateHearingMapService) createUserPrompt(input GenerateHearingMapServiceInput) string {
-	var b strings.Builder
-	b.WriteString("【会話履歴】\n")
-	for _, m := range input.HearingMessages {
-		msg := m.GetMessage()
-		b.WriteString(fmt.Sprintf("%s: %s\n", m.GetRole().Value(), (&msg).Value()))
-	}
-	return b.String()
-}
+const generateHearingMapUserPrompt = "ここまでのヒアリングの会話を分析し、mindmap 形式で整理してください。"
 
 var generateHearingMapSystemPrompt = `
 あなたは企業コンサルティング支援AIの構造化エンジンです。
@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"github.com/goda6565/ai-consultant/backend/internal/domain/hearing_message/value"
+	"github.com/goda6565/ai-consultant/backend/internal/domain/llm"
 	sharedValue "github.com/goda6565/ai-consultant/backend/internal/domain/shared/value"
 )
 
@@ -43,3 +44,19 @@ func (h *HearingMessage) GetMessage() value.Message {
 func (h *HearingMessage) GetCreatedAt() *time.Time {
 	return h.createdAt
 }
+
+func (h *HearingMessage) ToLLMMessage() llm.Message {
+	if h.role.Equals(value.RoleAssistant) {
+		return llm.NewAssistantMessage(h.message.Value())
+	}
+	return llm.NewUserMessage(h.message.Value())
+}
+
+// ToLLMMessages converts the hearing into conversation turns in order.
+func ToLLMMessages(hearingMessages []HearingMessage) []llm.Message {
+	messages := make([]llm.Message, 0, len(hearingMessages))
+	for _, hearingMessage := range hearingMessages {
+		messages = append(messages, hearingMessage.ToLLMMessage())
+	}
+	return messages
+}
@@ -23,6 +23,8 @@ type GenerateHearingMessageInput struct {
 	HearingMessages      []hearingMessageEntity.HearingMessage
 	TargetProblemFieldID sharedValue.ID
 	ProblemFields        []problemFieldEntity.ProblemField
+	// OnDelta receives the assistant message while it is generated
+	OnDelta llm.DeltaHandler
 }
 
 type GenerateHearingMessageOutput struct {
@@ -34,14 +36,21 @@ func NewGenerateHearingMessageService(llmClient llm.LLMClient) *GenerateHearingM
 }
 
 func (s *GenerateHearingMessageService) Execute(ctx context.Context, input GenerateHearingMessageInput) (*GenerateHearingMessageOutput, error) {
+	// the hearing so far is sent as conversation turns and the model replies as the interviewer
+	messages := hearingMessageEntity.ToLLMMessages(input.HearingMessages)
+	userPrompt := ""
+	if len(messages) == 0 || messages[len(messages)-1].Role != llm.RoleUser {
+		userPrompt = startHearingPrompt
+	}
 	llmInput := llm.GenerateTextInput{
-		SystemPrompt: s.createSystemPrompt(),
-		UserPrompt:   s.createUserPrompt(input.Problem, input.HearingMessages, input.TargetProblemFieldID, input.ProblemFields),
+		SystemPrompt: s.createSystemPrompt(input.Problem, input.TargetProblemFieldID, input.ProblemFields),
+		Messages:     messages,
+		UserPrompt:   userPrompt,
 		Config:       llm.LLMConfig{Provider: llm.VertexAI, Model: llm.Gemini25Flash},
 		Temperature:  0.0,
 	}
 
-	llmOutput, err := s.llmClient.GenerateText(ctx, llmInput)
+	llmOutput, err := llm.GenerateTextWithStream(ctx, s.llmClient, llmInput, input.OnDelta)
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate text: %w", err)
 	}
@@ -51,13 +60,8 @@ func (s *GenerateHearingMessageService) Execute(ctx context.Context, input Gener
 	}, nil
 }
 
-func (s *GenerateHearingMessageService) createSystemPrompt() string {
-	return strings.TrimSpace(generateHearingMessageSystemPrompt)
-}
-
-func (s *GenerateHearingMessageService) createUserPrompt(
+func (s *GenerateHearingMessageService) createSystemPrompt(
 	problem *problemEntity.Problem,
-	hearingMessages []hearingMessageEntity.HearingMessage,
 	targetProblemFieldID sharedValue.ID,
 	problemFields []problemFieldEntity.ProblemField,
 ) string {
@@ -70,6 +74,8 @@ func (s *GenerateHearingMessageService) createUserPrompt(
 		}
 	}
 	var b strings.Builder
+	b.WriteString(strings.TrimSpace(generateHearingMessageSystemPrompt))
+	b.WriteString("\n\n")
 
 	// --- 課題情報 ---
 	b.WriteString("【課題情報】\n")
@@ -94,29 +100,16 @@ func (s *GenerateHearingMessageService) createUserPrompt(
 	b.WriteString((&fld).Value())
 	b.WriteString("\n")
 
-	// --- 会話履歴 ---
-	b.WriteString("\n【これまでの会話履歴（古い→新しい）】\n")
-	if len(hearingMessages) == 0 {
-		b.WriteString("（まだ会話履歴はありません）\n")
-	} else {
-		for _, m := range hearingMessages {
-			role := "ユーザー"
-			if m.GetRole().Value() == "assistant" {
-				role = "アシスタント"
-			}
-			msg := m.GetMessage()
-			b.WriteString(fmt.Sprintf("%s: %s\n", role, (&msg).Value()))
-		}
-	}
-
 	// --- 出力指示 ---
 	b.WriteString("\n【出力指示】\n")
-	b.WriteString("ターゲット項目に関連する、次の最も効果的な質問を1つだけ生成してください。\n")
+	b.WriteString("これまでの会話を踏まえ、ターゲット項目に関連する、次の最も効果的な質問を1つだけ生成してください。\n")
 	b.WriteString("曖昧な回答が続く場合は具体例を提示して補足質問をしてください。\n")
 
 	return b.String()
 }
 
+const startHearingPrompt = "ヒアリングを開始してください。"
+
 var generateHearingMessageSystemPrompt = `
 あなたは戦略コンサルタントとして、クライアントの課題を正確に理解し、解決策につながる情報を効率的に引き出すプロのインタビュアーです。
 
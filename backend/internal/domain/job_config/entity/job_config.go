@@ -1,17 +1,24 @@
 package entity
 
 import (
+	jobConfigValue "github.com/goda6565/ai-consultant/backend/internal/domain/job_config/value"
 	sharedValue "github.com/goda6565/ai-consultant/backend/internal/domain/shared/value"
+	workflowValue "github.com/goda6565/ai-consultant/backend/internal/domain/workflow/value"
 )
 
 type JobConfig struct {
 	id                   sharedValue.ID
 	problemID            sharedValue.ID
 	enableInternalSearch bool
+	modelMap             jobConfigValue.ModelMap
+	budget               jobConfigValue.Budget
+	approvalGates        jobConfigValue.ApprovalGates
+	workflow             workflowValue.Name
+	documentScope        jobConfigValue.DocumentScope
 }
 
-func NewJobConfig(id sharedValue.ID, problemID sharedValue.ID, enableInternalSearch bool) *JobConfig {
-	return &JobConfig{id: id, problemID: problemID, enableInternalSearch: enableInternalSearch}
+func NewJobConfig(id sharedValue.ID, problemID sharedValue.ID, enableInternalSearch bool, modelMap jobConfigValue.ModelMap, budget jobConfigValue.Budget, approvalGates jobConfigValue.ApprovalGates, workflow workflowValue.Name, documentScope jobConfigValue.DocumentScope) *JobConfig {
+	return &JobConfig{id: id, problemID: problemID, enableInternalSearch: enableInternalSearch, modelMap: modelMap, budget: budget, approvalGates: approvalGates, workflow: workflow, documentScope: documentScope}
 }
 
 func (j *JobConfig) GetID() sharedValue.ID {
@@ -33,3 +40,43 @@ func (j *JobConfig) EnableInternalSearch() {
 func (j *JobConfig) DisableInternalSearch() {
 	j.enableInternalSearch = false
 }
+
+func (j *JobConfig) GetModelMap() jobConfigValue.ModelMap {
+	return j.modelMap
+}
+
+func (j *JobConfig) SetModelMap(modelMap jobConfigValue.ModelMap) {
+	j.modelMap = modelMap
+}
+
+func (j *JobConfig) GetBudget() jobConfigValue.Budget {
+	return j.budget
+}
+
+func (j *JobConfig) SetBudget(budget jobConfigValue.Budget) {
+	j.budget = budget
+}
+
+func (j *JobConfig) GetApprovalGates() jobConfigValue.ApprovalGates {
+	return j.approvalGates
+}
+
+func (j *JobConfig) SetApprovalGates(approvalGates jobConfigValue.ApprovalGates) {
+	j.approvalGates = approvalGates
+}
+
+func (j *JobConfig) GetWorkflowName() workflowValue.Name {
+	return j.workflow
+}
+
+func (j *JobConfig) SetWorkflowName(workflow workflowValue.Name) {
+	j.workflow = workflow
+}
+
+func (j *JobConfig) GetDocumentScope() jobConfigValue.DocumentScope {
+	return j.documentScope
+}
+
+func (j *JobConfig) SetDocumentScope(documentScope jobConfigValue.DocumentScope) {
+	j.documentScope = documentScope
+}
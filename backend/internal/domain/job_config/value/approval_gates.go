@@ -0,0 +1,52 @@
+package value
+
+import (
+	"fmt"
+
+	actionValue "github.com/goda6565/ai-consultant/backend/internal/domain/action/value"
+	"github.com/goda6565/ai-consultant/backend/internal/domain/errors"
+)
+
+// ApprovalGates は実行後に人間の承認を待つアクションの一覧
+type ApprovalGates struct {
+	actionTypes []actionValue.ActionType
+}
+
+func NewApprovalGates(values []string) (*ApprovalGates, error) {
+	actionTypes := []actionValue.ActionType{}
+	seen := map[string]bool{}
+	for _, v := range values {
+		if seen[v] {
+			continue
+		}
+		actionType, err := actionValue.NewActionType(v)
+		if err != nil || !isGateable(actionType) {
+			return nil, errors.NewDomainError(errors.ValidationError, fmt.Sprintf("invalid approval gate %s", v))
+		}
+		seen[v] = true
+		actionTypes = append(actionTypes, actionType)
+	}
+	return &ApprovalGates{actionTypes: actionTypes}, nil
+}
+
+// 承認を挟めるのは計画とレビューの直後のみ
+func isGateable(actionType actionValue.ActionType) bool {
+	return actionType.Equals(actionValue.ActionTypePlan) || actionType.Equals(actionValue.ActionTypeReview)
+}
+
+func (a ApprovalGates) Contains(actionType actionValue.ActionType) bool {
+	for _, gate := range a.actionTypes {
+		if gate.Equals(actionType) {
+			return true
+		}
+	}
+	return false
+}
+
+func (a ApprovalGates) Value() []string {
+	values := make([]string, 0, len(a.actionTypes))
+	for _, gate := range a.actionTypes {
+		values = append(values, gate.Value())
+	}
+	return values
+}
@@ -0,0 +1,71 @@
+package value
+
+import (
+	"slices"
+	"testing"
+
+	actionValue "github.com/goda6565/ai-consultant/backend/internal/domain/action/value"
+)
+
+func TestNewApprovalGates(t *testing.T) {
+	tests := []struct {
+		name     string
+		values   []string
+		expected []string
+		wantErr  bool
+	}{
+		{
+			name:     "plan and review",
+			values:   []string{"plan", "review"},
+			expected: []string{"plan", "review"},
+		},
+		{
+			name:     "duplicates are removed",
+			values:   []string{"review", "review"},
+			expected: []string{"review"},
+		},
+		{
+			name:     "empty",
+			values:   nil,
+			expected: []string{},
+		},
+		{
+			name:    "action without gate",
+			values:  []string{"write"},
+			wantErr: true,
+		},
+		{
+			name:    "unknown action",
+			values:  []string{"unknown"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gates, err := NewApprovalGates(tt.values)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewApprovalGates() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if !slices.Equal(gates.Value(), tt.expected) {
+				t.Errorf("Value() = %v, expected %v", gates.Value(), tt.expected)
+			}
+		})
+	}
+}
+
+func TestApprovalGates_Contains(t *testing.T) {
+	gates, err := NewApprovalGates([]string{"plan"})
+	if err != nil {
+		t.Fatalf("NewApprovalGates() error = %v", err)
+	}
+	if !gates.Contains(actionValue.ActionTypePlan) {
+		t.Error("Contains(plan) = false, expected true")
+	}
+	if gates.Contains(actionValue.ActionTypeReview) {
+		t.Error("Contains(review) = true, expected false")
+	}
+}
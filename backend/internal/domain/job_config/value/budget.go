@@ -0,0 +1,91 @@
+package value
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/goda6565/ai-consultant/backend/internal/domain/errors"
+)
+
+// BudgetNearLimitRatio を超えて消費したら上限が近いとみなす
+const BudgetNearLimitRatio = 0.8
+
+// Budget は提案ジョブ1回あたりの上限。0 の項目は無制限
+type Budget struct {
+	maxTokens   int
+	maxCost     float64
+	maxDuration time.Duration
+}
+
+func NewBudget(maxTokens int, maxCost float64, maxDuration time.Duration) (*Budget, error) {
+	if maxTokens < 0 || maxCost < 0 || maxDuration < 0 {
+		return nil, errors.NewDomainError(errors.ValidationError, "budget must not be negative")
+	}
+	return &Budget{maxTokens: maxTokens, maxCost: maxCost, maxDuration: maxDuration}, nil
+}
+
+func (b Budget) GetMaxTokens() int {
+	return b.maxTokens
+}
+
+func (b Budget) GetMaxCost() float64 {
+	return b.maxCost
+}
+
+func (b Budget) GetMaxDuration() time.Duration {
+	return b.maxDuration
+}
+
+func (b Budget) IsUnlimited() bool {
+	return b.maxTokens == 0 && b.maxCost == 0 && b.maxDuration == 0
+}
+
+type BudgetConsumption struct {
+	Tokens  int
+	Cost    float64
+	Elapsed time.Duration
+}
+
+type BudgetStatus string
+
+const (
+	BudgetStatusWithin    BudgetStatus = "within"
+	BudgetStatusNearLimit BudgetStatus = "nearLimit"
+	BudgetStatusExceeded  BudgetStatus = "exceeded"
+)
+
+type BudgetCheck struct {
+	Status BudgetStatus
+	// 上限に近づいた・超えた項目の説明
+	Reason string
+}
+
+// Check compares the consumption with each limit and reports the most severe status.
+func (b Budget) Check(consumption BudgetConsumption) BudgetCheck {
+	status := BudgetStatusWithin
+	reasons := []string{}
+	check := func(label string, ratio float64, detail string) {
+		switch {
+		case ratio >= 1:
+			status = BudgetStatusExceeded
+		case ratio >= BudgetNearLimitRatio:
+			if status == BudgetStatusWithin {
+				status = BudgetStatusNearLimit
+			}
+		default:
+			return
+		}
+		reasons = append(reasons, fmt.Sprintf("%s %s", label, detail))
+	}
+	if b.maxTokens > 0 {
+		check("トークン数", float64(consumption.Tokens)/float64(b.maxTokens), fmt.Sprintf("%d/%d", consumption.Tokens, b.maxTokens))
+	}
+	if b.maxCost > 0 {
+		check("コスト", consumption.Cost/b.maxCost, fmt.Sprintf("$%.4f/$%.4f", consumption.Cost, b.maxCost))
+	}
+	if b.maxDuration > 0 {
+		check("実行時間", float64(consumption.Elapsed)/float64(b.maxDuration), fmt.Sprintf("%s/%s", consumption.Elapsed.Round(time.Second), b.maxDuration))
+	}
+	return BudgetCheck{Status: status, Reason: strings.Join(reasons, ", ")}
+}
@@ -0,0 +1,66 @@
+package value
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBudget_Check(t *testing.T) {
+	budget, err := NewBudget(1000, 1.0, 10*time.Minute)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name        string
+		budget      Budget
+		consumption BudgetConsumption
+		expected    BudgetStatus
+	}{
+		{
+			name:        "within all limits",
+			budget:      *budget,
+			consumption: BudgetConsumption{Tokens: 100, Cost: 0.1, Elapsed: time.Minute},
+			expected:    BudgetStatusWithin,
+		},
+		{
+			name:        "near token limit",
+			budget:      *budget,
+			consumption: BudgetConsumption{Tokens: 850, Cost: 0.1, Elapsed: time.Minute},
+			expected:    BudgetStatusNearLimit,
+		},
+		{
+			name:        "exceeded duration wins over near cost",
+			budget:      *budget,
+			consumption: BudgetConsumption{Tokens: 100, Cost: 0.9, Elapsed: 11 * time.Minute},
+			expected:    BudgetStatusExceeded,
+		},
+		{
+			name:        "unlimited budget",
+			budget:      Budget{},
+			consumption: BudgetConsumption{Tokens: 1 << 30, Cost: 1000, Elapsed: 24 * time.Hour},
+			expected:    BudgetStatusWithin,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			check := tt.budget.Check(tt.consumption)
+			if check.Status != tt.expected {
+				t.Errorf("Status = %s, expected %s", check.Status, tt.expected)
+			}
+			if (check.Status == BudgetStatusWithin) != (check.Reason == "") {
+				t.Errorf("Reason = %q for status %s", check.Reason, check.Status)
+			}
+		})
+	}
+}
+
+func TestNewBudget(t *testing.T) {
+	if _, err := NewBudget(-1, 0, 0); err == nil {
+		t.Error("expected error for negative tokens")
+	}
+	if _, err := NewBudget(0, 0, 0); err != nil {
+		t.Errorf("unexpected error for unlimited budget: %v", err)
+	}
+}
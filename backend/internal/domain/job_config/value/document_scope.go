@@ -0,0 +1,74 @@
+package value
+
+import (
+	"fmt"
+
+	documentValue "github.com/goda6565/ai-consultant/backend/internal/domain/document/value"
+	"github.com/goda6565/ai-consultant/backend/internal/domain/errors"
+	sharedValue "github.com/goda6565/ai-consultant/backend/internal/domain/shared/value"
+)
+
+// DocumentScope は社内検索で参照してよいコレクションとドキュメントの一覧。
+// どちらも空の場合は制限しない
+type DocumentScope struct {
+	collections []documentValue.Collection
+	documentIDs []sharedValue.ID
+}
+
+func NewDocumentScope(collections []string, documentIDs []string) (*DocumentScope, error) {
+	scope := &DocumentScope{collections: []documentValue.Collection{}, documentIDs: []sharedValue.ID{}}
+	seenCollections := map[string]bool{}
+	for _, v := range collections {
+		if seenCollections[v] {
+			continue
+		}
+		collection, err := documentValue.NewCollection(v)
+		if err != nil || v == "" {
+			return nil, errors.NewDomainError(errors.ValidationError, fmt.Sprintf("invalid collection %q", v))
+		}
+		seenCollections[v] = true
+		scope.collections = append(scope.collections, collection)
+	}
+	seenDocumentIDs := map[string]bool{}
+	for _, v := range documentIDs {
+		if seenDocumentIDs[v] {
+			continue
+		}
+		documentID, err := sharedValue.NewID(v)
+		if err != nil {
+			return nil, errors.NewDomainError(errors.ValidationError, fmt.Sprintf("invalid document id %s", v))
+		}
+		seenDocumentIDs[v] = true
+		scope.documentIDs = append(scope.documentIDs, documentID)
+	}
+	return scope, nil
+}
+
+// IsRestricted は検索対象が制限されているかを返す
+func (s DocumentScope) IsRestricted() bool {
+	return len(s.collections) > 0 || len(s.documentIDs) > 0
+}
+
+func (s DocumentScope) GetCollections() []documentValue.Collection {
+	return s.collections
+}
+
+func (s DocumentScope) GetDocumentIDs() []sharedValue.ID {
+	return s.documentIDs
+}
+
+func (s DocumentScope) CollectionValues() []string {
+	values := make([]string, 0, len(s.collections))
+	for _, collection := range s.collections {
+		values = append(values, collection.Value())
+	}
+	return values
+}
+
+func (s DocumentScope) DocumentIDValues() []string {
+	values := make([]string, 0, len(s.documentIDs))
+	for _, documentID := range s.documentIDs {
+		values = append(values, documentID.Value())
+	}
+	return values
+}
@@ -0,0 +1,73 @@
+package value
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestNewDocumentScope(t *testing.T) {
+	documentID := "11111111-2222-3333-4444-555555555555"
+
+	tests := []struct {
+		name                string
+		collections         []string
+		documentIDs         []string
+		expectedCollections []string
+		expectedDocumentIDs []string
+		expectedRestricted  bool
+		wantErr             bool
+	}{
+		{
+			name:                "empty scope searches every document",
+			expectedCollections: []string{},
+			expectedDocumentIDs: []string{},
+		},
+		{
+			name:                "collections and documents",
+			collections:         []string{"client-a", "shared"},
+			documentIDs:         []string{documentID},
+			expectedCollections: []string{"client-a", "shared"},
+			expectedDocumentIDs: []string{documentID},
+			expectedRestricted:  true,
+		},
+		{
+			name:                "duplicates are removed",
+			collections:         []string{"client-a", "client-a"},
+			documentIDs:         []string{documentID, documentID},
+			expectedCollections: []string{"client-a"},
+			expectedDocumentIDs: []string{documentID},
+			expectedRestricted:  true,
+		},
+		{
+			name:        "empty collection",
+			collections: []string{""},
+			wantErr:     true,
+		},
+		{
+			name:        "invalid document id",
+			documentIDs: []string{"not-a-uuid"},
+			wantErr:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scope, err := NewDocumentScope(tt.collections, tt.documentIDs)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewDocumentScope() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if !slices.Equal(scope.CollectionValues(), tt.expectedCollections) {
+				t.Errorf("CollectionValues() = %v, expected %v", scope.CollectionValues(), tt.expectedCollections)
+			}
+			if !slices.Equal(scope.DocumentIDValues(), tt.expectedDocumentIDs) {
+				t.Errorf("DocumentIDValues() = %v, expected %v", scope.DocumentIDValues(), tt.expectedDocumentIDs)
+			}
+			if scope.IsRestricted() != tt.expectedRestricted {
+				t.Errorf("IsRestricted() = %v, expected %v", scope.IsRestricted(), tt.expectedRestricted)
+			}
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package value
+
+import (
+	"fmt"
+
+	actionValue "github.com/goda6565/ai-consultant/backend/internal/domain/action/value"
+	"github.com/goda6565/ai-consultant/backend/internal/domain/errors"
+	"github.com/goda6565/ai-consultant/backend/internal/domain/llm"
+)
+
+// DefaultModelKey はアクション個別の設定がない場合に使うモデルのキー
+const DefaultModelKey = "default"
+
+var DefaultLLMConfig = llm.LLMConfig{Provider: llm.VertexAI, Model: llm.Gemini25Flash}
+
+// ModelMap はアクションごとに使用する LLM の設定
+type ModelMap struct {
+	models map[string]llm.LLMConfig
+}
+
+func NewModelMap(models map[string]llm.LLMConfig) (*ModelMap, error) {
+	copied := make(map[string]llm.LLMConfig, len(models))
+	for key, config := range models {
+		if key != DefaultModelKey {
+			actionType, err := actionValue.NewActionType(key)
+			if err != nil || actionType.Equals(actionValue.ActionTypeDone) {
+				return nil, errors.NewDomainError(errors.ValidationError, fmt.Sprintf("invalid model map key %s", key))
+			}
+		}
+		if err := config.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid model config for %s: %w", key, err)
+		}
+		copied[key] = config
+	}
+	return &ModelMap{models: copied}, nil
+}
+
+func (m ModelMap) Value() map[string]llm.LLMConfig {
+	copied := make(map[string]llm.LLMConfig, len(m.models))
+	for key, config := range m.models {
+		copied[key] = config
+	}
+	return copied
+}
+
+func (m ModelMap) Get(actionType actionValue.ActionType) llm.LLMConfig {
+	if config, ok := m.models[actionType.Value()]; ok {
+		return config
+	}
+	return m.GetDefault()
+}
+
+func (m ModelMap) GetDefault() llm.LLMConfig {
+	if config, ok := m.models[DefaultModelKey]; ok {
+		return config
+	}
+	return DefaultLLMConfig
+}
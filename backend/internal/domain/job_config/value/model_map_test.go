@@ -0,0 +1,95 @@
+package value
+
+import (
+	"testing"
+
+	actionValue "github.com/goda6565/ai-consultant/backend/internal/domain/action/value"
+	"github.com/goda6565/ai-consultant/backend/internal/domain/llm"
+)
+
+func TestNewModelMap(t *testing.T) {
+	tests := []struct {
+		name    string
+		models  map[string]llm.LLMConfig
+		wantErr bool
+	}{
+		{
+			name: "valid action and default keys",
+			models: map[string]llm.LLMConfig{
+				DefaultModelKey:                              {Provider: llm.VertexAI, Model: llm.Gemini25Flash},
+				actionValue.ActionTypeWrite.Value():          {Provider: llm.OpenAI, Model: llm.GPT5},
+				actionValue.SelfActionTypeSkipper.Value():    {Provider: llm.OpenAI, Model: llm.GPT4o},
+				actionValue.SelfActionTypeSummarize.Value():  {Provider: llm.VertexAI, Model: llm.Gemini25Flash},
+				actionValue.SelfActionTypeTerminator.Value(): {Provider: llm.VertexAI, Model: llm.Gemini25Flash},
+			},
+			wantErr: false,
+		},
+		{
+			name:    "unknown key",
+			models:  map[string]llm.LLMConfig{"unknown": {Provider: llm.OpenAI, Model: llm.GPT4o}},
+			wantErr: true,
+		},
+		{
+			name:    "done is not configurable",
+			models:  map[string]llm.LLMConfig{actionValue.ActionTypeDone.Value(): {Provider: llm.OpenAI, Model: llm.GPT4o}},
+			wantErr: true,
+		},
+		{
+			name:    "model does not belong to provider",
+			models:  map[string]llm.LLMConfig{actionValue.ActionTypePlan.Value(): {Provider: llm.VertexAI, Model: llm.GPT4o}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewModelMap(tt.models)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewModelMap() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestModelMap_Get(t *testing.T) {
+	write := llm.LLMConfig{Provider: llm.OpenAI, Model: llm.GPT5}
+	fallback := llm.LLMConfig{Provider: llm.OpenAI, Model: llm.GPT4o}
+
+	tests := []struct {
+		name       string
+		models     map[string]llm.LLMConfig
+		actionType actionValue.ActionType
+		expected   llm.LLMConfig
+	}{
+		{
+			name:       "configured action",
+			models:     map[string]llm.LLMConfig{actionValue.ActionTypeWrite.Value(): write},
+			actionType: actionValue.ActionTypeWrite,
+			expected:   write,
+		},
+		{
+			name:       "falls back to default key",
+			models:     map[string]llm.LLMConfig{actionValue.ActionTypeWrite.Value(): write, DefaultModelKey: fallback},
+			actionType: actionValue.ActionTypePlan,
+			expected:   fallback,
+		},
+		{
+			name:       "falls back to built-in default",
+			models:     map[string]llm.LLMConfig{},
+			actionType: actionValue.SelfActionTypeOrchestrator,
+			expected:   DefaultLLMConfig,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			modelMap, err := NewModelMap(tt.models)
+			if err != nil {
+				t.Fatalf("NewModelMap() error = %v", err)
+			}
+			if got := modelMap.Get(tt.actionType); got != tt.expected {
+				t.Errorf("Get() = %v, expected %v", got, tt.expected)
+			}
+		})
+	}
+}
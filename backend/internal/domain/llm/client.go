@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"iter"
 	"strings"
 
 	"github.com/goda6565/ai-consultant/backend/internal/domain/errors"
@@ -34,6 +35,7 @@ const (
 //go:generate go tool mockgen -source=$GOFILE -destination=mock/$GOFILE -package=mock
 type LLMClient interface {
 	GenerateText(ctx context.Context, input GenerateTextInput) (*GenerateTextOutput, error)
+	GenerateTextStream(ctx context.Context, input GenerateTextInput) iter.Seq2[*GenerateTextStreamChunk, error]
 	GenerateStructuredText(ctx context.Context, input GenerateStructuredTextInput) (*GenerateStructuredTextOutput, error)
 	GenerateFunctionCall(ctx context.Context, input GenerateFunctionCallInput) (*GenerateFunctionCallOutput, error)
 	GenerateEmbedding(ctx context.Context, input GenerateEmbeddingInput) (*GenerateEmbeddingOutput, error)
@@ -73,37 +75,73 @@ type Usage struct {
 	TotalTokens  int
 }
 
+// SystemPrompt and UserPrompt are sent before and after Messages respectively.
 type GenerateTextInput struct {
 	SystemPrompt string
 	UserPrompt   string
+	Messages     []Message
 	Temperature  float32
 	Config       LLMConfig
 }
 
+func (i GenerateTextInput) Conversation() ([]Message, error) {
+	return BuildMessages(i.SystemPrompt, i.Messages, i.UserPrompt)
+}
+
 type GenerateTextOutput struct {
 	Text  string
 	Usage Usage
 }
 
+// GenerateTextStreamChunk is a piece of the streamed text.
+// The last chunk has an empty Delta and carries the usage of the whole call.
+type GenerateTextStreamChunk struct {
+	Delta string
+	Usage *Usage
+}
+
 type GenerateStructuredTextInput struct {
 	SystemPrompt string
 	UserPrompt   string
+	Messages     []Message
 	Temperature  float32
 	Schema       json.RawMessage
 	Config       LLMConfig
 }
 
+func (i GenerateStructuredTextInput) Conversation() ([]Message, error) {
+	return BuildMessages(i.SystemPrompt, i.Messages, i.UserPrompt)
+}
+
 type GenerateStructuredTextOutput struct {
 	Text  string
 	Usage Usage
 }
 
+// FunctionCallingMode controls whether the model must, may or must not call functions.
+type FunctionCallingMode string
+
+const (
+	// FunctionCallingModeAny forces the model to call at least one function. It is the default.
+	FunctionCallingModeAny FunctionCallingMode = ""
+	// FunctionCallingModeAuto lets the model choose between function calls and a text answer.
+	FunctionCallingModeAuto FunctionCallingMode = "auto"
+	// FunctionCallingModeNone forbids function calls while keeping the declarations for the history.
+	FunctionCallingModeNone FunctionCallingMode = "none"
+)
+
 type GenerateFunctionCallInput struct {
 	SystemPrompt string
 	UserPrompt   string
+	Messages     []Message
 	Temperature  float32
 	Config       LLMConfig
 	Functions    []Function
+	Mode         FunctionCallingMode
+}
+
+func (i GenerateFunctionCallInput) Conversation() ([]Message, error) {
+	return BuildMessages(i.SystemPrompt, i.Messages, i.UserPrompt)
 }
 
 type Function struct {
@@ -112,12 +150,18 @@ type Function struct {
 	Parameters  json.RawMessage
 }
 
+// GenerateFunctionCallOutput holds all function calls of the response.
+// FunctionCall is the first of them, and Text is the answer when the model calls no function in auto or none mode.
 type GenerateFunctionCallOutput struct {
-	FunctionCall FunctionCall
-	Usage        Usage
+	FunctionCall  FunctionCall
+	FunctionCalls []FunctionCall
+	Text          string
+	Usage         Usage
 }
 
 type FunctionCall struct {
+	// ID is set by providers that match tool results by id
+	ID        string
 	Name      string
 	Arguments map[string]any
 }
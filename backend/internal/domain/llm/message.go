@@ -0,0 +1,83 @@
+package llm
+
+import (
+	"fmt"
+
+	"github.com/goda6565/ai-consultant/backend/internal/domain/errors"
+)
+
+type Role string
+
+const (
+	RoleSystem    Role = "system"
+	RoleUser      Role = "user"
+	RoleAssistant Role = "assistant"
+	RoleTool      Role = "tool"
+)
+
+// Message is a turn of the conversation.
+// An assistant message may carry function calls, and a tool message carries the result of one of them.
+type Message struct {
+	Role          Role
+	Content       string
+	FunctionCalls []FunctionCall
+	// ToolCallID and Name identify the function call that a tool message answers
+	ToolCallID string
+	Name       string
+}
+
+func NewSystemMessage(content string) Message {
+	return Message{Role: RoleSystem, Content: content}
+}
+
+func NewUserMessage(content string) Message {
+	return Message{Role: RoleUser, Content: content}
+}
+
+func NewAssistantMessage(content string) Message {
+	return Message{Role: RoleAssistant, Content: content}
+}
+
+func NewFunctionCallMessage(content string, calls ...FunctionCall) Message {
+	return Message{Role: RoleAssistant, Content: content, FunctionCalls: calls}
+}
+
+func NewToolMessage(call FunctionCall, result string) Message {
+	return Message{Role: RoleTool, Content: result, ToolCallID: call.ID, Name: call.Name}
+}
+
+func (m Message) Validate() error {
+	switch m.Role {
+	case RoleSystem, RoleUser:
+		if len(m.FunctionCalls) > 0 {
+			return errors.NewDomainError(errors.ValidationError, fmt.Sprintf("%s message cannot have function calls", m.Role))
+		}
+	case RoleAssistant:
+	case RoleTool:
+		if m.Name == "" {
+			return errors.NewDomainError(errors.ValidationError, "tool message requires the function name")
+		}
+	default:
+		return errors.NewDomainError(errors.ValidationError, fmt.Sprintf("invalid message role %s", m.Role))
+	}
+	return nil
+}
+
+// BuildMessages returns the conversation in order of the system prompt, the messages and the user prompt.
+// Empty prompts are omitted so that callers can use either the prompts, the messages or both.
+func BuildMessages(systemPrompt string, messages []Message, userPrompt string) ([]Message, error) {
+	conversation := make([]Message, 0, len(messages)+2)
+	if systemPrompt != "" {
+		conversation = append(conversation, NewSystemMessage(systemPrompt))
+	}
+	for _, message := range messages {
+		if err := message.Validate(); err != nil {
+			return nil, err
+		}
+		conversation = append(conversation, message)
+	}
+	if userPrompt != "" {
+		conversation = append(conversation, NewUserMessage(userPrompt))
+	}
+	return conversation, nil
+}
@@ -11,6 +11,7 @@ package mock
 
 import (
 	context "context"
+	iter "iter"
 	reflect "reflect"
 
 	llm "github.com/goda6565/ai-consultant/backend/internal/domain/llm"
@@ -116,6 +117,20 @@ func (mr *MockLLMClientMockRecorder) GenerateText(ctx, input any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateText", reflect.TypeOf((*MockLLMClient)(nil).GenerateText), ctx, input)
 }
 
+// GenerateTextStream mocks base method.
+func (m *MockLLMClient) GenerateTextStream(ctx context.Context, input llm.GenerateTextInput) iter.Seq2[*llm.GenerateTextStreamChunk, error] {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GenerateTextStream", ctx, input)
+	ret0, _ := ret[0].(iter.Seq2[*llm.GenerateTextStreamChunk, error])
+	return ret0
+}
+
+// GenerateTextStream indicates an expected call of GenerateTextStream.
+func (mr *MockLLMClientMockRecorder) GenerateTextStream(ctx, input any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateTextStream", reflect.TypeOf((*MockLLMClient)(nil).GenerateTextStream), ctx, input)
+}
+
 // GetTokenCount mocks base method.
 func (m *MockLLMClient) GetTokenCount(ctx context.Context, input llm.CountTokenInput) (*llm.CountTokenOutput, error) {
 	m.ctrl.T.Helper()
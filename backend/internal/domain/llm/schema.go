@@ -0,0 +1,129 @@
+package llm
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/goda6565/ai-consultant/backend/internal/domain/errors"
+)
+
+// jsonSchema は構造化出力で使用している JSON Schema のサブセット
+type jsonSchema struct {
+	Type       string                 `json:"type"`
+	Properties map[string]*jsonSchema `json:"properties"`
+	Required   []string               `json:"required"`
+	Items      *jsonSchema            `json:"items"`
+	Enum       []any                  `json:"enum"`
+	Minimum    *float64               `json:"minimum"`
+	Maximum    *float64               `json:"maximum"`
+	Nullable   bool                   `json:"nullable"`
+}
+
+// ValidateJSON validates data against the schema used for structured output.
+// It supports type, properties, required, items, enum, minimum, maximum and nullable.
+func ValidateJSON(schema json.RawMessage, data []byte) error {
+	var s jsonSchema
+	if err := json.Unmarshal(schema, &s); err != nil {
+		return errors.NewDomainError(errors.ValidationError, fmt.Sprintf("invalid schema: %v", err))
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		return errors.NewDomainError(errors.ValidationError, fmt.Sprintf("invalid json: %v", err))
+	}
+	if decoder.More() {
+		return errors.NewDomainError(errors.ValidationError, "invalid json: unexpected data after top-level value")
+	}
+
+	if err := validateValue(&s, value, "$"); err != nil {
+		return errors.NewDomainError(errors.ValidationError, err.Error())
+	}
+	return nil
+}
+
+func validateValue(s *jsonSchema, value any, path string) error {
+	if s == nil {
+		return nil
+	}
+	if value == nil {
+		if s.Nullable || s.Type == "" || strings.EqualFold(s.Type, "null") {
+			return nil
+		}
+		return fmt.Errorf("%s: expected %s but got null", path, strings.ToLower(s.Type))
+	}
+
+	switch strings.ToLower(s.Type) {
+	case "object":
+		object, ok := value.(map[string]any)
+		if !ok {
+			return fmt.Errorf("%s: expected object", path)
+		}
+		for _, key := range s.Required {
+			if _, ok := object[key]; !ok {
+				return fmt.Errorf("%s: missing required property %q", path, key)
+			}
+		}
+		for key, property := range s.Properties {
+			if v, ok := object[key]; ok {
+				if err := validateValue(property, v, path+"."+key); err != nil {
+					return err
+				}
+			}
+		}
+	case "array":
+		array, ok := value.([]any)
+		if !ok {
+			return fmt.Errorf("%s: expected array", path)
+		}
+		for i, item := range array {
+			if err := validateValue(s.Items, item, fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return err
+			}
+		}
+	case "string":
+		if _, ok := value.(string); !ok {
+			return fmt.Errorf("%s: expected string", path)
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			return fmt.Errorf("%s: expected boolean", path)
+		}
+	case "number", "integer":
+		number, ok := value.(json.Number)
+		if !ok {
+			return fmt.Errorf("%s: expected %s", path, strings.ToLower(s.Type))
+		}
+		f, err := number.Float64()
+		if err != nil {
+			return fmt.Errorf("%s: invalid number %s", path, number)
+		}
+		if strings.EqualFold(s.Type, "integer") && f != math.Trunc(f) {
+			return fmt.Errorf("%s: expected integer but got %s", path, number)
+		}
+		if s.Minimum != nil && f < *s.Minimum {
+			return fmt.Errorf("%s: %s is less than minimum %v", path, number, *s.Minimum)
+		}
+		if s.Maximum != nil && f > *s.Maximum {
+			return fmt.Errorf("%s: %s is greater than maximum %v", path, number, *s.Maximum)
+		}
+	}
+
+	if len(s.Enum) > 0 && !containsEnum(s.Enum, value) {
+		return fmt.Errorf("%s: %v is not one of %v", path, value, s.Enum)
+	}
+	return nil
+}
+
+func containsEnum(enum []any, value any) bool {
+	for _, candidate := range enum {
+		if fmt.Sprint(candidate) == fmt.Sprint(value) {
+			return true
+		}
+	}
+	return false
+}
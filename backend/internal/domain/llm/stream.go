@@ -0,0 +1,76 @@
+package llm
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultDeltaFlushSize is the size in bytes of the buffered deltas that are passed on at once.
+	DefaultDeltaFlushSize = 256
+	// DefaultDeltaFlushInterval is the longest time the deltas are held back while the stream continues.
+	DefaultDeltaFlushInterval = 500 * time.Millisecond
+)
+
+// DeltaHandler receives each piece of the streamed text as soon as it is generated.
+type DeltaHandler func(ctx context.Context, delta string)
+
+// GenerateTextWithStream streams the text, passes every delta to onDelta and returns the whole text.
+// When onDelta is nil it falls back to GenerateText.
+func GenerateTextWithStream(ctx context.Context, client LLMClient, input GenerateTextInput, onDelta DeltaHandler) (*GenerateTextOutput, error) {
+	if onDelta == nil {
+		return client.GenerateText(ctx, input)
+	}
+	var b strings.Builder
+	usage := Usage{}
+	for chunk, err := range client.GenerateTextStream(ctx, input) {
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate text stream: %w", err)
+		}
+		if chunk.Usage != nil {
+			usage = *chunk.Usage
+		}
+		if chunk.Delta == "" {
+			continue
+		}
+		b.WriteString(chunk.Delta)
+		onDelta(ctx, chunk.Delta)
+	}
+	return &GenerateTextOutput{Text: b.String(), Usage: usage}, nil
+}
+
+// DeltaBuffer joins the deltas and passes them on when the buffered text reaches the size
+// or the interval has passed since the last flush, so that a handler persisting each delta is not called for every token.
+// Flush must be called when the stream ends to pass on the rest. It is not safe for concurrent use.
+type DeltaBuffer struct {
+	onDelta   DeltaHandler
+	size      int
+	interval  time.Duration
+	buffer    strings.Builder
+	flushedAt time.Time
+}
+
+func NewDeltaBuffer(onDelta DeltaHandler, size int, interval time.Duration) *DeltaBuffer {
+	return &DeltaBuffer{onDelta: onDelta, size: size, interval: interval, flushedAt: time.Now()}
+}
+
+// Handle buffers the delta. It is used as the DeltaHandler of the stream.
+func (b *DeltaBuffer) Handle(ctx context.Context, delta string) {
+	b.buffer.WriteString(delta)
+	if b.buffer.Len() >= b.size || time.Since(b.flushedAt) >= b.interval {
+		b.Flush(ctx)
+	}
+}
+
+// Flush passes on the buffered deltas if any.
+func (b *DeltaBuffer) Flush(ctx context.Context) {
+	b.flushedAt = time.Now()
+	if b.buffer.Len() == 0 {
+		return
+	}
+	delta := b.buffer.String()
+	b.buffer.Reset()
+	b.onDelta(ctx, delta)
+}
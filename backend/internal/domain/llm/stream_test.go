@@ -0,0 +1,161 @@
+package llm_test
+
+import (
+	"context"
+	stdErrors "errors"
+	"iter"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/goda6565/ai-consultant/backend/internal/domain/llm"
+	"github.com/goda6565/ai-consultant/backend/internal/domain/llm/mock"
+	"go.uber.org/mock/gomock"
+)
+
+func TestGenerateTextWithStream(t *testing.T) {
+	input := llm.GenerateTextInput{
+		UserPrompt: "hello",
+		Config:     llm.LLMConfig{Provider: llm.VertexAI, Model: llm.Gemini25Flash},
+	}
+	streamErr := stdErrors.New("stream error")
+	stream := func(err error) iter.Seq2[*llm.GenerateTextStreamChunk, error] {
+		return func(yield func(*llm.GenerateTextStreamChunk, error) bool) {
+			for _, delta := range []string{"こんに", "ちは"} {
+				if !yield(&llm.GenerateTextStreamChunk{Delta: delta}, nil) {
+					return
+				}
+			}
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			yield(&llm.GenerateTextStreamChunk{Usage: &llm.Usage{TotalTokens: 10}}, nil)
+		}
+	}
+
+	tests := []struct {
+		name           string
+		mockSetup      func(m *mock.MockLLMClient)
+		withHandler    bool
+		expectedText   string
+		expectedDeltas []string
+		expectedError  bool
+	}{
+		{
+			name: "deltas are passed to the handler",
+			mockSetup: func(m *mock.MockLLMClient) {
+				m.EXPECT().GenerateTextStream(gomock.Any(), input).Return(stream(nil)).Times(1)
+			},
+			withHandler:    true,
+			expectedText:   "こんにちは",
+			expectedDeltas: []string{"こんに", "ちは"},
+		},
+		{
+			name: "stream error is returned",
+			mockSetup: func(m *mock.MockLLMClient) {
+				m.EXPECT().GenerateTextStream(gomock.Any(), input).Return(stream(streamErr)).Times(1)
+			},
+			withHandler:    true,
+			expectedDeltas: []string{"こんに", "ちは"},
+			expectedError:  true,
+		},
+		{
+			name: "falls back to blocking call without handler",
+			mockSetup: func(m *mock.MockLLMClient) {
+				m.EXPECT().GenerateText(gomock.Any(), input).Return(&llm.GenerateTextOutput{Text: "こんにちは", Usage: llm.Usage{TotalTokens: 10}}, nil).Times(1)
+			},
+			expectedText: "こんにちは",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockClient := mock.NewMockLLMClient(ctrl)
+			tt.mockSetup(mockClient)
+
+			deltas := []string{}
+			var onDelta llm.DeltaHandler
+			if tt.withHandler {
+				onDelta = func(ctx context.Context, delta string) {
+					deltas = append(deltas, delta)
+				}
+			}
+			output, err := llm.GenerateTextWithStream(context.Background(), mockClient, input, onDelta)
+			if tt.expectedError {
+				if !stdErrors.Is(err, streamErr) {
+					t.Errorf("error = %v, expected %v", err, streamErr)
+				}
+			} else {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if output.Text != tt.expectedText || output.Usage.TotalTokens != 10 {
+					t.Errorf("output = %+v, expected text %s with 10 tokens", output, tt.expectedText)
+				}
+			}
+			if len(deltas) != len(tt.expectedDeltas) {
+				t.Errorf("deltas = %v, expected %v", deltas, tt.expectedDeltas)
+			}
+		})
+	}
+}
+
+func TestDeltaBuffer(t *testing.T) {
+	tests := []struct {
+		name           string
+		size           int
+		interval       time.Duration
+		wait           time.Duration
+		deltas         []string
+		expectedDeltas []string
+	}{
+		{
+			name:           "deltas are joined until the size is reached",
+			size:           6,
+			interval:       time.Hour,
+			deltas:         []string{"ab", "cd", "ef", "gh"},
+			expectedDeltas: []string{"abcdef", "gh"},
+		},
+		{
+			name:           "deltas are passed on after the interval",
+			size:           100,
+			interval:       10 * time.Millisecond,
+			wait:           20 * time.Millisecond,
+			deltas:         []string{"ab", "cd"},
+			expectedDeltas: []string{"ab", "cd"},
+		},
+		{
+			name:           "rest is passed on by flush",
+			size:           100,
+			interval:       time.Hour,
+			deltas:         []string{"ab", "cd"},
+			expectedDeltas: []string{"abcd"},
+		},
+		{
+			name:           "nothing is passed on without deltas",
+			size:           100,
+			interval:       time.Hour,
+			expectedDeltas: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			deltas := []string{}
+			buffer := llm.NewDeltaBuffer(func(ctx context.Context, delta string) {
+				deltas = append(deltas, delta)
+			}, tt.size, tt.interval)
+			for _, delta := range tt.deltas {
+				time.Sleep(tt.wait)
+				buffer.Handle(context.Background(), delta)
+			}
+			buffer.Flush(context.Background())
+			if !slices.Equal(deltas, tt.expectedDeltas) {
+				t.Errorf("deltas = %v, expected %v", deltas, tt.expectedDeltas)
+			}
+		})
+	}
+}
@@ -0,0 +1,80 @@
+package llm
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/goda6565/ai-consultant/backend/internal/domain/errors"
+)
+
+// MaxStructuredOutputRepairAttempts はスキーマに違反した出力をモデルに修正させる最大回数
+const MaxStructuredOutputRepairAttempts = 2
+
+type GenerateStructuredOutput[T any] struct {
+	Value T
+	Text  string
+	Usage Usage
+}
+
+// GenerateStructured generates structured text, validates it against input.Schema and decodes it into T.
+// When the output is invalid, the model is asked to repair it with the validation error.
+func GenerateStructured[T any](ctx context.Context, client LLMClient, input GenerateStructuredTextInput) (*GenerateStructuredOutput[T], error) {
+	usage := Usage{}
+	llmInput := input
+	var lastErr error
+	for attempt := 0; attempt <= MaxStructuredOutputRepairAttempts; attempt++ {
+		llmOutput, err := client.GenerateStructuredText(ctx, llmInput)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate structured text: %w", err)
+		}
+		usage.InputTokens += llmOutput.Usage.InputTokens
+		usage.OutputTokens += llmOutput.Usage.OutputTokens
+		usage.TotalTokens += llmOutput.Usage.TotalTokens
+
+		text := trimCodeFence(llmOutput.Text)
+		value, err := decodeStructured[T](input.Schema, text)
+		if err == nil {
+			return &GenerateStructuredOutput[T]{Value: *value, Text: text, Usage: usage}, nil
+		}
+		lastErr = err
+		llmInput.UserPrompt = createRepairPrompt(input.UserPrompt, llmOutput.Text, err)
+	}
+	return nil, errors.NewDomainError(errors.ValidationError, fmt.Sprintf("structured output does not match schema after %d repair attempts: %v", MaxStructuredOutputRepairAttempts, lastErr))
+}
+
+func decodeStructured[T any](schema json.RawMessage, text string) (*T, error) {
+	if err := ValidateJSON(schema, []byte(text)); err != nil {
+		return nil, err
+	}
+	var value T
+	if err := json.Unmarshal([]byte(text), &value); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal output: %w", err)
+	}
+	return &value, nil
+}
+
+// trimCodeFence removes a markdown code fence that some models wrap around JSON.
+func trimCodeFence(text string) string {
+	trimmed := strings.TrimSpace(text)
+	if !strings.HasPrefix(trimmed, "```") {
+		return trimmed
+	}
+	trimmed = strings.TrimPrefix(trimmed, "```")
+	if index := strings.Index(trimmed, "\n"); index >= 0 {
+		trimmed = trimmed[index+1:]
+	}
+	return strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(trimmed), "```"))
+}
+
+func createRepairPrompt(userPrompt string, previousOutput string, validationErr error) string {
+	var b strings.Builder
+	b.WriteString(userPrompt)
+	b.WriteString("\n\n=== 前回の出力 ===\n")
+	b.WriteString(previousOutput)
+	b.WriteString("\n\n=== 検証エラー ===\n")
+	b.WriteString(validationErr.Error())
+	b.WriteString("\n\n前回の出力は指定されたJSONスキーマに違反しています。内容は維持したまま、スキーマに完全に従ったJSONのみを出力してください。")
+	return b.String()
+}
@@ -0,0 +1,157 @@
+package llm_test
+
+import (
+	"context"
+	"encoding/json"
+	stdErrors "errors"
+	"strings"
+	"testing"
+
+	"github.com/goda6565/ai-consultant/backend/internal/domain/errors"
+	"github.com/goda6565/ai-consultant/backend/internal/domain/llm"
+	"github.com/goda6565/ai-consultant/backend/internal/domain/llm/mock"
+	"go.uber.org/mock/gomock"
+)
+
+type decision struct {
+	CanProceed bool   `json:"canProceed"`
+	Reason     string `json:"reason"`
+}
+
+var decisionSchema = json.RawMessage(`
+	{
+		"type": "object",
+		"properties": {
+			"canProceed": {"type": "boolean"},
+			"reason": {"type": "string"}
+		},
+		"required": ["canProceed", "reason"]
+	}
+`)
+
+func TestGenerateStructured(t *testing.T) {
+	input := llm.GenerateStructuredTextInput{
+		UserPrompt: "decide",
+		Schema:     decisionSchema,
+		Config:     llm.LLMConfig{Provider: llm.VertexAI, Model: llm.Gemini25Flash},
+	}
+	isRepairPrompt := gomock.Cond(func(x any) bool {
+		in, ok := x.(llm.GenerateStructuredTextInput)
+		return ok && strings.Contains(in.UserPrompt, "検証エラー")
+	})
+
+	tests := []struct {
+		name          string
+		mockSetup     func(m *mock.MockLLMClient)
+		expected      decision
+		expectedUsage int
+		expectedError bool
+	}{
+		{
+			name: "valid output is decoded",
+			mockSetup: func(m *mock.MockLLMClient) {
+				m.EXPECT().GenerateStructuredText(gomock.Any(), input).
+					Return(&llm.GenerateStructuredTextOutput{Text: `{"canProceed": true, "reason": "ok"}`, Usage: llm.Usage{TotalTokens: 10}}, nil).
+					Times(1)
+			},
+			expected:      decision{CanProceed: true, Reason: "ok"},
+			expectedUsage: 10,
+		},
+		{
+			name: "code fence is removed",
+			mockSetup: func(m *mock.MockLLMClient) {
+				m.EXPECT().GenerateStructuredText(gomock.Any(), input).
+					Return(&llm.GenerateStructuredTextOutput{Text: "```json\n{\"canProceed\": false, \"reason\": \"wait\"}\n```"}, nil).
+					Times(1)
+			},
+			expected: decision{CanProceed: false, Reason: "wait"},
+		},
+		{
+			name: "invalid output is repaired",
+			mockSetup: func(m *mock.MockLLMClient) {
+				gomock.InOrder(
+					m.EXPECT().GenerateStructuredText(gomock.Any(), input).
+						Return(&llm.GenerateStructuredTextOutput{Text: `{"canProceed": "yes"}`, Usage: llm.Usage{TotalTokens: 10}}, nil),
+					m.EXPECT().GenerateStructuredText(gomock.Any(), isRepairPrompt).
+						Return(&llm.GenerateStructuredTextOutput{Text: `{"canProceed": true, "reason": "fixed"}`, Usage: llm.Usage{TotalTokens: 5}}, nil),
+				)
+			},
+			expected:      decision{CanProceed: true, Reason: "fixed"},
+			expectedUsage: 15,
+		},
+		{
+			name: "fails after max repair attempts",
+			mockSetup: func(m *mock.MockLLMClient) {
+				m.EXPECT().GenerateStructuredText(gomock.Any(), gomock.Any()).
+					Return(&llm.GenerateStructuredTextOutput{Text: `not json`}, nil).
+					Times(llm.MaxStructuredOutputRepairAttempts + 1)
+			},
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockClient := mock.NewMockLLMClient(ctrl)
+			tt.mockSetup(mockClient)
+
+			output, err := llm.GenerateStructured[decision](context.Background(), mockClient, input)
+			if tt.expectedError {
+				var domainErr *errors.DomainError
+				if !stdErrors.As(err, &domainErr) {
+					t.Fatalf("expected DomainError, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if output.Value != tt.expected {
+				t.Errorf("Value = %+v, expected %+v", output.Value, tt.expected)
+			}
+			if output.Usage.TotalTokens != tt.expectedUsage {
+				t.Errorf("TotalTokens = %d, expected %d", output.Usage.TotalTokens, tt.expectedUsage)
+			}
+		})
+	}
+}
+
+func TestValidateJSON(t *testing.T) {
+	schema := json.RawMessage(`
+		{
+			"type": "object",
+			"properties": {
+				"topics": {"type": "array", "items": {"type": "string"}},
+				"score": {"type": "integer", "minimum": 1, "maximum": 10},
+				"level": {"type": "string", "enum": ["low", "high"]}
+			},
+			"required": ["topics"]
+		}
+	`)
+
+	tests := []struct {
+		name    string
+		data    string
+		wantErr bool
+	}{
+		{name: "valid", data: `{"topics": ["a", "b"], "score": 5, "level": "low"}`, wantErr: false},
+		{name: "missing required", data: `{"score": 5}`, wantErr: true},
+		{name: "wrong item type", data: `{"topics": ["a", 1]}`, wantErr: true},
+		{name: "not integer", data: `{"topics": [], "score": 1.5}`, wantErr: true},
+		{name: "out of range", data: `{"topics": [], "score": 11}`, wantErr: true},
+		{name: "not in enum", data: `{"topics": [], "level": "middle"}`, wantErr: true},
+		{name: "trailing data", data: `{"topics": []} {}`, wantErr: true},
+		{name: "invalid json", data: `{"topics": [`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := llm.ValidateJSON(schema, []byte(tt.data))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateJSON() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
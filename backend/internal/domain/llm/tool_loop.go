@@ -0,0 +1,156 @@
+package llm
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/goda6565/ai-consultant/backend/internal/domain/errors"
+)
+
+// DefaultMaxToolSteps は RunToolLoop でツールを呼び出せるラウンド数の既定値
+const DefaultMaxToolSteps = 5
+
+// ToolProvider provides functions to the tool loop and executes calls of them.
+type ToolProvider interface {
+	Tools() []Function
+	Call(ctx context.Context, call FunctionCall) (string, error)
+}
+
+type RunToolLoopInput struct {
+	SystemPrompt string
+	UserPrompt   string
+	Messages     []Message
+	Temperature  float32
+	Config       LLMConfig
+	Providers    []ToolProvider
+	// MaxSteps limits the rounds of function calls. DefaultMaxToolSteps is used when it is zero.
+	MaxSteps int
+}
+
+type ToolResult struct {
+	Call   FunctionCall
+	Result string
+	Err    error
+}
+
+type RunToolLoopOutput struct {
+	// Text is the final answer of the model
+	Text string
+	// Messages is the conversation including the function calls and the tool results
+	Messages    []Message
+	ToolResults []ToolResult
+	Steps       int
+	Usage       Usage
+}
+
+// RunToolLoop lets the model call the functions of the providers, possibly several in parallel,
+// and feeds the results back until it answers with text or reaches MaxSteps.
+// At the step limit the model is asked to answer without calling functions.
+// Errors of a tool are returned to the model as the result so that it can recover.
+func RunToolLoop(ctx context.Context, client LLMClient, input RunToolLoopInput) (*RunToolLoopOutput, error) {
+	functions, providers, err := collectTools(input.Providers)
+	if err != nil {
+		return nil, err
+	}
+	maxSteps := input.MaxSteps
+	if maxSteps <= 0 {
+		maxSteps = DefaultMaxToolSteps
+	}
+
+	messages := append([]Message{}, input.Messages...)
+	if input.UserPrompt != "" {
+		messages = append(messages, NewUserMessage(input.UserPrompt))
+	}
+	output := &RunToolLoopOutput{}
+	for step := 0; ; step++ {
+		mode := FunctionCallingModeAuto
+		if step >= maxSteps {
+			mode = FunctionCallingModeNone
+		}
+		llmOutput, err := client.GenerateFunctionCall(ctx, GenerateFunctionCallInput{
+			SystemPrompt: input.SystemPrompt,
+			Messages:     messages,
+			Temperature:  input.Temperature,
+			Config:       input.Config,
+			Functions:    functions,
+			Mode:         mode,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate function call: %w", err)
+		}
+		output.Usage.InputTokens += llmOutput.Usage.InputTokens
+		output.Usage.OutputTokens += llmOutput.Usage.OutputTokens
+		output.Usage.TotalTokens += llmOutput.Usage.TotalTokens
+
+		if len(llmOutput.FunctionCalls) == 0 || mode == FunctionCallingModeNone {
+			output.Text = llmOutput.Text
+			output.Messages = append(messages, NewAssistantMessage(llmOutput.Text))
+			output.Steps = step
+			return output, nil
+		}
+
+		messages = append(messages, NewFunctionCallMessage(llmOutput.Text, llmOutput.FunctionCalls...))
+		results := callTools(ctx, providers, llmOutput.FunctionCalls)
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		for _, result := range results {
+			content := result.Result
+			if result.Err != nil {
+				content = fmt.Sprintf("error: %v", result.Err)
+			}
+			messages = append(messages, NewToolMessage(result.Call, content))
+		}
+		output.ToolResults = append(output.ToolResults, results...)
+	}
+}
+
+func collectTools(toolProviders []ToolProvider) ([]Function, map[string]ToolProvider, error) {
+	functions := []Function{}
+	providers := map[string]ToolProvider{}
+	for _, provider := range toolProviders {
+		for _, function := range provider.Tools() {
+			if _, ok := providers[function.Name]; ok {
+				return nil, nil, errors.NewDomainError(errors.ValidationError, fmt.Sprintf("duplicate tool name: %s", function.Name))
+			}
+			providers[function.Name] = provider
+			functions = append(functions, function)
+		}
+	}
+	if len(functions) == 0 {
+		return nil, nil, errors.NewDomainError(errors.ValidationError, "tool loop requires at least one tool")
+	}
+	return functions, providers, nil
+}
+
+// callTools executes the calls in parallel and returns the results in the order of the calls.
+func callTools(ctx context.Context, providers map[string]ToolProvider, calls []FunctionCall) []ToolResult {
+	results := make([]ToolResult, len(calls))
+	wg := sync.WaitGroup{}
+	for i, call := range calls {
+		wg.Add(1)
+		go func(i int, call FunctionCall) {
+			defer wg.Done()
+			results[i] = callTool(ctx, providers, call)
+		}(i, call)
+	}
+	wg.Wait()
+	return results
+}
+
+func callTool(ctx context.Context, providers map[string]ToolProvider, call FunctionCall) (result ToolResult) {
+	result.Call = call
+	defer func() {
+		if r := recover(); r != nil {
+			result.Err = fmt.Errorf("tool %s panicked: %v", call.Name, r)
+		}
+	}()
+	provider, ok := providers[call.Name]
+	if !ok {
+		result.Err = errors.NewDomainError(errors.InvalidFunctionName, fmt.Sprintf("invalid function name: %s", call.Name))
+		return result
+	}
+	result.Result, result.Err = provider.Call(ctx, call)
+	return result
+}
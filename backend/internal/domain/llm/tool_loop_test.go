@@ -0,0 +1,171 @@
+package llm_test
+
+import (
+	"context"
+	stdErrors "errors"
+	"testing"
+
+	"github.com/goda6565/ai-consultant/backend/internal/domain/llm"
+	"github.com/goda6565/ai-consultant/backend/internal/domain/llm/mock"
+	"go.uber.org/mock/gomock"
+)
+
+type echoTools struct{}
+
+func (echoTools) Tools() []llm.Function {
+	return []llm.Function{{Name: "echo"}, {Name: "fail"}}
+}
+
+func (echoTools) Call(ctx context.Context, call llm.FunctionCall) (string, error) {
+	if call.Name == "fail" {
+		return "", stdErrors.New("tool failed")
+	}
+	return call.Arguments["text"].(string), nil
+}
+
+func isMode(mode llm.FunctionCallingMode) gomock.Matcher {
+	return gomock.Cond(func(x any) bool {
+		in, ok := x.(llm.GenerateFunctionCallInput)
+		return ok && in.Mode == mode
+	})
+}
+
+func TestRunToolLoop(t *testing.T) {
+	echo := func(id, text string) llm.FunctionCall {
+		return llm.FunctionCall{ID: id, Name: "echo", Arguments: map[string]any{"text": text}}
+	}
+
+	tests := []struct {
+		name            string
+		mockSetup       func(m *mock.MockLLMClient)
+		expectedText    string
+		expectedResults []string
+		expectedErrors  int
+		expectedSteps   int
+		expectedUsage   int
+		maxSteps        int
+	}{
+		{
+			name: "answers without calling tools",
+			mockSetup: func(m *mock.MockLLMClient) {
+				m.EXPECT().GenerateFunctionCall(gomock.Any(), isMode(llm.FunctionCallingModeAuto)).
+					Return(&llm.GenerateFunctionCallOutput{Text: "done", Usage: llm.Usage{TotalTokens: 3}}, nil).
+					Times(1)
+			},
+			expectedText:    "done",
+			expectedResults: nil,
+			expectedSteps:   0,
+			expectedUsage:   3,
+		},
+		{
+			name: "runs parallel calls and iterates until the answer",
+			mockSetup: func(m *mock.MockLLMClient) {
+				gomock.InOrder(
+					m.EXPECT().GenerateFunctionCall(gomock.Any(), isMode(llm.FunctionCallingModeAuto)).
+						Return(&llm.GenerateFunctionCallOutput{FunctionCalls: []llm.FunctionCall{echo("1", "a"), echo("2", "b")}, Usage: llm.Usage{TotalTokens: 3}}, nil),
+					m.EXPECT().GenerateFunctionCall(gomock.Any(), isMode(llm.FunctionCallingModeAuto)).
+						Return(&llm.GenerateFunctionCallOutput{FunctionCalls: []llm.FunctionCall{echo("3", "c")}, Usage: llm.Usage{TotalTokens: 3}}, nil),
+					m.EXPECT().GenerateFunctionCall(gomock.Any(), isMode(llm.FunctionCallingModeAuto)).
+						Return(&llm.GenerateFunctionCallOutput{Text: "done", Usage: llm.Usage{TotalTokens: 3}}, nil),
+				)
+			},
+			maxSteps:        3,
+			expectedText:    "done",
+			expectedResults: []string{"a", "b", "c"},
+			expectedSteps:   2,
+			expectedUsage:   9,
+		},
+		{
+			name: "returns tool errors to the model",
+			mockSetup: func(m *mock.MockLLMClient) {
+				gomock.InOrder(
+					m.EXPECT().GenerateFunctionCall(gomock.Any(), gomock.Any()).
+						Return(&llm.GenerateFunctionCallOutput{FunctionCalls: []llm.FunctionCall{{Name: "fail"}, {Name: "unknown"}}}, nil),
+					m.EXPECT().GenerateFunctionCall(gomock.Any(), gomock.Any()).
+						Return(&llm.GenerateFunctionCallOutput{Text: "done"}, nil),
+				)
+			},
+			expectedText:    "done",
+			expectedResults: []string{"", ""},
+			expectedErrors:  2,
+			expectedSteps:   1,
+		},
+		{
+			name: "forces the answer at the step limit",
+			mockSetup: func(m *mock.MockLLMClient) {
+				m.EXPECT().GenerateFunctionCall(gomock.Any(), isMode(llm.FunctionCallingModeAuto)).
+					Return(&llm.GenerateFunctionCallOutput{FunctionCalls: []llm.FunctionCall{echo("1", "a")}}, nil).
+					Times(2)
+				m.EXPECT().GenerateFunctionCall(gomock.Any(), isMode(llm.FunctionCallingModeNone)).
+					Return(&llm.GenerateFunctionCallOutput{Text: "limit"}, nil).
+					Times(1)
+			},
+			maxSteps:        2,
+			expectedText:    "limit",
+			expectedResults: []string{"a", "a"},
+			expectedSteps:   2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockClient := mock.NewMockLLMClient(ctrl)
+			tt.mockSetup(mockClient)
+
+			output, err := llm.RunToolLoop(context.Background(), mockClient, llm.RunToolLoopInput{
+				UserPrompt: "search",
+				Config:     llm.LLMConfig{Provider: llm.VertexAI, Model: llm.Gemini25Flash},
+				Providers:  []llm.ToolProvider{echoTools{}},
+				MaxSteps:   tt.maxSteps,
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if output.Text != tt.expectedText {
+				t.Errorf("Text = %s, expected %s", output.Text, tt.expectedText)
+			}
+			if len(output.ToolResults) != len(tt.expectedResults) {
+				t.Fatalf("ToolResults = %d, expected %d", len(output.ToolResults), len(tt.expectedResults))
+			}
+			errorCount := 0
+			for i, result := range output.ToolResults {
+				if result.Result != tt.expectedResults[i] {
+					t.Errorf("ToolResults[%d] = %s, expected %s", i, result.Result, tt.expectedResults[i])
+				}
+				if result.Err != nil {
+					errorCount++
+				}
+			}
+			if errorCount != tt.expectedErrors {
+				t.Errorf("errors = %d, expected %d", errorCount, tt.expectedErrors)
+			}
+			if output.Steps != tt.expectedSteps {
+				t.Errorf("Steps = %d, expected %d", output.Steps, tt.expectedSteps)
+			}
+			if output.Usage.TotalTokens != tt.expectedUsage {
+				t.Errorf("TotalTokens = %d, expected %d", output.Usage.TotalTokens, tt.expectedUsage)
+			}
+			for _, message := range output.Messages {
+				if err := message.Validate(); err != nil {
+					t.Errorf("invalid message %+v: %v", message, err)
+				}
+			}
+		})
+	}
+}
+
+func TestRunToolLoop_DuplicateTools(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockClient := mock.NewMockLLMClient(ctrl)
+
+	_, err := llm.RunToolLoop(context.Background(), mockClient, llm.RunToolLoopInput{
+		UserPrompt: "search",
+		Providers:  []llm.ToolProvider{echoTools{}, echoTools{}},
+	})
+	if err == nil {
+		t.Fatal("expected error for duplicate tools")
+	}
+}
@@ -43,6 +43,20 @@ func (m *MockProblemRepository) EXPECT() *MockProblemRepositoryMockRecorder {
 	return m.recorder
 }
 
+// ClearCancelRequest mocks base method.
+func (m *MockProblemRepository) ClearCancelRequest(ctx context.Context, id value0.ID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClearCancelRequest", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ClearCancelRequest indicates an expected call of ClearCancelRequest.
+func (mr *MockProblemRepositoryMockRecorder) ClearCancelRequest(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClearCancelRequest", reflect.TypeOf((*MockProblemRepository)(nil).ClearCancelRequest), ctx, id)
+}
+
 // Create mocks base method.
 func (m *MockProblemRepository) Create(ctx context.Context, problem *entity.Problem) error {
 	m.ctrl.T.Helper()
@@ -102,6 +116,50 @@ func (mr *MockProblemRepositoryMockRecorder) FindById(ctx, id any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockProblemRepository)(nil).FindById), ctx, id)
 }
 
+// IsCancelRequested mocks base method.
+func (m *MockProblemRepository) IsCancelRequested(ctx context.Context, id value0.ID) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsCancelRequested", ctx, id)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsCancelRequested indicates an expected call of IsCancelRequested.
+func (mr *MockProblemRepositoryMockRecorder) IsCancelRequested(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsCancelRequested", reflect.TypeOf((*MockProblemRepository)(nil).IsCancelRequested), ctx, id)
+}
+
+// RequestCancel mocks base method.
+func (m *MockProblemRepository) RequestCancel(ctx context.Context, id value0.ID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequestCancel", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RequestCancel indicates an expected call of RequestCancel.
+func (mr *MockProblemRepositoryMockRecorder) RequestCancel(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestCancel", reflect.TypeOf((*MockProblemRepository)(nil).RequestCancel), ctx, id)
+}
+
+// TransitionStatus mocks base method.
+func (m *MockProblemRepository) TransitionStatus(ctx context.Context, id value0.ID, from, to value.Status) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TransitionStatus", ctx, id, from, to)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TransitionStatus indicates an expected call of TransitionStatus.
+func (mr *MockProblemRepositoryMockRecorder) TransitionStatus(ctx, id, from, to any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TransitionStatus", reflect.TypeOf((*MockProblemRepository)(nil).TransitionStatus), ctx, id, from, to)
+}
+
 // UpdateStatus mocks base method.
 func (m *MockProblemRepository) UpdateStatus(ctx context.Context, id value0.ID, status value.Status) error {
 	m.ctrl.T.Helper()
@@ -14,5 +14,12 @@ type ProblemRepository interface {
 	FindById(ctx context.Context, id sharedValue.ID) (*entity.Problem, error)
 	Create(ctx context.Context, problem *entity.Problem) error
 	UpdateStatus(ctx context.Context, id sharedValue.ID, status value.Status) error
+	// TransitionStatus changes the status only while it is still from and reports whether it was changed
+	TransitionStatus(ctx context.Context, id sharedValue.ID, from value.Status, to value.Status) (bool, error)
+	// RequestCancel sets the cancellation flag that the running proposal job polls
+	RequestCancel(ctx context.Context, id sharedValue.ID) error
+	IsCancelRequested(ctx context.Context, id sharedValue.ID) (bool, error)
+	// ClearCancelRequest resets the cancellation flag so that a later run is not cancelled by it
+	ClearCancelRequest(ctx context.Context, id sharedValue.ID) error
 	Delete(ctx context.Context, id sharedValue.ID) (numDeleted int64, err error)
 }
@@ -10,6 +10,9 @@ const (
 	StatusProcessing Status = "processing"
 	StatusDone       Status = "done"
 	StatusFailed     Status = "failed"
+	StatusCancelled  Status = "cancelled"
+	// 承認ゲートで人間の判断を待っている
+	StatusPaused Status = "paused"
 )
 
 func (s Status) Equals(other Status) bool {
@@ -30,6 +33,12 @@ func NewStatus(value string) (Status, error) {
 		return StatusProcessing, nil
 	case "done":
 		return StatusDone, nil
+	case "failed":
+		return StatusFailed, nil
+	case "cancelled":
+		return StatusCancelled, nil
+	case "paused":
+		return StatusPaused, nil
 	default:
 		return "", errors.NewDomainError(errors.ValidationError, "invalid status")
 	}
@@ -51,15 +51,11 @@ func (s *GenerateProblemFieldService) Execute(ctx context.Context, input Generat
 		`),
 	}
 
-	llmOutput, err := s.llmClient.GenerateStructuredText(ctx, llmInput)
+	llmOutput, err := llm.GenerateStructured[GenerateProblemFieldServiceOutputStruct](ctx, s.llmClient, llmInput)
 	if err != nil {
-		return nil, fmt.Errorf("failed to generate text: %w", err)
-	}
-
-	var output GenerateProblemFieldServiceOutputStruct
-	if err := json.Unmarshal([]byte(llmOutput.Text), &output); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal output: %w", err)
+		return nil, fmt.Errorf("failed to generate structured output: %w", err)
 	}
+	output := llmOutput.Value
 
 	return &GenerateProblemFieldServiceOutput{Fields: output.Fields}, nil
 }
@@ -39,10 +39,11 @@ func NewJudgeProblemFieldCompletionService(llmClient llm.LLMClient) *JudgeProble
 
 func (s *JudgeProblemFieldCompletionService) Execute(ctx context.Context, input JudgeProblemFieldCompletionServiceInput) (*JudgeProblemFieldCompletionServiceOutput, error) {
 	logger := logger.GetLogger(ctx)
-	userPrompt := s.createUserPrompt(input.Problem, input.HearingMessages, input.TargetProblemFieldID, input.ProblemFields)
+	userPrompt := s.createUserPrompt(input.Problem, input.TargetProblemFieldID, input.ProblemFields)
 	logger.Info("user prompt", "userPrompt", userPrompt)
 	llmInput := llm.GenerateStructuredTextInput{
 		SystemPrompt: s.createSystemPrompt(),
+		Messages:     hearingMessageEntity.ToLLMMessages(input.HearingMessages),
 		UserPrompt:   userPrompt,
 		Config:       llm.LLMConfig{Provider: llm.VertexAI, Model: llm.Gemini25Flash},
 		Schema: json.RawMessage(`
@@ -55,15 +56,11 @@ func (s *JudgeProblemFieldCompletionService) Execute(ctx context.Context, input
 		`),
 	}
 
-	llmOutput, err := s.llmClient.GenerateStructuredText(ctx, llmInput)
+	llmOutput, err := llm.GenerateStructured[judgeProblemFieldCompletionLLMOutputStruct](ctx, s.llmClient, llmInput)
 	if err != nil {
-		return nil, fmt.Errorf("failed to generate text: %w", err)
-	}
-
-	var parsed judgeProblemFieldCompletionLLMOutputStruct
-	if err := json.Unmarshal([]byte(llmOutput.Text), &parsed); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal output: %w", err)
+		return nil, fmt.Errorf("failed to generate structured output: %w", err)
 	}
+	parsed := llmOutput.Value
 
 	return &JudgeProblemFieldCompletionServiceOutput{IsTargetProblemFieldAnswered: parsed.IsTargetProblemFieldAnswered}, nil
 }
@@ -74,7 +71,6 @@ func (s *JudgeProblemFieldCompletionService) createSystemPrompt() string {
 
 func (s *JudgeProblemFieldCompletionService) createUserPrompt(
 	problem problemEntity.Problem,
-	hearingMessages []hearingMessageEntity.HearingMessage,
 	targetProblemFieldID sharedValue.ID,
 	problemFields []problemFieldEntity.ProblemField,
 ) string {
@@ -86,6 +82,7 @@ func (s *JudgeProblemFieldCompletionService) createUserPrompt(
 		}
 	}
 	var b strings.Builder
+	b.WriteString("ここまでがクライアントとのヒアリングの会話です。\n\n")
 
 	// --- 課題情報 ---
 	b.WriteString("【課題情報】\n")
@@ -110,20 +107,7 @@ func (s *JudgeProblemFieldCompletionService) createUserPrompt(
 	b.WriteString((&fld).Value())
 	b.WriteString("\n")
 
-	// --- 会話履歴 ---
-	b.WriteString("\n【これまでの会話履歴（古い→新しい）】\n")
-	if len(hearingMessages) == 0 {
-		b.WriteString("（まだ会話履歴はありません）\n")
-	} else {
-		for _, m := range hearingMessages {
-			role := "user"
-			if m.GetRole().Value() == "assistant" {
-				role = "assistant"
-			}
-			msg := m.GetMessage()
-			b.WriteString(fmt.Sprintf("%s: %s\n", role, (&msg).Value()))
-		}
-	}
+	b.WriteString("\n上記の項目一覧とこれまでの会話を踏まえ、判定対象フィールドが十分に埋まっているかを判定してください。\n")
 
 	return b.String()
 }
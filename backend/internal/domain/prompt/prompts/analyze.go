@@ -29,6 +29,7 @@ var analyzeSystemPrompt = `
 - 各解釈には根拠を明示、曖昧な部分は「可能性」扱いとする
 - 出力の中で「信頼度」や「不確実性」についても言及する
 - 想定外・バイアス・前提誤りの可能性を検討
+- 数値は「[データ分析N]」の集計結果や検索結果にあるもののみを使い、推測で数値を作らない
 - 説明可能性 (explainability) の観点を踏まえて、なぜその解釈に至ったかを明示
 - 全体は 500 字以内（構造的整合性を重視）
 
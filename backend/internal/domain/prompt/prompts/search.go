@@ -122,15 +122,18 @@ var searchExplorePrompt = `
 与えられたトピックについて、実際に調査を行い、課題解決に必要な知見を収集すること。
 
 # 実行ルール
-- 出力は必ず「関数呼び出し形式」（FunctionCall）で行う
+- 調査は「関数呼び出し形式」（FunctionCall）で行う
 - 関数は渡されたツール群から選択して利用する
+- 観点の異なる検索は、複数の関数呼び出しを同時に行ってよい
+- 検索結果を確認し、不足があればクエリを変えて追加の検索を行う
 - 検索クエリは具体的・再現可能・目的に一致した内容にする
 - 不要な推測は禁止。「不明な点」は検索で補う
 - 出典候補は信頼性の高い一次情報・査読済み・公的資料を優先
+- 十分な情報が集まったら、関数を呼び出さずに調査結果の要点を3文以内のテキストで回答して終了する
 
-# 例
+# 関数呼び出しの例
 {
-  "function": "webSearch",
+  "function": "web_search",
   "arguments": {
     "query": "リモートワーク チーム生産性 改善施策 2025年 日本"
   }
@@ -142,7 +145,8 @@ var searchExploreUserPrompt = `
 %s
 
 # 指示
-上記トピックについて、不足している情報を補うための最も有効な検索を1件提案してください。
+上記トピックについて、不足している情報を補うための検索を行ってください。
+必要であれば複数の検索を行い、結果を踏まえて追加の検索を行ってください。
 `
 
 func SearchSynthesizePrompt() string {
@@ -176,6 +180,8 @@ var searchSynthesizePrompt = `
 5. Summaryは記事内の事実・データ・結論・経過を具体的に記述する  
    - 記事本文の要点を抜粋してまとめる  
    - 抽象的説明・一般論・分析・推測は禁止  
+6. Score（0〜1の関連度）が付いた検索結果は、Scoreの高いものを優先して残し、低いもの（目安0.3未満）は無関係として削除してよい  
+   - Score自体は出力に含めない  
 
 # 出力ルール
 - 出力形式（厳密遵守）：
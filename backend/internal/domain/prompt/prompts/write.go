@@ -6,8 +6,11 @@ import (
 	agentState "github.com/goda6565/ai-consultant/backend/internal/domain/agent/state"
 )
 
+// WriteChangeReasonDelimiter separates the proposal body from the change reason in the write output.
+const WriteChangeReasonDelimiter = "=== CHANGE_REASON ==="
+
 func WriteSystemPrompt(state agentState.State) string {
-	return writeSystemPrompt
+	return fmt.Sprintf(writeSystemPrompt, WriteChangeReasonDelimiter, WriteChangeReasonDelimiter)
 }
 
 func WriteUserPrompt(state agentState.State) string {
@@ -32,15 +35,15 @@ var writeSystemPrompt = `
 この最終ゴールを常に参照しながら、提案書を作成してください。
 
 # 実行ルール
-- 出力は必ず JSON {"content": "...", "change_reason": "..."} のみ
-- "content": 提案書本文（Markdown形式、提出可能レベル）
-- "change_reason": 今回の改訂理由（簡潔に）
+- 出力は必ず「提案書本文」「区切り行 %s」「今回の改訂理由」の順のみ
+- 提案書本文: Markdown形式、提出可能レベル
+- 改訂理由: 区切り行の後に簡潔に1〜2文で記述
 - 不確かな点は断定せず、「追加調査が必要」と明記する
 - 事実と見解を明確に分け、一次情報・公的/査読済み情報を優先
 - 内部メモや推論過程、今後のアクション計画は含めない
 
 # 出力形式
-- JSON形式で構造化出力
+- 提案書本文をそのまま出力し（JSONやコードブロックで囲まない）、最後に区切り行と改訂理由を付ける
 - Markdown 書式ルール：
   - 大見出し "##"、小見出し "###"
   - 箇条書き "-"、段落1〜3文以内
@@ -53,7 +56,9 @@ var writeSystemPrompt = `
 - ビジネス文書として明確・簡潔・構造的に記述
 - 不確実な点は「追加調査が必要」と明記（ただし調査内容は書かない）
 - 根拠・数値・出典を簡潔に提示
-- change_reason の分類：
+- 「[データ分析N]」の集計結果の数値は丸めや言い換えをせずそのまま使い、出典（CSVのタイトル）を明記する
+- 集計結果にない数値を社内データとして記載しない
+- 改訂理由の分類：
   1. 追加：新データ・根拠・事例を追加
   2. 修正：不正確・誤解を招く記述を修正
   3. 簡潔化：冗長表現を削除
@@ -62,8 +67,16 @@ var writeSystemPrompt = `
   6. レビュー反映：指摘事項を反映
 
 # 出力例（Few-shot）
-{"content": "## 現状分析\n支店長へのヒアリングによれば、顧客満足度調査の回答率は前年より10%低下している。現場担当者は待ち時間が主要な不満要因と述べた。一方、金融庁の顧客本位運営原則では顧客体験の継続的改善が求められている[1]。\n\n## 提案方針\n現場業務のボトルネックを可視化し、待ち時間短縮と対応品質の均一化を図る施策が有効と考えられる。具体的には、窓口対応プロセスのデジタル支援ツール導入と職員教育強化を組み合わせることが望ましい。\n\n## 参考文献\n[1] 金融庁, 「顧客本位の業務運営に関する原則」, 2023, 金融庁, https://www.fsa.go.jp/...",
-"change_reason": "修正: 内部計画の記述を削除し、提出可能な提案書本文として整えた。"}
+## 現状分析
+支店長へのヒアリングによれば、顧客満足度調査の回答率は前年より10%%低下している。現場担当者は待ち時間が主要な不満要因と述べた。一方、金融庁の顧客本位運営原則では顧客体験の継続的改善が求められている[1]。
+
+## 提案方針
+現場業務のボトルネックを可視化し、待ち時間短縮と対応品質の均一化を図る施策が有効と考えられる。具体的には、窓口対応プロセスのデジタル支援ツール導入と職員教育強化を組み合わせることが望ましい。
+
+## 参考文献
+[1] 金融庁, 「顧客本位の業務運営に関する原則」, 2023, 金融庁, https://www.fsa.go.jp/...
+%s
+修正: 内部計画の記述を削除し、提出可能な提案書本文として整えた。
 `
 
 var writeUserPrompt = `
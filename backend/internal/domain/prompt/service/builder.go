@@ -68,6 +68,11 @@ func (b *PromptBuilder) Build(input PromptBuilderInput) *PromptBuilderOutput {
 				UserPrompt:   prompts.SearchSynthesizeUserPrompt(input.Input),
 			}
 		}
+	case actionValue.ActionTypeDataAnalysis:
+		return &PromptBuilderOutput{
+			SystemPrompt: prompts.DataAnalysisSystemPrompt(),
+			UserPrompt:   prompts.DataAnalysisUserPrompt(input.Input, input.State),
+		}
 	case actionValue.ActionTypeAnalyze:
 		return &PromptBuilderOutput{
 			SystemPrompt: prompts.AnalyzeSystemPrompt(input.State),
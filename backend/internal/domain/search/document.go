@@ -4,16 +4,45 @@ import (
 	"context"
 )
 
+// DocumentSearchMode selects how documents are matched against the query.
+type DocumentSearchMode string
+
+const (
+	// DocumentSearchModeVector ranks chunks by the cosine similarity of the embedding. It is the default.
+	DocumentSearchModeVector DocumentSearchMode = ""
+	// DocumentSearchModeKeyword ranks chunks by the full-text match of the query, e.g. product codes or branch names.
+	DocumentSearchModeKeyword DocumentSearchMode = "keyword"
+	// DocumentSearchModeHybrid fuses the vector and the keyword rankings with reciprocal rank fusion.
+	DocumentSearchModeHybrid DocumentSearchMode = "hybrid"
+)
+
 type DocumentSearchInput struct {
-	Query         string
+	Query string
+	// Embedding is required unless Mode is DocumentSearchModeKeyword
 	Embedding     *[]float32
 	MaxNumResults int
+	Mode          DocumentSearchMode
+	// AllowedDocumentIDs restricts the search to the documents. Every document is searched when it is nil.
+	AllowedDocumentIDs *[]string
+	// MinSimilarity drops the chunks whose cosine similarity is below it, including those found by the keyword ranking
+	// in DocumentSearchModeHybrid. Nothing is dropped when it is nil. It is ignored in DocumentSearchModeKeyword.
+	MinSimilarity *float64
 }
 
 type DocumentSearchResult struct {
-	Title   string
-	Content string
-	URL     string
+	DocumentID string
+	ChunkID    string
+	// ChunkIndex is the position of the chunk in the document, starting from 0
+	ChunkIndex int
+	Title      string
+	Content    string
+	// Similarity is the cosine similarity to the query embedding, nil in DocumentSearchModeKeyword.
+	// When the query is expanded, it is the highest among the variants.
+	Similarity *float64
+	// QueryVariants are the variants of the query that found the chunk, nil when the query is not expanded
+	QueryVariants []QueryVariant
+	// RerankScore is the relevance to the query from 0 to 1 given by the Reranker, nil when the results are not reranked
+	RerankScore *float64
 }
 
 type DocumentSearchOutput struct {
@@ -0,0 +1,57 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: query_expander.go
+//
+// Generated by this command:
+//
+//	mockgen -source=query_expander.go -destination=mock/query_expander.go -package=mock
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	search "github.com/goda6565/ai-consultant/backend/internal/domain/search"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockQueryExpander is a mock of QueryExpander interface.
+type MockQueryExpander struct {
+	ctrl     *gomock.Controller
+	recorder *MockQueryExpanderMockRecorder
+	isgomock struct{}
+}
+
+// MockQueryExpanderMockRecorder is the mock recorder for MockQueryExpander.
+type MockQueryExpanderMockRecorder struct {
+	mock *MockQueryExpander
+}
+
+// NewMockQueryExpander creates a new mock instance.
+func NewMockQueryExpander(ctrl *gomock.Controller) *MockQueryExpander {
+	mock := &MockQueryExpander{ctrl: ctrl}
+	mock.recorder = &MockQueryExpanderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockQueryExpander) EXPECT() *MockQueryExpanderMockRecorder {
+	return m.recorder
+}
+
+// Expand mocks base method.
+func (m *MockQueryExpander) Expand(ctx context.Context, input search.ExpandQueryInput) (*search.ExpandQueryOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Expand", ctx, input)
+	ret0, _ := ret[0].(*search.ExpandQueryOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Expand indicates an expected call of Expand.
+func (mr *MockQueryExpanderMockRecorder) Expand(ctx, input any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Expand", reflect.TypeOf((*MockQueryExpander)(nil).Expand), ctx, input)
+}
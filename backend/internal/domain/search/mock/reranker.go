@@ -0,0 +1,57 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: reranker.go
+//
+// Generated by this command:
+//
+//	mockgen -source=reranker.go -destination=mock/reranker.go -package=mock
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	search "github.com/goda6565/ai-consultant/backend/internal/domain/search"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockReranker is a mock of Reranker interface.
+type MockReranker struct {
+	ctrl     *gomock.Controller
+	recorder *MockRerankerMockRecorder
+	isgomock struct{}
+}
+
+// MockRerankerMockRecorder is the mock recorder for MockReranker.
+type MockRerankerMockRecorder struct {
+	mock *MockReranker
+}
+
+// NewMockReranker creates a new mock instance.
+func NewMockReranker(ctrl *gomock.Controller) *MockReranker {
+	mock := &MockReranker{ctrl: ctrl}
+	mock.recorder = &MockRerankerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReranker) EXPECT() *MockRerankerMockRecorder {
+	return m.recorder
+}
+
+// Rerank mocks base method.
+func (m *MockReranker) Rerank(ctx context.Context, input search.RerankInput) (*search.RerankOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Rerank", ctx, input)
+	ret0, _ := ret[0].(*search.RerankOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Rerank indicates an expected call of Rerank.
+func (mr *MockRerankerMockRecorder) Rerank(ctx, input any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rerank", reflect.TypeOf((*MockReranker)(nil).Rerank), ctx, input)
+}
@@ -0,0 +1,56 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: scraper.go
+//
+// Generated by this command:
+//
+//	mockgen -source=scraper.go -destination=mock/scraper.go -package=mock
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockScraperClient is a mock of ScraperClient interface.
+type MockScraperClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockScraperClientMockRecorder
+	isgomock struct{}
+}
+
+// MockScraperClientMockRecorder is the mock recorder for MockScraperClient.
+type MockScraperClientMockRecorder struct {
+	mock *MockScraperClient
+}
+
+// NewMockScraperClient creates a new mock instance.
+func NewMockScraperClient(ctrl *gomock.Controller) *MockScraperClient {
+	mock := &MockScraperClient{ctrl: ctrl}
+	mock.recorder = &MockScraperClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockScraperClient) EXPECT() *MockScraperClientMockRecorder {
+	return m.recorder
+}
+
+// Scrape mocks base method.
+func (m *MockScraperClient) Scrape(ctx context.Context, url string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Scrape", ctx, url)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Scrape indicates an expected call of Scrape.
+func (mr *MockScraperClientMockRecorder) Scrape(ctx, url any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Scrape", reflect.TypeOf((*MockScraperClient)(nil).Scrape), ctx, url)
+}
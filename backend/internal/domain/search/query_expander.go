@@ -0,0 +1,43 @@
+package search
+
+import (
+	"context"
+
+	"github.com/goda6565/ai-consultant/backend/internal/domain/llm"
+)
+
+// QueryVariantKind tells how a query variant is derived from the original query.
+type QueryVariantKind string
+
+const (
+	// QueryVariantKindOriginal is the query as given.
+	QueryVariantKindOriginal QueryVariantKind = "original"
+	// QueryVariantKindParaphrase rewords the query with other terms, e.g. synonyms used in internal documents.
+	QueryVariantKindParaphrase QueryVariantKind = "paraphrase"
+	// QueryVariantKindHypothetical is a hypothetical answer to the query (HyDE). Its embedding is closer to the passages that answer it.
+	QueryVariantKindHypothetical QueryVariantKind = "hypothetical"
+)
+
+type QueryVariant struct {
+	Kind QueryVariantKind `json:"kind"`
+	Text string           `json:"text"`
+}
+
+type ExpandQueryInput struct {
+	Query           string
+	NumParaphrases  int
+	NumHypothetical int
+	// Config is the model that generates the variants
+	Config llm.LLMConfig
+}
+
+type ExpandQueryOutput struct {
+	// Variants start with the original query
+	Variants []QueryVariant
+}
+
+//go:generate go tool mockgen -source=$GOFILE -destination=mock/$GOFILE -package=mock
+type QueryExpander interface {
+	// Expand generates the variants of the query that are searched together with it
+	Expand(ctx context.Context, input ExpandQueryInput) (*ExpandQueryOutput, error)
+}
@@ -0,0 +1,32 @@
+package search
+
+import (
+	"context"
+
+	"github.com/goda6565/ai-consultant/backend/internal/domain/llm"
+)
+
+// RerankConfig selects whether the document search results are reranked.
+type RerankConfig struct {
+	Enabled bool
+}
+
+type RerankInput struct {
+	Query      string
+	Candidates []DocumentSearchResult
+	// MaxNumResults is the number of results to keep. All candidates are kept when it is 0.
+	MaxNumResults int
+	// Config is the model that scores the candidates
+	Config llm.LLMConfig
+}
+
+type RerankOutput struct {
+	// Results are sorted by RerankScore in descending order
+	Results []DocumentSearchResult
+}
+
+//go:generate go tool mockgen -source=$GOFILE -destination=mock/$GOFILE -package=mock
+type Reranker interface {
+	// Rerank scores the candidates of a document search against the query
+	Rerank(ctx context.Context, input RerankInput) (*RerankOutput, error)
+}
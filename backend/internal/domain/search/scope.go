@@ -0,0 +1,20 @@
+package search
+
+import (
+	"context"
+)
+
+type allowedDocumentIDsKeyType struct{}
+
+var allowedDocumentIDsKey = allowedDocumentIDsKeyType{}
+
+// WithAllowedDocumentIDs restricts the document searches made with the returned context to the documents.
+func WithAllowedDocumentIDs(ctx context.Context, documentIDs []string) context.Context {
+	return context.WithValue(ctx, allowedDocumentIDsKey, documentIDs)
+}
+
+// AllowedDocumentIDsFromContext returns the documents set by WithAllowedDocumentIDs. ok is false when the search is not restricted.
+func AllowedDocumentIDsFromContext(ctx context.Context) (documentIDs []string, ok bool) {
+	documentIDs, ok = ctx.Value(allowedDocumentIDsKey).([]string)
+	return documentIDs, ok
+}
@@ -0,0 +1,11 @@
+package search
+
+import (
+	"context"
+)
+
+//go:generate go tool mockgen -source=$GOFILE -destination=mock/$GOFILE -package=mock
+type ScraperClient interface {
+	// Scrape returns the text of the page
+	Scrape(ctx context.Context, url string) (string, error)
+}
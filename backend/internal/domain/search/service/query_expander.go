@@ -0,0 +1,100 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/goda6565/ai-consultant/backend/internal/domain/llm"
+	"github.com/goda6565/ai-consultant/backend/internal/domain/search"
+)
+
+// LLMQueryExpander asks the LLM for the paraphrases and the hypothetical answers of the query in one call.
+type LLMQueryExpander struct {
+	llmClient llm.LLMClient
+}
+
+func NewLLMQueryExpander(llmClient llm.LLMClient) search.QueryExpander {
+	return &LLMQueryExpander{llmClient: llmClient}
+}
+
+type ExpandQueryOutputStruct struct {
+	Paraphrases  []string `json:"paraphrases"`
+	Hypothetical []string `json:"hypothetical"`
+}
+
+func (e *LLMQueryExpander) Expand(ctx context.Context, input search.ExpandQueryInput) (*search.ExpandQueryOutput, error) {
+	variants := []search.QueryVariant{{Kind: search.QueryVariantKindOriginal, Text: input.Query}}
+	if input.NumParaphrases <= 0 && input.NumHypothetical <= 0 {
+		return &search.ExpandQueryOutput{Variants: variants}, nil
+	}
+	llmInput := llm.GenerateStructuredTextInput{
+		SystemPrompt: expandQuerySystemPrompt,
+		UserPrompt:   fmt.Sprintf("=== 検索クエリ ===\n%s\n\n言い換え: %d件\n仮想回答: %d件", input.Query, input.NumParaphrases, input.NumHypothetical),
+		Config:       input.Config,
+		Temperature:  0.0,
+		Schema: json.RawMessage(`
+			{
+				"type": "object",
+				"properties": {
+					"paraphrases": {
+						"type": "array",
+						"items": {"type": "string"}
+					},
+					"hypothetical": {
+						"type": "array",
+						"items": {"type": "string"}
+					}
+				},
+				"required": ["paraphrases", "hypothetical"]
+			}
+		`),
+	}
+	llmOutput, err := llm.GenerateStructured[ExpandQueryOutputStruct](ctx, e.llmClient, llmInput)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate structured output: %w", err)
+	}
+
+	// 指定より多く返されても件数を守り、元のクエリと同じものや重複は除く
+	seen := map[string]bool{strings.TrimSpace(input.Query): true}
+	appendVariants := func(kind search.QueryVariantKind, texts []string, limit int) {
+		count := 0
+		for _, text := range texts {
+			text = strings.TrimSpace(text)
+			if count >= limit || text == "" || seen[text] {
+				continue
+			}
+			seen[text] = true
+			variants = append(variants, search.QueryVariant{Kind: kind, Text: text})
+			count++
+		}
+	}
+	appendVariants(search.QueryVariantKindParaphrase, llmOutput.Value.Paraphrases, input.NumParaphrases)
+	appendVariants(search.QueryVariantKindHypothetical, llmOutput.Value.Hypothetical, input.NumHypothetical)
+	return &search.ExpandQueryOutput{Variants: variants}, nil
+}
+
+var expandQuerySystemPrompt = `
+あなたは社内ドキュメント検索の「クエリ拡張担当」です。
+1つの検索クエリだけでは、社内文書で使われている別の言い回しや、答えが書かれた文章を取りこぼします。
+検索クエリから、指定された件数の「言い換え」と「仮想回答」を作成してください。
+
+# 言い換え（paraphrases）
+- クエリと同じ情報を探すための、別の語彙・表現による検索クエリ
+- 同義語、社内で使われがちな略語・正式名称、より具体的または一般的な言い方を使う
+- 型番・固有名詞・数値はそのまま残す
+
+# 仮想回答（hypothetical）
+- クエリへの答えが社内文書に書かれているとしたら、どのような文章になるかを想像して2〜3文で書く
+- 事実かどうかは問わない。社内文書らしい文体・用語で書く
+- 型番・固有名詞・数値はそのまま残す
+
+# 出力形式
+必ず次のJSON形式で出力してください。
+
+{
+  "paraphrases": ["言い換え1", "言い換え2"],
+  "hypothetical": ["仮想回答1"]
+}
+`
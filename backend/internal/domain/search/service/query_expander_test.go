@@ -0,0 +1,83 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/goda6565/ai-consultant/backend/internal/domain/llm"
+	"github.com/goda6565/ai-consultant/backend/internal/domain/llm/mock"
+	"github.com/goda6565/ai-consultant/backend/internal/domain/search"
+	"go.uber.org/mock/gomock"
+)
+
+func TestLLMQueryExpander_Expand(t *testing.T) {
+	query := "AB-123 の保守期限"
+
+	tests := []struct {
+		name     string
+		output   string
+		expected []search.QueryVariant
+	}{
+		{
+			name:   "original first, then paraphrases and hypothetical answers",
+			output: `{"paraphrases": ["AB-123 サポート終了日"], "hypothetical": ["AB-123 の保守は2026年3月末で終了する。"]}`,
+			expected: []search.QueryVariant{
+				{Kind: search.QueryVariantKindOriginal, Text: query},
+				{Kind: search.QueryVariantKindParaphrase, Text: "AB-123 サポート終了日"},
+				{Kind: search.QueryVariantKindHypothetical, Text: "AB-123 の保守は2026年3月末で終了する。"},
+			},
+		},
+		{
+			name:   "duplicates, blanks and extra variants are dropped",
+			output: `{"paraphrases": [" AB-123 の保守期限 ", "", "AB-123 サポート終了日", "AB-123 保守終了", "AB-123 サポート終了日"], "hypothetical": []}`,
+			expected: []search.QueryVariant{
+				{Kind: search.QueryVariantKindOriginal, Text: query},
+				{Kind: search.QueryVariantKindParaphrase, Text: "AB-123 サポート終了日"},
+				{Kind: search.QueryVariantKindParaphrase, Text: "AB-123 保守終了"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockClient := mock.NewMockLLMClient(ctrl)
+			config := llm.LLMConfig{Provider: llm.OpenAI, Model: llm.GPT4o}
+			mockClient.EXPECT().GenerateStructuredText(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, input llm.GenerateStructuredTextInput) (*llm.GenerateStructuredTextOutput, error) {
+				if input.Config != config {
+					t.Errorf("config = %v, expected %v", input.Config, config)
+				}
+				return &llm.GenerateStructuredTextOutput{Text: tt.output}, nil
+			}).Times(1)
+
+			output, err := NewLLMQueryExpander(mockClient).Expand(context.Background(), search.ExpandQueryInput{Query: query, NumParaphrases: 2, NumHypothetical: 1, Config: config})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(output.Variants) != len(tt.expected) {
+				t.Fatalf("variants = %v, expected %v", output.Variants, tt.expected)
+			}
+			for n, variant := range output.Variants {
+				if variant != tt.expected[n] {
+					t.Errorf("variants[%d] = %v, expected %v", n, variant, tt.expected[n])
+				}
+			}
+		})
+	}
+}
+
+func TestLLMQueryExpander_ExpandWithoutVariants(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	// LLM is not called
+	mockClient := mock.NewMockLLMClient(ctrl)
+
+	output, err := NewLLMQueryExpander(mockClient).Expand(context.Background(), search.ExpandQueryInput{Query: "query"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(output.Variants) != 1 || output.Variants[0].Kind != search.QueryVariantKindOriginal {
+		t.Errorf("variants = %v, expected only the original query", output.Variants)
+	}
+}
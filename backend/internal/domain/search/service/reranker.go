@@ -0,0 +1,141 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/goda6565/ai-consultant/backend/internal/domain/llm"
+	"github.com/goda6565/ai-consultant/backend/internal/domain/search"
+)
+
+// maxRerankContentLength は採点に渡す各候補の本文の最大文字数
+const maxRerankContentLength = 1000
+
+// maxRerankScore は LLM が付ける関連度の最大値。0〜1 に正規化して返す
+const maxRerankScore = 10
+
+// LLMReranker asks the LLM to score every candidate against the query in one call.
+type LLMReranker struct {
+	llmClient llm.LLMClient
+}
+
+// NewReranker returns nil when reranking is disabled, so that the document search keeps the fused order.
+func NewReranker(config search.RerankConfig, llmClient llm.LLMClient) search.Reranker {
+	if !config.Enabled {
+		return nil
+	}
+	return NewLLMReranker(llmClient)
+}
+
+func NewLLMReranker(llmClient llm.LLMClient) search.Reranker {
+	return &LLMReranker{llmClient: llmClient}
+}
+
+type RerankOutputStruct struct {
+	Scores []RerankScoreStruct `json:"scores"`
+}
+
+type RerankScoreStruct struct {
+	Index int `json:"index"`
+	Score int `json:"score"`
+}
+
+func (r *LLMReranker) Rerank(ctx context.Context, input search.RerankInput) (*search.RerankOutput, error) {
+	if len(input.Candidates) == 0 {
+		return &search.RerankOutput{Results: []search.DocumentSearchResult{}}, nil
+	}
+	llmInput := llm.GenerateStructuredTextInput{
+		SystemPrompt: rerankSystemPrompt,
+		UserPrompt:   r.createUserPrompt(input),
+		Config:       input.Config,
+		Temperature:  0.0,
+		Schema: json.RawMessage(`
+			{
+				"type": "object",
+				"properties": {
+					"scores": {
+						"type": "array",
+						"items": {
+							"type": "object",
+							"properties": {
+								"index": {"type": "integer"},
+								"score": {"type": "integer", "minimum": 0, "maximum": 10}
+							},
+							"required": ["index", "score"]
+						}
+					}
+				},
+				"required": ["scores"]
+			}
+		`),
+	}
+	llmOutput, err := llm.GenerateStructured[RerankOutputStruct](ctx, r.llmClient, llmInput)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate structured output: %w", err)
+	}
+
+	// 採点されなかった候補は 0 点として扱う
+	scores := make([]float64, len(input.Candidates))
+	for _, score := range llmOutput.Value.Scores {
+		if score.Index < 0 || score.Index >= len(input.Candidates) {
+			continue
+		}
+		scores[score.Index] = float64(score.Score) / maxRerankScore
+	}
+	results := make([]search.DocumentSearchResult, len(input.Candidates))
+	for n, candidate := range input.Candidates {
+		score := scores[n]
+		candidate.RerankScore = &score
+		results[n] = candidate
+	}
+	sort.SliceStable(results, func(a, b int) bool {
+		return *results[a].RerankScore > *results[b].RerankScore
+	})
+	if input.MaxNumResults > 0 && input.MaxNumResults < len(results) {
+		results = results[:input.MaxNumResults]
+	}
+	return &search.RerankOutput{Results: results}, nil
+}
+
+func (r *LLMReranker) createUserPrompt(input search.RerankInput) string {
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("=== 検索クエリ ===\n%s\n\n", input.Query))
+	b.WriteString("=== 候補 ===\n")
+	for n, candidate := range input.Candidates {
+		content := []rune(candidate.Content)
+		if len(content) > maxRerankContentLength {
+			content = content[:maxRerankContentLength]
+		}
+		b.WriteString(fmt.Sprintf("[%d] Title: %s\n%s\n\n", n, candidate.Title, string(content)))
+	}
+	return b.String()
+}
+
+var rerankSystemPrompt = `
+あなたは社内ドキュメント検索の「関連度評価者（Reranker）」です。
+検索クエリに対して、各候補の本文がどれだけ直接的に答えているかを採点してください。
+
+# 採点基準
+- 10: クエリに直接答える具体的な事実・データ・手順が含まれている
+- 7〜9: クエリの主題について具体的に述べているが、一部しか答えていない
+- 4〜6: 関連する話題だが、クエリへの答えとしては間接的
+- 1〜3: 用語が一致するだけで内容はほとんど関係がない
+- 0: 無関係
+
+# ルール
+- タイトルではなく本文の内容で判断する
+- すべての候補を [番号] の index で1回ずつ採点する
+
+# 出力形式
+必ず次のJSON形式で出力してください。
+
+{
+  "scores": [
+    {"index": 0, "score": 8},
+    {"index": 1, "score": 2}
+  ]
+}
+`
@@ -0,0 +1,94 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/goda6565/ai-consultant/backend/internal/domain/llm"
+	"github.com/goda6565/ai-consultant/backend/internal/domain/llm/mock"
+	"github.com/goda6565/ai-consultant/backend/internal/domain/search"
+	"go.uber.org/mock/gomock"
+)
+
+func TestLLMReranker_Rerank(t *testing.T) {
+	candidates := []search.DocumentSearchResult{
+		{Title: "a", Content: "支店の来店数"},
+		{Title: "b", Content: "型番 AB-123 の仕様"},
+		{Title: "c", Content: "無関係な議事録"},
+	}
+
+	tests := []struct {
+		name           string
+		output         string
+		maxNumResults  int
+		expectedTitles []string
+		expectedScores []float64
+	}{
+		{
+			name:           "sorted by score and truncated",
+			output:         `{"scores": [{"index": 0, "score": 6}, {"index": 1, "score": 9}, {"index": 2, "score": 1}]}`,
+			maxNumResults:  2,
+			expectedTitles: []string{"b", "a"},
+			expectedScores: []float64{0.9, 0.6},
+		},
+		{
+			name:           "unscored and unknown candidates",
+			output:         `{"scores": [{"index": 2, "score": 5}, {"index": 7, "score": 10}]}`,
+			expectedTitles: []string{"c", "a", "b"},
+			expectedScores: []float64{0.5, 0, 0},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockClient := mock.NewMockLLMClient(ctrl)
+			config := llm.LLMConfig{Provider: llm.OpenAI, Model: llm.GPT4o}
+			mockClient.EXPECT().GenerateStructuredText(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, input llm.GenerateStructuredTextInput) (*llm.GenerateStructuredTextOutput, error) {
+				if input.Config != config {
+					t.Errorf("config = %v, expected %v", input.Config, config)
+				}
+				return &llm.GenerateStructuredTextOutput{Text: tt.output}, nil
+			}).Times(1)
+
+			output, err := NewLLMReranker(mockClient).Rerank(context.Background(), search.RerankInput{Query: "AB-123", Candidates: candidates, MaxNumResults: tt.maxNumResults, Config: config})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(output.Results) != len(tt.expectedTitles) {
+				t.Fatalf("results = %d, expected %d", len(output.Results), len(tt.expectedTitles))
+			}
+			for n, result := range output.Results {
+				if result.Title != tt.expectedTitles[n] || result.RerankScore == nil || *result.RerankScore != tt.expectedScores[n] {
+					t.Errorf("results[%d] = %s (%v), expected %s (%v)", n, result.Title, result.RerankScore, tt.expectedTitles[n], tt.expectedScores[n])
+				}
+			}
+		})
+	}
+}
+
+func TestLLMReranker_NoCandidates(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	// the LLM is not called without candidates
+	mockClient := mock.NewMockLLMClient(ctrl)
+
+	output, err := NewLLMReranker(mockClient).Rerank(context.Background(), search.RerankInput{Query: "AB-123"})
+	if err != nil || len(output.Results) != 0 {
+		t.Errorf("output = %v, err = %v, expected no results", output, err)
+	}
+}
+
+func TestNewReranker(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockClient := mock.NewMockLLMClient(ctrl)
+
+	if reranker := NewReranker(search.RerankConfig{Enabled: false}, mockClient); reranker != nil {
+		t.Errorf("reranker = %v, expected nil when disabled", reranker)
+	}
+	if reranker := NewReranker(search.RerankConfig{Enabled: true}, mockClient); reranker == nil {
+		t.Error("reranker is nil, expected the LLM reranker when enabled")
+	}
+}
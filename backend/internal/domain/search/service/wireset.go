@@ -0,0 +1,8 @@
+package service
+
+import "github.com/google/wire"
+
+var Set = wire.NewSet(
+	NewReranker,
+	NewLLMQueryExpander,
+)
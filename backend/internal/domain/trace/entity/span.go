@@ -0,0 +1,72 @@
+package entity
+
+import (
+	"maps"
+	"time"
+
+	sharedValue "github.com/goda6565/ai-consultant/backend/internal/domain/shared/value"
+	traceValue "github.com/goda6565/ai-consultant/backend/internal/domain/trace/value"
+)
+
+// Span は提案ジョブの実行トレースの1区間。parentID を辿ると実行全体の木になる
+type Span struct {
+	id         sharedValue.ID
+	problemID  sharedValue.ID
+	parentID   *sharedValue.ID
+	name       string
+	kind       traceValue.SpanKind
+	status     traceValue.SpanStatus
+	error      string
+	attributes map[string]string
+	startedAt  time.Time
+	endedAt    time.Time
+}
+
+func NewSpan(id sharedValue.ID, problemID sharedValue.ID, parentID *sharedValue.ID, name string, kind traceValue.SpanKind, status traceValue.SpanStatus, error string, attributes map[string]string, startedAt time.Time, endedAt time.Time) *Span {
+	return &Span{id: id, problemID: problemID, parentID: parentID, name: name, kind: kind, status: status, error: error, attributes: maps.Clone(attributes), startedAt: startedAt, endedAt: endedAt}
+}
+
+func (s *Span) GetID() sharedValue.ID {
+	return s.id
+}
+
+func (s *Span) GetProblemID() sharedValue.ID {
+	return s.problemID
+}
+
+// GetParentID returns nil for the root span of a run.
+func (s *Span) GetParentID() *sharedValue.ID {
+	return s.parentID
+}
+
+func (s *Span) GetName() string {
+	return s.name
+}
+
+func (s *Span) GetKind() traceValue.SpanKind {
+	return s.kind
+}
+
+func (s *Span) GetStatus() traceValue.SpanStatus {
+	return s.status
+}
+
+func (s *Span) GetError() string {
+	return s.error
+}
+
+func (s *Span) GetAttributes() map[string]string {
+	return maps.Clone(s.attributes)
+}
+
+func (s *Span) GetStartedAt() time.Time {
+	return s.startedAt
+}
+
+func (s *Span) GetEndedAt() time.Time {
+	return s.endedAt
+}
+
+func (s *Span) GetDuration() time.Duration {
+	return s.endedAt.Sub(s.startedAt)
+}
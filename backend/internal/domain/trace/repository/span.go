@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"context"
+
+	sharedValue "github.com/goda6565/ai-consultant/backend/internal/domain/shared/value"
+	traceEntity "github.com/goda6565/ai-consultant/backend/internal/domain/trace/entity"
+)
+
+//go:generate go tool mockgen -source=$GOFILE -destination=mock/$GOFILE -package=mock
+type SpanRepository interface {
+	Create(ctx context.Context, span *traceEntity.Span) error
+	// FindByProblemID returns the spans of all runs of the problem in the order of the start time
+	FindByProblemID(ctx context.Context, problemID sharedValue.ID) ([]traceEntity.Span, error)
+}
@@ -0,0 +1,164 @@
+package service
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	traceEntity "github.com/goda6565/ai-consultant/backend/internal/domain/trace/entity"
+	traceValue "github.com/goda6565/ai-consultant/backend/internal/domain/trace/value"
+)
+
+type SpanNode struct {
+	Span     traceEntity.Span
+	Children []*SpanNode
+}
+
+// BuildSpanTree returns the root spans with their descendants, each level in the order of the start time.
+// A span whose parent was not saved, e.g. because the job was killed, is returned as a root.
+func BuildSpanTree(spans []traceEntity.Span) []*SpanNode {
+	sorted := append([]traceEntity.Span{}, spans...)
+	sort.SliceStable(sorted, func(a, b int) bool {
+		return sorted[a].GetStartedAt().Before(sorted[b].GetStartedAt())
+	})
+	nodes := make(map[string]*SpanNode, len(sorted))
+	for _, span := range sorted {
+		nodes[span.GetID().Value()] = &SpanNode{Span: span, Children: []*SpanNode{}}
+	}
+	roots := []*SpanNode{}
+	for _, span := range sorted {
+		node := nodes[span.GetID().Value()]
+		if parentID := span.GetParentID(); parentID != nil {
+			if parent, ok := nodes[parentID.Value()]; ok {
+				parent.Children = append(parent.Children, node)
+				continue
+			}
+		}
+		roots = append(roots, node)
+	}
+	return roots
+}
+
+// OTLP/JSON の形式。https://opentelemetry.io/docs/specs/otlp/#json-protobuf-encoding
+const (
+	otlpServiceName      = "ai-consultant"
+	otlpScopeName        = "proposal-job"
+	otlpSpanKindInternal = 1
+	otlpStatusCodeOK     = 1
+	otlpStatusCodeError  = 2
+	// SpanKindAttributeKey はエージェントの区間の種類を表す属性
+	SpanKindAttributeKey = "agent.span.kind"
+)
+
+type OTLPTrace struct {
+	ResourceSpans []OTLPResourceSpans `json:"resourceSpans"`
+}
+
+type OTLPResourceSpans struct {
+	Resource   OTLPResource     `json:"resource"`
+	ScopeSpans []OTLPScopeSpans `json:"scopeSpans"`
+}
+
+type OTLPResource struct {
+	Attributes []OTLPKeyValue `json:"attributes"`
+}
+
+type OTLPScopeSpans struct {
+	Scope OTLPScope  `json:"scope"`
+	Spans []OTLPSpan `json:"spans"`
+}
+
+type OTLPScope struct {
+	Name string `json:"name"`
+}
+
+type OTLPSpan struct {
+	TraceID           string         `json:"traceId"`
+	SpanID            string         `json:"spanId"`
+	ParentSpanID      string         `json:"parentSpanId,omitempty"`
+	Name              string         `json:"name"`
+	Kind              int            `json:"kind"`
+	StartTimeUnixNano string         `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string         `json:"endTimeUnixNano"`
+	Attributes        []OTLPKeyValue `json:"attributes"`
+	Status            OTLPStatus     `json:"status"`
+}
+
+type OTLPKeyValue struct {
+	Key   string       `json:"key"`
+	Value OTLPAnyValue `json:"value"`
+}
+
+type OTLPAnyValue struct {
+	StringValue string `json:"stringValue"`
+}
+
+type OTLPStatus struct {
+	Code    int    `json:"code"`
+	Message string `json:"message,omitempty"`
+}
+
+// ExportOTLP converts the spans of a problem into an OTLP/JSON trace.
+// The trace ID is derived from the problem ID, so all runs of the problem share one trace.
+func ExportOTLP(spans []traceEntity.Span) OTLPTrace {
+	otlpSpans := []OTLPSpan{}
+	for _, span := range spans {
+		otlpSpan := OTLPSpan{
+			TraceID:           hexID(span.GetProblemID().Value()),
+			SpanID:            spanID(span.GetID().Value()),
+			Name:              span.GetName(),
+			Kind:              otlpSpanKindInternal,
+			StartTimeUnixNano: strconv.FormatInt(span.GetStartedAt().UnixNano(), 10),
+			EndTimeUnixNano:   strconv.FormatInt(span.GetEndedAt().UnixNano(), 10),
+			Attributes:        otlpAttributes(span),
+			Status:            OTLPStatus{Code: otlpStatusCodeOK},
+		}
+		if parentID := span.GetParentID(); parentID != nil {
+			otlpSpan.ParentSpanID = spanID(parentID.Value())
+		}
+		if span.GetStatus().Equals(traceValue.SpanStatusError) {
+			otlpSpan.Status = OTLPStatus{Code: otlpStatusCodeError, Message: span.GetError()}
+		}
+		otlpSpans = append(otlpSpans, otlpSpan)
+	}
+	return OTLPTrace{
+		ResourceSpans: []OTLPResourceSpans{
+			{
+				Resource:   OTLPResource{Attributes: []OTLPKeyValue{stringKeyValue("service.name", otlpServiceName)}},
+				ScopeSpans: []OTLPScopeSpans{{Scope: OTLPScope{Name: otlpScopeName}, Spans: otlpSpans}},
+			},
+		},
+	}
+}
+
+func otlpAttributes(span traceEntity.Span) []OTLPKeyValue {
+	attributes := span.GetAttributes()
+	keys := make([]string, 0, len(attributes))
+	for key := range attributes {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	keyValues := []OTLPKeyValue{stringKeyValue(SpanKindAttributeKey, span.GetKind().Value())}
+	for _, key := range keys {
+		keyValues = append(keyValues, stringKeyValue(key, attributes[key]))
+	}
+	return keyValues
+}
+
+func stringKeyValue(key string, value string) OTLPKeyValue {
+	return OTLPKeyValue{Key: key, Value: OTLPAnyValue{StringValue: value}}
+}
+
+// hexID は UUID を OTLP の16バイトの ID に変換する
+func hexID(id string) string {
+	return strings.ReplaceAll(id, "-", "")
+}
+
+// spanID は UUID の下位8バイトを OTLP の span ID にする
+func spanID(id string) string {
+	hex := hexID(id)
+	if len(hex) < 16 {
+		return strings.Repeat("0", 16-len(hex)) + hex
+	}
+	return hex[len(hex)-16:]
+}
@@ -0,0 +1,91 @@
+package service
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	sharedValue "github.com/goda6565/ai-consultant/backend/internal/domain/shared/value"
+	traceEntity "github.com/goda6565/ai-consultant/backend/internal/domain/trace/entity"
+	traceValue "github.com/goda6565/ai-consultant/backend/internal/domain/trace/value"
+)
+
+var (
+	testProblemID = sharedValue.ID("11111111-2222-3333-4444-555555555555")
+	testRunID     = sharedValue.ID("aaaaaaaa-0000-0000-0000-000000000001")
+	testStepID    = sharedValue.ID("aaaaaaaa-0000-0000-0000-000000000002")
+	testActionID  = sharedValue.ID("aaaaaaaa-0000-0000-0000-000000000003")
+	testStartedAt = time.Date(2025, 10, 19, 9, 0, 0, 0, time.UTC)
+)
+
+func newTestSpan(id sharedValue.ID, parentID *sharedValue.ID, name string, kind traceValue.SpanKind, offset time.Duration) traceEntity.Span {
+	startedAt := testStartedAt.Add(offset)
+	return *traceEntity.NewSpan(id, testProblemID, parentID, name, kind, traceValue.SpanStatusOK, "", map[string]string{"b": "2", "a": "1"}, startedAt, startedAt.Add(time.Second))
+}
+
+func TestBuildSpanTree(t *testing.T) {
+	orphanParentID := sharedValue.ID("aaaaaaaa-0000-0000-0000-000000000009")
+	spans := []traceEntity.Span{
+		// saved in the order of the end time
+		newTestSpan(testActionID, &testStepID, "plan", traceValue.SpanKindAction, 2*time.Second),
+		newTestSpan(testStepID, &testRunID, "step 0", traceValue.SpanKindStep, time.Second),
+		newTestSpan(testRunID, nil, "proposal", traceValue.SpanKindRun, 0),
+		newTestSpan(sharedValue.ID("aaaaaaaa-0000-0000-0000-000000000004"), &orphanParentID, "write", traceValue.SpanKindAction, 3*time.Second),
+	}
+
+	roots := BuildSpanTree(spans)
+	if len(roots) != 2 {
+		t.Fatalf("roots = %d, expected 2", len(roots))
+	}
+	if roots[0].Span.GetName() != "proposal" || roots[1].Span.GetName() != "write" {
+		t.Errorf("roots = %s, %s, expected proposal, write", roots[0].Span.GetName(), roots[1].Span.GetName())
+	}
+	if len(roots[0].Children) != 1 || roots[0].Children[0].Span.GetName() != "step 0" {
+		t.Fatalf("unexpected children of the run span")
+	}
+	if len(roots[0].Children[0].Children) != 1 || roots[0].Children[0].Children[0].Span.GetName() != "plan" {
+		t.Errorf("unexpected children of the step span")
+	}
+}
+
+func TestExportOTLP(t *testing.T) {
+	failed := *traceEntity.NewSpan(testActionID, testProblemID, &testRunID, "plan", traceValue.SpanKindAction, traceValue.SpanStatusError, "boom", nil, testStartedAt, testStartedAt.Add(time.Millisecond))
+	trace := ExportOTLP([]traceEntity.Span{newTestSpan(testRunID, nil, "proposal", traceValue.SpanKindRun, 0), failed})
+
+	spans := trace.ResourceSpans[0].ScopeSpans[0].Spans
+	if len(spans) != 2 {
+		t.Fatalf("spans = %d, expected 2", len(spans))
+	}
+	run, action := spans[0], spans[1]
+	if run.TraceID != "11111111222233334444555555555555" || action.TraceID != run.TraceID {
+		t.Errorf("traceId = %s, expected the problem id in hex", run.TraceID)
+	}
+	if run.SpanID != "0000000000000001" || run.ParentSpanID != "" {
+		t.Errorf("spanId = %s, parentSpanId = %s", run.SpanID, run.ParentSpanID)
+	}
+	if action.ParentSpanID != run.SpanID {
+		t.Errorf("parentSpanId = %s, expected %s", action.ParentSpanID, run.SpanID)
+	}
+	if run.StartTimeUnixNano != "1760864400000000000" || run.EndTimeUnixNano != "1760864401000000000" {
+		t.Errorf("start = %s, end = %s", run.StartTimeUnixNano, run.EndTimeUnixNano)
+	}
+	keys := []string{}
+	for _, attribute := range run.Attributes {
+		keys = append(keys, attribute.Key)
+	}
+	if strings.Join(keys, ",") != SpanKindAttributeKey+",a,b" {
+		t.Errorf("attribute keys = %v", keys)
+	}
+	if run.Status.Code != otlpStatusCodeOK || action.Status.Code != otlpStatusCodeError || action.Status.Message != "boom" {
+		t.Errorf("status = %v, %v", run.Status, action.Status)
+	}
+
+	data, err := json.Marshal(trace)
+	if err != nil {
+		t.Fatalf("failed to marshal: %v", err)
+	}
+	if !strings.Contains(string(data), `"resourceSpans":[{"resource":{"attributes":[{"key":"service.name","value":{"stringValue":"ai-consultant"}}]}`) {
+		t.Errorf("unexpected json: %s", data)
+	}
+}
@@ -0,0 +1,120 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"sync"
+	"time"
+
+	sharedValue "github.com/goda6565/ai-consultant/backend/internal/domain/shared/value"
+	traceEntity "github.com/goda6565/ai-consultant/backend/internal/domain/trace/entity"
+	traceRepository "github.com/goda6565/ai-consultant/backend/internal/domain/trace/repository"
+	traceValue "github.com/goda6565/ai-consultant/backend/internal/domain/trace/value"
+	"github.com/goda6565/ai-consultant/backend/internal/pkg/logger"
+	"github.com/goda6565/ai-consultant/backend/internal/pkg/uuid"
+)
+
+// Tracer records the spans of a proposal run.
+// The run span is carried by the context so that any code called during the run can add child spans with Start.
+type Tracer struct {
+	spanRepository traceRepository.SpanRepository
+}
+
+func NewTracer(spanRepository traceRepository.SpanRepository) *Tracer {
+	return &Tracer{spanRepository: spanRepository}
+}
+
+type spanContextKeyType struct{}
+
+var spanContextKey = spanContextKeyType{}
+
+type spanContext struct {
+	tracer    *Tracer
+	problemID sharedValue.ID
+	spanID    sharedValue.ID
+}
+
+// StartRun starts the root span of a run of the problem.
+func (t *Tracer) StartRun(ctx context.Context, problemID sharedValue.ID, name string) (context.Context, *ActiveSpan) {
+	return t.start(ctx, problemID, nil, name, traceValue.SpanKindRun)
+}
+
+// Start starts a child of the span carried by the context.
+// It does nothing and returns a nil span, which is safe to use, when the context is not traced.
+func Start(ctx context.Context, name string, kind traceValue.SpanKind) (context.Context, *ActiveSpan) {
+	parent, ok := ctx.Value(spanContextKey).(spanContext)
+	if !ok {
+		return ctx, nil
+	}
+	return parent.tracer.start(ctx, parent.problemID, &parent.spanID, name, kind)
+}
+
+func (t *Tracer) start(ctx context.Context, problemID sharedValue.ID, parentID *sharedValue.ID, name string, kind traceValue.SpanKind) (context.Context, *ActiveSpan) {
+	id, err := sharedValue.NewID(uuid.NewUUID())
+	if err != nil {
+		return ctx, nil
+	}
+	span := &ActiveSpan{
+		tracer: t,
+		// the span is saved even after the run is cancelled
+		ctx:        context.WithoutCancel(ctx),
+		id:         id,
+		problemID:  problemID,
+		parentID:   parentID,
+		name:       name,
+		kind:       kind,
+		attributes: map[string]string{},
+		startedAt:  time.Now(),
+	}
+	return context.WithValue(ctx, spanContextKey, spanContext{tracer: t, problemID: problemID, spanID: id}), span
+}
+
+// ActiveSpan is a span in progress. All methods are safe for a nil span and for concurrent use.
+type ActiveSpan struct {
+	tracer     *Tracer
+	ctx        context.Context
+	id         sharedValue.ID
+	problemID  sharedValue.ID
+	parentID   *sharedValue.ID
+	name       string
+	kind       traceValue.SpanKind
+	startedAt  time.Time
+	mu         sync.Mutex
+	attributes map[string]string
+	ended      bool
+}
+
+func (s *ActiveSpan) SetAttribute(key string, value any) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.attributes[key] = fmt.Sprint(value)
+}
+
+// End saves the span with the error as its status. Only the first call is saved.
+// A failure to save never fails the run: a missing span is better than a failed proposal.
+func (s *ActiveSpan) End(err error) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	if s.ended {
+		s.mu.Unlock()
+		return
+	}
+	s.ended = true
+	attributes := maps.Clone(s.attributes)
+	s.mu.Unlock()
+
+	status, message := traceValue.SpanStatusOK, ""
+	if err != nil {
+		status, message = traceValue.SpanStatusError, err.Error()
+	}
+	span := traceEntity.NewSpan(s.id, s.problemID, s.parentID, s.name, s.kind, status, message, attributes, s.startedAt, time.Now())
+	if err := s.tracer.spanRepository.Create(s.ctx, span); err != nil {
+		logger.GetLogger(s.ctx).Warn("failed to save trace span", "problemID", s.problemID.Value(), "name", s.name, "error", err)
+	}
+}
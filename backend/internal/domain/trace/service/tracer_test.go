@@ -0,0 +1,65 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	sharedValue "github.com/goda6565/ai-consultant/backend/internal/domain/shared/value"
+	traceEntity "github.com/goda6565/ai-consultant/backend/internal/domain/trace/entity"
+	"github.com/goda6565/ai-consultant/backend/internal/domain/trace/repository/mock"
+	traceValue "github.com/goda6565/ai-consultant/backend/internal/domain/trace/value"
+	"go.uber.org/mock/gomock"
+)
+
+func TestTracer(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock.NewMockSpanRepository(ctrl)
+	saved := map[string]*traceEntity.Span{}
+	mockRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, span *traceEntity.Span) error {
+		saved[span.GetName()] = span
+		return nil
+	}).Times(3)
+
+	problemID := sharedValue.ID("11111111-2222-3333-4444-555555555555")
+	ctx, run := NewTracer(mockRepo).StartRun(context.Background(), problemID, "proposal")
+	stepCtx, step := Start(ctx, "step 0", traceValue.SpanKindStep)
+	_, action := Start(stepCtx, "plan", traceValue.SpanKindAction)
+	action.SetAttribute("attempt", 1)
+	action.End(errors.New("boom"))
+	action.End(nil) // saved only once
+	step.End(nil)
+	run.End(nil)
+
+	if saved["proposal"].GetParentID() != nil {
+		t.Errorf("run span must be a root, got parent %v", *saved["proposal"].GetParentID())
+	}
+	if parentID := saved["step 0"].GetParentID(); parentID == nil || *parentID != saved["proposal"].GetID() {
+		t.Errorf("step span must be a child of the run span")
+	}
+	if parentID := saved["plan"].GetParentID(); parentID == nil || *parentID != saved["step 0"].GetID() {
+		t.Errorf("action span must be a child of the step span")
+	}
+	if saved["plan"].GetStatus() != traceValue.SpanStatusError || saved["plan"].GetError() != "boom" {
+		t.Errorf("status = %s, error = %s, expected error boom", saved["plan"].GetStatus(), saved["plan"].GetError())
+	}
+	if saved["plan"].GetAttributes()["attempt"] != "1" {
+		t.Errorf("attributes = %v, expected attempt 1", saved["plan"].GetAttributes())
+	}
+	if saved["plan"].GetProblemID() != problemID {
+		t.Errorf("problemID = %s, expected %s", saved["plan"].GetProblemID(), problemID)
+	}
+}
+
+func TestStart_WithoutTrace(t *testing.T) {
+	ctx := context.Background()
+	got, span := Start(ctx, "plan", traceValue.SpanKindAction)
+	if span != nil || got != ctx {
+		t.Fatal("expected no span for an untraced context")
+	}
+	// nil span must be safe to use
+	span.SetAttribute("key", "value")
+	span.End(nil)
+}
@@ -0,0 +1,7 @@
+package service
+
+import "github.com/google/wire"
+
+var Set = wire.NewSet(
+	NewTracer,
+)
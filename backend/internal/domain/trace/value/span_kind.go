@@ -0,0 +1,45 @@
+package value
+
+import "github.com/goda6565/ai-consultant/backend/internal/domain/errors"
+
+type SpanKind string
+
+const (
+	// SpanKindRun は提案ジョブ1回の実行全体
+	SpanKindRun SpanKind = "run"
+	// SpanKindStep はエージェントループの1ステップ
+	SpanKindStep SpanKind = "step"
+	// SpanKindDecision は orchestrator, skipper, terminator などの判断
+	SpanKindDecision SpanKind = "decision"
+	SpanKindAction   SpanKind = "action"
+	// SpanKindTool は検索などアクション内部の処理
+	SpanKindTool SpanKind = "tool"
+	SpanKindLLM  SpanKind = "llm"
+)
+
+func (s SpanKind) Equals(other SpanKind) bool {
+	return s == other
+}
+
+func (s SpanKind) Value() string {
+	return string(s)
+}
+
+func NewSpanKind(value string) (SpanKind, error) {
+	switch value {
+	case "run":
+		return SpanKindRun, nil
+	case "step":
+		return SpanKindStep, nil
+	case "decision":
+		return SpanKindDecision, nil
+	case "action":
+		return SpanKindAction, nil
+	case "tool":
+		return SpanKindTool, nil
+	case "llm":
+		return SpanKindLLM, nil
+	default:
+		return "", errors.NewDomainError(errors.ValidationError, "invalid span kind")
+	}
+}
@@ -0,0 +1,29 @@
+package value
+
+import "github.com/goda6565/ai-consultant/backend/internal/domain/errors"
+
+type SpanStatus string
+
+const (
+	SpanStatusOK    SpanStatus = "ok"
+	SpanStatusError SpanStatus = "error"
+)
+
+func (s SpanStatus) Equals(other SpanStatus) bool {
+	return s == other
+}
+
+func (s SpanStatus) Value() string {
+	return string(s)
+}
+
+func NewSpanStatus(value string) (SpanStatus, error) {
+	switch value {
+	case "ok":
+		return SpanStatusOK, nil
+	case "error":
+		return SpanStatusError, nil
+	default:
+		return "", errors.NewDomainError(errors.ValidationError, "invalid span status")
+	}
+}
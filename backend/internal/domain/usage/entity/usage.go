@@ -0,0 +1,52 @@
+package entity
+
+import (
+	"time"
+
+	actionValue "github.com/goda6565/ai-consultant/backend/internal/domain/action/value"
+	"github.com/goda6565/ai-consultant/backend/internal/domain/llm"
+	sharedValue "github.com/goda6565/ai-consultant/backend/internal/domain/shared/value"
+)
+
+// Usage は1回のLLM呼び出しで消費したトークン数
+type Usage struct {
+	id         sharedValue.ID
+	problemID  sharedValue.ID
+	actionType actionValue.ActionType
+	provider   llm.Provider
+	model      string
+	usage      llm.Usage
+	createdAt  *time.Time
+}
+
+func NewUsage(id sharedValue.ID, problemID sharedValue.ID, actionType actionValue.ActionType, provider llm.Provider, model string, usage llm.Usage, createdAt *time.Time) *Usage {
+	return &Usage{id: id, problemID: problemID, actionType: actionType, provider: provider, model: model, usage: usage, createdAt: createdAt}
+}
+
+func (u *Usage) GetID() sharedValue.ID {
+	return u.id
+}
+
+func (u *Usage) GetProblemID() sharedValue.ID {
+	return u.problemID
+}
+
+func (u *Usage) GetActionType() actionValue.ActionType {
+	return u.actionType
+}
+
+func (u *Usage) GetProvider() llm.Provider {
+	return u.provider
+}
+
+func (u *Usage) GetModel() string {
+	return u.model
+}
+
+func (u *Usage) GetUsage() llm.Usage {
+	return u.usage
+}
+
+func (u *Usage) GetCreatedAt() *time.Time {
+	return u.createdAt
+}
@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	sharedValue "github.com/goda6565/ai-consultant/backend/internal/domain/shared/value"
+	usageEntity "github.com/goda6565/ai-consultant/backend/internal/domain/usage/entity"
+)
+
+//go:generate go tool mockgen -source=$GOFILE -destination=mock/$GOFILE -package=mock
+type UsageRepository interface {
+	FindByProblemID(ctx context.Context, problemID sharedValue.ID) ([]usageEntity.Usage, error)
+	FindByProblemIDSince(ctx context.Context, problemID sharedValue.ID, since time.Time) ([]usageEntity.Usage, error)
+	Create(ctx context.Context, usage *usageEntity.Usage) error
+}
@@ -0,0 +1,103 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	actionValue "github.com/goda6565/ai-consultant/backend/internal/domain/action/value"
+	"github.com/goda6565/ai-consultant/backend/internal/domain/llm"
+	sharedValue "github.com/goda6565/ai-consultant/backend/internal/domain/shared/value"
+	"github.com/goda6565/ai-consultant/backend/internal/domain/usage/entity"
+	"github.com/goda6565/ai-consultant/backend/internal/domain/usage/repository"
+	"github.com/goda6565/ai-consultant/backend/internal/domain/usage/value"
+)
+
+type LedgerService struct {
+	usageRepository repository.UsageRepository
+	priceTable      *value.PriceTable
+}
+
+func NewLedgerService(usageRepository repository.UsageRepository, priceTable *value.PriceTable) *LedgerService {
+	return &LedgerService{
+		usageRepository: usageRepository,
+		priceTable:      priceTable,
+	}
+}
+
+type UsageSummary struct {
+	Calls int
+	Usage llm.Usage
+	Cost  float64
+}
+
+type ActionUsageSummary struct {
+	ActionType actionValue.ActionType
+	UsageSummary
+}
+
+type LedgerSummary struct {
+	Total   UsageSummary
+	Actions []ActionUsageSummary
+}
+
+// TotalExcept returns the totals excluding the given action types.
+func (s *LedgerSummary) TotalExcept(actionTypes ...actionValue.ActionType) UsageSummary {
+	total := s.Total
+	for _, action := range s.Actions {
+		if !slices.Contains(actionTypes, action.ActionType) {
+			continue
+		}
+		total.Calls -= action.Calls
+		total.Usage.InputTokens -= action.Usage.InputTokens
+		total.Usage.OutputTokens -= action.Usage.OutputTokens
+		total.Usage.TotalTokens -= action.Usage.TotalTokens
+		total.Cost -= action.Cost
+	}
+	return total
+}
+
+// Summarize returns the totals and a per-action breakdown of the usages recorded for the problem.
+func (s *LedgerService) Summarize(ctx context.Context, problemID sharedValue.ID) (*LedgerSummary, error) {
+	usages, err := s.usageRepository.FindByProblemID(ctx, problemID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find usages: %w", err)
+	}
+	return s.summarize(usages), nil
+}
+
+// SummarizeSince is Summarize limited to the usages recorded since the given time, e.g. the start of a run.
+func (s *LedgerService) SummarizeSince(ctx context.Context, problemID sharedValue.ID, since time.Time) (*LedgerSummary, error) {
+	usages, err := s.usageRepository.FindByProblemIDSince(ctx, problemID, since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find usages: %w", err)
+	}
+	return s.summarize(usages), nil
+}
+
+func (s *LedgerService) summarize(usages []entity.Usage) *LedgerSummary {
+	summary := &LedgerSummary{Actions: []ActionUsageSummary{}}
+	// アクションは最初に記録された順に並べる
+	indexes := make(map[actionValue.ActionType]int)
+	for _, usage := range usages {
+		index, ok := indexes[usage.GetActionType()]
+		if !ok {
+			index = len(summary.Actions)
+			indexes[usage.GetActionType()] = index
+			summary.Actions = append(summary.Actions, ActionUsageSummary{ActionType: usage.GetActionType()})
+		}
+		cost := s.priceTable.Cost(usage.GetModel(), usage.GetUsage())
+		summary.Total.add(usage.GetUsage(), cost)
+		summary.Actions[index].add(usage.GetUsage(), cost)
+	}
+	return summary
+}
+
+func (s *UsageSummary) add(usage llm.Usage, cost float64) {
+	s.Calls++
+	s.Usage.InputTokens += usage.InputTokens
+	s.Usage.OutputTokens += usage.OutputTokens
+	s.Usage.TotalTokens += usage.TotalTokens
+	s.Cost += cost
+}
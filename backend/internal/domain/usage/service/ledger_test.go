@@ -0,0 +1,72 @@
+package service
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	actionValue "github.com/goda6565/ai-consultant/backend/internal/domain/action/value"
+	"github.com/goda6565/ai-consultant/backend/internal/domain/llm"
+	sharedValue "github.com/goda6565/ai-consultant/backend/internal/domain/shared/value"
+	"github.com/goda6565/ai-consultant/backend/internal/domain/usage/entity"
+	"github.com/goda6565/ai-consultant/backend/internal/domain/usage/repository/mock"
+	"github.com/goda6565/ai-consultant/backend/internal/domain/usage/value"
+	"go.uber.org/mock/gomock"
+)
+
+func TestLedgerService_Summarize(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock.NewMockUsageRepository(ctrl)
+	priceTable, err := value.NewPriceTable(map[string]value.Price{
+		"gpt-4o":               {InputPerMillionTokens: 2, OutputPerMillionTokens: 10},
+		"gemini-embedding-001": {InputPerMillionTokens: 1},
+	})
+	if err != nil {
+		t.Fatalf("failed to create price table: %v", err)
+	}
+	problemID := sharedValue.ID("problem-id")
+
+	newUsage := func(actionType actionValue.ActionType, model string, usage llm.Usage) entity.Usage {
+		return *entity.NewUsage(sharedValue.ID("id"), problemID, actionType, llm.OpenAI, model, usage, nil)
+	}
+	mockRepo.EXPECT().FindByProblemID(gomock.Any(), problemID).Return([]entity.Usage{
+		newUsage(actionValue.ActionTypePlan, "gpt-4o", llm.Usage{InputTokens: 1000, OutputTokens: 100, TotalTokens: 1100}),
+		newUsage(actionValue.ActionTypeInternalSearch, "gemini-embedding-001", llm.Usage{TotalTokens: 500}),
+		newUsage(actionValue.ActionTypePlan, "gpt-4o", llm.Usage{InputTokens: 2000, OutputTokens: 200, TotalTokens: 2200}),
+		newUsage(actionValue.ActionTypeWrite, "unknown-model", llm.Usage{InputTokens: 10, OutputTokens: 10, TotalTokens: 20}),
+	}, nil).Times(1)
+
+	summary, err := NewLedgerService(mockRepo, priceTable).Summarize(context.Background(), problemID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []struct {
+		actionType  actionValue.ActionType
+		calls       int
+		totalTokens int
+		cost        float64
+	}{
+		{actionType: actionValue.ActionTypePlan, calls: 2, totalTokens: 3300, cost: 0.009},
+		{actionType: actionValue.ActionTypeInternalSearch, calls: 1, totalTokens: 500, cost: 0.0005},
+		{actionType: actionValue.ActionTypeWrite, calls: 1, totalTokens: 20, cost: 0},
+	}
+	if len(summary.Actions) != len(expected) {
+		t.Fatalf("actions = %d, expected %d", len(summary.Actions), len(expected))
+	}
+	for i, e := range expected {
+		action := summary.Actions[i]
+		if action.ActionType != e.actionType || action.Calls != e.calls || action.Usage.TotalTokens != e.totalTokens || math.Abs(action.Cost-e.cost) > 1e-9 {
+			t.Errorf("actions[%d] = %+v, expected %+v", i, action, e)
+		}
+	}
+	if summary.Total.Calls != 4 || summary.Total.Usage.TotalTokens != 3820 || math.Abs(summary.Total.Cost-0.0095) > 1e-9 {
+		t.Errorf("total = %+v", summary.Total)
+	}
+
+	if except := summary.TotalExcept(actionValue.ActionTypePlan); except.Calls != 2 || except.Usage.TotalTokens != 520 || math.Abs(except.Cost-0.0005) > 1e-9 {
+		t.Errorf("total except plan = %+v", except)
+	}
+}
@@ -0,0 +1,60 @@
+package value
+
+import (
+	"fmt"
+
+	"github.com/goda6565/ai-consultant/backend/internal/domain/errors"
+	"github.com/goda6565/ai-consultant/backend/internal/domain/llm"
+)
+
+const Currency = "USD"
+
+// Price は100万トークンあたりの料金 (USD)
+type Price struct {
+	InputPerMillionTokens  float64
+	OutputPerMillionTokens float64
+}
+
+// DefaultPrices は公開されている標準料金
+var DefaultPrices = map[string]Price{
+	string(llm.GPT4o):                          {InputPerMillionTokens: 2.5, OutputPerMillionTokens: 10},
+	string(llm.GPT5):                           {InputPerMillionTokens: 1.25, OutputPerMillionTokens: 10},
+	string(llm.Gemini25Flash):                  {InputPerMillionTokens: 0.3, OutputPerMillionTokens: 2.5},
+	string(llm.EmbeddingModelOpenAIEmbeddings): {InputPerMillionTokens: 0.02},
+	string(llm.GeminiEmbedding001):             {InputPerMillionTokens: 0.15},
+}
+
+type PriceTable struct {
+	prices map[string]Price
+}
+
+// NewPriceTable creates a price table keyed by model name.
+func NewPriceTable(prices map[string]Price) (*PriceTable, error) {
+	table := make(map[string]Price, len(prices))
+	for model, price := range prices {
+		if price.InputPerMillionTokens < 0 || price.OutputPerMillionTokens < 0 {
+			return nil, errors.NewDomainError(errors.ValidationError, fmt.Sprintf("price for model %s must not be negative", model))
+		}
+		table[model] = price
+	}
+	return &PriceTable{prices: table}, nil
+}
+
+func (t *PriceTable) Get(model string) (Price, bool) {
+	price, ok := t.prices[model]
+	return price, ok
+}
+
+// Cost returns the cost of the usage. Models without a price cost nothing.
+func (t *PriceTable) Cost(model string, usage llm.Usage) float64 {
+	price, ok := t.prices[model]
+	if !ok {
+		return 0
+	}
+	inputTokens := usage.InputTokens
+	// 埋め込みは入力・出力の内訳がないため合計を入力として扱う
+	if inputTokens == 0 && usage.OutputTokens == 0 {
+		inputTokens = usage.TotalTokens
+	}
+	return (float64(inputTokens)*price.InputPerMillionTokens + float64(usage.OutputTokens)*price.OutputPerMillionTokens) / 1_000_000
+}
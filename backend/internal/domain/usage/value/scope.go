@@ -0,0 +1,29 @@
+package value
+
+import (
+	"context"
+
+	actionValue "github.com/goda6565/ai-consultant/backend/internal/domain/action/value"
+	sharedValue "github.com/goda6565/ai-consultant/backend/internal/domain/shared/value"
+)
+
+// Scope はLLM呼び出しの使用量をどの課題・アクションに計上するかを表す
+type Scope struct {
+	ProblemID  sharedValue.ID
+	ActionType actionValue.ActionType
+}
+
+type scopeKeyType struct{}
+
+var scopeKey = scopeKeyType{}
+
+// WithScope attributes LLM calls made with the returned context to the problem and action type.
+func WithScope(ctx context.Context, problemID sharedValue.ID, actionType actionValue.ActionType) context.Context {
+	return context.WithValue(ctx, scopeKey, Scope{ProblemID: problemID, ActionType: actionType})
+}
+
+// ScopeFromContext returns the scope set by WithScope. ok is false when the call is not attributed.
+func ScopeFromContext(ctx context.Context) (scope Scope, ok bool) {
+	scope, ok = ctx.Value(scopeKey).(Scope)
+	return scope, ok
+}
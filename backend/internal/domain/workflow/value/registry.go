@@ -0,0 +1,185 @@
+package value
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"path"
+	"slices"
+	"sync"
+
+	actionValue "github.com/goda6565/ai-consultant/backend/internal/domain/action/value"
+	"github.com/goda6565/ai-consultant/backend/internal/domain/errors"
+)
+
+// Name はワークフローの名前。ジョブ設定ごとに選択する
+type Name string
+
+// DefaultName は計画・収集・分析・執筆・レビューを繰り返す標準のワークフロー
+const DefaultName Name = "standard"
+
+// NewName validates that the workflow is defined. The default workflow is used when the value is empty.
+func NewName(value string) (Name, error) {
+	if value == "" {
+		return DefaultName, nil
+	}
+	workflows, err := loadWorkflows()
+	if err != nil {
+		return "", err
+	}
+	if _, ok := workflows[Name(value)]; !ok {
+		return "", errors.NewDomainError(errors.ValidationError, fmt.Sprintf("unknown workflow %s", value))
+	}
+	return Name(value), nil
+}
+
+func (n Name) Value() string {
+	return string(n)
+}
+
+//go:embed definitions/*.json
+var definitions embed.FS
+
+type workflowDefinition struct {
+	Name        string               `json:"name"`
+	Description string               `json:"description"`
+	Start       string               `json:"start"`
+	Terminal    string               `json:"terminal"`
+	Nodes       []nodeDefinition     `json:"nodes"`
+	Edges       []edgeDefinition     `json:"edges"`
+	Parallel    []parallelDefinition `json:"parallel"`
+	Notes       []string             `json:"notes"`
+}
+
+type nodeDefinition struct {
+	Action   string `json:"action"`
+	Role     string `json:"role"`
+	Strength string `json:"strength"`
+	Limit    string `json:"limit"`
+}
+
+type edgeDefinition struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+	When string `json:"when"`
+}
+
+type parallelDefinition struct {
+	Actions []string `json:"actions"`
+	When    string   `json:"when"`
+}
+
+// loadWorkflows parses and validates the embedded definitions once.
+var loadWorkflows = sync.OnceValues(func() (map[Name]*Workflow, error) {
+	entries, err := definitions.ReadDir("definitions")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read workflow definitions: %w", err)
+	}
+	workflows := map[Name]*Workflow{}
+	for _, entry := range entries {
+		data, err := definitions.ReadFile(path.Join("definitions", entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read workflow definition %s: %w", entry.Name(), err)
+		}
+		workflow, err := ParseWorkflow(data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse workflow definition %s: %w", entry.Name(), err)
+		}
+		if _, ok := workflows[workflow.GetName()]; ok {
+			return nil, errors.NewDomainError(errors.ValidationError, fmt.Sprintf("duplicate workflow %s", workflow.GetName()))
+		}
+		workflows[workflow.GetName()] = workflow
+	}
+	if _, ok := workflows[DefaultName]; !ok {
+		return nil, errors.NewDomainError(errors.ValidationError, fmt.Sprintf("default workflow %s is not defined", DefaultName))
+	}
+	return workflows, nil
+})
+
+// ParseWorkflow builds a workflow from its JSON definition.
+func ParseWorkflow(data []byte) (*Workflow, error) {
+	var definition workflowDefinition
+	if err := json.Unmarshal(data, &definition); err != nil {
+		return nil, errors.NewDomainError(errors.ValidationError, fmt.Sprintf("invalid workflow json: %v", err))
+	}
+	start, err := actionValue.NewActionType(definition.Start)
+	if err != nil {
+		return nil, fmt.Errorf("invalid start: %w", err)
+	}
+	terminal, err := actionValue.NewActionType(definition.Terminal)
+	if err != nil {
+		return nil, fmt.Errorf("invalid terminal: %w", err)
+	}
+	nodes := make([]Node, len(definition.Nodes))
+	for i, node := range definition.Nodes {
+		actionType, err := actionValue.NewActionType(node.Action)
+		if err != nil {
+			return nil, fmt.Errorf("invalid node %s: %w", node.Action, err)
+		}
+		nodes[i] = Node{Action: actionType, Role: node.Role, Strength: node.Strength, Limit: node.Limit}
+	}
+	edges := make([]Edge, len(definition.Edges))
+	for i, edge := range definition.Edges {
+		from, err := actionValue.NewActionType(edge.From)
+		if err != nil {
+			return nil, fmt.Errorf("invalid edge from %s: %w", edge.From, err)
+		}
+		to, err := actionValue.NewActionType(edge.To)
+		if err != nil {
+			return nil, fmt.Errorf("invalid edge to %s: %w", edge.To, err)
+		}
+		condition, err := NewCondition(edge.When)
+		if err != nil {
+			return nil, err
+		}
+		edges[i] = Edge{From: from, To: to, Condition: condition}
+	}
+	parallels := make([]Parallel, len(definition.Parallel))
+	for i, parallel := range definition.Parallel {
+		actions := make([]actionValue.ActionType, len(parallel.Actions))
+		for j, action := range parallel.Actions {
+			actionType, err := actionValue.NewActionType(action)
+			if err != nil {
+				return nil, fmt.Errorf("invalid parallel action %s: %w", action, err)
+			}
+			actions[j] = actionType
+		}
+		condition, err := NewCondition(parallel.When)
+		if err != nil {
+			return nil, err
+		}
+		parallels[i] = Parallel{Actions: actions, Condition: condition}
+	}
+	return NewWorkflow(Name(definition.Name), definition.Description, start, terminal, nodes, edges, parallels, definition.Notes)
+}
+
+// GetWorkflow returns the workflow of the name.
+func GetWorkflow(name Name) (*Workflow, error) {
+	workflows, err := loadWorkflows()
+	if err != nil {
+		return nil, err
+	}
+	workflow, ok := workflows[name]
+	if !ok {
+		return nil, errors.NewDomainError(errors.ValidationError, fmt.Sprintf("unknown workflow %s", name))
+	}
+	return workflow, nil
+}
+
+// ListWorkflows returns the defined workflows sorted by name.
+func ListWorkflows() ([]*Workflow, error) {
+	workflows, err := loadWorkflows()
+	if err != nil {
+		return nil, err
+	}
+	names := make([]Name, 0, len(workflows))
+	for name := range workflows {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	list := make([]*Workflow, len(names))
+	for i, name := range names {
+		list[i] = workflows[name]
+	}
+	return list, nil
+}
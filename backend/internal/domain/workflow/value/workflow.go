@@ -0,0 +1,313 @@
+package value
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	actionValue "github.com/goda6565/ai-consultant/backend/internal/domain/action/value"
+	"github.com/goda6565/ai-consultant/backend/internal/domain/errors"
+)
+
+// Condition はエッジを通るための条件。空の場合は常に通れる
+type Condition string
+
+const (
+	ConditionAlways                 Condition = ""
+	ConditionInternalSearchEnabled  Condition = "internalSearchEnabled"
+	ConditionInternalSearchDisabled Condition = "internalSearchDisabled"
+)
+
+func NewCondition(value string) (Condition, error) {
+	switch value {
+	case string(ConditionAlways):
+		return ConditionAlways, nil
+	case string(ConditionInternalSearchEnabled):
+		return ConditionInternalSearchEnabled, nil
+	case string(ConditionInternalSearchDisabled):
+		return ConditionInternalSearchDisabled, nil
+	default:
+		return "", errors.NewDomainError(errors.ValidationError, fmt.Sprintf("invalid workflow condition %s", value))
+	}
+}
+
+// Flags はエッジの条件を評価するためのジョブ設定
+type Flags struct {
+	EnableInternalSearch bool
+}
+
+func (f Flags) Satisfies(condition Condition) bool {
+	switch condition {
+	case ConditionInternalSearchEnabled:
+		return f.EnableInternalSearch
+	case ConditionInternalSearchDisabled:
+		return !f.EnableInternalSearch
+	default:
+		return true
+	}
+}
+
+// allFlags returns every combination of the flags to validate the workflow for all job configs.
+func allFlags() []Flags {
+	return []Flags{{EnableInternalSearch: false}, {EnableInternalSearch: true}}
+}
+
+// Node はワークフローで実行できるアクションとプロンプトに載せる説明
+type Node struct {
+	Action   actionValue.ActionType
+	Role     string
+	Strength string
+	Limit    string
+}
+
+// Edge は From の完了後に進むアクション。同じ From のエッジは定義順に評価し、最初に条件を満たしたものを使う
+type Edge struct {
+	From      actionValue.ActionType
+	To        actionValue.ActionType
+	Condition Condition
+}
+
+// Parallel は互いに独立しているため同時に実行できるアクションの組。
+// Actions はエッジでつながる順に並べ、先頭のアクションに進んだときに組全体を実行する
+type Parallel struct {
+	Actions   []actionValue.ActionType
+	Condition Condition
+}
+
+// Workflow は提案ジョブのアクションの遷移を表すグラフ
+type Workflow struct {
+	name        Name
+	description string
+	start       actionValue.ActionType
+	// このアクションの完了後に終了するかを判断する
+	terminal  actionValue.ActionType
+	nodes     []Node
+	edges     []Edge
+	parallels []Parallel
+	notes     []string
+}
+
+func NewWorkflow(name Name, description string, start actionValue.ActionType, terminal actionValue.ActionType, nodes []Node, edges []Edge, parallels []Parallel, notes []string) (*Workflow, error) {
+	workflow := &Workflow{name: name, description: description, start: start, terminal: terminal, nodes: nodes, edges: edges, parallels: parallels, notes: notes}
+	if err := workflow.validate(); err != nil {
+		return nil, fmt.Errorf("invalid workflow %s: %w", name, err)
+	}
+	return workflow, nil
+}
+
+func (w *Workflow) GetName() Name {
+	return w.name
+}
+
+func (w *Workflow) GetDescription() string {
+	return w.description
+}
+
+func (w *Workflow) GetStart() actionValue.ActionType {
+	return w.start
+}
+
+func (w *Workflow) IsTerminal(actionType actionValue.ActionType) bool {
+	return w.terminal.Equals(actionType)
+}
+
+// Next returns the action after the current one. The current action is kept when it has no edge.
+func (w *Workflow) Next(current actionValue.ActionType, flags Flags) actionValue.ActionType {
+	for _, edge := range w.edges {
+		if edge.From.Equals(current) && flags.Satisfies(edge.Condition) {
+			return edge.To
+		}
+	}
+	return current
+}
+
+// Branches returns the actions to run concurrently when the current action starts a parallel group, or nil.
+// The actions are in the order of the edges, which is also the order to merge their outputs.
+func (w *Workflow) Branches(current actionValue.ActionType, flags Flags) []actionValue.ActionType {
+	for _, parallel := range w.parallels {
+		if parallel.Actions[0].Equals(current) && flags.Satisfies(parallel.Condition) {
+			return slices.Clone(parallel.Actions)
+		}
+	}
+	return nil
+}
+
+// Render returns the route and the roles of the actions reachable with the flags for the prompts.
+func (w *Workflow) Render(flags Flags) string {
+	var b strings.Builder
+	b.WriteString("【アクションルート】\n")
+	route := []string{w.start.Value()}
+	visited := map[actionValue.ActionType]bool{w.start: true}
+	for current := w.start; ; {
+		next := w.Next(current, flags)
+		if next.Equals(current) {
+			break
+		}
+		if visited[next] {
+			route = append(route, fmt.Sprintf("%s（内容が不十分な場合は再ループ）", next.Value()))
+			break
+		}
+		route = append(route, next.Value())
+		visited[next] = true
+		current = next
+	}
+	b.WriteString(strings.Join(route, " ──▶ "))
+	b.WriteString("\n")
+	for _, parallel := range w.parallels {
+		if !flags.Satisfies(parallel.Condition) {
+			continue
+		}
+		names := make([]string, len(parallel.Actions))
+		for i, actionType := range parallel.Actions {
+			names[i] = actionType.Value()
+		}
+		b.WriteString(fmt.Sprintf("（%s は同時に実行される）\n", strings.Join(names, " と ")))
+	}
+	b.WriteString("\n")
+	b.WriteString(fmt.Sprintf("%sで十分な品質に達した場合のみ「完了」と判断する。\n", w.terminal.Value()))
+
+	b.WriteString("\n【各アクションの役割と限界】\n")
+	reachable := w.reachable(flags)
+	for _, node := range w.nodes {
+		if !reachable[node.Action] {
+			continue
+		}
+		b.WriteString(fmt.Sprintf("- %s: %s\n", node.Action.Value(), node.Role))
+		b.WriteString(fmt.Sprintf("    得意: %s\n", node.Strength))
+		b.WriteString(fmt.Sprintf("    限界: %s\n\n", node.Limit))
+	}
+
+	if len(w.notes) > 0 {
+		b.WriteString("【注意】\n")
+		for _, note := range w.notes {
+			b.WriteString(note)
+			b.WriteString("\n")
+		}
+	}
+	return b.String()
+}
+
+func (w *Workflow) reachable(flags Flags) map[actionValue.ActionType]bool {
+	reachable := map[actionValue.ActionType]bool{w.start: true}
+	queue := []actionValue.ActionType{w.start}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		next := w.Next(current, flags)
+		if !reachable[next] {
+			reachable[next] = true
+			queue = append(queue, next)
+		}
+	}
+	return reachable
+}
+
+func (w *Workflow) validate() error {
+	if w.name == "" {
+		return errors.NewDomainError(errors.ValidationError, "workflow name is required")
+	}
+	nodes := map[actionValue.ActionType]bool{}
+	for _, node := range w.nodes {
+		if !isWorkflowAction(node.Action) {
+			return errors.NewDomainError(errors.ValidationError, fmt.Sprintf("action %s cannot be a workflow node", node.Action.Value()))
+		}
+		if nodes[node.Action] {
+			return errors.NewDomainError(errors.ValidationError, fmt.Sprintf("duplicate node %s", node.Action.Value()))
+		}
+		nodes[node.Action] = true
+	}
+	if !nodes[w.start] {
+		return errors.NewDomainError(errors.ValidationError, fmt.Sprintf("start %s is not a node", w.start.Value()))
+	}
+	if !nodes[w.terminal] {
+		return errors.NewDomainError(errors.ValidationError, fmt.Sprintf("terminal %s is not a node", w.terminal.Value()))
+	}
+	for _, edge := range w.edges {
+		if !nodes[edge.From] || !nodes[edge.To] {
+			return errors.NewDomainError(errors.ValidationError, fmt.Sprintf("edge %s -> %s refers to an unknown node", edge.From.Value(), edge.To.Value()))
+		}
+		if edge.From.Equals(edge.To) {
+			return errors.NewDomainError(errors.ValidationError, fmt.Sprintf("edge %s -> %s loops to itself", edge.From.Value(), edge.To.Value()))
+		}
+	}
+
+	if err := w.validateParallels(nodes); err != nil {
+		return err
+	}
+
+	used := map[actionValue.ActionType]bool{}
+	for _, flags := range allFlags() {
+		reachable := w.reachable(flags)
+		for actionType := range reachable {
+			used[actionType] = true
+			// 完了しなかった場合も先に進めるよう、すべてのアクションに次のアクションが必要
+			if w.Next(actionType, flags).Equals(actionType) {
+				return errors.NewDomainError(errors.ValidationError, fmt.Sprintf("node %s has no edge when %+v", actionType.Value(), flags))
+			}
+		}
+		if !reachable[w.terminal] {
+			return errors.NewDomainError(errors.ValidationError, fmt.Sprintf("terminal %s is not reachable when %+v", w.terminal.Value(), flags))
+		}
+		// 社内データを使うアクションは内部検索が有効な場合のみ実行できる
+		for _, actionType := range []actionValue.ActionType{actionValue.ActionTypeInternalSearch, actionValue.ActionTypeDataAnalysis} {
+			if !flags.EnableInternalSearch && reachable[actionType] {
+				return errors.NewDomainError(errors.ValidationError, fmt.Sprintf("%s must be reachable only when internal search is enabled", actionType.Value()))
+			}
+		}
+	}
+	for actionType := range nodes {
+		if !used[actionType] {
+			return errors.NewDomainError(errors.ValidationError, fmt.Sprintf("node %s is not reachable", actionType.Value()))
+		}
+	}
+	return nil
+}
+
+func (w *Workflow) validateParallels(nodes map[actionValue.ActionType]bool) error {
+	grouped := map[actionValue.ActionType]bool{}
+	for _, parallel := range w.parallels {
+		if len(parallel.Actions) < 2 {
+			return errors.NewDomainError(errors.ValidationError, "parallel requires at least two actions")
+		}
+		for _, actionType := range parallel.Actions {
+			if !nodes[actionType] {
+				return errors.NewDomainError(errors.ValidationError, fmt.Sprintf("parallel action %s is not a node", actionType.Value()))
+			}
+			if grouped[actionType] {
+				return errors.NewDomainError(errors.ValidationError, fmt.Sprintf("action %s is in more than one parallel", actionType.Value()))
+			}
+			// 終了判定は組の最後のアクションの後にしか行われない
+			if w.terminal.Equals(actionType) {
+				return errors.NewDomainError(errors.ValidationError, fmt.Sprintf("terminal %s cannot be in a parallel", actionType.Value()))
+			}
+			grouped[actionType] = true
+		}
+		// 並列実行しても直列に実行した場合と同じアクションに進むよう、組はエッジでつながっている必要がある
+		for _, flags := range allFlags() {
+			if !flags.Satisfies(parallel.Condition) {
+				continue
+			}
+			for i := 0; i < len(parallel.Actions)-1; i++ {
+				if !w.Next(parallel.Actions[i], flags).Equals(parallel.Actions[i+1]) {
+					return errors.NewDomainError(errors.ValidationError, fmt.Sprintf("parallel actions %s and %s are not connected when %+v", parallel.Actions[i].Value(), parallel.Actions[i+1].Value(), flags))
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func isWorkflowAction(actionType actionValue.ActionType) bool {
+	switch actionType {
+	case actionValue.ActionTypePlan,
+		actionValue.ActionTypeExternalSearch,
+		actionValue.ActionTypeInternalSearch,
+		actionValue.ActionTypeDataAnalysis,
+		actionValue.ActionTypeAnalyze,
+		actionValue.ActionTypeWrite,
+		actionValue.ActionTypeReview:
+		return true
+	default:
+		return false
+	}
+}
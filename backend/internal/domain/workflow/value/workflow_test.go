@@ -0,0 +1,190 @@
+package value
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	actionValue "github.com/goda6565/ai-consultant/backend/internal/domain/action/value"
+)
+
+func TestListWorkflows(t *testing.T) {
+	workflows, err := ListWorkflows()
+	if err != nil {
+		t.Fatalf("ListWorkflows() error = %v", err)
+	}
+	names := []string{}
+	for _, workflow := range workflows {
+		names = append(names, workflow.GetName().Value())
+	}
+	if strings.Join(names, ",") != "quickMemo,standard" {
+		t.Errorf("names = %v, expected [quickMemo standard]", names)
+	}
+}
+
+func TestWorkflow_Next(t *testing.T) {
+	standard, err := GetWorkflow(DefaultName)
+	if err != nil {
+		t.Fatalf("GetWorkflow() error = %v", err)
+	}
+	quickMemo, err := GetWorkflow("quickMemo")
+	if err != nil {
+		t.Fatalf("GetWorkflow() error = %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		workflow *Workflow
+		current  actionValue.ActionType
+		flags    Flags
+		expected actionValue.ActionType
+	}{
+		{name: "plan to external search", workflow: standard, current: actionValue.ActionTypePlan, expected: actionValue.ActionTypeExternalSearch},
+		{name: "internal search enabled", workflow: standard, current: actionValue.ActionTypeExternalSearch, flags: Flags{EnableInternalSearch: true}, expected: actionValue.ActionTypeInternalSearch},
+		{name: "internal search disabled", workflow: standard, current: actionValue.ActionTypeExternalSearch, expected: actionValue.ActionTypeAnalyze},
+		{name: "internal search to data analysis", workflow: standard, current: actionValue.ActionTypeInternalSearch, flags: Flags{EnableInternalSearch: true}, expected: actionValue.ActionTypeDataAnalysis},
+		{name: "review loops to plan", workflow: standard, current: actionValue.ActionTypeReview, expected: actionValue.ActionTypePlan},
+		{name: "action without edge stays", workflow: standard, current: actionValue.ActionTypeDone, expected: actionValue.ActionTypeDone},
+		{name: "quick memo skips analyze", workflow: quickMemo, current: actionValue.ActionTypeExternalSearch, expected: actionValue.ActionTypeWrite},
+		{name: "quick memo loops after write", workflow: quickMemo, current: actionValue.ActionTypeWrite, expected: actionValue.ActionTypePlan},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.workflow.Next(tt.current, tt.flags); got != tt.expected {
+				t.Errorf("Next() = %s, expected %s", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestWorkflow_Branches(t *testing.T) {
+	standard, err := GetWorkflow(DefaultName)
+	if err != nil {
+		t.Fatalf("GetWorkflow() error = %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		current  actionValue.ActionType
+		flags    Flags
+		expected []actionValue.ActionType
+	}{
+		{name: "searches run together", current: actionValue.ActionTypeExternalSearch, flags: Flags{EnableInternalSearch: true}, expected: []actionValue.ActionType{actionValue.ActionTypeExternalSearch, actionValue.ActionTypeInternalSearch}},
+		{name: "internal search disabled", current: actionValue.ActionTypeExternalSearch, expected: nil},
+		{name: "not the first action of the group", current: actionValue.ActionTypeInternalSearch, flags: Flags{EnableInternalSearch: true}, expected: nil},
+		{name: "action without group", current: actionValue.ActionTypePlan, flags: Flags{EnableInternalSearch: true}, expected: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := standard.Branches(tt.current, tt.flags); !slices.Equal(got, tt.expected) {
+				t.Errorf("Branches() = %v, expected %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestWorkflow_Render(t *testing.T) {
+	standard, err := GetWorkflow(DefaultName)
+	if err != nil {
+		t.Fatalf("GetWorkflow() error = %v", err)
+	}
+
+	disabled := standard.Render(Flags{})
+	if !strings.Contains(disabled, "plan ──▶ externalSearch ──▶ analyze ──▶ write ──▶ review ──▶ plan") {
+		t.Errorf("unexpected route:\n%s", disabled)
+	}
+	if strings.Contains(disabled, "- internalSearch") || strings.Contains(disabled, "- dataAnalysis") {
+		t.Errorf("internal data actions must not be rendered when disabled:\n%s", disabled)
+	}
+	enabled := standard.Render(Flags{EnableInternalSearch: true})
+	if !strings.Contains(enabled, "externalSearch ──▶ internalSearch ──▶ dataAnalysis ──▶ analyze") || !strings.Contains(enabled, "- internalSearch") {
+		t.Errorf("unexpected route:\n%s", enabled)
+	}
+	if !strings.Contains(enabled, "externalSearch と internalSearch は同時に実行される") || strings.Contains(disabled, "同時に実行") {
+		t.Errorf("parallel actions must be rendered only when enabled:\n%s\n%s", enabled, disabled)
+	}
+}
+
+func TestParseWorkflow(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		wantErr bool
+	}{
+		{
+			name:    "valid",
+			data:    `{"name": "w", "start": "plan", "terminal": "write", "nodes": [{"action": "plan"}, {"action": "write"}], "edges": [{"from": "plan", "to": "write"}, {"from": "write", "to": "plan"}]}`,
+			wantErr: false,
+		},
+		{
+			name:    "node without edge",
+			data:    `{"name": "w", "start": "plan", "terminal": "write", "nodes": [{"action": "plan"}, {"action": "write"}], "edges": [{"from": "plan", "to": "write"}]}`,
+			wantErr: true,
+		},
+		{
+			name:    "edge to unknown node",
+			data:    `{"name": "w", "start": "plan", "terminal": "plan", "nodes": [{"action": "plan"}], "edges": [{"from": "plan", "to": "write"}]}`,
+			wantErr: true,
+		},
+		{
+			name:    "unreachable node",
+			data:    `{"name": "w", "start": "plan", "terminal": "write", "nodes": [{"action": "plan"}, {"action": "write"}, {"action": "review"}], "edges": [{"from": "plan", "to": "write"}, {"from": "write", "to": "plan"}, {"from": "review", "to": "plan"}]}`,
+			wantErr: true,
+		},
+		{
+			name:    "internal search without condition",
+			data:    `{"name": "w", "start": "plan", "terminal": "write", "nodes": [{"action": "plan"}, {"action": "internalSearch"}, {"action": "write"}], "edges": [{"from": "plan", "to": "internalSearch"}, {"from": "internalSearch", "to": "write"}, {"from": "write", "to": "plan"}]}`,
+			wantErr: true,
+		},
+		{
+			name:    "self action node",
+			data:    `{"name": "w", "start": "plan", "terminal": "orchestrator", "nodes": [{"action": "plan"}, {"action": "orchestrator"}], "edges": [{"from": "plan", "to": "orchestrator"}, {"from": "orchestrator", "to": "plan"}]}`,
+			wantErr: true,
+		},
+		{
+			name:    "valid parallel",
+			data:    `{"name": "w", "start": "plan", "terminal": "write", "nodes": [{"action": "plan"}, {"action": "externalSearch"}, {"action": "write"}], "edges": [{"from": "plan", "to": "externalSearch"}, {"from": "externalSearch", "to": "write"}, {"from": "write", "to": "plan"}], "parallel": [{"actions": ["plan", "externalSearch"]}]}`,
+			wantErr: false,
+		},
+		{
+			name:    "parallel actions not connected",
+			data:    `{"name": "w", "start": "plan", "terminal": "write", "nodes": [{"action": "plan"}, {"action": "externalSearch"}, {"action": "write"}], "edges": [{"from": "plan", "to": "externalSearch"}, {"from": "externalSearch", "to": "write"}, {"from": "write", "to": "plan"}], "parallel": [{"actions": ["externalSearch", "plan"]}]}`,
+			wantErr: true,
+		},
+		{
+			name:    "parallel with a single action",
+			data:    `{"name": "w", "start": "plan", "terminal": "write", "nodes": [{"action": "plan"}, {"action": "write"}], "edges": [{"from": "plan", "to": "write"}, {"from": "write", "to": "plan"}], "parallel": [{"actions": ["plan"]}]}`,
+			wantErr: true,
+		},
+		{
+			name:    "parallel with terminal",
+			data:    `{"name": "w", "start": "plan", "terminal": "write", "nodes": [{"action": "plan"}, {"action": "write"}], "edges": [{"from": "plan", "to": "write"}, {"from": "write", "to": "plan"}], "parallel": [{"actions": ["plan", "write"]}]}`,
+			wantErr: true,
+		},
+		{
+			name:    "unknown condition",
+			data:    `{"name": "w", "start": "plan", "terminal": "write", "nodes": [{"action": "plan"}, {"action": "write"}], "edges": [{"from": "plan", "to": "write", "when": "sometimes"}, {"from": "write", "to": "plan"}]}`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseWorkflow([]byte(tt.data))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseWorkflow() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNewName(t *testing.T) {
+	if name, err := NewName(""); err != nil || name != DefaultName {
+		t.Errorf("NewName(\"\") = %s, %v, expected %s", name, err, DefaultName)
+	}
+	if _, err := NewName("unknown"); err == nil {
+		t.Error("expected error for unknown workflow")
+	}
+}
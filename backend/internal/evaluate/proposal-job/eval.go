@@ -15,7 +15,11 @@ import (
 	actionRepository "github.com/goda6565/ai-consultant/backend/internal/domain/action/repository"
 	actionService "github.com/goda6565/ai-consultant/backend/internal/domain/action/service"
 	agentService "github.com/goda6565/ai-consultant/backend/internal/domain/agent/service"
+	mockApprovalRepository "github.com/goda6565/ai-consultant/backend/internal/domain/approval/repository/mock"
+	mockCheckpointRepository "github.com/goda6565/ai-consultant/backend/internal/domain/checkpoint/repository/mock"
+	mockDocumentRepository "github.com/goda6565/ai-consultant/backend/internal/domain/document/repository/mock"
 	mockEventRepository "github.com/goda6565/ai-consultant/backend/internal/domain/event/repository/mock"
+	mockGoalRevisionRepository "github.com/goda6565/ai-consultant/backend/internal/domain/goal_revision/repository/mock"
 	mockHearingRepository "github.com/goda6565/ai-consultant/backend/internal/domain/hearing/repository/mock"
 	mockHearingMessageRepository "github.com/goda6565/ai-consultant/backend/internal/domain/hearing_message/repository/mock"
 	mockJobConfigRepository "github.com/goda6565/ai-consultant/backend/internal/domain/job_config/repository/mock"
@@ -24,6 +28,9 @@ import (
 	reportEntity "github.com/goda6565/ai-consultant/backend/internal/domain/report/entity"
 	reportRepository "github.com/goda6565/ai-consultant/backend/internal/domain/report/repository"
 	sharedValue "github.com/goda6565/ai-consultant/backend/internal/domain/shared/value"
+	mockSpanRepository "github.com/goda6565/ai-consultant/backend/internal/domain/trace/repository/mock"
+	traceService "github.com/goda6565/ai-consultant/backend/internal/domain/trace/service"
+	usageService "github.com/goda6565/ai-consultant/backend/internal/domain/usage/service"
 	"github.com/goda6565/ai-consultant/backend/internal/evaluate"
 	llmasjudge "github.com/goda6565/ai-consultant/backend/internal/evaluate/proposal-job/llm-as-a-judge"
 	"github.com/goda6565/ai-consultant/backend/internal/pkg/logger"
@@ -35,16 +42,19 @@ const EvaluateProblemID = "378b8576-1482-4d14-852f-90e753fabce3"
 const numEvaluations = 3
 
 type ProposalJobEval struct {
-	orchestrator     *agentService.Orchestrator
-	summarizeService *agentService.SummarizeService
-	goalService      *agentService.GoalService
-	terminator       *agentService.Terminator
-	skipper          *agentService.Skipper
-	actionFactory    *actionService.ActionFactory
-	reportRepository reportRepository.ReportRepository
-	actionRepository actionRepository.ActionRepository
-	judge            *llmasjudge.Judge
-	outputDir        string
+	orchestrator        *agentService.Orchestrator
+	summarizeService    *agentService.SummarizeService
+	goalService         *agentService.GoalService
+	terminator          *agentService.Terminator
+	skipper             *agentService.Skipper
+	reflection          *agentService.Reflection
+	goalRevisionService *agentService.GoalRevisionService
+	actionFactory       *actionService.ActionFactory
+	reportRepository    reportRepository.ReportRepository
+	actionRepository    actionRepository.ActionRepository
+	ledgerService       *usageService.LedgerService
+	judge               *llmasjudge.Judge
+	outputDir           string
 }
 
 func NewProposalJobEval(
@@ -53,22 +63,28 @@ func NewProposalJobEval(
 	goalService *agentService.GoalService,
 	terminator *agentService.Terminator,
 	skipper *agentService.Skipper,
+	reflection *agentService.Reflection,
+	goalRevisionService *agentService.GoalRevisionService,
 	actionFactory *actionService.ActionFactory,
 	reportRepository reportRepository.ReportRepository,
 	actionRepository actionRepository.ActionRepository,
+	ledgerService *usageService.LedgerService,
 	judge *llmasjudge.Judge,
 ) evaluate.Evaluator {
 	return &ProposalJobEval{
-		orchestrator:     orchestrator,
-		summarizeService: summarizeService,
-		goalService:      goalService,
-		terminator:       terminator,
-		skipper:          skipper,
-		actionFactory:    actionFactory,
-		reportRepository: reportRepository,
-		actionRepository: actionRepository,
-		judge:            judge,
-		outputDir:        "",
+		orchestrator:        orchestrator,
+		summarizeService:    summarizeService,
+		goalService:         goalService,
+		terminator:          terminator,
+		skipper:             skipper,
+		reflection:          reflection,
+		goalRevisionService: goalRevisionService,
+		actionFactory:       actionFactory,
+		reportRepository:    reportRepository,
+		actionRepository:    actionRepository,
+		ledgerService:       ledgerService,
+		judge:               judge,
+		outputDir:           "",
 	}
 }
 
@@ -83,6 +99,8 @@ func (e *ProposalJobEval) prepareForEvaluation(ctx context.Context) (proposal.Ex
 	problemRepository := mockProblemRepository.NewMockProblemRepository(ctrl)
 	problemRepository.EXPECT().FindById(gomock.Any(), gomock.Any()).Return(mockProblem, nil).AnyTimes()
 	problemRepository.EXPECT().UpdateStatus(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	problemRepository.EXPECT().IsCancelRequested(gomock.Any(), gomock.Any()).Return(false, nil).AnyTimes()
+	problemRepository.EXPECT().ClearCancelRequest(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
 
 	problemFieldRepository := mockProblemFieldRepository.NewMockProblemFieldRepository(ctrl)
 	problemFieldRepository.EXPECT().FindByProblemID(gomock.Any(), gomock.Any()).Return(mockProblemFields, nil).AnyTimes()
@@ -99,6 +117,25 @@ func (e *ProposalJobEval) prepareForEvaluation(ctx context.Context) (proposal.Ex
 	jobConfigRepository := mockJobConfigRepository.NewMockJobConfigRepository(ctrl)
 	jobConfigRepository.EXPECT().FindByProblemID(gomock.Any(), gomock.Any()).Return(mockJobConfig, nil).AnyTimes()
 
+	// 評価のジョブ設定は検索対象を制限しないため呼ばれない
+	documentRepository := mockDocumentRepository.NewMockDocumentRepository(ctrl)
+
+	// 評価は毎回最初から実行する
+	checkpointRepository := mockCheckpointRepository.NewMockCheckpointRepository(ctrl)
+	checkpointRepository.EXPECT().FindLatestByProblemID(gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+	checkpointRepository.EXPECT().Save(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	checkpointRepository.EXPECT().DeleteByProblemID(gomock.Any(), gomock.Any()).Return(int64(0), nil).AnyTimes()
+
+	// 評価のジョブ設定には承認ゲートがないため呼ばれない
+	approvalRepository := mockApprovalRepository.NewMockApprovalRepository(ctrl)
+
+	goalRevisionRepository := mockGoalRevisionRepository.NewMockGoalRevisionRepository(ctrl)
+	goalRevisionRepository.EXPECT().Save(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+	// 評価ではトレースを保存しない
+	spanRepository := mockSpanRepository.NewMockSpanRepository(ctrl)
+	spanRepository.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
 	executeProposalUseCase := proposal.NewExecuteProposalUseCase(
 		problemRepository,
 		problemFieldRepository,
@@ -111,9 +148,17 @@ func (e *ProposalJobEval) prepareForEvaluation(ctx context.Context) (proposal.Ex
 		e.goalService,
 		e.terminator,
 		e.skipper,
+		e.reflection,
+		e.goalRevisionService,
 		e.actionFactory,
 		e.reportRepository,
 		jobConfigRepository,
+		documentRepository,
+		e.ledgerService,
+		checkpointRepository,
+		approvalRepository,
+		goalRevisionRepository,
+		traceService.NewTracer(spanRepository),
 	)
 	return executeProposalUseCase, nil
 }
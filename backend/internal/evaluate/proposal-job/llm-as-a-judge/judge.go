@@ -59,17 +59,12 @@ func (j *Judge) Judge(ctx context.Context, problemDescription string, actions []
 		}
 		temperature += 0.1
 
-		output, err := j.llmClient.GenerateStructuredText(ctx, input)
+		output, err := llm.GenerateStructured[JudgmentResult](ctx, j.llmClient, input)
 		if err != nil {
 			return nil, fmt.Errorf("failed to generate judgment (round %d): %w", i+1, err)
 		}
 
-		var result JudgmentResult
-		if err := json.Unmarshal([]byte(output.Text), &result); err != nil {
-			return nil, fmt.Errorf("failed to parse judgment result (round %d): %w", i+1, err)
-		}
-
-		results = append(results, result)
+		results = append(results, output.Value)
 	}
 
 	// 複数の評価結果を統合
@@ -0,0 +1,62 @@
+package memory
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	sharedValue "github.com/goda6565/ai-consultant/backend/internal/domain/shared/value"
+	usageEntity "github.com/goda6565/ai-consultant/backend/internal/domain/usage/entity"
+	usageRepository "github.com/goda6565/ai-consultant/backend/internal/domain/usage/repository"
+)
+
+type MemoryUsageRepository struct {
+	mu     sync.RWMutex
+	usages []usageEntity.Usage
+}
+
+func NewMemoryUsageRepository() usageRepository.UsageRepository {
+	return &MemoryUsageRepository{
+		usages: []usageEntity.Usage{},
+	}
+}
+
+func (r *MemoryUsageRepository) FindByProblemID(ctx context.Context, problemID sharedValue.ID) ([]usageEntity.Usage, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	usages := []usageEntity.Usage{}
+	for _, usage := range r.usages {
+		if usage.GetProblemID().Equals(problemID) {
+			usages = append(usages, usage)
+		}
+	}
+	return usages, nil
+}
+
+func (r *MemoryUsageRepository) FindByProblemIDSince(ctx context.Context, problemID sharedValue.ID, since time.Time) ([]usageEntity.Usage, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	usages := []usageEntity.Usage{}
+	for _, usage := range r.usages {
+		if usage.GetProblemID().Equals(problemID) && !usage.GetCreatedAt().Before(since) {
+			usages = append(usages, usage)
+		}
+	}
+	return usages, nil
+}
+
+func (r *MemoryUsageRepository) Create(ctx context.Context, usage *usageEntity.Usage) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// set the created time like the database does
+	createdAt := usage.GetCreatedAt()
+	if createdAt == nil {
+		now := time.Now()
+		createdAt = &now
+	}
+	r.usages = append(r.usages, *usageEntity.NewUsage(usage.GetID(), usage.GetProblemID(), usage.GetActionType(), usage.GetProvider(), usage.GetModel(), usage.GetUsage(), createdAt))
+	return nil
+}
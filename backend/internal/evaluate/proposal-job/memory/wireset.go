@@ -5,4 +5,5 @@ import "github.com/google/wire"
 var Set = wire.NewSet(
 	NewMemoryActionRepository,
 	NewMemoryReportRepository,
+	NewMemoryUsageRepository,
 )
@@ -7,11 +7,13 @@ import (
 	hearingMessageEntity "github.com/goda6565/ai-consultant/backend/internal/domain/hearing_message/entity"
 	hearingMessageValue "github.com/goda6565/ai-consultant/backend/internal/domain/hearing_message/value"
 	jobConfigEntity "github.com/goda6565/ai-consultant/backend/internal/domain/job_config/entity"
+	jobConfigValue "github.com/goda6565/ai-consultant/backend/internal/domain/job_config/value"
 	problemEntity "github.com/goda6565/ai-consultant/backend/internal/domain/problem/entity"
 	problemValue "github.com/goda6565/ai-consultant/backend/internal/domain/problem/value"
 	problemFieldEntity "github.com/goda6565/ai-consultant/backend/internal/domain/problem_field/entity"
 	problemFieldValue "github.com/goda6565/ai-consultant/backend/internal/domain/problem_field/value"
 	sharedValue "github.com/goda6565/ai-consultant/backend/internal/domain/shared/value"
+	workflowValue "github.com/goda6565/ai-consultant/backend/internal/domain/workflow/value"
 	"github.com/google/uuid"
 )
 
@@ -234,7 +236,7 @@ func (m *MockDataProvider) CreateMockHearingMessages() []hearingMessageEntity.He
 func (m *MockDataProvider) CreateMockJobConfig() *jobConfigEntity.JobConfig {
 	jobConfigID, _ := sharedValue.NewID(uuid.New().String())
 	problemID, _ := sharedValue.NewID(EvaluateProblemID)
-	return jobConfigEntity.NewJobConfig(jobConfigID, problemID, false)
+	return jobConfigEntity.NewJobConfig(jobConfigID, problemID, false, jobConfigValue.ModelMap{}, jobConfigValue.Budget{}, jobConfigValue.ApprovalGates{}, workflowValue.DefaultName, jobConfigValue.DocumentScope{})
 }
 
 // GetMockData returns all mock data needed for evaluation
@@ -0,0 +1,16 @@
+package mock
+
+import (
+	"github.com/goda6565/ai-consultant/backend/internal/domain/dataset"
+	datasetMock "github.com/goda6565/ai-consultant/backend/internal/domain/dataset/mock"
+	gomock "go.uber.org/mock/gomock"
+)
+
+func NewMockDatasetClient() (dataset.DatasetClient, func()) {
+	ctrl := gomock.NewController(nil)
+	m := datasetMock.NewMockDatasetClient(ctrl)
+	m.EXPECT().List(gomock.Any()).Return([]dataset.DatasetInfo{}, nil).AnyTimes()
+	return m, func() {
+		ctrl.Finish()
+	}
+}
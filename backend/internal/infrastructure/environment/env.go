@@ -24,6 +24,10 @@ type Environment struct {
 	CloudStorageEnvironment
 	DocumentAIEnvironment
 	VertexAIEnvironment
+	OpenAIEnvironment
+	LLMUsageEnvironment
+	LLMCassetteEnvironment
+	DocumentSearchEnvironment
 	SyncQueueEnvironment
 	RedisEnvironment
 	GoogleSearchEnvironment
@@ -80,6 +84,28 @@ type VertexAIEnvironment struct {
 	VertexAILocation string `env:"VERTEX_AI_LOCATION,required"`
 }
 
+type OpenAIEnvironment struct {
+	OpenAIAPIKey  string `env:"OPENAI_API_KEY"`
+	OpenAIBaseURL string `env:"OPENAI_BASE_URL" envDefault:"https://api.openai.com/v1"`
+}
+
+type LLMUsageEnvironment struct {
+	// モデル名ごとの100万トークンあたりの料金 (USD) を上書きする JSON
+	// e.g. {"gpt-4o": {"input": 2.5, "output": 10}}
+	LLMPriceTable string `env:"LLM_PRICE_TABLE"`
+}
+
+type LLMCassetteEnvironment struct {
+	// off, record, replay
+	LLMCassetteMode string `env:"LLM_CASSETTE_MODE" envDefault:"off"`
+	LLMCassetteDir  string `env:"LLM_CASSETTE_DIR"`
+}
+
+type DocumentSearchEnvironment struct {
+	// LLM で検索結果を並べ替えるか
+	DocumentSearchRerank bool `env:"DOCUMENT_SEARCH_RERANK" envDefault:"true"`
+}
+
 type SyncQueueEnvironment struct {
 	QueueName     string `env:"SYNC_QUEUE_NAME"`
 	QueueLocation string `env:"SYNC_QUEUE_LOCATION"`
@@ -1,5 +1,7 @@
 package errors
 
+import "net/http"
+
 type InfrastructureErrorType string
 
 const (
@@ -9,6 +11,9 @@ const (
 	ForbiddenError       InfrastructureErrorType = "forbidden_error"
 	BadRequestError      InfrastructureErrorType = "bad_request_error"
 	BadResponseError     InfrastructureErrorType = "bad_response_error"
+	RateLimitError       InfrastructureErrorType = "rate_limit_error"
+	UnavailableError     InfrastructureErrorType = "unavailable_error"
+	TimeoutError         InfrastructureErrorType = "timeout_error"
 )
 
 type InfrastructureError struct {
@@ -26,3 +31,18 @@ func NewInfrastructureError(errorType InfrastructureErrorType, message string) e
 		Message:   message,
 	}
 }
+
+// ExternalServiceErrorType classifies the status code returned by an external service.
+// Non-transient failures stay ExternalServiceError so that they are not mistaken for errors of this API.
+func ExternalServiceErrorType(statusCode int) InfrastructureErrorType {
+	switch {
+	case statusCode == http.StatusTooManyRequests:
+		return RateLimitError
+	case statusCode == http.StatusRequestTimeout || statusCode == http.StatusGatewayTimeout:
+		return TimeoutError
+	case statusCode >= http.StatusInternalServerError:
+		return UnavailableError
+	default:
+		return ExternalServiceError
+	}
+}
@@ -0,0 +1,72 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: agent_checkpoint.sql
+
+package app
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const deleteAgentCheckpointsByProblemID = `-- name: DeleteAgentCheckpointsByProblemID :execrows
+DELETE FROM agent_checkpoints WHERE problem_id = $1
+`
+
+func (q *Queries) DeleteAgentCheckpointsByProblemID(ctx context.Context, problemID pgtype.UUID) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteAgentCheckpointsByProblemID, problemID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const getLatestAgentCheckpointByProblemID = `-- name: GetLatestAgentCheckpointByProblemID :one
+SELECT id, problem_id, step, state, fail_count, elapsed_ms, created_at, run_started_at FROM agent_checkpoints WHERE problem_id = $1 ORDER BY step DESC LIMIT 1
+`
+
+func (q *Queries) GetLatestAgentCheckpointByProblemID(ctx context.Context, problemID pgtype.UUID) (AgentCheckpoint, error) {
+	row := q.db.QueryRow(ctx, getLatestAgentCheckpointByProblemID, problemID)
+	var i AgentCheckpoint
+	err := row.Scan(
+		&i.ID,
+		&i.ProblemID,
+		&i.Step,
+		&i.State,
+		&i.FailCount,
+		&i.ElapsedMs,
+		&i.CreatedAt,
+		&i.RunStartedAt,
+	)
+	return i, err
+}
+
+const upsertAgentCheckpoint = `-- name: UpsertAgentCheckpoint :exec
+INSERT INTO agent_checkpoints (id, problem_id, step, state, fail_count, elapsed_ms, run_started_at) VALUES ($1, $2, $3, $4, $5, $6, $7)
+ON CONFLICT (problem_id, step) DO UPDATE SET state = EXCLUDED.state, fail_count = EXCLUDED.fail_count, elapsed_ms = EXCLUDED.elapsed_ms, run_started_at = EXCLUDED.run_started_at, created_at = CURRENT_TIMESTAMP
+`
+
+type UpsertAgentCheckpointParams struct {
+	ID           pgtype.UUID
+	ProblemID    pgtype.UUID
+	Step         int32
+	State        []byte
+	FailCount    int32
+	ElapsedMs    int64
+	RunStartedAt pgtype.Timestamptz
+}
+
+func (q *Queries) UpsertAgentCheckpoint(ctx context.Context, arg UpsertAgentCheckpointParams) error {
+	_, err := q.db.Exec(ctx, upsertAgentCheckpoint,
+		arg.ID,
+		arg.ProblemID,
+		arg.Step,
+		arg.State,
+		arg.FailCount,
+		arg.ElapsedMs,
+		arg.RunStartedAt,
+	)
+	return err
+}
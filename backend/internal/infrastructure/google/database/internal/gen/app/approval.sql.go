@@ -0,0 +1,168 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: approval.sql
+
+package app
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const createApproval = `-- name: CreateApproval :exec
+INSERT INTO approvals (id, problem_id, action_type, step, output) VALUES ($1, $2, $3, $4, $5)
+`
+
+type CreateApprovalParams struct {
+	ID         pgtype.UUID
+	ProblemID  pgtype.UUID
+	ActionType string
+	Step       int32
+	Output     string
+}
+
+func (q *Queries) CreateApproval(ctx context.Context, arg CreateApprovalParams) error {
+	_, err := q.db.Exec(ctx, createApproval,
+		arg.ID,
+		arg.ProblemID,
+		arg.ActionType,
+		arg.Step,
+		arg.Output,
+	)
+	return err
+}
+
+const decideApproval = `-- name: DecideApproval :execrows
+UPDATE approvals SET decision = $2, feedback = $3, goal = $4, decided_at = CURRENT_TIMESTAMP WHERE id = $1 AND decision = 'pending'
+`
+
+type DecideApprovalParams struct {
+	ID       pgtype.UUID
+	Decision string
+	Feedback string
+	Goal     pgtype.Text
+}
+
+func (q *Queries) DecideApproval(ctx context.Context, arg DecideApprovalParams) (int64, error) {
+	result, err := q.db.Exec(ctx, decideApproval,
+		arg.ID,
+		arg.Decision,
+		arg.Feedback,
+		arg.Goal,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const expirePendingApprovalsByProblemID = `-- name: ExpirePendingApprovalsByProblemID :exec
+UPDATE approvals SET decision = 'expired', decided_at = CURRENT_TIMESTAMP WHERE problem_id = $1 AND decision = 'pending'
+`
+
+func (q *Queries) ExpirePendingApprovalsByProblemID(ctx context.Context, problemID pgtype.UUID) error {
+	_, err := q.db.Exec(ctx, expirePendingApprovalsByProblemID, problemID)
+	return err
+}
+
+const getApprovalByID = `-- name: GetApprovalByID :one
+SELECT id, problem_id, action_type, step, output, decision, feedback, goal, created_at, decided_at, applied_at FROM approvals WHERE id = $1
+`
+
+func (q *Queries) GetApprovalByID(ctx context.Context, id pgtype.UUID) (Approval, error) {
+	row := q.db.QueryRow(ctx, getApprovalByID, id)
+	var i Approval
+	err := row.Scan(
+		&i.ID,
+		&i.ProblemID,
+		&i.ActionType,
+		&i.Step,
+		&i.Output,
+		&i.Decision,
+		&i.Feedback,
+		&i.Goal,
+		&i.CreatedAt,
+		&i.DecidedAt,
+		&i.AppliedAt,
+	)
+	return i, err
+}
+
+const getLatestApprovalByProblemID = `-- name: GetLatestApprovalByProblemID :one
+SELECT id, problem_id, action_type, step, output, decision, feedback, goal, created_at, decided_at, applied_at FROM approvals WHERE problem_id = $1 ORDER BY created_at DESC LIMIT 1
+`
+
+func (q *Queries) GetLatestApprovalByProblemID(ctx context.Context, problemID pgtype.UUID) (Approval, error) {
+	row := q.db.QueryRow(ctx, getLatestApprovalByProblemID, problemID)
+	var i Approval
+	err := row.Scan(
+		&i.ID,
+		&i.ProblemID,
+		&i.ActionType,
+		&i.Step,
+		&i.Output,
+		&i.Decision,
+		&i.Feedback,
+		&i.Goal,
+		&i.CreatedAt,
+		&i.DecidedAt,
+		&i.AppliedAt,
+	)
+	return i, err
+}
+
+const listPendingApprovals = `-- name: ListPendingApprovals :many
+SELECT id, problem_id, action_type, step, output, decision, feedback, goal, created_at, decided_at, applied_at FROM approvals WHERE decision = 'pending' ORDER BY created_at ASC
+`
+
+func (q *Queries) ListPendingApprovals(ctx context.Context) ([]Approval, error) {
+	rows, err := q.db.Query(ctx, listPendingApprovals)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Approval
+	for rows.Next() {
+		var i Approval
+		if err := rows.Scan(
+			&i.ID,
+			&i.ProblemID,
+			&i.ActionType,
+			&i.Step,
+			&i.Output,
+			&i.Decision,
+			&i.Feedback,
+			&i.Goal,
+			&i.CreatedAt,
+			&i.DecidedAt,
+			&i.AppliedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const markApprovalApplied = `-- name: MarkApprovalApplied :exec
+UPDATE approvals SET applied_at = CURRENT_TIMESTAMP WHERE id = $1
+`
+
+func (q *Queries) MarkApprovalApplied(ctx context.Context, id pgtype.UUID) error {
+	_, err := q.db.Exec(ctx, markApprovalApplied, id)
+	return err
+}
+
+const reopenApproval = `-- name: ReopenApproval :exec
+UPDATE approvals SET decision = 'pending', feedback = '', goal = NULL, decided_at = NULL WHERE id = $1 AND applied_at IS NULL
+`
+
+func (q *Queries) ReopenApproval(ctx context.Context, id pgtype.UUID) error {
+	_, err := q.db.Exec(ctx, reopenApproval, id)
+	return err
+}
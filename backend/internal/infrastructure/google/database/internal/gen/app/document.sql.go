@@ -12,7 +12,7 @@ import (
 )
 
 const createDocument = `-- name: CreateDocument :exec
-INSERT INTO documents (id, title, document_type, bucket_name, object_name, document_status, retry_count) VALUES ($1, $2, $3, $4, $5, $6, $7)
+INSERT INTO documents (id, title, document_type, bucket_name, object_name, document_status, retry_count, collection) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
 `
 
 type CreateDocumentParams struct {
@@ -23,6 +23,7 @@ type CreateDocumentParams struct {
 	ObjectName     string
 	DocumentStatus string
 	RetryCount     int32
+	Collection     string
 }
 
 func (q *Queries) CreateDocument(ctx context.Context, arg CreateDocumentParams) error {
@@ -34,6 +35,7 @@ func (q *Queries) CreateDocument(ctx context.Context, arg CreateDocumentParams)
 		arg.ObjectName,
 		arg.DocumentStatus,
 		arg.RetryCount,
+		arg.Collection,
 	)
 	return err
 }
@@ -51,7 +53,7 @@ func (q *Queries) DeleteDocument(ctx context.Context, id pgtype.UUID) (int64, er
 }
 
 const getAllDocuments = `-- name: GetAllDocuments :many
-SELECT id, title, document_type, bucket_name, object_name, document_status, retry_count, created_at, updated_at FROM documents ORDER BY created_at DESC
+SELECT id, title, document_type, bucket_name, object_name, document_status, retry_count, created_at, updated_at, collection FROM documents ORDER BY created_at DESC
 `
 
 func (q *Queries) GetAllDocuments(ctx context.Context) ([]Document, error) {
@@ -73,6 +75,7 @@ func (q *Queries) GetAllDocuments(ctx context.Context) ([]Document, error) {
 			&i.RetryCount,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.Collection,
 		); err != nil {
 			return nil, err
 		}
@@ -85,7 +88,7 @@ func (q *Queries) GetAllDocuments(ctx context.Context) ([]Document, error) {
 }
 
 const getDocument = `-- name: GetDocument :one
-SELECT id, title, document_type, bucket_name, object_name, document_status, retry_count, created_at, updated_at FROM documents WHERE id = $1
+SELECT id, title, document_type, bucket_name, object_name, document_status, retry_count, created_at, updated_at, collection FROM documents WHERE id = $1
 `
 
 func (q *Queries) GetDocument(ctx context.Context, id pgtype.UUID) (Document, error) {
@@ -101,12 +104,13 @@ func (q *Queries) GetDocument(ctx context.Context, id pgtype.UUID) (Document, er
 		&i.RetryCount,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Collection,
 	)
 	return i, err
 }
 
 const getDocumentByTitle = `-- name: GetDocumentByTitle :one
-SELECT id, title, document_type, bucket_name, object_name, document_status, retry_count, created_at, updated_at FROM documents WHERE title = $1
+SELECT id, title, document_type, bucket_name, object_name, document_status, retry_count, created_at, updated_at, collection FROM documents WHERE title = $1
 `
 
 func (q *Queries) GetDocumentByTitle(ctx context.Context, title string) (Document, error) {
@@ -122,12 +126,72 @@ func (q *Queries) GetDocumentByTitle(ctx context.Context, title string) (Documen
 		&i.RetryCount,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Collection,
 	)
 	return i, err
 }
 
+const getDocumentIDsByCollections = `-- name: GetDocumentIDsByCollections :many
+SELECT id FROM documents WHERE collection = ANY($1::text[])
+`
+
+func (q *Queries) GetDocumentIDsByCollections(ctx context.Context, collections []string) ([]pgtype.UUID, error) {
+	rows, err := q.db.Query(ctx, getDocumentIDsByCollections, collections)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []pgtype.UUID
+	for rows.Next() {
+		var id pgtype.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getDocumentsByIDs = `-- name: GetDocumentsByIDs :many
+SELECT id, title, document_type, bucket_name, object_name, document_status, retry_count, created_at, updated_at, collection FROM documents WHERE id = ANY($1::uuid[])
+`
+
+func (q *Queries) GetDocumentsByIDs(ctx context.Context, ids []pgtype.UUID) ([]Document, error) {
+	rows, err := q.db.Query(ctx, getDocumentsByIDs, ids)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Document
+	for rows.Next() {
+		var i Document
+		if err := rows.Scan(
+			&i.ID,
+			&i.Title,
+			&i.DocumentType,
+			&i.BucketName,
+			&i.ObjectName,
+			&i.DocumentStatus,
+			&i.RetryCount,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Collection,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const updateDocument = `-- name: UpdateDocument :execrows
-UPDATE documents SET title = $2, document_type = $3, bucket_name = $4, object_name = $5, document_status = $6, retry_count = $7 WHERE id = $1
+UPDATE documents SET title = $2, document_type = $3, bucket_name = $4, object_name = $5, document_status = $6, retry_count = $7, collection = $8 WHERE id = $1
 `
 
 type UpdateDocumentParams struct {
@@ -138,6 +202,7 @@ type UpdateDocumentParams struct {
 	ObjectName     string
 	DocumentStatus string
 	RetryCount     int32
+	Collection     string
 }
 
 func (q *Queries) UpdateDocument(ctx context.Context, arg UpdateDocumentParams) (int64, error) {
@@ -149,6 +214,7 @@ func (q *Queries) UpdateDocument(ctx context.Context, arg UpdateDocumentParams)
 		arg.ObjectName,
 		arg.DocumentStatus,
 		arg.RetryCount,
+		arg.Collection,
 	)
 	if err != nil {
 		return 0, err
@@ -0,0 +1,70 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: goal_revision.sql
+
+package app
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const listGoalRevisionsByProblemID = `-- name: ListGoalRevisionsByProblemID :many
+SELECT id, problem_id, version, goal, reason, action_type, created_at FROM goal_revisions WHERE problem_id = $1 ORDER BY version ASC
+`
+
+func (q *Queries) ListGoalRevisionsByProblemID(ctx context.Context, problemID pgtype.UUID) ([]GoalRevision, error) {
+	rows, err := q.db.Query(ctx, listGoalRevisionsByProblemID, problemID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GoalRevision
+	for rows.Next() {
+		var i GoalRevision
+		if err := rows.Scan(
+			&i.ID,
+			&i.ProblemID,
+			&i.Version,
+			&i.Goal,
+			&i.Reason,
+			&i.ActionType,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const upsertGoalRevision = `-- name: UpsertGoalRevision :exec
+INSERT INTO goal_revisions (id, problem_id, version, goal, reason, action_type) VALUES ($1, $2, $3, $4, $5, $6)
+ON CONFLICT (problem_id, version) DO UPDATE SET goal = EXCLUDED.goal, reason = EXCLUDED.reason, action_type = EXCLUDED.action_type, created_at = CURRENT_TIMESTAMP
+`
+
+type UpsertGoalRevisionParams struct {
+	ID         pgtype.UUID
+	ProblemID  pgtype.UUID
+	Version    int32
+	Goal       string
+	Reason     string
+	ActionType string
+}
+
+func (q *Queries) UpsertGoalRevision(ctx context.Context, arg UpsertGoalRevisionParams) error {
+	_, err := q.db.Exec(ctx, upsertGoalRevision,
+		arg.ID,
+		arg.ProblemID,
+		arg.Version,
+		arg.Goal,
+		arg.Reason,
+		arg.ActionType,
+	)
+	return err
+}
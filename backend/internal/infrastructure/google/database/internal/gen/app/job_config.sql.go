@@ -10,17 +10,37 @@ import (
 )
 
 const createJobConfig = `-- name: CreateJobConfig :exec
-INSERT INTO job_configs (id, problem_id, enable_internal_search) VALUES ($1, $2, $3)
+INSERT INTO job_configs (id, problem_id, enable_internal_search, models, budget_max_tokens, budget_max_cost, budget_max_duration_seconds, approval_gates, workflow, allowed_collections, allowed_document_ids) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
 `
 
 type CreateJobConfigParams struct {
-	ID                   string
-	ProblemID            string
-	EnableInternalSearch bool
+	ID                       string
+	ProblemID                string
+	EnableInternalSearch     bool
+	Models                   []byte
+	BudgetMaxTokens          int32
+	BudgetMaxCost            float64
+	BudgetMaxDurationSeconds int32
+	ApprovalGates            []string
+	Workflow                 string
+	AllowedCollections       []string
+	AllowedDocumentIds       []string
 }
 
 func (q *Queries) CreateJobConfig(ctx context.Context, arg CreateJobConfigParams) error {
-	_, err := q.db.Exec(ctx, createJobConfig, arg.ID, arg.ProblemID, arg.EnableInternalSearch)
+	_, err := q.db.Exec(ctx, createJobConfig,
+		arg.ID,
+		arg.ProblemID,
+		arg.EnableInternalSearch,
+		arg.Models,
+		arg.BudgetMaxTokens,
+		arg.BudgetMaxCost,
+		arg.BudgetMaxDurationSeconds,
+		arg.ApprovalGates,
+		arg.Workflow,
+		arg.AllowedCollections,
+		arg.AllowedDocumentIds,
+	)
 	return err
 }
 
@@ -37,26 +57,57 @@ func (q *Queries) DeleteJobConfigByProblemID(ctx context.Context, problemID stri
 }
 
 const getJobConfigByProblemID = `-- name: GetJobConfigByProblemID :one
-SELECT id, problem_id, enable_internal_search FROM job_configs WHERE problem_id = $1
+SELECT id, problem_id, enable_internal_search, models, budget_max_tokens, budget_max_cost, budget_max_duration_seconds, approval_gates, workflow, allowed_collections, allowed_document_ids FROM job_configs WHERE problem_id = $1
 `
 
 func (q *Queries) GetJobConfigByProblemID(ctx context.Context, problemID string) (JobConfig, error) {
 	row := q.db.QueryRow(ctx, getJobConfigByProblemID, problemID)
 	var i JobConfig
-	err := row.Scan(&i.ID, &i.ProblemID, &i.EnableInternalSearch)
+	err := row.Scan(
+		&i.ID,
+		&i.ProblemID,
+		&i.EnableInternalSearch,
+		&i.Models,
+		&i.BudgetMaxTokens,
+		&i.BudgetMaxCost,
+		&i.BudgetMaxDurationSeconds,
+		&i.ApprovalGates,
+		&i.Workflow,
+		&i.AllowedCollections,
+		&i.AllowedDocumentIds,
+	)
 	return i, err
 }
 
 const updateJobConfig = `-- name: UpdateJobConfig :exec
-UPDATE job_configs SET enable_internal_search = $2 WHERE id = $1
+UPDATE job_configs SET enable_internal_search = $2, models = $3, budget_max_tokens = $4, budget_max_cost = $5, budget_max_duration_seconds = $6, approval_gates = $7, workflow = $8, allowed_collections = $9, allowed_document_ids = $10 WHERE id = $1
 `
 
 type UpdateJobConfigParams struct {
-	ID                   string
-	EnableInternalSearch bool
+	ID                       string
+	EnableInternalSearch     bool
+	Models                   []byte
+	BudgetMaxTokens          int32
+	BudgetMaxCost            float64
+	BudgetMaxDurationSeconds int32
+	ApprovalGates            []string
+	Workflow                 string
+	AllowedCollections       []string
+	AllowedDocumentIds       []string
 }
 
 func (q *Queries) UpdateJobConfig(ctx context.Context, arg UpdateJobConfigParams) error {
-	_, err := q.db.Exec(ctx, updateJobConfig, arg.ID, arg.EnableInternalSearch)
+	_, err := q.db.Exec(ctx, updateJobConfig,
+		arg.ID,
+		arg.EnableInternalSearch,
+		arg.Models,
+		arg.BudgetMaxTokens,
+		arg.BudgetMaxCost,
+		arg.BudgetMaxDurationSeconds,
+		arg.ApprovalGates,
+		arg.Workflow,
+		arg.AllowedCollections,
+		arg.AllowedDocumentIds,
+	)
 	return err
 }
@@ -0,0 +1,114 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: llm_usage.sql
+
+package app
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const createLLMUsage = `-- name: CreateLLMUsage :exec
+INSERT INTO llm_usages (id, problem_id, action_type, provider, model, input_tokens, output_tokens, total_tokens) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+`
+
+type CreateLLMUsageParams struct {
+	ID           pgtype.UUID
+	ProblemID    pgtype.UUID
+	ActionType   string
+	Provider     string
+	Model        string
+	InputTokens  int32
+	OutputTokens int32
+	TotalTokens  int32
+}
+
+func (q *Queries) CreateLLMUsage(ctx context.Context, arg CreateLLMUsageParams) error {
+	_, err := q.db.Exec(ctx, createLLMUsage,
+		arg.ID,
+		arg.ProblemID,
+		arg.ActionType,
+		arg.Provider,
+		arg.Model,
+		arg.InputTokens,
+		arg.OutputTokens,
+		arg.TotalTokens,
+	)
+	return err
+}
+
+const getLLMUsagesByProblemID = `-- name: GetLLMUsagesByProblemID :many
+SELECT id, problem_id, action_type, provider, model, input_tokens, output_tokens, total_tokens, created_at FROM llm_usages WHERE problem_id = $1 ORDER BY created_at ASC
+`
+
+func (q *Queries) GetLLMUsagesByProblemID(ctx context.Context, problemID pgtype.UUID) ([]LlmUsage, error) {
+	rows, err := q.db.Query(ctx, getLLMUsagesByProblemID, problemID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []LlmUsage
+	for rows.Next() {
+		var i LlmUsage
+		if err := rows.Scan(
+			&i.ID,
+			&i.ProblemID,
+			&i.ActionType,
+			&i.Provider,
+			&i.Model,
+			&i.InputTokens,
+			&i.OutputTokens,
+			&i.TotalTokens,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getLLMUsagesByProblemIDSince = `-- name: GetLLMUsagesByProblemIDSince :many
+SELECT id, problem_id, action_type, provider, model, input_tokens, output_tokens, total_tokens, created_at FROM llm_usages WHERE problem_id = $1 AND created_at >= $2 ORDER BY created_at ASC
+`
+
+type GetLLMUsagesByProblemIDSinceParams struct {
+	ProblemID pgtype.UUID
+	Since     pgtype.Timestamptz
+}
+
+func (q *Queries) GetLLMUsagesByProblemIDSince(ctx context.Context, arg GetLLMUsagesByProblemIDSinceParams) ([]LlmUsage, error) {
+	rows, err := q.db.Query(ctx, getLLMUsagesByProblemIDSince, arg.ProblemID, arg.Since)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []LlmUsage
+	for rows.Next() {
+		var i LlmUsage
+		if err := rows.Scan(
+			&i.ID,
+			&i.ProblemID,
+			&i.ActionType,
+			&i.Provider,
+			&i.Model,
+			&i.InputTokens,
+			&i.OutputTokens,
+			&i.TotalTokens,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
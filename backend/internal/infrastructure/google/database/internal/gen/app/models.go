@@ -17,6 +17,31 @@ type Action struct {
 	CreatedAt  pgtype.Timestamptz
 }
 
+type AgentCheckpoint struct {
+	ID           pgtype.UUID
+	ProblemID    pgtype.UUID
+	Step         int32
+	State        []byte
+	FailCount    int32
+	ElapsedMs    int64
+	CreatedAt    pgtype.Timestamptz
+	RunStartedAt pgtype.Timestamptz
+}
+
+type Approval struct {
+	ID         pgtype.UUID
+	ProblemID  pgtype.UUID
+	ActionType string
+	Step       int32
+	Output     string
+	Decision   string
+	Feedback   string
+	Goal       pgtype.Text
+	CreatedAt  pgtype.Timestamptz
+	DecidedAt  pgtype.Timestamptz
+	AppliedAt  pgtype.Timestamptz
+}
+
 type Document struct {
 	ID             pgtype.UUID
 	Title          string
@@ -27,6 +52,17 @@ type Document struct {
 	RetryCount     int32
 	CreatedAt      pgtype.Timestamptz
 	UpdatedAt      pgtype.Timestamptz
+	Collection     string
+}
+
+type GoalRevision struct {
+	ID         pgtype.UUID
+	ProblemID  pgtype.UUID
+	Version    int32
+	Goal       string
+	Reason     string
+	ActionType string
+	CreatedAt  pgtype.Timestamptz
 }
 
 type Hearing struct {
@@ -52,17 +88,38 @@ type HearingMessage struct {
 }
 
 type JobConfig struct {
-	ID                   string
-	ProblemID            string
-	EnableInternalSearch bool
+	ID                       string
+	ProblemID                string
+	EnableInternalSearch     bool
+	Models                   []byte
+	BudgetMaxTokens          int32
+	BudgetMaxCost            float64
+	BudgetMaxDurationSeconds int32
+	ApprovalGates            []string
+	Workflow                 string
+	AllowedCollections       []string
+	AllowedDocumentIds       []string
+}
+
+type LlmUsage struct {
+	ID           pgtype.UUID
+	ProblemID    pgtype.UUID
+	ActionType   string
+	Provider     string
+	Model        string
+	InputTokens  int32
+	OutputTokens int32
+	TotalTokens  int32
+	CreatedAt    pgtype.Timestamptz
 }
 
 type Problem struct {
-	ID          pgtype.UUID
-	Title       string
-	Description string
-	Status      string
-	CreatedAt   pgtype.Timestamptz
+	ID                pgtype.UUID
+	Title             string
+	Description       string
+	Status            string
+	CreatedAt         pgtype.Timestamptz
+	CancelRequestedAt pgtype.Timestamptz
 }
 
 type ProblemField struct {
@@ -79,3 +136,16 @@ type Report struct {
 	Content   string
 	CreatedAt pgtype.Timestamptz
 }
+
+type TraceSpan struct {
+	ID         pgtype.UUID
+	ProblemID  pgtype.UUID
+	ParentID   pgtype.UUID
+	Name       string
+	Kind       string
+	Status     string
+	Error      string
+	Attributes []byte
+	StartedAt  pgtype.Timestamptz
+	EndedAt    pgtype.Timestamptz
+}
@@ -11,6 +11,18 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const clearProblemCancelRequest = `-- name: ClearProblemCancelRequest :execrows
+UPDATE problems SET cancel_requested_at = NULL WHERE id = $1
+`
+
+func (q *Queries) ClearProblemCancelRequest(ctx context.Context, id pgtype.UUID) (int64, error) {
+	result, err := q.db.Exec(ctx, clearProblemCancelRequest, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const createProblem = `-- name: CreateProblem :exec
 INSERT INTO problems (id, title, description, status) VALUES ($1, $2, $3, $4)
 `
@@ -45,7 +57,7 @@ func (q *Queries) DeleteProblem(ctx context.Context, id pgtype.UUID) (int64, err
 }
 
 const getAllProblems = `-- name: GetAllProblems :many
-SELECT id, title, description, status, created_at FROM problems ORDER BY created_at DESC
+SELECT id, title, description, status, created_at, cancel_requested_at FROM problems ORDER BY created_at DESC
 `
 
 func (q *Queries) GetAllProblems(ctx context.Context) ([]Problem, error) {
@@ -63,6 +75,7 @@ func (q *Queries) GetAllProblems(ctx context.Context) ([]Problem, error) {
 			&i.Description,
 			&i.Status,
 			&i.CreatedAt,
+			&i.CancelRequestedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -75,7 +88,7 @@ func (q *Queries) GetAllProblems(ctx context.Context) ([]Problem, error) {
 }
 
 const getProblemById = `-- name: GetProblemById :one
-SELECT id, title, description, status, created_at FROM problems WHERE id = $1
+SELECT id, title, description, status, created_at, cancel_requested_at FROM problems WHERE id = $1
 `
 
 func (q *Queries) GetProblemById(ctx context.Context, id pgtype.UUID) (Problem, error) {
@@ -87,10 +100,52 @@ func (q *Queries) GetProblemById(ctx context.Context, id pgtype.UUID) (Problem,
 		&i.Description,
 		&i.Status,
 		&i.CreatedAt,
+		&i.CancelRequestedAt,
 	)
 	return i, err
 }
 
+const isProblemCancelRequested = `-- name: IsProblemCancelRequested :one
+SELECT (cancel_requested_at IS NOT NULL)::boolean AS cancel_requested FROM problems WHERE id = $1
+`
+
+func (q *Queries) IsProblemCancelRequested(ctx context.Context, id pgtype.UUID) (bool, error) {
+	row := q.db.QueryRow(ctx, isProblemCancelRequested, id)
+	var cancel_requested bool
+	err := row.Scan(&cancel_requested)
+	return cancel_requested, err
+}
+
+const requestProblemCancel = `-- name: RequestProblemCancel :execrows
+UPDATE problems SET cancel_requested_at = CURRENT_TIMESTAMP WHERE id = $1
+`
+
+func (q *Queries) RequestProblemCancel(ctx context.Context, id pgtype.UUID) (int64, error) {
+	result, err := q.db.Exec(ctx, requestProblemCancel, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const transitionProblemStatus = `-- name: TransitionProblemStatus :execrows
+UPDATE problems SET status = $1 WHERE id = $2 AND status = $3
+`
+
+type TransitionProblemStatusParams struct {
+	ToStatus   string
+	ID         pgtype.UUID
+	FromStatus string
+}
+
+func (q *Queries) TransitionProblemStatus(ctx context.Context, arg TransitionProblemStatusParams) (int64, error) {
+	result, err := q.db.Exec(ctx, transitionProblemStatus, arg.ToStatus, arg.ID, arg.FromStatus)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const updateProblemStatus = `-- name: UpdateProblemStatus :execrows
 UPDATE problems SET status = $2 WHERE id = $1
 `
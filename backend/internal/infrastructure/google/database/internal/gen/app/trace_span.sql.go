@@ -0,0 +1,80 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: trace_span.sql
+
+package app
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const createTraceSpan = `-- name: CreateTraceSpan :exec
+INSERT INTO trace_spans (id, problem_id, parent_id, name, kind, status, error, attributes, started_at, ended_at) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+`
+
+type CreateTraceSpanParams struct {
+	ID         pgtype.UUID
+	ProblemID  pgtype.UUID
+	ParentID   pgtype.UUID
+	Name       string
+	Kind       string
+	Status     string
+	Error      string
+	Attributes []byte
+	StartedAt  pgtype.Timestamptz
+	EndedAt    pgtype.Timestamptz
+}
+
+func (q *Queries) CreateTraceSpan(ctx context.Context, arg CreateTraceSpanParams) error {
+	_, err := q.db.Exec(ctx, createTraceSpan,
+		arg.ID,
+		arg.ProblemID,
+		arg.ParentID,
+		arg.Name,
+		arg.Kind,
+		arg.Status,
+		arg.Error,
+		arg.Attributes,
+		arg.StartedAt,
+		arg.EndedAt,
+	)
+	return err
+}
+
+const listTraceSpansByProblemID = `-- name: ListTraceSpansByProblemID :many
+SELECT id, problem_id, parent_id, name, kind, status, error, attributes, started_at, ended_at FROM trace_spans WHERE problem_id = $1 ORDER BY started_at ASC
+`
+
+func (q *Queries) ListTraceSpansByProblemID(ctx context.Context, problemID pgtype.UUID) ([]TraceSpan, error) {
+	rows, err := q.db.Query(ctx, listTraceSpansByProblemID, problemID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []TraceSpan
+	for rows.Next() {
+		var i TraceSpan
+		if err := rows.Scan(
+			&i.ID,
+			&i.ProblemID,
+			&i.ParentID,
+			&i.Name,
+			&i.Kind,
+			&i.Status,
+			&i.Error,
+			&i.Attributes,
+			&i.StartedAt,
+			&i.EndedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -15,4 +15,6 @@ type Vector struct {
 	Content       string
 	ParentContent string
 	Embedding     pgvector.Vector
+	ContentTsv    interface{}
+	ChunkIndex    int32
 }
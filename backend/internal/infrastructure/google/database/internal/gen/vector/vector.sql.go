@@ -13,12 +13,13 @@ import (
 )
 
 const createVector = `-- name: CreateVector :exec
-INSERT INTO vectors (id, document_id, content, parent_content, embedding) VALUES ($1, $2, $3, $4, $5)
+INSERT INTO vectors (id, document_id, chunk_index, content, parent_content, embedding) VALUES ($1, $2, $3, $4, $5, $6)
 `
 
 type CreateVectorParams struct {
 	ID            pgtype.UUID
 	DocumentID    pgtype.UUID
+	ChunkIndex    int32
 	Content       string
 	ParentContent string
 	Embedding     pgvector.Vector
@@ -28,6 +29,7 @@ func (q *Queries) CreateVector(ctx context.Context, arg CreateVectorParams) erro
 	_, err := q.db.Exec(ctx, createVector,
 		arg.ID,
 		arg.DocumentID,
+		arg.ChunkIndex,
 		arg.Content,
 		arg.ParentContent,
 		arg.Embedding,
@@ -47,25 +49,143 @@ func (q *Queries) DeleteVector(ctx context.Context, documentID pgtype.UUID) (int
 	return result.RowsAffected(), nil
 }
 
+const searchFullText = `-- name: SearchFullText :many
+SELECT id, document_id, chunk_index, content, parent_content, ts_rank_cd(content_tsv, bigram_tsquery($1::text))::float8 AS rank FROM vectors WHERE content_tsv @@ bigram_tsquery($1::text) AND (NOT $2::bool OR document_id = ANY($3::uuid[])) ORDER BY rank DESC LIMIT $4
+`
+
+type SearchFullTextParams struct {
+	Query             string
+	RestrictDocuments bool
+	DocumentIds       []pgtype.UUID
+	MaxNumResults     int32
+}
+
+type SearchFullTextRow struct {
+	ID            pgtype.UUID
+	DocumentID    pgtype.UUID
+	ChunkIndex    int32
+	Content       string
+	ParentContent string
+	Rank          float64
+}
+
+func (q *Queries) SearchFullText(ctx context.Context, arg SearchFullTextParams) ([]SearchFullTextRow, error) {
+	rows, err := q.db.Query(ctx, searchFullText,
+		arg.Query,
+		arg.RestrictDocuments,
+		arg.DocumentIds,
+		arg.MaxNumResults,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []SearchFullTextRow
+	for rows.Next() {
+		var i SearchFullTextRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.DocumentID,
+			&i.ChunkIndex,
+			&i.Content,
+			&i.ParentContent,
+			&i.Rank,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const searchFullTextWithSimilarity = `-- name: SearchFullTextWithSimilarity :many
+SELECT id, document_id, chunk_index, content, parent_content, ts_rank_cd(content_tsv, bigram_tsquery($1::text))::float8 AS rank, (1 - (embedding <=> $2::vector))::float8 AS similarity FROM vectors WHERE content_tsv @@ bigram_tsquery($1::text) AND (NOT $3::bool OR document_id = ANY($4::uuid[])) ORDER BY rank DESC LIMIT $5
+`
+
+type SearchFullTextWithSimilarityParams struct {
+	Query             string
+	Embedding         pgvector.Vector
+	RestrictDocuments bool
+	DocumentIds       []pgtype.UUID
+	MaxNumResults     int32
+}
+
+type SearchFullTextWithSimilarityRow struct {
+	ID            pgtype.UUID
+	DocumentID    pgtype.UUID
+	ChunkIndex    int32
+	Content       string
+	ParentContent string
+	Rank          float64
+	Similarity    float64
+}
+
+func (q *Queries) SearchFullTextWithSimilarity(ctx context.Context, arg SearchFullTextWithSimilarityParams) ([]SearchFullTextWithSimilarityRow, error) {
+	rows, err := q.db.Query(ctx, searchFullTextWithSimilarity,
+		arg.Query,
+		arg.Embedding,
+		arg.RestrictDocuments,
+		arg.DocumentIds,
+		arg.MaxNumResults,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []SearchFullTextWithSimilarityRow
+	for rows.Next() {
+		var i SearchFullTextWithSimilarityRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.DocumentID,
+			&i.ChunkIndex,
+			&i.Content,
+			&i.ParentContent,
+			&i.Rank,
+			&i.Similarity,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const searchVector = `-- name: SearchVector :many
-SELECT id, document_id, content, parent_content, (1 - (embedding <=> $1))::float8 AS similarity FROM vectors ORDER BY similarity DESC LIMIT $2
+SELECT id, document_id, chunk_index, content, parent_content, (1 - (embedding <=> $1::vector))::float8 AS similarity FROM vectors WHERE (NOT $2::bool OR document_id = ANY($3::uuid[])) AND ($4::float8 IS NULL OR 1 - (embedding <=> $1::vector) >= $4::float8) ORDER BY similarity DESC LIMIT $5
 `
 
 type SearchVectorParams struct {
-	Embedding pgvector.Vector
-	Limit     int32
+	Embedding         pgvector.Vector
+	RestrictDocuments bool
+	DocumentIds       []pgtype.UUID
+	MinSimilarity     pgtype.Float8
+	MaxNumResults     int32
 }
 
 type SearchVectorRow struct {
 	ID            pgtype.UUID
 	DocumentID    pgtype.UUID
+	ChunkIndex    int32
 	Content       string
 	ParentContent string
 	Similarity    float64
 }
 
 func (q *Queries) SearchVector(ctx context.Context, arg SearchVectorParams) ([]SearchVectorRow, error) {
-	rows, err := q.db.Query(ctx, searchVector, arg.Embedding, arg.Limit)
+	rows, err := q.db.Query(ctx, searchVector,
+		arg.Embedding,
+		arg.RestrictDocuments,
+		arg.DocumentIds,
+		arg.MinSimilarity,
+		arg.MaxNumResults,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -76,6 +196,7 @@ func (q *Queries) SearchVector(ctx context.Context, arg SearchVectorParams) ([]S
 		if err := rows.Scan(
 			&i.ID,
 			&i.DocumentID,
+			&i.ChunkIndex,
 			&i.Content,
 			&i.ParentContent,
 			&i.Similarity,
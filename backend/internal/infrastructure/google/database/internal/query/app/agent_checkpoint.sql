@@ -0,0 +1,9 @@
+-- name: UpsertAgentCheckpoint :exec
+INSERT INTO agent_checkpoints (id, problem_id, step, state, fail_count, elapsed_ms, run_started_at) VALUES ($1, $2, $3, $4, $5, $6, $7)
+ON CONFLICT (problem_id, step) DO UPDATE SET state = EXCLUDED.state, fail_count = EXCLUDED.fail_count, elapsed_ms = EXCLUDED.elapsed_ms, run_started_at = EXCLUDED.run_started_at, created_at = CURRENT_TIMESTAMP;
+
+-- name: GetLatestAgentCheckpointByProblemID :one
+SELECT * FROM agent_checkpoints WHERE problem_id = $1 ORDER BY step DESC LIMIT 1;
+
+-- name: DeleteAgentCheckpointsByProblemID :execrows
+DELETE FROM agent_checkpoints WHERE problem_id = $1;
@@ -0,0 +1,23 @@
+-- name: GetApprovalByID :one
+SELECT * FROM approvals WHERE id = $1;
+
+-- name: GetLatestApprovalByProblemID :one
+SELECT * FROM approvals WHERE problem_id = $1 ORDER BY created_at DESC LIMIT 1;
+
+-- name: ListPendingApprovals :many
+SELECT * FROM approvals WHERE decision = 'pending' ORDER BY created_at ASC;
+
+-- name: CreateApproval :exec
+INSERT INTO approvals (id, problem_id, action_type, step, output) VALUES ($1, $2, $3, $4, $5);
+
+-- name: DecideApproval :execrows
+UPDATE approvals SET decision = $2, feedback = $3, goal = $4, decided_at = CURRENT_TIMESTAMP WHERE id = $1 AND decision = 'pending';
+
+-- name: MarkApprovalApplied :exec
+UPDATE approvals SET applied_at = CURRENT_TIMESTAMP WHERE id = $1;
+
+-- name: ReopenApproval :exec
+UPDATE approvals SET decision = 'pending', feedback = '', goal = NULL, decided_at = NULL WHERE id = $1 AND applied_at IS NULL;
+
+-- name: ExpirePendingApprovalsByProblemID :exec
+UPDATE approvals SET decision = 'expired', decided_at = CURRENT_TIMESTAMP WHERE problem_id = $1 AND decision = 'pending';
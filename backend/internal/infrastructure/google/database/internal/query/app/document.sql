@@ -1,17 +1,23 @@
 -- name: GetDocument :one
 SELECT * FROM documents WHERE id = $1;
 
+-- name: GetDocumentsByIDs :many
+SELECT * FROM documents WHERE id = ANY(sqlc.arg(ids)::uuid[]);
+
 -- name: GetAllDocuments :many
 SELECT * FROM documents ORDER BY created_at DESC;
 
 -- name: GetDocumentByTitle :one
 SELECT * FROM documents WHERE title = $1;
 
+-- name: GetDocumentIDsByCollections :many
+SELECT id FROM documents WHERE collection = ANY(sqlc.arg(collections)::text[]);
+
 -- name: CreateDocument :exec
-INSERT INTO documents (id, title, document_type, bucket_name, object_name, document_status, retry_count) VALUES ($1, $2, $3, $4, $5, $6, $7);
+INSERT INTO documents (id, title, document_type, bucket_name, object_name, document_status, retry_count, collection) VALUES ($1, $2, $3, $4, $5, $6, $7, $8);
 
 -- name: UpdateDocument :execrows
-UPDATE documents SET title = $2, document_type = $3, bucket_name = $4, object_name = $5, document_status = $6, retry_count = $7 WHERE id = $1;
+UPDATE documents SET title = $2, document_type = $3, bucket_name = $4, object_name = $5, document_status = $6, retry_count = $7, collection = $8 WHERE id = $1;
 
 -- name: DeleteDocument :execrows
 DELETE FROM documents WHERE id = $1;
\ No newline at end of file
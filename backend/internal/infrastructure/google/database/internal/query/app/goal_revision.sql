@@ -0,0 +1,6 @@
+-- name: UpsertGoalRevision :exec
+INSERT INTO goal_revisions (id, problem_id, version, goal, reason, action_type) VALUES ($1, $2, $3, $4, $5, $6)
+ON CONFLICT (problem_id, version) DO UPDATE SET goal = EXCLUDED.goal, reason = EXCLUDED.reason, action_type = EXCLUDED.action_type, created_at = CURRENT_TIMESTAMP;
+
+-- name: ListGoalRevisionsByProblemID :many
+SELECT * FROM goal_revisions WHERE problem_id = $1 ORDER BY version ASC;
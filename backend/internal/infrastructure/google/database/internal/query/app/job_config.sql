@@ -2,10 +2,10 @@
 SELECT * FROM job_configs WHERE problem_id = $1;
 
 -- name: CreateJobConfig :exec
-INSERT INTO job_configs (id, problem_id, enable_internal_search) VALUES ($1, $2, $3);
+INSERT INTO job_configs (id, problem_id, enable_internal_search, models, budget_max_tokens, budget_max_cost, budget_max_duration_seconds, approval_gates, workflow, allowed_collections, allowed_document_ids) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11);
 
 -- name: UpdateJobConfig :exec
-UPDATE job_configs SET enable_internal_search = $2 WHERE id = $1;
+UPDATE job_configs SET enable_internal_search = $2, models = $3, budget_max_tokens = $4, budget_max_cost = $5, budget_max_duration_seconds = $6, approval_gates = $7, workflow = $8, allowed_collections = $9, allowed_document_ids = $10 WHERE id = $1;
 
 -- name: DeleteJobConfigByProblemID :execrows
 DELETE FROM job_configs WHERE problem_id = $1;
@@ -0,0 +1,8 @@
+-- name: CreateLLMUsage :exec
+INSERT INTO llm_usages (id, problem_id, action_type, provider, model, input_tokens, output_tokens, total_tokens) VALUES ($1, $2, $3, $4, $5, $6, $7, $8);
+
+-- name: GetLLMUsagesByProblemID :many
+SELECT * FROM llm_usages WHERE problem_id = $1 ORDER BY created_at ASC;
+
+-- name: GetLLMUsagesByProblemIDSince :many
+SELECT * FROM llm_usages WHERE problem_id = sqlc.arg(problem_id) AND created_at >= sqlc.arg(since) ORDER BY created_at ASC;
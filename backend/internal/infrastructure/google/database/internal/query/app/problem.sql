@@ -11,4 +11,16 @@ SELECT * FROM problems WHERE id = $1;
 SELECT * FROM problems ORDER BY created_at DESC;
 
 -- name: UpdateProblemStatus :execrows
-UPDATE problems SET status = $2 WHERE id = $1;
\ No newline at end of file
+UPDATE problems SET status = $2 WHERE id = $1;
+
+-- name: TransitionProblemStatus :execrows
+UPDATE problems SET status = sqlc.arg(to_status) WHERE id = sqlc.arg(id) AND status = sqlc.arg(from_status);
+
+-- name: RequestProblemCancel :execrows
+UPDATE problems SET cancel_requested_at = CURRENT_TIMESTAMP WHERE id = $1;
+
+-- name: ClearProblemCancelRequest :execrows
+UPDATE problems SET cancel_requested_at = NULL WHERE id = $1;
+
+-- name: IsProblemCancelRequested :one
+SELECT (cancel_requested_at IS NOT NULL)::boolean AS cancel_requested FROM problems WHERE id = $1;
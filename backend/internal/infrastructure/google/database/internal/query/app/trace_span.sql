@@ -0,0 +1,5 @@
+-- name: CreateTraceSpan :exec
+INSERT INTO trace_spans (id, problem_id, parent_id, name, kind, status, error, attributes, started_at, ended_at) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10);
+
+-- name: ListTraceSpansByProblemID :many
+SELECT * FROM trace_spans WHERE problem_id = $1 ORDER BY started_at ASC;
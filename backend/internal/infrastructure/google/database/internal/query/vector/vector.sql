@@ -1,8 +1,14 @@
 -- name: CreateVector :exec
-INSERT INTO vectors (id, document_id, content, parent_content, embedding) VALUES ($1, $2, $3, $4, $5);
+INSERT INTO vectors (id, document_id, chunk_index, content, parent_content, embedding) VALUES ($1, $2, $3, $4, $5, $6);
 
 -- name: SearchVector :many
-SELECT id, document_id, content, parent_content, (1 - (embedding <=> $1))::float8 AS similarity FROM vectors ORDER BY similarity DESC LIMIT $2;
+SELECT id, document_id, chunk_index, content, parent_content, (1 - (embedding <=> sqlc.arg(embedding)::vector))::float8 AS similarity FROM vectors WHERE (NOT sqlc.arg(restrict_documents)::bool OR document_id = ANY(sqlc.arg(document_ids)::uuid[])) AND (sqlc.narg(min_similarity)::float8 IS NULL OR 1 - (embedding <=> sqlc.arg(embedding)::vector) >= sqlc.narg(min_similarity)::float8) ORDER BY similarity DESC LIMIT sqlc.arg(max_num_results);
+
+-- name: SearchFullText :many
+SELECT id, document_id, chunk_index, content, parent_content, ts_rank_cd(content_tsv, bigram_tsquery(sqlc.arg(query)::text))::float8 AS rank FROM vectors WHERE content_tsv @@ bigram_tsquery(sqlc.arg(query)::text) AND (NOT sqlc.arg(restrict_documents)::bool OR document_id = ANY(sqlc.arg(document_ids)::uuid[])) ORDER BY rank DESC LIMIT sqlc.arg(max_num_results);
+
+-- name: SearchFullTextWithSimilarity :many
+SELECT id, document_id, chunk_index, content, parent_content, ts_rank_cd(content_tsv, bigram_tsquery(sqlc.arg(query)::text))::float8 AS rank, (1 - (embedding <=> sqlc.arg(embedding)::vector))::float8 AS similarity FROM vectors WHERE content_tsv @@ bigram_tsquery(sqlc.arg(query)::text) AND (NOT sqlc.arg(restrict_documents)::bool OR document_id = ANY(sqlc.arg(document_ids)::uuid[])) ORDER BY rank DESC LIMIT sqlc.arg(max_num_results);
 
 -- name: DeleteVector :execrows
-DELETE FROM vectors WHERE document_id = $1;
\ No newline at end of file
+DELETE FROM vectors WHERE document_id = $1;
@@ -0,0 +1,267 @@
+package approval
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	actionValue "github.com/goda6565/ai-consultant/backend/internal/domain/action/value"
+	agentValue "github.com/goda6565/ai-consultant/backend/internal/domain/agent/value"
+	approvalEntity "github.com/goda6565/ai-consultant/backend/internal/domain/approval/entity"
+	approvalRepository "github.com/goda6565/ai-consultant/backend/internal/domain/approval/repository"
+	approvalValue "github.com/goda6565/ai-consultant/backend/internal/domain/approval/value"
+	sharedValue "github.com/goda6565/ai-consultant/backend/internal/domain/shared/value"
+	"github.com/goda6565/ai-consultant/backend/internal/infrastructure/errors"
+	"github.com/goda6565/ai-consultant/backend/internal/infrastructure/google/database"
+	"github.com/goda6565/ai-consultant/backend/internal/infrastructure/google/database/internal/gen/app"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type ApprovalRepository struct {
+	tx   pgx.Tx
+	pool *database.AppPool
+}
+
+func NewApprovalRepository(pool *database.AppPool) approvalRepository.ApprovalRepository {
+	return &ApprovalRepository{tx: nil, pool: pool}
+}
+
+func (r *ApprovalRepository) WithTx(tx pgx.Tx) *ApprovalRepository {
+	return &ApprovalRepository{tx: tx, pool: r.pool}
+}
+
+func (r *ApprovalRepository) FindByID(ctx context.Context, id sharedValue.ID) (*approvalEntity.Approval, error) {
+	var q *app.Queries
+	if r.tx != nil {
+		q = app.New(r.pool).WithTx(r.tx)
+	} else {
+		q = app.New(r.pool)
+	}
+
+	var approvalID pgtype.UUID
+	if err := approvalID.Scan(id.Value()); err != nil {
+		return nil, errors.NewInfrastructureError(errors.ExternalServiceError, fmt.Sprintf("failed to scan id: %v", err))
+	}
+
+	approval, err := q.GetApprovalByID(ctx, approvalID)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil
+		}
+		return nil, errors.NewInfrastructureError(errors.ExternalServiceError, fmt.Sprintf("failed to get approval by id: %v", err))
+	}
+
+	return toEntity(approval)
+}
+
+func (r *ApprovalRepository) FindLatestByProblemID(ctx context.Context, problemID sharedValue.ID) (*approvalEntity.Approval, error) {
+	var q *app.Queries
+	if r.tx != nil {
+		q = app.New(r.pool).WithTx(r.tx)
+	} else {
+		q = app.New(r.pool)
+	}
+
+	var pID pgtype.UUID
+	if err := pID.Scan(problemID.Value()); err != nil {
+		return nil, errors.NewInfrastructureError(errors.ExternalServiceError, fmt.Sprintf("failed to scan problem id: %v", err))
+	}
+
+	approval, err := q.GetLatestApprovalByProblemID(ctx, pID)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil
+		}
+		return nil, errors.NewInfrastructureError(errors.ExternalServiceError, fmt.Sprintf("failed to get latest approval by problem id: %v", err))
+	}
+
+	return toEntity(approval)
+}
+
+func (r *ApprovalRepository) FindPending(ctx context.Context) ([]approvalEntity.Approval, error) {
+	var q *app.Queries
+	if r.tx != nil {
+		q = app.New(r.pool).WithTx(r.tx)
+	} else {
+		q = app.New(r.pool)
+	}
+
+	approvals, err := q.ListPendingApprovals(ctx)
+	if err != nil {
+		return nil, errors.NewInfrastructureError(errors.ExternalServiceError, fmt.Sprintf("failed to list pending approvals: %v", err))
+	}
+
+	entities := make([]approvalEntity.Approval, 0, len(approvals))
+	for _, approval := range approvals {
+		entity, err := toEntity(approval)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert approval to entity: %w", err)
+		}
+		entities = append(entities, *entity)
+	}
+
+	return entities, nil
+}
+
+func (r *ApprovalRepository) Create(ctx context.Context, approval *approvalEntity.Approval) error {
+	var q *app.Queries
+	if r.tx != nil {
+		q = app.New(r.pool).WithTx(r.tx)
+	} else {
+		q = app.New(r.pool)
+	}
+
+	var id pgtype.UUID
+	if err := id.Scan(approval.GetID().Value()); err != nil {
+		return errors.NewInfrastructureError(errors.ExternalServiceError, fmt.Sprintf("failed to scan id: %v", err))
+	}
+
+	var problemID pgtype.UUID
+	if err := problemID.Scan(approval.GetProblemID().Value()); err != nil {
+		return errors.NewInfrastructureError(errors.ExternalServiceError, fmt.Sprintf("failed to scan problem id: %v", err))
+	}
+
+	err := q.CreateApproval(ctx, app.CreateApprovalParams{
+		ID:         id,
+		ProblemID:  problemID,
+		ActionType: approval.GetActionType().Value(),
+		Step:       int32(approval.GetStep()),
+		Output:     approval.GetOutput(),
+	})
+	if err != nil {
+		return errors.NewInfrastructureError(errors.ExternalServiceError, fmt.Sprintf("failed to create approval: %v", err))
+	}
+
+	return nil
+}
+
+func (r *ApprovalRepository) Decide(ctx context.Context, approval *approvalEntity.Approval) (bool, error) {
+	var q *app.Queries
+	if r.tx != nil {
+		q = app.New(r.pool).WithTx(r.tx)
+	} else {
+		q = app.New(r.pool)
+	}
+
+	var id pgtype.UUID
+	if err := id.Scan(approval.GetID().Value()); err != nil {
+		return false, errors.NewInfrastructureError(errors.ExternalServiceError, fmt.Sprintf("failed to scan id: %v", err))
+	}
+
+	var goal pgtype.Text
+	if approval.GetGoal() != nil {
+		goal = pgtype.Text{String: approval.GetGoal().Value(), Valid: true}
+	}
+
+	numUpdated, err := q.DecideApproval(ctx, app.DecideApprovalParams{
+		ID:       id,
+		Decision: approval.GetDecision().Value(),
+		Feedback: approval.GetFeedback(),
+		Goal:     goal,
+	})
+	if err != nil {
+		return false, errors.NewInfrastructureError(errors.ExternalServiceError, fmt.Sprintf("failed to decide approval: %v", err))
+	}
+
+	return numUpdated > 0, nil
+}
+
+func (r *ApprovalRepository) MarkApplied(ctx context.Context, id sharedValue.ID) error {
+	var q *app.Queries
+	if r.tx != nil {
+		q = app.New(r.pool).WithTx(r.tx)
+	} else {
+		q = app.New(r.pool)
+	}
+
+	var approvalID pgtype.UUID
+	if err := approvalID.Scan(id.Value()); err != nil {
+		return errors.NewInfrastructureError(errors.ExternalServiceError, fmt.Sprintf("failed to scan id: %v", err))
+	}
+
+	err := q.MarkApprovalApplied(ctx, approvalID)
+	if err != nil {
+		return errors.NewInfrastructureError(errors.ExternalServiceError, fmt.Sprintf("failed to mark approval applied: %v", err))
+	}
+
+	return nil
+}
+
+func (r *ApprovalRepository) Reopen(ctx context.Context, id sharedValue.ID) error {
+	var q *app.Queries
+	if r.tx != nil {
+		q = app.New(r.pool).WithTx(r.tx)
+	} else {
+		q = app.New(r.pool)
+	}
+
+	var approvalID pgtype.UUID
+	if err := approvalID.Scan(id.Value()); err != nil {
+		return errors.NewInfrastructureError(errors.ExternalServiceError, fmt.Sprintf("failed to scan id: %v", err))
+	}
+
+	err := q.ReopenApproval(ctx, approvalID)
+	if err != nil {
+		return errors.NewInfrastructureError(errors.ExternalServiceError, fmt.Sprintf("failed to reopen approval: %v", err))
+	}
+
+	return nil
+}
+
+func (r *ApprovalRepository) ExpirePendingByProblemID(ctx context.Context, problemID sharedValue.ID) error {
+	var q *app.Queries
+	if r.tx != nil {
+		q = app.New(r.pool).WithTx(r.tx)
+	} else {
+		q = app.New(r.pool)
+	}
+
+	var pID pgtype.UUID
+	if err := pID.Scan(problemID.Value()); err != nil {
+		return errors.NewInfrastructureError(errors.ExternalServiceError, fmt.Sprintf("failed to scan problem id: %v", err))
+	}
+
+	err := q.ExpirePendingApprovalsByProblemID(ctx, pID)
+	if err != nil {
+		return errors.NewInfrastructureError(errors.ExternalServiceError, fmt.Sprintf("failed to expire pending approvals: %v", err))
+	}
+
+	return nil
+}
+
+func toEntity(approval app.Approval) (*approvalEntity.Approval, error) {
+	id, err := sharedValue.NewID(approval.ID.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to create id: %w", err)
+	}
+
+	problemID, err := sharedValue.NewID(approval.ProblemID.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to create problem id: %w", err)
+	}
+
+	actionType, err := actionValue.NewActionType(approval.ActionType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create action type: %w", err)
+	}
+
+	decision, err := approvalValue.NewDecision(approval.Decision)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create decision: %w", err)
+	}
+
+	var goal *agentValue.Goal
+	if approval.Goal.Valid {
+		goal = agentValue.NewGoal(approval.Goal.String)
+	}
+
+	return approvalEntity.NewApproval(id, problemID, actionType, int(approval.Step), approval.Output, decision, approval.Feedback, goal, toTime(approval.CreatedAt), toTime(approval.DecidedAt), toTime(approval.AppliedAt)), nil
+}
+
+func toTime(t pgtype.Timestamptz) *time.Time {
+	if !t.Valid {
+		return nil
+	}
+	return &t.Time
+}
@@ -0,0 +1,7 @@
+package approval
+
+import "github.com/google/wire"
+
+var Set = wire.NewSet(
+	NewApprovalRepository,
+)
@@ -0,0 +1,145 @@
+package checkpoint
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/goda6565/ai-consultant/backend/internal/domain/agent/state"
+	checkpointEntity "github.com/goda6565/ai-consultant/backend/internal/domain/checkpoint/entity"
+	checkpointRepository "github.com/goda6565/ai-consultant/backend/internal/domain/checkpoint/repository"
+	sharedValue "github.com/goda6565/ai-consultant/backend/internal/domain/shared/value"
+	"github.com/goda6565/ai-consultant/backend/internal/infrastructure/errors"
+	"github.com/goda6565/ai-consultant/backend/internal/infrastructure/google/database"
+	"github.com/goda6565/ai-consultant/backend/internal/infrastructure/google/database/internal/gen/app"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type CheckpointRepository struct {
+	tx   pgx.Tx
+	pool *database.AppPool
+}
+
+func NewCheckpointRepository(pool *database.AppPool) checkpointRepository.CheckpointRepository {
+	return &CheckpointRepository{tx: nil, pool: pool}
+}
+
+func (r *CheckpointRepository) WithTx(tx pgx.Tx) *CheckpointRepository {
+	return &CheckpointRepository{tx: tx, pool: r.pool}
+}
+
+func (r *CheckpointRepository) FindLatestByProblemID(ctx context.Context, problemID sharedValue.ID) (*checkpointEntity.Checkpoint, error) {
+	var q *app.Queries
+	if r.tx != nil {
+		q = app.New(r.pool).WithTx(r.tx)
+	} else {
+		q = app.New(r.pool)
+	}
+
+	var pID pgtype.UUID
+	if err := pID.Scan(problemID.Value()); err != nil {
+		return nil, errors.NewInfrastructureError(errors.ExternalServiceError, fmt.Sprintf("failed to scan problem id: %v", err))
+	}
+
+	checkpoint, err := q.GetLatestAgentCheckpointByProblemID(ctx, pID)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil
+		}
+		return nil, errors.NewInfrastructureError(errors.ExternalServiceError, fmt.Sprintf("failed to get latest checkpoint by problem id: %v", err))
+	}
+
+	entity, err := toEntity(checkpoint)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert checkpoint to entity: %w", err)
+	}
+
+	return entity, nil
+}
+
+func (r *CheckpointRepository) Save(ctx context.Context, checkpoint *checkpointEntity.Checkpoint) error {
+	var q *app.Queries
+	if r.tx != nil {
+		q = app.New(r.pool).WithTx(r.tx)
+	} else {
+		q = app.New(r.pool)
+	}
+
+	var id pgtype.UUID
+	if err := id.Scan(checkpoint.GetID().Value()); err != nil {
+		return errors.NewInfrastructureError(errors.ExternalServiceError, fmt.Sprintf("failed to scan id: %v", err))
+	}
+
+	var problemID pgtype.UUID
+	if err := problemID.Scan(checkpoint.GetProblemID().Value()); err != nil {
+		return errors.NewInfrastructureError(errors.ExternalServiceError, fmt.Sprintf("failed to scan problem id: %v", err))
+	}
+
+	snapshot, err := json.Marshal(checkpoint.GetSnapshot())
+	if err != nil {
+		return errors.NewInfrastructureError(errors.InternalError, fmt.Sprintf("failed to marshal state: %v", err))
+	}
+
+	err = q.UpsertAgentCheckpoint(ctx, app.UpsertAgentCheckpointParams{
+		ID:           id,
+		ProblemID:    problemID,
+		Step:         int32(checkpoint.GetStep()),
+		State:        snapshot,
+		FailCount:    int32(checkpoint.GetFailCount()),
+		ElapsedMs:    checkpoint.GetElapsed().Milliseconds(),
+		RunStartedAt: pgtype.Timestamptz{Time: checkpoint.GetRunStartedAt(), Valid: true},
+	})
+	if err != nil {
+		return errors.NewInfrastructureError(errors.ExternalServiceError, fmt.Sprintf("failed to save checkpoint: %v", err))
+	}
+
+	return nil
+}
+
+func (r *CheckpointRepository) DeleteByProblemID(ctx context.Context, problemID sharedValue.ID) (numDeleted int64, err error) {
+	var q *app.Queries
+	if r.tx != nil {
+		q = app.New(r.pool).WithTx(r.tx)
+	} else {
+		q = app.New(r.pool)
+	}
+
+	var pID pgtype.UUID
+	if err := pID.Scan(problemID.Value()); err != nil {
+		return 0, errors.NewInfrastructureError(errors.ExternalServiceError, fmt.Sprintf("failed to scan problem id: %v", err))
+	}
+
+	numDeleted, err = q.DeleteAgentCheckpointsByProblemID(ctx, pID)
+	if err != nil {
+		return 0, errors.NewInfrastructureError(errors.ExternalServiceError, fmt.Sprintf("failed to delete checkpoints by problem id: %v", err))
+	}
+
+	return numDeleted, nil
+}
+
+func toEntity(checkpoint app.AgentCheckpoint) (*checkpointEntity.Checkpoint, error) {
+	id, err := sharedValue.NewID(checkpoint.ID.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to create id: %w", err)
+	}
+
+	problemID, err := sharedValue.NewID(checkpoint.ProblemID.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to create problem id: %w", err)
+	}
+
+	var snapshot state.Snapshot
+	if err := json.Unmarshal(checkpoint.State, &snapshot); err != nil {
+		return nil, errors.NewInfrastructureError(errors.InternalError, fmt.Sprintf("failed to unmarshal state: %v", err))
+	}
+
+	var createdAt *time.Time
+	if checkpoint.CreatedAt.Valid {
+		createdAt = &checkpoint.CreatedAt.Time
+	}
+
+	elapsed := time.Duration(checkpoint.ElapsedMs) * time.Millisecond
+	return checkpointEntity.NewCheckpoint(id, problemID, int(checkpoint.Step), snapshot, int(checkpoint.FailCount), elapsed, checkpoint.RunStartedAt.Time, createdAt), nil
+}
@@ -0,0 +1,7 @@
+package checkpoint
+
+import "github.com/google/wire"
+
+var Set = wire.NewSet(
+	NewCheckpointRepository,
+)
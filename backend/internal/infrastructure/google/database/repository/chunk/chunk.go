@@ -49,6 +49,7 @@ func (v *ChunkRepository) Create(ctx context.Context, chunk *entity.Chunk) error
 	err := q.CreateVector(ctx, vector.CreateVectorParams{
 		ID:            id,
 		DocumentID:    documentID,
+		ChunkIndex:    int32(chunk.GetIndex()),
 		Content:       chunk.GetContent().Value(),
 		ParentContent: chunk.GetParentContent().Value(),
 		Embedding:     pgVector,
@@ -79,6 +79,27 @@ func (r *DocumentRepository) FindByTitle(ctx context.Context, title value.Title)
 	return toEntity(document)
 }
 
+func (r *DocumentRepository) FindIDsByCollections(ctx context.Context, collections []value.Collection) ([]sharedValue.ID, error) {
+	q := app.New(r.pool)
+	names := make([]string, 0, len(collections))
+	for _, collection := range collections {
+		names = append(names, collection.Value())
+	}
+	documentIDs, err := q.GetDocumentIDsByCollections(ctx, names)
+	if err != nil {
+		return nil, errors.NewInfrastructureError(errors.ExternalServiceError, fmt.Sprintf("failed to get document ids by collections: %v", err))
+	}
+	ids := make([]sharedValue.ID, 0, len(documentIDs))
+	for _, documentID := range documentIDs {
+		id, err := sharedValue.NewID(documentID.String())
+		if err != nil {
+			return nil, fmt.Errorf("failed to create id: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
 func (r *DocumentRepository) Create(ctx context.Context, document *entity.Document) error {
 	q := app.New(r.pool)
 	var id pgtype.UUID
@@ -93,6 +114,7 @@ func (r *DocumentRepository) Create(ctx context.Context, document *entity.Docume
 		ObjectName:     document.GetStorageInfo().ObjectName(),
 		DocumentStatus: document.GetStatus().Value(),
 		RetryCount:     int32(document.GetRetryCount().Value()),
+		Collection:     document.GetCollection().Value(),
 	})
 	if err != nil {
 		return errors.NewInfrastructureError(errors.ExternalServiceError, fmt.Sprintf("failed to create document: %v", err))
@@ -114,6 +136,7 @@ func (r *DocumentRepository) Update(ctx context.Context, document *entity.Docume
 		ObjectName:     document.GetStorageInfo().ObjectName(),
 		DocumentStatus: document.GetStatus().Value(),
 		RetryCount:     int32(document.GetRetryCount().Value()),
+		Collection:     document.GetCollection().Value(),
 	})
 	if err != nil {
 		return 0, errors.NewInfrastructureError(errors.ExternalServiceError, fmt.Sprintf("failed to update document: %v", err))
@@ -151,6 +174,10 @@ func toEntity(document app.Document) (*entity.Document, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to create document status: %w", err)
 	}
+	collection, err := value.NewCollection(document.Collection)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create collection: %w", err)
+	}
 	retryCount := value.NewRetryCount(int(document.RetryCount))
 	storagePath := value.NewStorageInfo(document.BucketName, document.ObjectName)
 	createdAt := document.CreatedAt.Time
@@ -163,6 +190,7 @@ func toEntity(document app.Document) (*entity.Document, error) {
 		storagePath,
 		documentStatus,
 		retryCount,
+		collection,
 		&createdAt,
 		&updatedAt,
 	), nil
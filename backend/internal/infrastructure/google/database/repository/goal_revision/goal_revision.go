@@ -0,0 +1,119 @@
+package goalrevision
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	actionValue "github.com/goda6565/ai-consultant/backend/internal/domain/action/value"
+	agentValue "github.com/goda6565/ai-consultant/backend/internal/domain/agent/value"
+	goalRevisionEntity "github.com/goda6565/ai-consultant/backend/internal/domain/goal_revision/entity"
+	goalRevisionRepository "github.com/goda6565/ai-consultant/backend/internal/domain/goal_revision/repository"
+	sharedValue "github.com/goda6565/ai-consultant/backend/internal/domain/shared/value"
+	"github.com/goda6565/ai-consultant/backend/internal/infrastructure/errors"
+	"github.com/goda6565/ai-consultant/backend/internal/infrastructure/google/database"
+	"github.com/goda6565/ai-consultant/backend/internal/infrastructure/google/database/internal/gen/app"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type GoalRevisionRepository struct {
+	tx   pgx.Tx
+	pool *database.AppPool
+}
+
+func NewGoalRevisionRepository(pool *database.AppPool) goalRevisionRepository.GoalRevisionRepository {
+	return &GoalRevisionRepository{tx: nil, pool: pool}
+}
+
+func (r *GoalRevisionRepository) WithTx(tx pgx.Tx) *GoalRevisionRepository {
+	return &GoalRevisionRepository{tx: tx, pool: r.pool}
+}
+
+func (r *GoalRevisionRepository) FindByProblemID(ctx context.Context, problemID sharedValue.ID) ([]goalRevisionEntity.GoalRevision, error) {
+	var q *app.Queries
+	if r.tx != nil {
+		q = app.New(r.pool).WithTx(r.tx)
+	} else {
+		q = app.New(r.pool)
+	}
+
+	var pID pgtype.UUID
+	if err := pID.Scan(problemID.Value()); err != nil {
+		return nil, errors.NewInfrastructureError(errors.ExternalServiceError, fmt.Sprintf("failed to scan problem id: %v", err))
+	}
+
+	goalRevisions, err := q.ListGoalRevisionsByProblemID(ctx, pID)
+	if err != nil {
+		return nil, errors.NewInfrastructureError(errors.ExternalServiceError, fmt.Sprintf("failed to list goal revisions by problem id: %v", err))
+	}
+
+	entities := make([]goalRevisionEntity.GoalRevision, 0, len(goalRevisions))
+	for _, goalRevision := range goalRevisions {
+		entity, err := toEntity(goalRevision)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert goal revision to entity: %w", err)
+		}
+		entities = append(entities, *entity)
+	}
+
+	return entities, nil
+}
+
+func (r *GoalRevisionRepository) Save(ctx context.Context, goalRevision *goalRevisionEntity.GoalRevision) error {
+	var q *app.Queries
+	if r.tx != nil {
+		q = app.New(r.pool).WithTx(r.tx)
+	} else {
+		q = app.New(r.pool)
+	}
+
+	var id pgtype.UUID
+	if err := id.Scan(goalRevision.GetID().Value()); err != nil {
+		return errors.NewInfrastructureError(errors.ExternalServiceError, fmt.Sprintf("failed to scan id: %v", err))
+	}
+
+	var problemID pgtype.UUID
+	if err := problemID.Scan(goalRevision.GetProblemID().Value()); err != nil {
+		return errors.NewInfrastructureError(errors.ExternalServiceError, fmt.Sprintf("failed to scan problem id: %v", err))
+	}
+
+	goal := goalRevision.GetGoal()
+	err := q.UpsertGoalRevision(ctx, app.UpsertGoalRevisionParams{
+		ID:         id,
+		ProblemID:  problemID,
+		Version:    int32(goalRevision.GetVersion()),
+		Goal:       goal.Value(),
+		Reason:     goalRevision.GetReason(),
+		ActionType: goalRevision.GetActionType().Value(),
+	})
+	if err != nil {
+		return errors.NewInfrastructureError(errors.ExternalServiceError, fmt.Sprintf("failed to save goal revision: %v", err))
+	}
+
+	return nil
+}
+
+func toEntity(goalRevision app.GoalRevision) (*goalRevisionEntity.GoalRevision, error) {
+	id, err := sharedValue.NewID(goalRevision.ID.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to create id: %w", err)
+	}
+
+	problemID, err := sharedValue.NewID(goalRevision.ProblemID.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to create problem id: %w", err)
+	}
+
+	actionType, err := actionValue.NewActionType(goalRevision.ActionType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create action type: %w", err)
+	}
+
+	var createdAt *time.Time
+	if goalRevision.CreatedAt.Valid {
+		createdAt = &goalRevision.CreatedAt.Time
+	}
+
+	return goalRevisionEntity.NewGoalRevision(id, problemID, int(goalRevision.Version), *agentValue.NewGoal(goalRevision.Goal), goalRevision.Reason, actionType, createdAt), nil
+}
@@ -0,0 +1,7 @@
+package goalrevision
+
+import "github.com/google/wire"
+
+var Set = wire.NewSet(
+	NewGoalRevisionRepository,
+)
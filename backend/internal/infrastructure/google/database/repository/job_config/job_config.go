@@ -2,11 +2,16 @@ package jobconfig
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"time"
 
 	jobConfigEntity "github.com/goda6565/ai-consultant/backend/internal/domain/job_config/entity"
 	jobConfigRepository "github.com/goda6565/ai-consultant/backend/internal/domain/job_config/repository"
+	jobConfigValue "github.com/goda6565/ai-consultant/backend/internal/domain/job_config/value"
+	"github.com/goda6565/ai-consultant/backend/internal/domain/llm"
 	sharedValue "github.com/goda6565/ai-consultant/backend/internal/domain/shared/value"
+	workflowValue "github.com/goda6565/ai-consultant/backend/internal/domain/workflow/value"
 	"github.com/goda6565/ai-consultant/backend/internal/infrastructure/errors"
 	"github.com/goda6565/ai-consultant/backend/internal/infrastructure/google/database"
 	"github.com/goda6565/ai-consultant/backend/internal/infrastructure/google/database/internal/gen/app"
@@ -54,10 +59,24 @@ func (r *JobConfigRepository) Create(ctx context.Context, jobConfig *jobConfigEn
 		q = app.New(r.pool)
 	}
 
-	err := q.CreateJobConfig(ctx, app.CreateJobConfigParams{
-		ID:                   jobConfig.GetID().Value(),
-		ProblemID:            jobConfig.GetProblemID().Value(),
-		EnableInternalSearch: jobConfig.GetEnableInternalSearch(),
+	models, err := marshalModelMap(jobConfig.GetModelMap())
+	if err != nil {
+		return err
+	}
+	budget := jobConfig.GetBudget()
+
+	err = q.CreateJobConfig(ctx, app.CreateJobConfigParams{
+		ID:                       jobConfig.GetID().Value(),
+		ProblemID:                jobConfig.GetProblemID().Value(),
+		EnableInternalSearch:     jobConfig.GetEnableInternalSearch(),
+		Models:                   models,
+		BudgetMaxTokens:          int32(budget.GetMaxTokens()),
+		BudgetMaxCost:            budget.GetMaxCost(),
+		BudgetMaxDurationSeconds: int32(budget.GetMaxDuration() / time.Second),
+		ApprovalGates:            jobConfig.GetApprovalGates().Value(),
+		Workflow:                 jobConfig.GetWorkflowName().Value(),
+		AllowedCollections:       jobConfig.GetDocumentScope().CollectionValues(),
+		AllowedDocumentIds:       jobConfig.GetDocumentScope().DocumentIDValues(),
 	})
 	if err != nil {
 		return errors.NewInfrastructureError(errors.ExternalServiceError, fmt.Sprintf("failed to create job config: %v", err))
@@ -74,9 +93,23 @@ func (r *JobConfigRepository) Update(ctx context.Context, jobConfig *jobConfigEn
 		q = app.New(r.pool)
 	}
 
-	err := q.UpdateJobConfig(ctx, app.UpdateJobConfigParams{
-		ID:                   jobConfig.GetID().Value(),
-		EnableInternalSearch: jobConfig.GetEnableInternalSearch(),
+	models, err := marshalModelMap(jobConfig.GetModelMap())
+	if err != nil {
+		return err
+	}
+	budget := jobConfig.GetBudget()
+
+	err = q.UpdateJobConfig(ctx, app.UpdateJobConfigParams{
+		ID:                       jobConfig.GetID().Value(),
+		EnableInternalSearch:     jobConfig.GetEnableInternalSearch(),
+		Models:                   models,
+		BudgetMaxTokens:          int32(budget.GetMaxTokens()),
+		BudgetMaxCost:            budget.GetMaxCost(),
+		BudgetMaxDurationSeconds: int32(budget.GetMaxDuration() / time.Second),
+		ApprovalGates:            jobConfig.GetApprovalGates().Value(),
+		Workflow:                 jobConfig.GetWorkflowName().Value(),
+		AllowedCollections:       jobConfig.GetDocumentScope().CollectionValues(),
+		AllowedDocumentIds:       jobConfig.GetDocumentScope().DocumentIDValues(),
 	})
 	if err != nil {
 		return errors.NewInfrastructureError(errors.ExternalServiceError, fmt.Sprintf("failed to update job config: %v", err))
@@ -112,5 +145,61 @@ func toEntity(jobConfig app.JobConfig) (*jobConfigEntity.JobConfig, error) {
 		return nil, fmt.Errorf("failed to create problem id: %w", err)
 	}
 
-	return jobConfigEntity.NewJobConfig(id, problemID, jobConfig.EnableInternalSearch), nil
+	modelMap, err := unmarshalModelMap(jobConfig.Models)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create model map: %w", err)
+	}
+
+	budget, err := jobConfigValue.NewBudget(int(jobConfig.BudgetMaxTokens), jobConfig.BudgetMaxCost, time.Duration(jobConfig.BudgetMaxDurationSeconds)*time.Second)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create budget: %w", err)
+	}
+
+	approvalGates, err := jobConfigValue.NewApprovalGates(jobConfig.ApprovalGates)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create approval gates: %w", err)
+	}
+
+	workflow, err := workflowValue.NewName(jobConfig.Workflow)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create workflow name: %w", err)
+	}
+
+	documentScope, err := jobConfigValue.NewDocumentScope(jobConfig.AllowedCollections, jobConfig.AllowedDocumentIds)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create document scope: %w", err)
+	}
+
+	return jobConfigEntity.NewJobConfig(id, problemID, jobConfig.EnableInternalSearch, *modelMap, *budget, *approvalGates, workflow, *documentScope), nil
+}
+
+type modelConfig struct {
+	Provider string `json:"provider"`
+	Model    string `json:"model"`
+}
+
+func marshalModelMap(modelMap jobConfigValue.ModelMap) ([]byte, error) {
+	models := make(map[string]modelConfig)
+	for key, config := range modelMap.Value() {
+		models[key] = modelConfig{Provider: string(config.Provider), Model: string(config.Model)}
+	}
+	data, err := json.Marshal(models)
+	if err != nil {
+		return nil, errors.NewInfrastructureError(errors.InternalError, fmt.Sprintf("failed to marshal model map: %v", err))
+	}
+	return data, nil
+}
+
+func unmarshalModelMap(data []byte) (*jobConfigValue.ModelMap, error) {
+	models := make(map[string]modelConfig)
+	if len(data) > 0 {
+		if err := json.Unmarshal(data, &models); err != nil {
+			return nil, errors.NewInfrastructureError(errors.InternalError, fmt.Sprintf("failed to unmarshal model map: %v", err))
+		}
+	}
+	configs := make(map[string]llm.LLMConfig, len(models))
+	for key, config := range models {
+		configs[key] = llm.LLMConfig{Provider: llm.Provider(config.Provider), Model: llm.LLMModel(config.Model)}
+	}
+	return jobConfigValue.NewModelMap(configs)
 }
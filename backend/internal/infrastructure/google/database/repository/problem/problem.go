@@ -95,6 +95,85 @@ func (r *ProblemRepository) UpdateStatus(ctx context.Context, id sharedValue.ID,
 	return nil
 }
 
+func (r *ProblemRepository) TransitionStatus(ctx context.Context, id sharedValue.ID, from value.Status, to value.Status) (bool, error) {
+	var q *app.Queries
+	if r.tx != nil {
+		q = app.New(r.pool).WithTx(r.tx)
+	} else {
+		q = app.New(r.pool)
+	}
+	var problemID pgtype.UUID
+	if err := problemID.Scan(id.Value()); err != nil {
+		return false, errors.NewInfrastructureError(errors.ExternalServiceError, fmt.Sprintf("failed to scan id: %v", err))
+	}
+	numUpdated, err := q.TransitionProblemStatus(ctx, app.TransitionProblemStatusParams{
+		ID:         problemID,
+		FromStatus: from.Value(),
+		ToStatus:   to.Value(),
+	})
+	if err != nil {
+		return false, errors.NewInfrastructureError(errors.ExternalServiceError, fmt.Sprintf("failed to transition problem status: %v", err))
+	}
+	return numUpdated > 0, nil
+}
+
+func (r *ProblemRepository) RequestCancel(ctx context.Context, id sharedValue.ID) error {
+	var q *app.Queries
+	if r.tx != nil {
+		q = app.New(r.pool).WithTx(r.tx)
+	} else {
+		q = app.New(r.pool)
+	}
+	var problemID pgtype.UUID
+	if err := problemID.Scan(id.Value()); err != nil {
+		return errors.NewInfrastructureError(errors.ExternalServiceError, fmt.Sprintf("failed to scan id: %v", err))
+	}
+	_, err := q.RequestProblemCancel(ctx, problemID)
+	if err != nil {
+		return errors.NewInfrastructureError(errors.ExternalServiceError, fmt.Sprintf("failed to request problem cancel: %v", err))
+	}
+	return nil
+}
+
+func (r *ProblemRepository) ClearCancelRequest(ctx context.Context, id sharedValue.ID) error {
+	var q *app.Queries
+	if r.tx != nil {
+		q = app.New(r.pool).WithTx(r.tx)
+	} else {
+		q = app.New(r.pool)
+	}
+	var problemID pgtype.UUID
+	if err := problemID.Scan(id.Value()); err != nil {
+		return errors.NewInfrastructureError(errors.ExternalServiceError, fmt.Sprintf("failed to scan id: %v", err))
+	}
+	_, err := q.ClearProblemCancelRequest(ctx, problemID)
+	if err != nil {
+		return errors.NewInfrastructureError(errors.ExternalServiceError, fmt.Sprintf("failed to clear problem cancel request: %v", err))
+	}
+	return nil
+}
+
+func (r *ProblemRepository) IsCancelRequested(ctx context.Context, id sharedValue.ID) (bool, error) {
+	var q *app.Queries
+	if r.tx != nil {
+		q = app.New(r.pool).WithTx(r.tx)
+	} else {
+		q = app.New(r.pool)
+	}
+	var problemID pgtype.UUID
+	if err := problemID.Scan(id.Value()); err != nil {
+		return false, errors.NewInfrastructureError(errors.ExternalServiceError, fmt.Sprintf("failed to scan id: %v", err))
+	}
+	cancelRequested, err := q.IsProblemCancelRequested(ctx, problemID)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return false, nil
+		}
+		return false, errors.NewInfrastructureError(errors.ExternalServiceError, fmt.Sprintf("failed to check problem cancel request: %v", err))
+	}
+	return cancelRequested, nil
+}
+
 func (r *ProblemRepository) Delete(ctx context.Context, id sharedValue.ID) (numDeleted int64, err error) {
 	var q *app.Queries
 	if r.tx != nil {
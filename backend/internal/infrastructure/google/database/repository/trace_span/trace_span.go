@@ -0,0 +1,147 @@
+package tracespan
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	sharedValue "github.com/goda6565/ai-consultant/backend/internal/domain/shared/value"
+	traceEntity "github.com/goda6565/ai-consultant/backend/internal/domain/trace/entity"
+	traceRepository "github.com/goda6565/ai-consultant/backend/internal/domain/trace/repository"
+	traceValue "github.com/goda6565/ai-consultant/backend/internal/domain/trace/value"
+	"github.com/goda6565/ai-consultant/backend/internal/infrastructure/errors"
+	"github.com/goda6565/ai-consultant/backend/internal/infrastructure/google/database"
+	"github.com/goda6565/ai-consultant/backend/internal/infrastructure/google/database/internal/gen/app"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type SpanRepository struct {
+	tx   pgx.Tx
+	pool *database.AppPool
+}
+
+func NewSpanRepository(pool *database.AppPool) traceRepository.SpanRepository {
+	return &SpanRepository{tx: nil, pool: pool}
+}
+
+func (r *SpanRepository) WithTx(tx pgx.Tx) *SpanRepository {
+	return &SpanRepository{tx: tx, pool: r.pool}
+}
+
+func (r *SpanRepository) Create(ctx context.Context, span *traceEntity.Span) error {
+	var q *app.Queries
+	if r.tx != nil {
+		q = app.New(r.pool).WithTx(r.tx)
+	} else {
+		q = app.New(r.pool)
+	}
+
+	var id pgtype.UUID
+	if err := id.Scan(span.GetID().Value()); err != nil {
+		return errors.NewInfrastructureError(errors.ExternalServiceError, fmt.Sprintf("failed to scan id: %v", err))
+	}
+
+	var problemID pgtype.UUID
+	if err := problemID.Scan(span.GetProblemID().Value()); err != nil {
+		return errors.NewInfrastructureError(errors.ExternalServiceError, fmt.Sprintf("failed to scan problem id: %v", err))
+	}
+
+	var parentID pgtype.UUID
+	if span.GetParentID() != nil {
+		if err := parentID.Scan(span.GetParentID().Value()); err != nil {
+			return errors.NewInfrastructureError(errors.ExternalServiceError, fmt.Sprintf("failed to scan parent id: %v", err))
+		}
+	}
+
+	attributes, err := json.Marshal(span.GetAttributes())
+	if err != nil {
+		return errors.NewInfrastructureError(errors.ExternalServiceError, fmt.Sprintf("failed to marshal attributes: %v", err))
+	}
+
+	err = q.CreateTraceSpan(ctx, app.CreateTraceSpanParams{
+		ID:         id,
+		ProblemID:  problemID,
+		ParentID:   parentID,
+		Name:       span.GetName(),
+		Kind:       span.GetKind().Value(),
+		Status:     span.GetStatus().Value(),
+		Error:      span.GetError(),
+		Attributes: attributes,
+		StartedAt:  pgtype.Timestamptz{Time: span.GetStartedAt(), Valid: true},
+		EndedAt:    pgtype.Timestamptz{Time: span.GetEndedAt(), Valid: true},
+	})
+	if err != nil {
+		return errors.NewInfrastructureError(errors.ExternalServiceError, fmt.Sprintf("failed to create trace span: %v", err))
+	}
+
+	return nil
+}
+
+func (r *SpanRepository) FindByProblemID(ctx context.Context, problemID sharedValue.ID) ([]traceEntity.Span, error) {
+	var q *app.Queries
+	if r.tx != nil {
+		q = app.New(r.pool).WithTx(r.tx)
+	} else {
+		q = app.New(r.pool)
+	}
+
+	var pID pgtype.UUID
+	if err := pID.Scan(problemID.Value()); err != nil {
+		return nil, errors.NewInfrastructureError(errors.ExternalServiceError, fmt.Sprintf("failed to scan problem id: %v", err))
+	}
+
+	spans, err := q.ListTraceSpansByProblemID(ctx, pID)
+	if err != nil {
+		return nil, errors.NewInfrastructureError(errors.ExternalServiceError, fmt.Sprintf("failed to list trace spans by problem id: %v", err))
+	}
+
+	entities := make([]traceEntity.Span, 0, len(spans))
+	for _, span := range spans {
+		entity, err := toEntity(span)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert trace span to entity: %w", err)
+		}
+		entities = append(entities, *entity)
+	}
+
+	return entities, nil
+}
+
+func toEntity(span app.TraceSpan) (*traceEntity.Span, error) {
+	id, err := sharedValue.NewID(span.ID.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to create id: %w", err)
+	}
+
+	problemID, err := sharedValue.NewID(span.ProblemID.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to create problem id: %w", err)
+	}
+
+	var parentID *sharedValue.ID
+	if span.ParentID.Valid {
+		id, err := sharedValue.NewID(span.ParentID.String())
+		if err != nil {
+			return nil, fmt.Errorf("failed to create parent id: %w", err)
+		}
+		parentID = &id
+	}
+
+	kind, err := traceValue.NewSpanKind(span.Kind)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create span kind: %w", err)
+	}
+
+	status, err := traceValue.NewSpanStatus(span.Status)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create span status: %w", err)
+	}
+
+	attributes := map[string]string{}
+	if err := json.Unmarshal(span.Attributes, &attributes); err != nil {
+		return nil, errors.NewInfrastructureError(errors.ExternalServiceError, fmt.Sprintf("failed to unmarshal attributes: %v", err))
+	}
+
+	return traceEntity.NewSpan(id, problemID, parentID, span.Name, kind, status, span.Error, attributes, span.StartedAt.Time, span.EndedAt.Time), nil
+}
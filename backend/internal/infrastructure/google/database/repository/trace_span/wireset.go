@@ -0,0 +1,7 @@
+package tracespan
+
+import "github.com/google/wire"
+
+var Set = wire.NewSet(
+	NewSpanRepository,
+)
@@ -0,0 +1,159 @@
+package usage
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	actionValue "github.com/goda6565/ai-consultant/backend/internal/domain/action/value"
+	"github.com/goda6565/ai-consultant/backend/internal/domain/llm"
+	sharedValue "github.com/goda6565/ai-consultant/backend/internal/domain/shared/value"
+	usageEntity "github.com/goda6565/ai-consultant/backend/internal/domain/usage/entity"
+	usageRepository "github.com/goda6565/ai-consultant/backend/internal/domain/usage/repository"
+	"github.com/goda6565/ai-consultant/backend/internal/infrastructure/errors"
+	"github.com/goda6565/ai-consultant/backend/internal/infrastructure/google/database"
+	"github.com/goda6565/ai-consultant/backend/internal/infrastructure/google/database/internal/gen/app"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type UsageRepository struct {
+	tx   pgx.Tx
+	pool *database.AppPool
+}
+
+func NewUsageRepository(pool *database.AppPool) usageRepository.UsageRepository {
+	return &UsageRepository{tx: nil, pool: pool}
+}
+
+func (r *UsageRepository) WithTx(tx pgx.Tx) *UsageRepository {
+	return &UsageRepository{tx: tx, pool: r.pool}
+}
+
+func (r *UsageRepository) FindByProblemID(ctx context.Context, problemID sharedValue.ID) ([]usageEntity.Usage, error) {
+	var q *app.Queries
+	if r.tx != nil {
+		q = app.New(r.pool).WithTx(r.tx)
+	} else {
+		q = app.New(r.pool)
+	}
+
+	var pID pgtype.UUID
+	if err := pID.Scan(problemID.Value()); err != nil {
+		return nil, errors.NewInfrastructureError(errors.ExternalServiceError, fmt.Sprintf("failed to scan problem id: %v", err))
+	}
+
+	usages, err := q.GetLLMUsagesByProblemID(ctx, pID)
+	if err != nil {
+		return nil, errors.NewInfrastructureError(errors.ExternalServiceError, fmt.Sprintf("failed to get llm usages by problem id: %v", err))
+	}
+
+	entities := make([]usageEntity.Usage, len(usages))
+	for i, usage := range usages {
+		entity, err := toEntity(usage)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert llm usage to entity: %v", err)
+		}
+		entities[i] = *entity
+	}
+
+	return entities, nil
+}
+
+func (r *UsageRepository) FindByProblemIDSince(ctx context.Context, problemID sharedValue.ID, since time.Time) ([]usageEntity.Usage, error) {
+	var q *app.Queries
+	if r.tx != nil {
+		q = app.New(r.pool).WithTx(r.tx)
+	} else {
+		q = app.New(r.pool)
+	}
+
+	var pID pgtype.UUID
+	if err := pID.Scan(problemID.Value()); err != nil {
+		return nil, errors.NewInfrastructureError(errors.ExternalServiceError, fmt.Sprintf("failed to scan problem id: %v", err))
+	}
+
+	usages, err := q.GetLLMUsagesByProblemIDSince(ctx, app.GetLLMUsagesByProblemIDSinceParams{
+		ProblemID: pID,
+		Since:     pgtype.Timestamptz{Time: since, Valid: true},
+	})
+	if err != nil {
+		return nil, errors.NewInfrastructureError(errors.ExternalServiceError, fmt.Sprintf("failed to get llm usages by problem id since: %v", err))
+	}
+
+	entities := make([]usageEntity.Usage, len(usages))
+	for i, usage := range usages {
+		entity, err := toEntity(usage)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert llm usage to entity: %v", err)
+		}
+		entities[i] = *entity
+	}
+
+	return entities, nil
+}
+
+func (r *UsageRepository) Create(ctx context.Context, usage *usageEntity.Usage) error {
+	var q *app.Queries
+	if r.tx != nil {
+		q = app.New(r.pool).WithTx(r.tx)
+	} else {
+		q = app.New(r.pool)
+	}
+
+	var id pgtype.UUID
+	if err := id.Scan(usage.GetID().Value()); err != nil {
+		return errors.NewInfrastructureError(errors.ExternalServiceError, fmt.Sprintf("failed to scan id: %v", err))
+	}
+
+	var problemID pgtype.UUID
+	if err := problemID.Scan(usage.GetProblemID().Value()); err != nil {
+		return errors.NewInfrastructureError(errors.ExternalServiceError, fmt.Sprintf("failed to scan problem id: %v", err))
+	}
+
+	tokens := usage.GetUsage()
+	err := q.CreateLLMUsage(ctx, app.CreateLLMUsageParams{
+		ID:           id,
+		ProblemID:    problemID,
+		ActionType:   usage.GetActionType().Value(),
+		Provider:     string(usage.GetProvider()),
+		Model:        usage.GetModel(),
+		InputTokens:  int32(tokens.InputTokens),
+		OutputTokens: int32(tokens.OutputTokens),
+		TotalTokens:  int32(tokens.TotalTokens),
+	})
+	if err != nil {
+		return errors.NewInfrastructureError(errors.ExternalServiceError, fmt.Sprintf("failed to create llm usage: %v", err))
+	}
+
+	return nil
+}
+
+func toEntity(usage app.LlmUsage) (*usageEntity.Usage, error) {
+	id, err := sharedValue.NewID(usage.ID.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to create id: %w", err)
+	}
+
+	problemID, err := sharedValue.NewID(usage.ProblemID.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to create problem id: %w", err)
+	}
+
+	actionType, err := actionValue.NewActionType(usage.ActionType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create action type: %w", err)
+	}
+
+	var createdAt *time.Time
+	if usage.CreatedAt.Valid {
+		createdAt = &usage.CreatedAt.Time
+	}
+
+	tokens := llm.Usage{
+		InputTokens:  int(usage.InputTokens),
+		OutputTokens: int(usage.OutputTokens),
+		TotalTokens:  int(usage.TotalTokens),
+	}
+	return usageEntity.NewUsage(id, problemID, actionType, llm.Provider(usage.Provider), usage.Model, tokens, createdAt), nil
+}
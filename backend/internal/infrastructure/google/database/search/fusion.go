@@ -0,0 +1,59 @@
+package search
+
+import (
+	"sort"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// rrfK dampens the weight of the top ranks so that a chunk found by both rankings beats one ranked first by only one.
+// 60 is the value of the original paper (Cormack et al., 2009).
+const rrfK = 60
+
+type rankedChunk struct {
+	id            pgtype.UUID
+	documentID    pgtype.UUID
+	chunkIndex    int
+	parentContent string
+	// similarity is nil when it is not computed, i.e. for the keyword ranking of DocumentSearchModeKeyword
+	similarity *float64
+}
+
+// fuseRanks merges the rankings with reciprocal rank fusion: score = Σ 1 / (rrfK + rank).
+// Chunks with the same score keep the order in which they first appear in the rankings.
+// The similarity of a chunk is kept whichever ranking it comes from.
+func fuseRanks(rankings ...[]rankedChunk) []rankedChunk {
+	scores := map[pgtype.UUID]float64{}
+	positions := map[pgtype.UUID]int{}
+	fused := []rankedChunk{}
+	for _, ranking := range rankings {
+		for n, chunk := range ranking {
+			position, ok := positions[chunk.id]
+			if !ok {
+				positions[chunk.id] = len(fused)
+				fused = append(fused, chunk)
+			} else if fused[position].similarity == nil {
+				fused[position].similarity = chunk.similarity
+			}
+			scores[chunk.id] += 1 / float64(rrfK+n+1)
+		}
+	}
+	sort.SliceStable(fused, func(a, b int) bool {
+		return scores[fused[a].id] > scores[fused[b].id]
+	})
+	return fused
+}
+
+// filterBySimilarity drops the chunks whose similarity is below minSimilarity or unknown. Nothing is dropped when it is nil.
+func filterBySimilarity(chunks []rankedChunk, minSimilarity *float64) []rankedChunk {
+	if minSimilarity == nil {
+		return chunks
+	}
+	filtered := []rankedChunk{}
+	for _, chunk := range chunks {
+		if chunk.similarity != nil && *chunk.similarity >= *minSimilarity {
+			filtered = append(filtered, chunk)
+		}
+	}
+	return filtered
+}
@@ -0,0 +1,127 @@
+package search
+
+import (
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func testChunk(n byte) rankedChunk {
+	return rankedChunk{id: pgtype.UUID{Bytes: [16]byte{n}, Valid: true}, parentContent: string('a' + n)}
+}
+
+func TestFuseRanks(t *testing.T) {
+	a, b, c, d := testChunk(0), testChunk(1), testChunk(2), testChunk(3)
+
+	tests := []struct {
+		name     string
+		rankings [][]rankedChunk
+		expected []rankedChunk
+	}{
+		{
+			name:     "single ranking keeps its order",
+			rankings: [][]rankedChunk{{a, b, c}},
+			expected: []rankedChunk{a, b, c},
+		},
+		{
+			name: "chunk found by both rankings comes first",
+			// c: 1/63 + 1/61 > a: 1/61, d: 1/62
+			rankings: [][]rankedChunk{{a, b, c}, {d, c}},
+			expected: []rankedChunk{c, a, d, b},
+		},
+		{
+			name:     "ties keep the order of first appearance",
+			rankings: [][]rankedChunk{{a, b}, {c, d}},
+			expected: []rankedChunk{a, c, b, d},
+		},
+		{
+			name:     "empty ranking does not change the order",
+			rankings: [][]rankedChunk{{a, b}, {}},
+			expected: []rankedChunk{a, b},
+		},
+		{
+			name:     "no results",
+			rankings: [][]rankedChunk{{}, {}},
+			expected: []rankedChunk{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fused := fuseRanks(tt.rankings...)
+			if len(fused) != len(tt.expected) {
+				t.Fatalf("fused = %d chunks, expected %d", len(fused), len(tt.expected))
+			}
+			for n := range fused {
+				if fused[n].id != tt.expected[n].id {
+					t.Errorf("fused[%d] = %s, expected %s", n, fused[n].parentContent, tt.expected[n].parentContent)
+				}
+			}
+		})
+	}
+}
+
+func TestFuseRanks_KeepsSimilarity(t *testing.T) {
+	similarity := 0.8
+	keyword := testChunk(0)
+	vector := testChunk(0)
+	vector.similarity = &similarity
+
+	fused := fuseRanks([]rankedChunk{keyword}, []rankedChunk{vector})
+	if len(fused) != 1 {
+		t.Fatalf("fused = %d chunks, expected 1", len(fused))
+	}
+	if fused[0].similarity == nil || *fused[0].similarity != similarity {
+		t.Errorf("similarity = %v, expected %v", fused[0].similarity, similarity)
+	}
+}
+
+func TestFilterBySimilarity(t *testing.T) {
+	minSimilarity := 0.5
+	similarChunk := func(n byte, similarity float64) rankedChunk {
+		chunk := testChunk(n)
+		chunk.similarity = &similarity
+		return chunk
+	}
+	relevant, keywordOnly, unknown := similarChunk(0, 0.7), similarChunk(1, 0.2), testChunk(2)
+
+	tests := []struct {
+		name          string
+		chunks        []rankedChunk
+		minSimilarity *float64
+		expected      []rankedChunk
+	}{
+		{
+			name:          "chunks below the threshold are dropped even when found by keywords",
+			chunks:        []rankedChunk{keywordOnly, relevant, unknown},
+			minSimilarity: &minSimilarity,
+			expected:      []rankedChunk{relevant},
+		},
+		{
+			name: "query with no relevant hits returns nothing",
+			// 日本語の bigram がたまたま一致しただけのチャンク
+			chunks:        []rankedChunk{keywordOnly, similarChunk(3, 0.1)},
+			minSimilarity: &minSimilarity,
+			expected:      []rankedChunk{},
+		},
+		{
+			name:     "nothing is dropped without a threshold",
+			chunks:   []rankedChunk{keywordOnly, relevant, unknown},
+			expected: []rankedChunk{keywordOnly, relevant, unknown},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filtered := filterBySimilarity(tt.chunks, tt.minSimilarity)
+			if len(filtered) != len(tt.expected) {
+				t.Fatalf("filtered = %d chunks, expected %d", len(filtered), len(tt.expected))
+			}
+			for n := range filtered {
+				if filtered[n].id != tt.expected[n].id {
+					t.Errorf("filtered[%d] = %s, expected %s", n, filtered[n].parentContent, tt.expected[n].parentContent)
+				}
+			}
+		})
+	}
+}
@@ -9,9 +9,13 @@ import (
 	"github.com/goda6565/ai-consultant/backend/internal/infrastructure/google/database"
 	"github.com/goda6565/ai-consultant/backend/internal/infrastructure/google/database/internal/gen/app"
 	"github.com/goda6565/ai-consultant/backend/internal/infrastructure/google/database/internal/gen/vector"
+	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/pgvector/pgvector-go"
 )
 
+// hybridCandidateFactor is how many candidates per result each ranking fetches before the fusion
+const hybridCandidateFactor = 4
+
 type SearchClient struct {
 	vectorPool *database.VectorPool
 	appPool    *database.AppPool
@@ -22,37 +26,168 @@ func NewSearchClient(vectorPool *database.VectorPool, appPool *database.AppPool)
 }
 
 func (v *SearchClient) Search(ctx context.Context, input searchClient.DocumentSearchInput) (*searchClient.DocumentSearchOutput, error) {
-	vectorQ := vector.New(v.vectorPool)
 	appQ := app.New(v.appPool)
+	documentIDs, err := toDocumentIDs(input.AllowedDocumentIDs)
+	if err != nil {
+		return nil, err
+	}
+	var chunks []rankedChunk
+	switch input.Mode {
+	case searchClient.DocumentSearchModeVector:
+		chunks, err = v.searchVector(ctx, input, documentIDs, input.MaxNumResults)
+	case searchClient.DocumentSearchModeKeyword:
+		chunks, err = v.searchFullText(ctx, input, documentIDs, input.MaxNumResults)
+	case searchClient.DocumentSearchModeHybrid:
+		chunks, err = v.searchHybrid(ctx, input, documentIDs)
+	default:
+		return nil, errors.NewInfrastructureError(errors.InternalError, fmt.Sprintf("invalid search mode %s", input.Mode))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// ドキュメント情報は別の DB にあるため、まとめて1回で取得する
+	ids := make([]pgtype.UUID, 0, len(chunks))
+	for _, chunk := range chunks {
+		ids = append(ids, chunk.documentID)
+	}
+	documents, err := appQ.GetDocumentsByIDs(ctx, ids)
+	if err != nil {
+		return nil, errors.NewInfrastructureError(errors.ExternalServiceError, fmt.Sprintf("failed to get documents: %v", err))
+	}
+	titles := make(map[pgtype.UUID]string, len(documents))
+	for _, document := range documents {
+		titles[document.ID] = document.Title
+	}
+	return &searchClient.DocumentSearchOutput{Results: toResults(chunks, titles, input.MaxNumResults)}, nil
+}
+
+// toResults drops the chunks of documents without a title before cutting the results to maxNumResults,
+// so that the candidates after them fill the place of the deleted documents.
+func toResults(chunks []rankedChunk, titles map[pgtype.UUID]string, maxNumResults int) []searchClient.DocumentSearchResult {
+	results := []searchClient.DocumentSearchResult{}
+	for _, chunk := range chunks {
+		if maxNumResults > 0 && len(results) >= maxNumResults {
+			break
+		}
+		title, ok := titles[chunk.documentID]
+		if !ok {
+			// 削除中のドキュメントのチャンクは返さない
+			continue
+		}
+		results = append(results, searchClient.DocumentSearchResult{
+			DocumentID: chunk.documentID.String(),
+			ChunkID:    chunk.id.String(),
+			ChunkIndex: chunk.chunkIndex,
+			Title:      title,
+			Content:    chunk.parentContent,
+			Similarity: chunk.similarity,
+		})
+	}
+	return results
+}
+
+func (v *SearchClient) searchVector(ctx context.Context, input searchClient.DocumentSearchInput, documentIDs []pgtype.UUID, limit int) ([]rankedChunk, error) {
+	vectorQ := vector.New(v.vectorPool)
 	if input.Embedding == nil {
 		return nil, errors.NewInfrastructureError(errors.InternalError, "embedding is required")
 	}
 	pgVector := pgvector.NewVector(*input.Embedding)
 	// <=> cosine similarity
 	rows, err := vectorQ.SearchVector(ctx, vector.SearchVectorParams{
-		Embedding: pgVector,
-		Limit:     int32(input.MaxNumResults),
+		Embedding:         pgVector,
+		RestrictDocuments: documentIDs != nil,
+		DocumentIds:       documentIDs,
+		MinSimilarity:     toMinSimilarity(input.MinSimilarity),
+		MaxNumResults:     int32(limit),
 	})
 	if err != nil {
 		return nil, errors.NewInfrastructureError(errors.ExternalServiceError, fmt.Sprintf("failed to search vector: %v", err))
 	}
+	chunks := make([]rankedChunk, 0, len(rows))
+	for _, row := range rows {
+		similarity := row.Similarity
+		chunks = append(chunks, rankedChunk{id: row.ID, documentID: row.DocumentID, chunkIndex: int(row.ChunkIndex), parentContent: row.ParentContent, similarity: &similarity})
+	}
+	return chunks, nil
+}
 
-	results := []searchClient.DocumentSearchResult{}
+func (v *SearchClient) searchFullText(ctx context.Context, input searchClient.DocumentSearchInput, documentIDs []pgtype.UUID, limit int) ([]rankedChunk, error) {
+	vectorQ := vector.New(v.vectorPool)
+	// 英数字は単語、日本語は2文字ずつのトークンで全文検索する
+	rows, err := vectorQ.SearchFullText(ctx, vector.SearchFullTextParams{
+		Query:             input.Query,
+		RestrictDocuments: documentIDs != nil,
+		DocumentIds:       documentIDs,
+		MaxNumResults:     int32(limit),
+	})
+	if err != nil {
+		return nil, errors.NewInfrastructureError(errors.ExternalServiceError, fmt.Sprintf("failed to search full text: %v", err))
+	}
+	chunks := make([]rankedChunk, 0, len(rows))
 	for _, row := range rows {
-		document, err := appQ.GetDocument(ctx, row.DocumentID)
-		if err != nil {
-			return nil, errors.NewInfrastructureError(errors.ExternalServiceError, fmt.Sprintf("failed to get document: %v", err))
-		}
-		url := fmt.Sprintf("https://storage.googleapis.com/%s/%s", document.BucketName, document.ObjectName)
-		result := searchClient.DocumentSearchResult{
-			Title:   document.Title,
-			Content: row.ParentContent,
-			URL:     url,
+		chunks = append(chunks, rankedChunk{id: row.ID, documentID: row.DocumentID, chunkIndex: int(row.ChunkIndex), parentContent: row.ParentContent})
+	}
+	return chunks, nil
+}
+
+// searchHybrid fuses the vector and the keyword rankings. The keyword hits carry their similarity as well,
+// so that MinSimilarity drops the chunks that only share a bigram with the query.
+func (v *SearchClient) searchHybrid(ctx context.Context, input searchClient.DocumentSearchInput, documentIDs []pgtype.UUID) ([]rankedChunk, error) {
+	limit := input.MaxNumResults * hybridCandidateFactor
+	vectorChunks, err := v.searchVector(ctx, input, documentIDs, limit)
+	if err != nil {
+		return nil, err
+	}
+	fullTextChunks, err := v.searchFullTextWithSimilarity(ctx, input, documentIDs, limit)
+	if err != nil {
+		return nil, err
+	}
+	return filterBySimilarity(fuseRanks(vectorChunks, fullTextChunks), input.MinSimilarity), nil
+}
+
+func (v *SearchClient) searchFullTextWithSimilarity(ctx context.Context, input searchClient.DocumentSearchInput, documentIDs []pgtype.UUID, limit int) ([]rankedChunk, error) {
+	vectorQ := vector.New(v.vectorPool)
+	if input.Embedding == nil {
+		return nil, errors.NewInfrastructureError(errors.InternalError, "embedding is required")
+	}
+	rows, err := vectorQ.SearchFullTextWithSimilarity(ctx, vector.SearchFullTextWithSimilarityParams{
+		Query:             input.Query,
+		Embedding:         pgvector.NewVector(*input.Embedding),
+		RestrictDocuments: documentIDs != nil,
+		DocumentIds:       documentIDs,
+		MaxNumResults:     int32(limit),
+	})
+	if err != nil {
+		return nil, errors.NewInfrastructureError(errors.ExternalServiceError, fmt.Sprintf("failed to search full text: %v", err))
+	}
+	chunks := make([]rankedChunk, 0, len(rows))
+	for _, row := range rows {
+		similarity := row.Similarity
+		chunks = append(chunks, rankedChunk{id: row.ID, documentID: row.DocumentID, chunkIndex: int(row.ChunkIndex), parentContent: row.ParentContent, similarity: &similarity})
+	}
+	return chunks, nil
+}
+
+// toDocumentIDs returns nil when the search is not restricted, and an empty slice when no document is allowed
+func toDocumentIDs(allowedDocumentIDs *[]string) ([]pgtype.UUID, error) {
+	if allowedDocumentIDs == nil {
+		return nil, nil
+	}
+	documentIDs := make([]pgtype.UUID, 0, len(*allowedDocumentIDs))
+	for _, allowedDocumentID := range *allowedDocumentIDs {
+		var documentID pgtype.UUID
+		if err := documentID.Scan(allowedDocumentID); err != nil {
+			return nil, errors.NewInfrastructureError(errors.InternalError, fmt.Sprintf("failed to scan document id: %v", err))
 		}
-		results = append(results, result)
+		documentIDs = append(documentIDs, documentID)
 	}
-	if input.MaxNumResults > 0 && input.MaxNumResults < len(results) {
-		results = results[:input.MaxNumResults]
+	return documentIDs, nil
+}
+
+func toMinSimilarity(minSimilarity *float64) pgtype.Float8 {
+	if minSimilarity == nil {
+		return pgtype.Float8{}
 	}
-	return &searchClient.DocumentSearchOutput{Results: results}, nil
+	return pgtype.Float8{Float64: *minSimilarity, Valid: true}
 }
@@ -0,0 +1,44 @@
+package search
+
+import (
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func TestToResults(t *testing.T) {
+	document := func(n byte) pgtype.UUID {
+		return pgtype.UUID{Bytes: [16]byte{0: 0xff, 1: n}, Valid: true}
+	}
+	chunks := make([]rankedChunk, 4)
+	for n := range chunks {
+		chunks[n] = testChunk(byte(n))
+		chunks[n].documentID = document(byte(n))
+	}
+	// the document of the first chunk is being deleted
+	titles := map[pgtype.UUID]string{document(1): "b", document(2): "c", document(3): "d"}
+
+	tests := []struct {
+		name          string
+		maxNumResults int
+		expected      []string
+	}{
+		{name: "chunks after the deleted document fill the results", maxNumResults: 2, expected: []string{"b", "c"}},
+		{name: "no limit returns every chunk with a document", maxNumResults: 0, expected: []string{"b", "c", "d"}},
+		{name: "limit above the candidates returns every chunk with a document", maxNumResults: 10, expected: []string{"b", "c", "d"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results := toResults(chunks, titles, tt.maxNumResults)
+			if len(results) != len(tt.expected) {
+				t.Fatalf("results = %d, expected %d", len(results), len(tt.expected))
+			}
+			for i, title := range tt.expected {
+				if results[i].Title != title {
+					t.Errorf("results[%d] = %s, expected %s", i, results[i].Title, title)
+				}
+			}
+		})
+	}
+}
@@ -111,7 +111,7 @@ func TestVectorUnitOfWork_WithTx_Commit(t *testing.T) {
 		t.Fatalf("Failed to create embedding: %v", err)
 	}
 
-	chunk := entity.NewChunk(chunkID, documentID, content, parentContent, embedding)
+	chunk := entity.NewChunk(chunkID, documentID, 0, content, parentContent, embedding)
 
 	err = uow.WithTx(ctx, func(ctx context.Context) error {
 		repo := uow.ChunkRepository(ctx)
@@ -173,7 +173,7 @@ func TestVectorUnitOfWork_WithTx_Rollback(t *testing.T) {
 		t.Fatalf("Failed to create embedding: %v", err)
 	}
 
-	chunk := entity.NewChunk(chunkID, documentID, content, parentContent, embedding)
+	chunk := entity.NewChunk(chunkID, documentID, 0, content, parentContent, embedding)
 	expectedErr := errors.New("test error")
 
 	err = uow.WithTx(ctx, func(ctx context.Context) error {
@@ -3,7 +3,9 @@ package gemini
 import (
 	"context"
 	"encoding/json"
+	stdErrors "errors"
 	"fmt"
+	"iter"
 
 	"github.com/goda6565/ai-consultant/backend/internal/domain/llm"
 	"github.com/goda6565/ai-consultant/backend/internal/infrastructure/environment"
@@ -15,7 +17,7 @@ type GeminiClient struct {
 	client *genai.Client
 }
 
-func NewGeminiClient(ctx context.Context, e *environment.Environment) llm.LLMClient {
+func NewGeminiClient(ctx context.Context, e *environment.Environment) *GeminiClient {
 	client, err := genai.NewClient(ctx, &genai.ClientConfig{
 		Backend: genai.Backend(
 			genai.BackendVertexAI,
@@ -30,14 +32,16 @@ func NewGeminiClient(ctx context.Context, e *environment.Environment) llm.LLMCli
 }
 
 func (c *GeminiClient) GenerateText(ctx context.Context, input llm.GenerateTextInput) (*llm.GenerateTextOutput, error) {
-	response, err := c.client.Models.GenerateContent(ctx, string(input.Config.Model), []*genai.Content{
-		genai.NewContentFromText(input.SystemPrompt, genai.RoleModel),
-		genai.NewContentFromText(input.UserPrompt, genai.RoleUser),
-	}, &genai.GenerateContentConfig{
-		Temperature: &input.Temperature,
+	systemInstruction, contents, err := conversation(input.Conversation())
+	if err != nil {
+		return nil, err
+	}
+	response, err := c.client.Models.GenerateContent(ctx, string(input.Config.Model), contents, &genai.GenerateContentConfig{
+		SystemInstruction: systemInstruction,
+		Temperature:       &input.Temperature,
 	})
 	if err != nil {
-		return nil, errors.NewInfrastructureError(errors.ExternalServiceError, fmt.Sprintf("failed to generate text: %v", err))
+		return nil, wrapError("failed to generate text", err)
 	}
 	usage := llm.Usage{
 		InputTokens:  int(response.UsageMetadata.PromptTokenCount),
@@ -47,24 +51,61 @@ func (c *GeminiClient) GenerateText(ctx context.Context, input llm.GenerateTextI
 	return &llm.GenerateTextOutput{Text: response.Text(), Usage: usage}, nil
 }
 
+func (c *GeminiClient) GenerateTextStream(ctx context.Context, input llm.GenerateTextInput) iter.Seq2[*llm.GenerateTextStreamChunk, error] {
+	return func(yield func(*llm.GenerateTextStreamChunk, error) bool) {
+		systemInstruction, contents, err := conversation(input.Conversation())
+		if err != nil {
+			yield(nil, err)
+			return
+		}
+		usage := llm.Usage{}
+		stream := c.client.Models.GenerateContentStream(ctx, string(input.Config.Model), contents, &genai.GenerateContentConfig{
+			SystemInstruction: systemInstruction,
+			Temperature:       &input.Temperature,
+		})
+		for response, err := range stream {
+			if err != nil {
+				yield(nil, wrapError("failed to generate text stream", err))
+				return
+			}
+			// usage metadata is cumulative, so the last one is the usage of the whole call
+			if response.UsageMetadata != nil {
+				usage = llm.Usage{
+					InputTokens:  int(response.UsageMetadata.PromptTokenCount),
+					OutputTokens: int(response.UsageMetadata.CandidatesTokenCount),
+					TotalTokens:  int(response.UsageMetadata.TotalTokenCount),
+				}
+			}
+			if text := response.Text(); text != "" {
+				if !yield(&llm.GenerateTextStreamChunk{Delta: text}, nil) {
+					return
+				}
+			}
+		}
+		yield(&llm.GenerateTextStreamChunk{Usage: &usage}, nil)
+	}
+}
+
 func (c *GeminiClient) GenerateStructuredText(ctx context.Context, input llm.GenerateStructuredTextInput) (*llm.GenerateStructuredTextOutput, error) {
 	var schema genai.Schema
 	if err := json.Unmarshal(input.Schema, &schema); err != nil {
 		return nil, errors.NewInfrastructureError(errors.ExternalServiceError, fmt.Sprintf("failed to unmarshal schema: %v", err))
 	}
 
+	systemInstruction, contents, err := conversation(input.Conversation())
+	if err != nil {
+		return nil, err
+	}
 	config := &genai.GenerateContentConfig{
-		Temperature:      &input.Temperature,
-		ResponseMIMEType: "application/json",
-		ResponseSchema:   &schema,
+		SystemInstruction: systemInstruction,
+		Temperature:       &input.Temperature,
+		ResponseMIMEType:  "application/json",
+		ResponseSchema:    &schema,
 	}
 
-	response, err := c.client.Models.GenerateContent(ctx, string(input.Config.Model), []*genai.Content{
-		genai.NewContentFromText(input.SystemPrompt, genai.RoleModel),
-		genai.NewContentFromText(input.UserPrompt, genai.RoleUser),
-	}, config)
+	response, err := c.client.Models.GenerateContent(ctx, string(input.Config.Model), contents, config)
 	if err != nil {
-		return nil, errors.NewInfrastructureError(errors.ExternalServiceError, fmt.Sprintf("failed to generate structured text: %v", err))
+		return nil, wrapError("failed to generate structured text", err)
 	}
 	usage := llm.Usage{
 		InputTokens:  int(response.UsageMetadata.PromptTokenCount),
@@ -94,38 +135,44 @@ func (c *GeminiClient) GenerateFunctionCall(ctx context.Context, input llm.Gener
 		tools = append(tools, &genai.Tool{FunctionDeclarations: fns})
 	}
 
+	systemInstruction, contents, err := conversation(input.Conversation())
+	if err != nil {
+		return nil, err
+	}
 	cfg := &genai.GenerateContentConfig{
-		Temperature: &input.Temperature,
-		Tools:       tools,
+		SystemInstruction: systemInstruction,
+		Temperature:       &input.Temperature,
+		Tools:             tools,
 		ToolConfig: &genai.ToolConfig{
 			FunctionCallingConfig: &genai.FunctionCallingConfig{
-				// ANY mode forces the model to predict only function calls
-				Mode: genai.FunctionCallingConfigModeAny,
+				Mode: functionCallingMode(input.Mode),
 			},
 		},
 	}
 
-	response, err := c.client.Models.GenerateContent(ctx, string(input.Config.Model), []*genai.Content{
-		genai.NewContentFromText(input.SystemPrompt, genai.RoleModel),
-		genai.NewContentFromText(input.UserPrompt, genai.RoleUser),
-	}, cfg)
+	response, err := c.client.Models.GenerateContent(ctx, string(input.Config.Model), contents, cfg)
 	if err != nil {
-		return nil, errors.NewInfrastructureError(errors.ExternalServiceError, fmt.Sprintf("failed to generate function call: %v", err))
+		return nil, wrapError("failed to generate function call", err)
 	}
 
-	// Extract function call if present
 	if len(response.Candidates) == 0 || response.Candidates[0] == nil || response.Candidates[0].Content == nil {
-		return nil, errors.NewInfrastructureError(errors.ExternalServiceError, "no function call found")
+		return nil, errors.NewInfrastructureError(errors.ExternalServiceError, "no candidate found")
 	}
 
-	parts := response.Candidates[0].Content.Parts
-	if len(parts) == 0 || parts[0] == nil || parts[0].FunctionCall == nil {
-		return nil, errors.NewInfrastructureError(errors.ExternalServiceError, "no function call found")
+	// collect all function calls, the model may call several functions in parallel
+	functionCalls := []llm.FunctionCall{}
+	for _, part := range response.Candidates[0].Content.Parts {
+		if part == nil || part.FunctionCall == nil {
+			continue
+		}
+		functionCalls = append(functionCalls, llm.FunctionCall{
+			ID:        part.FunctionCall.ID,
+			Name:      part.FunctionCall.Name,
+			Arguments: part.FunctionCall.Args,
+		})
 	}
-
-	functionCall := llm.FunctionCall{
-		Name:      parts[0].FunctionCall.Name,
-		Arguments: parts[0].FunctionCall.Args,
+	if len(functionCalls) == 0 && input.Mode == llm.FunctionCallingModeAny {
+		return nil, errors.NewInfrastructureError(errors.ExternalServiceError, "no function call found")
 	}
 
 	usage := llm.Usage{
@@ -134,7 +181,23 @@ func (c *GeminiClient) GenerateFunctionCall(ctx context.Context, input llm.Gener
 		TotalTokens:  int(response.UsageMetadata.TotalTokenCount),
 	}
 
-	return &llm.GenerateFunctionCallOutput{FunctionCall: functionCall, Usage: usage}, nil
+	output := &llm.GenerateFunctionCallOutput{FunctionCalls: functionCalls, Text: response.Text(), Usage: usage}
+	if len(functionCalls) > 0 {
+		output.FunctionCall = functionCalls[0]
+	}
+	return output, nil
+}
+
+func functionCallingMode(mode llm.FunctionCallingMode) genai.FunctionCallingConfigMode {
+	switch mode {
+	case llm.FunctionCallingModeAuto:
+		return genai.FunctionCallingConfigModeAuto
+	case llm.FunctionCallingModeNone:
+		return genai.FunctionCallingConfigModeNone
+	default:
+		// ANY mode forces the model to predict only function calls
+		return genai.FunctionCallingConfigModeAny
+	}
 }
 
 func (c *GeminiClient) GenerateEmbedding(ctx context.Context, input llm.GenerateEmbeddingInput) (*llm.GenerateEmbeddingOutput, error) {
@@ -144,7 +207,7 @@ func (c *GeminiClient) GenerateEmbedding(ctx context.Context, input llm.Generate
 	dimensions := int32(llm.EmbeddingDimensions)
 	response, err := c.client.Models.EmbedContent(ctx, string(input.Config.Model), contents, &genai.EmbedContentConfig{OutputDimensionality: &dimensions})
 	if err != nil {
-		return nil, errors.NewInfrastructureError(errors.ExternalServiceError, fmt.Sprintf("failed to generate embedding: %v", err))
+		return nil, wrapError("failed to generate embedding", err)
 	}
 	embeddings := response.Embeddings[0].Values
 	usage := llm.Usage{
@@ -163,7 +226,7 @@ func (c *GeminiClient) GenerateEmbeddingBatch(ctx context.Context, input llm.Gen
 	dimensions := int32(llm.EmbeddingDimensions)
 	response, err := c.client.Models.EmbedContent(ctx, string(input.Config.Model), contents, &genai.EmbedContentConfig{OutputDimensionality: &dimensions})
 	if err != nil {
-		return nil, errors.NewInfrastructureError(errors.ExternalServiceError, fmt.Sprintf("failed to generate embedding batch: %v", err))
+		return nil, wrapError("failed to generate embedding batch", err)
 	}
 	embeddings := make([][]float32, len(response.Embeddings))
 	for i, embedding := range response.Embeddings {
@@ -183,7 +246,19 @@ func (c *GeminiClient) GetTokenCount(ctx context.Context, input llm.CountTokenIn
 	}
 	response, err := c.client.Models.CountTokens(ctx, string(input.Config.Model), contents, &genai.CountTokensConfig{})
 	if err != nil {
-		return nil, errors.NewInfrastructureError(errors.ExternalServiceError, fmt.Sprintf("failed to get token count: %v", err))
+		return nil, wrapError("failed to get token count", err)
 	}
 	return &llm.CountTokenOutput{TokenCount: int(response.TotalTokens)}, nil
 }
+
+func wrapError(message string, err error) error {
+	errorType := errors.ExternalServiceError
+	var apiErr genai.APIError
+	switch {
+	case stdErrors.As(err, &apiErr):
+		errorType = errors.ExternalServiceErrorType(apiErr.Code)
+	case stdErrors.Is(err, context.DeadlineExceeded):
+		errorType = errors.TimeoutError
+	}
+	return errors.NewInfrastructureError(errorType, fmt.Sprintf("%s: %v", message, err))
+}
@@ -0,0 +1,69 @@
+package gemini
+
+import (
+	"fmt"
+
+	"github.com/goda6565/ai-consultant/backend/internal/domain/llm"
+	"github.com/goda6565/ai-consultant/backend/internal/infrastructure/errors"
+	"google.golang.org/genai"
+)
+
+func conversation(messages []llm.Message, err error) (*genai.Content, []*genai.Content, error) {
+	if err != nil {
+		return nil, nil, errors.NewInfrastructureError(errors.BadRequestError, fmt.Sprintf("invalid messages: %v", err))
+	}
+	return toContents(messages)
+}
+
+// toContents maps the conversation to contents of Gemini.
+// System messages are sent as the system instruction instead of model contents.
+func toContents(messages []llm.Message) (*genai.Content, []*genai.Content, error) {
+	var systemInstruction *genai.Content
+	contents := make([]*genai.Content, 0, len(messages))
+	for _, message := range messages {
+		switch message.Role {
+		case llm.RoleSystem:
+			if systemInstruction == nil {
+				systemInstruction = &genai.Content{}
+			}
+			systemInstruction.Parts = append(systemInstruction.Parts, genai.NewPartFromText(message.Content))
+		case llm.RoleUser:
+			contents = append(contents, genai.NewContentFromText(message.Content, genai.RoleUser))
+		case llm.RoleAssistant:
+			parts := []*genai.Part{}
+			if message.Content != "" {
+				parts = append(parts, genai.NewPartFromText(message.Content))
+			}
+			for _, call := range message.FunctionCalls {
+				parts = append(parts, &genai.Part{FunctionCall: &genai.FunctionCall{
+					ID:   call.ID,
+					Name: call.Name,
+					Args: call.Arguments,
+				}})
+			}
+			contents = append(contents, genai.NewContentFromParts(parts, genai.RoleModel))
+		case llm.RoleTool:
+			part := &genai.Part{FunctionResponse: &genai.FunctionResponse{
+				ID:       message.ToolCallID,
+				Name:     message.Name,
+				Response: map[string]any{"output": message.Content},
+			}}
+			// responses to parallel calls must be sent together in one content
+			if n := len(contents); n > 0 && isFunctionResponse(contents[n-1]) {
+				contents[n-1].Parts = append(contents[n-1].Parts, part)
+				continue
+			}
+			contents = append(contents, genai.NewContentFromParts([]*genai.Part{part}, genai.RoleUser))
+		default:
+			return nil, nil, errors.NewInfrastructureError(errors.BadRequestError, fmt.Sprintf("unsupported message role: %s", message.Role))
+		}
+	}
+	if len(contents) == 0 {
+		return nil, nil, errors.NewInfrastructureError(errors.BadRequestError, "at least one user or assistant message is required")
+	}
+	return systemInstruction, contents, nil
+}
+
+func isFunctionResponse(content *genai.Content) bool {
+	return content.Role == genai.RoleUser && len(content.Parts) > 0 && content.Parts[0].FunctionResponse != nil
+}
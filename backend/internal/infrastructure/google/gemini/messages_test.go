@@ -0,0 +1,45 @@
+package gemini
+
+import (
+	"testing"
+
+	"github.com/goda6565/ai-consultant/backend/internal/domain/llm"
+	"google.golang.org/genai"
+)
+
+func TestToContents(t *testing.T) {
+	call := llm.FunctionCall{ID: "call-1", Name: "web_search", Arguments: map[string]any{"query": "golang"}}
+	systemInstruction, contents, err := toContents([]llm.Message{
+		llm.NewSystemMessage("You are a helpful assistant."),
+		llm.NewUserMessage("hello"),
+		llm.NewAssistantMessage("hi"),
+		llm.NewFunctionCallMessage("", call),
+		llm.NewToolMessage(call, "result"),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if systemInstruction == nil || len(systemInstruction.Parts) != 1 || systemInstruction.Parts[0].Text != "You are a helpful assistant." {
+		t.Errorf("unexpected system instruction: %+v", systemInstruction)
+	}
+
+	expectedRoles := []string{genai.RoleUser, genai.RoleModel, genai.RoleModel, genai.RoleUser}
+	if len(contents) != len(expectedRoles) {
+		t.Fatalf("len(contents) = %d, expected %d", len(contents), len(expectedRoles))
+	}
+	for i, role := range expectedRoles {
+		if contents[i].Role != role {
+			t.Errorf("contents[%d].Role = %s, expected %s", i, contents[i].Role, role)
+		}
+	}
+	if fc := contents[2].Parts[0].FunctionCall; fc == nil || fc.Name != "web_search" || fc.ID != "call-1" {
+		t.Errorf("unexpected function call: %+v", fc)
+	}
+	if fr := contents[3].Parts[0].FunctionResponse; fr == nil || fr.Name != "web_search" || fr.Response["output"] != "result" {
+		t.Errorf("unexpected function response: %+v", fr)
+	}
+
+	if _, _, err := toContents([]llm.Message{llm.NewSystemMessage("system only")}); err == nil {
+		t.Error("expected error without user message")
+	}
+}
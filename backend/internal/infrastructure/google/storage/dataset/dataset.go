@@ -0,0 +1,99 @@
+package dataset
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/goda6565/ai-consultant/backend/internal/domain/dataset"
+	"github.com/goda6565/ai-consultant/backend/internal/domain/document/entity"
+	"github.com/goda6565/ai-consultant/backend/internal/domain/document/repository"
+	documentValue "github.com/goda6565/ai-consultant/backend/internal/domain/document/value"
+	"github.com/goda6565/ai-consultant/backend/internal/domain/search"
+	sharedValue "github.com/goda6565/ai-consultant/backend/internal/domain/shared/value"
+	"github.com/goda6565/ai-consultant/backend/internal/infrastructure/errors"
+	storagePorts "github.com/goda6565/ai-consultant/backend/internal/usecase/ports/storage"
+)
+
+// DatasetClient reads the uploaded CSV documents from the storage.
+// When the context is scoped with search.WithAllowedDocumentIDs, only the allowed documents are listed and loaded.
+type DatasetClient struct {
+	documentRepository repository.DocumentRepository
+	storagePort        storagePorts.StoragePort
+}
+
+func NewDatasetClient(documentRepository repository.DocumentRepository, storagePort storagePorts.StoragePort) dataset.DatasetClient {
+	return &DatasetClient{documentRepository: documentRepository, storagePort: storagePort}
+}
+
+func (c *DatasetClient) List(ctx context.Context) ([]dataset.DatasetInfo, error) {
+	documents, err := c.documentRepository.FindAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find documents: %w", err)
+	}
+	infos := []dataset.DatasetInfo{}
+	for _, document := range documents {
+		if !isReadable(&document) {
+			continue
+		}
+		id := document.GetID()
+		if !isAllowed(ctx, id.Value()) {
+			continue
+		}
+		title := document.GetTitle()
+		infos = append(infos, dataset.DatasetInfo{ID: id.Value(), Title: title.Value()})
+	}
+	// プロンプトに載せる順序を安定させる
+	slices.SortFunc(infos, func(a, b dataset.DatasetInfo) int {
+		return strings.Compare(a.Title, b.Title)
+	})
+	return infos, nil
+}
+
+func (c *DatasetClient) Load(ctx context.Context, id string) (*dataset.Table, error) {
+	documentID, err := sharedValue.NewID(id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create document id: %w", err)
+	}
+	if !isAllowed(ctx, id) {
+		return nil, errors.NewInfrastructureError(errors.InternalError, fmt.Sprintf("document is not allowed: %s", id))
+	}
+	document, err := c.documentRepository.FindById(ctx, documentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find document: %w", err)
+	}
+	if document == nil {
+		return nil, errors.NewInfrastructureError(errors.InternalError, fmt.Sprintf("document not found: %s", id))
+	}
+	if !isReadable(document) {
+		return nil, errors.NewInfrastructureError(errors.InternalError, fmt.Sprintf("document is not a processed csv: %s", id))
+	}
+	reader, err := c.storagePort.Download(ctx, document.GetStorageInfo())
+	if err != nil {
+		return nil, fmt.Errorf("failed to download document: %w", err)
+	}
+	defer func() {
+		_ = reader.Close()
+	}()
+	title := document.GetTitle()
+	table, err := dataset.ParseCSV(dataset.DatasetInfo{ID: id, Title: title.Value()}, reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse csv: %w", err)
+	}
+	return table, nil
+}
+
+// isAllowed reports whether the document is in the scope of the run. Every document is allowed when the context is not scoped.
+func isAllowed(ctx context.Context, id string) bool {
+	documentIDs, ok := search.AllowedDocumentIDsFromContext(ctx)
+	if !ok {
+		return true
+	}
+	return slices.Contains(documentIDs, id)
+}
+
+// isReadable reports whether the document is a csv whose upload has finished. A pending, processing or failed upload may be incomplete.
+func isReadable(document *entity.Document) bool {
+	return document.GetDocumentType().Equals(documentValue.DocumentExtensionCSV) && document.GetStatus().Equals(documentValue.DocumentStatusDone)
+}
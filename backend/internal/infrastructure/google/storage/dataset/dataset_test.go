@@ -0,0 +1,123 @@
+package dataset
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/goda6565/ai-consultant/backend/internal/domain/document/entity"
+	"github.com/goda6565/ai-consultant/backend/internal/domain/document/repository/mock"
+	"github.com/goda6565/ai-consultant/backend/internal/domain/document/value"
+	"github.com/goda6565/ai-consultant/backend/internal/domain/search"
+	sharedValue "github.com/goda6565/ai-consultant/backend/internal/domain/shared/value"
+	storageMock "github.com/goda6565/ai-consultant/backend/internal/usecase/ports/storage/mock"
+	"go.uber.org/mock/gomock"
+)
+
+var (
+	allowedID = "11111111-0000-0000-0000-000000000001"
+	otherID   = "11111111-0000-0000-0000-000000000002"
+	pdfID     = "11111111-0000-0000-0000-000000000003"
+	// アップロードが終わっていない CSV
+	processingID = "11111111-0000-0000-0000-000000000004"
+	failedID     = "11111111-0000-0000-0000-000000000005"
+)
+
+func newTestDocument(id string, title string, documentType string, status value.DocumentStatus) *entity.Document {
+	documentTitle, _ := value.NewTitle(title)
+	documentExtension, _ := value.NewDocumentType(documentType)
+	return entity.NewDocument(
+		sharedValue.ID(id),
+		documentTitle,
+		documentExtension,
+		value.NewStorageInfo("test-bucket", id),
+		status,
+		value.NewRetryCount(0),
+		value.Collection(""),
+		nil,
+		nil,
+	)
+}
+
+func TestDatasetClient_List(t *testing.T) {
+	documents := []entity.Document{
+		*newTestDocument(allowedID, "A社売上", "csv", value.DocumentStatusDone),
+		*newTestDocument(otherID, "B社売上", "csv", value.DocumentStatusDone),
+		*newTestDocument(pdfID, "報告書", "pdf", value.DocumentStatusDone),
+		*newTestDocument(processingID, "C社売上", "csv", value.DocumentStatusProcessing),
+		*newTestDocument(failedID, "D社売上", "csv", value.DocumentStatusFailed),
+	}
+
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		expected []string
+	}{
+		{
+			name:     "every processed csv is listed when the run is not scoped",
+			ctx:      context.Background(),
+			expected: []string{allowedID, otherID},
+		},
+		{
+			name:     "disallowed csv is not listed",
+			ctx:      search.WithAllowedDocumentIDs(context.Background(), []string{allowedID, pdfID}),
+			expected: []string{allowedID},
+		},
+		{
+			name:     "nothing is listed for an empty scope",
+			ctx:      search.WithAllowedDocumentIDs(context.Background(), []string{}),
+			expected: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockRepo := mock.NewMockDocumentRepository(ctrl)
+			mockRepo.EXPECT().FindAll(gomock.Any()).Return(documents, nil).Times(1)
+
+			infos, err := NewDatasetClient(mockRepo, storageMock.NewMockStoragePort(ctrl)).List(tt.ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(infos) != len(tt.expected) {
+				t.Fatalf("infos = %v, expected %v", infos, tt.expected)
+			}
+			for n, info := range infos {
+				if info.ID != tt.expected[n] {
+					t.Errorf("infos[%d] = %s, expected %s", n, info.ID, tt.expected[n])
+				}
+			}
+		})
+	}
+}
+
+func TestDatasetClient_Load(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := mock.NewMockDocumentRepository(ctrl)
+	mockStorage := storageMock.NewMockStoragePort(ctrl)
+	mockRepo.EXPECT().FindById(gomock.Any(), sharedValue.ID(allowedID)).Return(newTestDocument(allowedID, "A社売上", "csv", value.DocumentStatusDone), nil).Times(1)
+	mockRepo.EXPECT().FindById(gomock.Any(), sharedValue.ID(processingID)).Return(newTestDocument(processingID, "C社売上", "csv", value.DocumentStatusProcessing), nil).Times(1)
+	mockStorage.EXPECT().Download(gomock.Any(), gomock.Any()).Return(io.NopCloser(strings.NewReader("月,売上\n1月,100\n")), nil).Times(1)
+	client := NewDatasetClient(mockRepo, mockStorage)
+	ctx := search.WithAllowedDocumentIDs(context.Background(), []string{allowedID, processingID})
+
+	table, err := client.Load(ctx, allowedID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(table.Rows) != 1 {
+		t.Errorf("rows = %d, expected 1", len(table.Rows))
+	}
+	// a csv still being uploaded is not downloaded
+	if _, err := client.Load(ctx, processingID); err == nil {
+		t.Error("expected an error for a document being processed")
+	}
+	// the repository and the storage are not read for a disallowed document
+	if _, err := client.Load(ctx, otherID); err == nil {
+		t.Error("expected an error for a disallowed document")
+	}
+}
@@ -0,0 +1,9 @@
+package dataset
+
+import (
+	"github.com/google/wire"
+)
+
+var Set = wire.NewSet(
+	NewDatasetClient,
+)
@@ -2,6 +2,7 @@ package handler
 
 import (
 	"github.com/goda6565/ai-consultant/backend/internal/infrastructure/http/echo/admin/handler/action"
+	"github.com/goda6565/ai-consultant/backend/internal/infrastructure/http/echo/admin/handler/approval"
 	"github.com/goda6565/ai-consultant/backend/internal/infrastructure/http/echo/admin/handler/document"
 	"github.com/goda6565/ai-consultant/backend/internal/infrastructure/http/echo/admin/handler/event"
 	"github.com/goda6565/ai-consultant/backend/internal/infrastructure/http/echo/admin/handler/hearing"
@@ -10,6 +11,8 @@ import (
 	"github.com/goda6565/ai-consultant/backend/internal/infrastructure/http/echo/admin/handler/job_config"
 	"github.com/goda6565/ai-consultant/backend/internal/infrastructure/http/echo/admin/handler/problem"
 	"github.com/goda6565/ai-consultant/backend/internal/infrastructure/http/echo/admin/handler/report"
+	"github.com/goda6565/ai-consultant/backend/internal/infrastructure/http/echo/admin/handler/trace"
+	"github.com/goda6565/ai-consultant/backend/internal/infrastructure/http/echo/admin/handler/usage"
 	gen "github.com/goda6565/ai-consultant/backend/internal/infrastructure/http/echo/admin/internal"
 )
 
@@ -22,6 +25,7 @@ type AdminRestHandlers struct {
 	*problem.DeleteProblemHandler
 	*problem.GetProblemHandler
 	*problem.ListProblemHandler
+	*problem.CancelProblemHandler
 	*hearing.CreateHearingHandler
 	*hearing.GetHearingHandler
 	*hearingmessage.ListHearingMessageHandler
@@ -31,6 +35,11 @@ type AdminRestHandlers struct {
 	*jobconfig.UpdateJobConfigHandler
 	*jobconfig.GetJobConfigHandler
 	*hearingmap.GetHearingMapHandler
+	*usage.GetUsageHandler
+	*approval.ListPendingApprovalHandler
+	*approval.DecideApprovalHandler
+	*trace.GetTraceHandler
+	*trace.ExportTraceHandler
 }
 
 func NewAdminHandlers(
@@ -42,6 +51,7 @@ func NewAdminHandlers(
 	deleteProblemHandler *problem.DeleteProblemHandler,
 	getProblemHandler *problem.GetProblemHandler,
 	listProblemHandler *problem.ListProblemHandler,
+	cancelProblemHandler *problem.CancelProblemHandler,
 	createHearingHandler *hearing.CreateHearingHandler,
 	getHearingHandler *hearing.GetHearingHandler,
 	listHearingMessageHandler *hearingmessage.ListHearingMessageHandler,
@@ -51,6 +61,11 @@ func NewAdminHandlers(
 	updateJobConfigHandler *jobconfig.UpdateJobConfigHandler,
 	getJobConfigHandler *jobconfig.GetJobConfigHandler,
 	getHearingMapHandler *hearingmap.GetHearingMapHandler,
+	getUsageHandler *usage.GetUsageHandler,
+	listPendingApprovalHandler *approval.ListPendingApprovalHandler,
+	decideApprovalHandler *approval.DecideApprovalHandler,
+	getTraceHandler *trace.GetTraceHandler,
+	exportTraceHandler *trace.ExportTraceHandler,
 ) gen.StrictServerInterface {
 	return &AdminRestHandlers{
 		createDocumentHandler,
@@ -61,6 +76,7 @@ func NewAdminHandlers(
 		deleteProblemHandler,
 		getProblemHandler,
 		listProblemHandler,
+		cancelProblemHandler,
 		createHearingHandler,
 		getHearingHandler,
 		listHearingMessageHandler,
@@ -70,5 +86,10 @@ func NewAdminHandlers(
 		updateJobConfigHandler,
 		getJobConfigHandler,
 		getHearingMapHandler,
+		getUsageHandler,
+		listPendingApprovalHandler,
+		decideApprovalHandler,
+		getTraceHandler,
+		exportTraceHandler,
 	}
 }
@@ -0,0 +1,31 @@
+package approval
+
+import (
+	"context"
+
+	gen "github.com/goda6565/ai-consultant/backend/internal/infrastructure/http/echo/admin/internal"
+	"github.com/goda6565/ai-consultant/backend/internal/usecase/approval"
+)
+
+type DecideApprovalHandler struct {
+	decideApprovalUseCase approval.DecideApprovalInputPort
+}
+
+func NewDecideApprovalHandler(decideApprovalUseCase approval.DecideApprovalInputPort) *DecideApprovalHandler {
+	return &DecideApprovalHandler{decideApprovalUseCase: decideApprovalUseCase}
+}
+
+func (h *DecideApprovalHandler) DecideApproval(ctx context.Context, request gen.DecideApprovalRequestObject) (gen.DecideApprovalResponseObject, error) {
+	output, err := h.decideApprovalUseCase.Execute(ctx, approval.DecideApprovalUseCaseInput{
+		ApprovalID: request.ApprovalId.String(),
+		Decision:   string(request.Body.Decision),
+		Feedback:   request.Body.Feedback,
+		Goal:       request.Body.Goal,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return gen.DecideApproval200JSONResponse{
+		DecideApprovalSuccessJSONResponse: gen.DecideApprovalSuccessJSONResponse(toApprovalJSON(output.Approval)),
+	}, nil
+}
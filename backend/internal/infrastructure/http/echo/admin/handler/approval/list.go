@@ -0,0 +1,54 @@
+package approval
+
+import (
+	"context"
+
+	"github.com/goda6565/ai-consultant/backend/internal/domain/approval/entity"
+	gen "github.com/goda6565/ai-consultant/backend/internal/infrastructure/http/echo/admin/internal"
+	"github.com/goda6565/ai-consultant/backend/internal/usecase/approval"
+	"github.com/google/uuid"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+type ListPendingApprovalHandler struct {
+	listPendingApprovalUseCase approval.ListPendingApprovalInputPort
+}
+
+func NewListPendingApprovalHandler(listPendingApprovalUseCase approval.ListPendingApprovalInputPort) *ListPendingApprovalHandler {
+	return &ListPendingApprovalHandler{listPendingApprovalUseCase: listPendingApprovalUseCase}
+}
+
+func (h *ListPendingApprovalHandler) ListPendingApprovals(ctx context.Context, request gen.ListPendingApprovalsRequestObject) (gen.ListPendingApprovalsResponseObject, error) {
+	output, err := h.listPendingApprovalUseCase.Execute(ctx)
+	if err != nil {
+		return nil, err
+	}
+	approvalsJSON := make([]gen.Approval, len(output.Approvals))
+	for i, approval := range output.Approvals {
+		approvalsJSON[i] = toApprovalJSON(&approval)
+	}
+	return gen.ListPendingApprovals200JSONResponse{
+		ListPendingApprovalsSuccessJSONResponse: gen.ListPendingApprovalsSuccessJSONResponse{
+			Approvals: approvalsJSON,
+		},
+	}, nil
+}
+
+func toApprovalJSON(approval *entity.Approval) gen.Approval {
+	var goal *string
+	if approval.GetGoal() != nil {
+		value := approval.GetGoal().Value()
+		goal = &value
+	}
+	return gen.Approval{
+		Id:         openapi_types.UUID(uuid.MustParse(approval.GetID().Value())),
+		ProblemId:  openapi_types.UUID(uuid.MustParse(approval.GetProblemID().Value())),
+		ActionType: gen.ApprovalGate(approval.GetActionType().Value()),
+		Output:     approval.GetOutput(),
+		Decision:   gen.ApprovalDecision(approval.GetDecision().Value()),
+		Feedback:   approval.GetFeedback(),
+		Goal:       goal,
+		CreatedAt:  *approval.GetCreatedAt(),
+		DecidedAt:  approval.GetDecidedAt(),
+	}
+}
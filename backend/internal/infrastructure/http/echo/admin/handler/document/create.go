@@ -20,10 +20,15 @@ func NewCreateDocumentHandler(createDocumentUseCase document.CreateDocumentInput
 }
 
 func (h *CreateDocumentHandler) CreateDocument(ctx context.Context, request gen.CreateDocumentRequestObject) (gen.CreateDocumentResponseObject, error) {
+	collection := ""
+	if request.Body.Collection != nil {
+		collection = *request.Body.Collection
+	}
 	createDocumentOutput, err := h.createDocumentUseCase.Execute(ctx, document.CreateDocumentUseCaseInput{
 		Title:        request.Body.Title,
 		DocumentType: string(request.Body.DocumentType),
 		File:         bytes.NewReader(request.Body.Data),
+		Collection:   collection,
 	})
 	if err != nil {
 		return nil, err
@@ -36,6 +36,7 @@ func toDocumentJSONResponse(document *entity.Document) gen.GetDocumentResponseOb
 			Id:             openapi_types.UUID(uuid.MustParse(document.GetID().Value())),
 			ObjectName:     document.GetStorageInfo().ObjectName(),
 			RetryCount:     document.GetRetryCount().Value(),
+			Collection:     document.GetCollection().Value(),
 			Title:          document.GetTitle().Value(),
 			UpdatedAt:      *document.GetUpdatedAt(),
 		},
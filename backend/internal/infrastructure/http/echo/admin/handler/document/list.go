@@ -47,6 +47,7 @@ func toSingleDocumentJSON(document *entity.Document) gen.Document {
 		Id:             openapi_types.UUID(uuid.MustParse(document.GetID().Value())),
 		ObjectName:     document.GetStorageInfo().ObjectName(),
 		RetryCount:     document.GetRetryCount().Value(),
+		Collection:     document.GetCollection().Value(),
 		Title:          document.GetTitle().Value(),
 		UpdatedAt:      *document.GetUpdatedAt(),
 	}
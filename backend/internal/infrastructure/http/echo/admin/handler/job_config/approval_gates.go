@@ -0,0 +1,25 @@
+package jobconfig
+
+import (
+	jobConfigValue "github.com/goda6565/ai-consultant/backend/internal/domain/job_config/value"
+	gen "github.com/goda6565/ai-consultant/backend/internal/infrastructure/http/echo/admin/internal"
+)
+
+func toApprovalGatesJSON(approvalGates jobConfigValue.ApprovalGates) []gen.ApprovalGate {
+	gates := []gen.ApprovalGate{}
+	for _, gate := range approvalGates.Value() {
+		gates = append(gates, gen.ApprovalGate(gate))
+	}
+	return gates
+}
+
+func fromApprovalGatesJSON(approvalGates *[]gen.ApprovalGate) []string {
+	if approvalGates == nil {
+		return nil
+	}
+	gates := []string{}
+	for _, gate := range *approvalGates {
+		gates = append(gates, string(gate))
+	}
+	return gates
+}
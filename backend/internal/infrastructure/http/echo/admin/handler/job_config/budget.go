@@ -0,0 +1,28 @@
+package jobconfig
+
+import (
+	"time"
+
+	jobConfigValue "github.com/goda6565/ai-consultant/backend/internal/domain/job_config/value"
+	gen "github.com/goda6565/ai-consultant/backend/internal/infrastructure/http/echo/admin/internal"
+	"github.com/goda6565/ai-consultant/backend/internal/usecase/job_config"
+)
+
+func toBudgetJSON(budget jobConfigValue.Budget) gen.Budget {
+	return gen.Budget{
+		MaxTokens:          budget.GetMaxTokens(),
+		MaxCost:            budget.GetMaxCost(),
+		MaxDurationSeconds: int(budget.GetMaxDuration() / time.Second),
+	}
+}
+
+func fromBudgetJSON(budget *gen.Budget) *jobconfig.UpdateJobConfigBudgetInput {
+	if budget == nil {
+		return nil
+	}
+	return &jobconfig.UpdateJobConfigBudgetInput{
+		MaxTokens:   budget.MaxTokens,
+		MaxCost:     budget.MaxCost,
+		MaxDuration: time.Duration(budget.MaxDurationSeconds) * time.Second,
+	}
+}
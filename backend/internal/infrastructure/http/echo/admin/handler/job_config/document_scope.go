@@ -0,0 +1,34 @@
+package jobconfig
+
+import (
+	jobConfigValue "github.com/goda6565/ai-consultant/backend/internal/domain/job_config/value"
+	gen "github.com/goda6565/ai-consultant/backend/internal/infrastructure/http/echo/admin/internal"
+	"github.com/goda6565/ai-consultant/backend/internal/usecase/job_config"
+	"github.com/google/uuid"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+func toDocumentScopeJSON(documentScope jobConfigValue.DocumentScope) gen.DocumentScope {
+	documentIDs := []openapi_types.UUID{}
+	for _, documentID := range documentScope.DocumentIDValues() {
+		documentIDs = append(documentIDs, openapi_types.UUID(uuid.MustParse(documentID)))
+	}
+	return gen.DocumentScope{
+		Collections: documentScope.CollectionValues(),
+		DocumentIds: documentIDs,
+	}
+}
+
+func fromDocumentScopeJSON(documentScope *gen.DocumentScope) *jobconfig.UpdateJobConfigDocumentScopeInput {
+	if documentScope == nil {
+		return nil
+	}
+	documentIDs := []string{}
+	for _, documentID := range documentScope.DocumentIds {
+		documentIDs = append(documentIDs, documentID.String())
+	}
+	return &jobconfig.UpdateJobConfigDocumentScopeInput{
+		Collections: documentScope.Collections,
+		DocumentIDs: documentIDs,
+	}
+}
@@ -35,6 +35,11 @@ func toGetJobConfigJSONResponse(jobConfig *jobConfigEntity.JobConfig) gen.GetJob
 			Id:                   openapi_types.UUID(uuid.MustParse(id.Value())),
 			ProblemId:            openapi_types.UUID(uuid.MustParse(problemID.Value())),
 			EnableInternalSearch: enableInternalSearch,
+			Models:               toModelMapJSON(jobConfig.GetModelMap()),
+			Budget:               toBudgetJSON(jobConfig.GetBudget()),
+			ApprovalGates:        toApprovalGatesJSON(jobConfig.GetApprovalGates()),
+			Workflow:             jobConfig.GetWorkflowName().Value(),
+			DocumentScope:        toDocumentScopeJSON(jobConfig.GetDocumentScope()),
 		},
 	}
 }
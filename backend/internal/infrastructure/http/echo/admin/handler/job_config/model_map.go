@@ -0,0 +1,32 @@
+package jobconfig
+
+import (
+	jobConfigValue "github.com/goda6565/ai-consultant/backend/internal/domain/job_config/value"
+	"github.com/goda6565/ai-consultant/backend/internal/domain/llm"
+	gen "github.com/goda6565/ai-consultant/backend/internal/infrastructure/http/echo/admin/internal"
+)
+
+func toModelMapJSON(modelMap jobConfigValue.ModelMap) gen.ModelMap {
+	models := gen.ModelMap{}
+	for key, config := range modelMap.Value() {
+		models[key] = gen.ModelConfig{
+			Provider: gen.LlmProvider(config.Provider),
+			Model:    gen.LlmModel(config.Model),
+		}
+	}
+	return models
+}
+
+func fromModelMapJSON(models *gen.ModelMap) map[string]llm.LLMConfig {
+	if models == nil {
+		return nil
+	}
+	configs := make(map[string]llm.LLMConfig, len(*models))
+	for key, config := range *models {
+		configs[key] = llm.LLMConfig{
+			Provider: llm.Provider(config.Provider),
+			Model:    llm.LLMModel(config.Model),
+		}
+	}
+	return configs
+}
@@ -24,6 +24,11 @@ func (h *UpdateJobConfigHandler) UpdateJobConfig(ctx context.Context, request ge
 	output, err := h.handler.Execute(ctx, jobconfig.UpdateJobConfigUseCaseInput{
 		ProblemID:            problemID,
 		EnableInternalSearch: enableInternalSearch,
+		Models:               fromModelMapJSON(request.Body.Models),
+		Budget:               fromBudgetJSON(request.Body.Budget),
+		ApprovalGates:        fromApprovalGatesJSON(request.Body.ApprovalGates),
+		Workflow:             request.Body.Workflow,
+		DocumentScope:        fromDocumentScopeJSON(request.Body.DocumentScope),
 	})
 	if err != nil {
 		return nil, err
@@ -40,6 +45,11 @@ func toUpdateJobConfigJSONResponse(jobConfig *jobConfigEntity.JobConfig) gen.Upd
 			Id:                   openapi_types.UUID(uuid.MustParse(id.Value())),
 			ProblemId:            openapi_types.UUID(uuid.MustParse(problemID.Value())),
 			EnableInternalSearch: enableInternalSearch,
+			Models:               toModelMapJSON(jobConfig.GetModelMap()),
+			Budget:               toBudgetJSON(jobConfig.GetBudget()),
+			ApprovalGates:        toApprovalGatesJSON(jobConfig.GetApprovalGates()),
+			Workflow:             jobConfig.GetWorkflowName().Value(),
+			DocumentScope:        toDocumentScopeJSON(jobConfig.GetDocumentScope()),
 		},
 	}
 }
@@ -0,0 +1,24 @@
+package problem
+
+import (
+	"context"
+
+	gen "github.com/goda6565/ai-consultant/backend/internal/infrastructure/http/echo/admin/internal"
+	"github.com/goda6565/ai-consultant/backend/internal/usecase/problem"
+)
+
+type CancelProblemHandler struct {
+	cancelProblemUseCase problem.CancelProblemInputPort
+}
+
+func NewCancelProblemHandler(cancelProblemUseCase problem.CancelProblemInputPort) *CancelProblemHandler {
+	return &CancelProblemHandler{cancelProblemUseCase: cancelProblemUseCase}
+}
+
+func (h *CancelProblemHandler) CancelProblem(ctx context.Context, request gen.CancelProblemRequestObject) (gen.CancelProblemResponseObject, error) {
+	err := h.cancelProblemUseCase.Execute(ctx, problem.CancelProblemUseCaseInput{ProblemID: request.ProblemId.String()})
+	if err != nil {
+		return nil, err
+	}
+	return gen.CancelProblem202Response{}, nil
+}
@@ -7,4 +7,5 @@ var Set = wire.NewSet(
 	NewCreateProblemHandler,
 	NewDeleteProblemHandler,
 	NewGetProblemHandler,
+	NewCancelProblemHandler,
 )
@@ -0,0 +1,35 @@
+package trace
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	gen "github.com/goda6565/ai-consultant/backend/internal/infrastructure/http/echo/admin/internal"
+	"github.com/goda6565/ai-consultant/backend/internal/usecase/trace"
+)
+
+type ExportTraceHandler struct {
+	exportTraceUseCase trace.ExportTraceInputPort
+}
+
+func NewExportTraceHandler(exportTraceUseCase trace.ExportTraceInputPort) *ExportTraceHandler {
+	return &ExportTraceHandler{exportTraceUseCase: exportTraceUseCase}
+}
+
+func (h *ExportTraceHandler) ExportTrace(ctx context.Context, request gen.ExportTraceRequestObject) (gen.ExportTraceResponseObject, error) {
+	exportTraceOutput, err := h.exportTraceUseCase.Execute(ctx, trace.ExportTraceUseCaseInput{ProblemID: request.ProblemId.String()})
+	if err != nil {
+		return nil, err
+	}
+	// OTLP/JSON は自由形式のオブジェクトとして返す
+	data, err := json.Marshal(exportTraceOutput.Trace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal trace: %w", err)
+	}
+	var otlpTrace gen.OTLPTrace
+	if err := json.Unmarshal(data, &otlpTrace); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal trace: %w", err)
+	}
+	return gen.ExportTrace200JSONResponse{ExportTraceSuccessJSONResponse: gen.ExportTraceSuccessJSONResponse(otlpTrace)}, nil
+}
@@ -0,0 +1,56 @@
+package trace
+
+import (
+	"context"
+
+	traceService "github.com/goda6565/ai-consultant/backend/internal/domain/trace/service"
+	gen "github.com/goda6565/ai-consultant/backend/internal/infrastructure/http/echo/admin/internal"
+	"github.com/goda6565/ai-consultant/backend/internal/usecase/trace"
+	"github.com/google/uuid"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+type GetTraceHandler struct {
+	getTraceUseCase trace.GetTraceInputPort
+}
+
+func NewGetTraceHandler(getTraceUseCase trace.GetTraceInputPort) *GetTraceHandler {
+	return &GetTraceHandler{getTraceUseCase: getTraceUseCase}
+}
+
+func (h *GetTraceHandler) GetTrace(ctx context.Context, request gen.GetTraceRequestObject) (gen.GetTraceResponseObject, error) {
+	getTraceOutput, err := h.getTraceUseCase.Execute(ctx, trace.GetTraceUseCaseInput{ProblemID: request.ProblemId.String()})
+	if err != nil {
+		return nil, err
+	}
+	return gen.GetTrace200JSONResponse{
+		GetTraceSuccessJSONResponse: gen.GetTraceSuccessJSONResponse{
+			ProblemId: openapi_types.UUID(uuid.MustParse(getTraceOutput.ProblemID.Value())),
+			Spans:     toTraceSpansJSON(getTraceOutput.Spans),
+		},
+	}, nil
+}
+
+func toTraceSpansJSON(nodes []*traceService.SpanNode) []gen.TraceSpan {
+	spans := make([]gen.TraceSpan, len(nodes))
+	for i, node := range nodes {
+		span := node.Span
+		spans[i] = gen.TraceSpan{
+			Id:         openapi_types.UUID(uuid.MustParse(span.GetID().Value())),
+			Name:       span.GetName(),
+			Kind:       gen.TraceSpanKind(span.GetKind().Value()),
+			Status:     gen.TraceSpanStatus(span.GetStatus().Value()),
+			Error:      span.GetError(),
+			Attributes: span.GetAttributes(),
+			StartedAt:  span.GetStartedAt(),
+			EndedAt:    span.GetEndedAt(),
+			DurationMs: span.GetDuration().Milliseconds(),
+			Children:   toTraceSpansJSON(node.Children),
+		}
+		if parentID := span.GetParentID(); parentID != nil {
+			id := openapi_types.UUID(uuid.MustParse(parentID.Value()))
+			spans[i].ParentId = &id
+		}
+	}
+	return spans
+}
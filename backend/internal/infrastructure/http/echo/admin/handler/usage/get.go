@@ -0,0 +1,60 @@
+package usage
+
+import (
+	"context"
+
+	usageService "github.com/goda6565/ai-consultant/backend/internal/domain/usage/service"
+	usageValue "github.com/goda6565/ai-consultant/backend/internal/domain/usage/value"
+	gen "github.com/goda6565/ai-consultant/backend/internal/infrastructure/http/echo/admin/internal"
+	"github.com/goda6565/ai-consultant/backend/internal/usecase/usage"
+	"github.com/google/uuid"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+type GetUsageHandler struct {
+	getUsageUseCase usage.GetUsageInputPort
+}
+
+func NewGetUsageHandler(getUsageUseCase usage.GetUsageInputPort) *GetUsageHandler {
+	return &GetUsageHandler{getUsageUseCase: getUsageUseCase}
+}
+
+func (h *GetUsageHandler) GetUsage(ctx context.Context, request gen.GetUsageRequestObject) (gen.GetUsageResponseObject, error) {
+	getUsageOutput, err := h.getUsageUseCase.Execute(ctx, usage.GetUsageUseCaseInput{ProblemID: request.ProblemId.String()})
+	if err != nil {
+		return nil, err
+	}
+	return toUsageJSONResponse(getUsageOutput), nil
+}
+
+func toUsageJSONResponse(output *usage.GetUsageUseCaseOutput) gen.GetUsageResponseObject {
+	actions := make([]gen.ActionUsage, len(output.Summary.Actions))
+	for i, action := range output.Summary.Actions {
+		actions[i] = gen.ActionUsage{
+			ActionType:   action.ActionType.Value(),
+			Calls:        action.Calls,
+			InputTokens:  action.Usage.InputTokens,
+			OutputTokens: action.Usage.OutputTokens,
+			TotalTokens:  action.Usage.TotalTokens,
+			Cost:         action.Cost,
+		}
+	}
+	return gen.GetUsage200JSONResponse{
+		GetUsageSuccessJSONResponse: gen.GetUsageSuccessJSONResponse{
+			ProblemId: openapi_types.UUID(uuid.MustParse(output.ProblemID.Value())),
+			Currency:  usageValue.Currency,
+			Total:     toUsageSummaryJSON(output.Summary.Total),
+			Actions:   actions,
+		},
+	}
+}
+
+func toUsageSummaryJSON(summary usageService.UsageSummary) gen.UsageSummary {
+	return gen.UsageSummary{
+		Calls:        summary.Calls,
+		InputTokens:  summary.Usage.InputTokens,
+		OutputTokens: summary.Usage.OutputTokens,
+		TotalTokens:  summary.Usage.TotalTokens,
+		Cost:         summary.Cost,
+	}
+}
@@ -27,22 +27,54 @@ const (
 	BearerAuthScopes = "BearerAuth.Scopes"
 )
 
+// Defines values for TraceSpanKind.
+const (
+	TraceSpanKindAction   TraceSpanKind = "action"
+	TraceSpanKindDecision TraceSpanKind = "decision"
+	TraceSpanKindLlm      TraceSpanKind = "llm"
+	TraceSpanKindRun      TraceSpanKind = "run"
+	TraceSpanKindStep     TraceSpanKind = "step"
+	TraceSpanKindTool     TraceSpanKind = "tool"
+)
+
+// Defines values for TraceSpanStatus.
+const (
+	TraceSpanStatusError TraceSpanStatus = "error"
+	TraceSpanStatusOk    TraceSpanStatus = "ok"
+)
+
 // Defines values for ActionType.
 const (
 	ActionTypeAnalyze        ActionType = "analyze"
+	ActionTypeDataAnalysis   ActionType = "dataAnalysis"
 	ActionTypeDone           ActionType = "done"
 	ActionTypeExternalSearch ActionType = "externalSearch"
 	ActionTypeInternalSearch ActionType = "internalSearch"
 	ActionTypePlan           ActionType = "plan"
+	ActionTypeReflection     ActionType = "reflection"
 	ActionTypeReview         ActionType = "review"
 	ActionTypeWrite          ActionType = "write"
 )
 
+// Defines values for ApprovalDecision.
+const (
+	ApprovalDecisionApprove ApprovalDecision = "approve"
+	ApprovalDecisionExpired ApprovalDecision = "expired"
+	ApprovalDecisionPending ApprovalDecision = "pending"
+	ApprovalDecisionRevise  ApprovalDecision = "revise"
+)
+
+// Defines values for ApprovalGate.
+const (
+	ApprovalGatePlan   ApprovalGate = "plan"
+	ApprovalGateReview ApprovalGate = "review"
+)
+
 // Defines values for DocumentStatus.
 const (
-	DocumentStatusDone       DocumentStatus = "done"
-	DocumentStatusFailed     DocumentStatus = "failed"
-	DocumentStatusProcessing DocumentStatus = "processing"
+	Done       DocumentStatus = "done"
+	Failed     DocumentStatus = "failed"
+	Processing DocumentStatus = "processing"
 )
 
 // Defines values for DocumentType.
@@ -64,9 +96,11 @@ const (
 
 // Defines values for EventType.
 const (
-	EventTypeAction EventType = "action"
-	EventTypeInput  EventType = "input"
-	EventTypeOutput EventType = "output"
+	EventTypeAction       EventType = "action"
+	EventTypeDelta        EventType = "delta"
+	EventTypeGoalRevision EventType = "goalRevision"
+	EventTypeInput        EventType = "input"
+	EventTypeOutput       EventType = "output"
 )
 
 // Defines values for HearingMessageRole.
@@ -75,11 +109,26 @@ const (
 	User      HearingMessageRole = "user"
 )
 
+// Defines values for LlmModel.
+const (
+	Gemini25Flash LlmModel = "gemini-2.5-flash"
+	Gpt4o         LlmModel = "gpt-4o"
+	Gpt5          LlmModel = "gpt-5"
+)
+
+// Defines values for LlmProvider.
+const (
+	Openai   LlmProvider = "openai"
+	Vertexai LlmProvider = "vertexai"
+)
+
 // Defines values for ProblemStatus.
 const (
+	ProblemStatusCancelled  ProblemStatus = "cancelled"
 	ProblemStatusDone       ProblemStatus = "done"
 	ProblemStatusFailed     ProblemStatus = "failed"
 	ProblemStatusHearing    ProblemStatus = "hearing"
+	ProblemStatusPaused     ProblemStatus = "paused"
 	ProblemStatusPending    ProblemStatus = "pending"
 	ProblemStatusProcessing ProblemStatus = "processing"
 )
@@ -94,9 +143,48 @@ type Action struct {
 	ProblemId  openapi_types.UUID `json:"problemId"`
 }
 
+// ActionUsage defines model for ActionUsage.
+type ActionUsage struct {
+	ActionType   string  `json:"actionType"`
+	Calls        int     `json:"calls"`
+	Cost         float64 `json:"cost"`
+	InputTokens  int     `json:"inputTokens"`
+	OutputTokens int     `json:"outputTokens"`
+	TotalTokens  int     `json:"totalTokens"`
+}
+
+// Approval defines model for Approval.
+type Approval struct {
+	// ActionType Action after which the proposal job waits for a human decision
+	ActionType ApprovalGate     `json:"actionType"`
+	CreatedAt  time.Time        `json:"createdAt"`
+	DecidedAt  *time.Time       `json:"decidedAt,omitempty"`
+	Decision   ApprovalDecision `json:"decision"`
+	Feedback   string           `json:"feedback"`
+
+	// Goal Goal edited by the reviewer
+	Goal *string            `json:"goal,omitempty"`
+	Id   openapi_types.UUID `json:"id"`
+
+	// Output Output of the action waiting for approval
+	Output    string             `json:"output"`
+	ProblemId openapi_types.UUID `json:"problemId"`
+}
+
+// Budget Limits of a proposal job. 0 means unlimited.
+type Budget struct {
+	// MaxCost Maximum cost in USD
+	MaxCost            float64 `json:"maxCost"`
+	MaxDurationSeconds int     `json:"maxDurationSeconds"`
+	MaxTokens          int     `json:"maxTokens"`
+}
+
 // Document defines model for Document.
 type Document struct {
-	BucketName     string             `json:"bucketName"`
+	BucketName string `json:"bucketName"`
+
+	// Collection Collection the document belongs to. Empty when the document is not grouped.
+	Collection     string             `json:"collection"`
 	CreatedAt      time.Time          `json:"createdAt"`
 	DocumentStatus DocumentStatus     `json:"documentStatus"`
 	DocumentType   DocumentType       `json:"documentType"`
@@ -107,6 +195,12 @@ type Document struct {
 	UpdatedAt      time.Time          `json:"updatedAt"`
 }
 
+// DocumentScope Documents the internal search of a proposal job may read. All documents are searched when both are empty.
+type DocumentScope struct {
+	Collections []string             `json:"collections"`
+	DocumentIds []openapi_types.UUID `json:"documentIds"`
+}
+
 // Event defines model for Event.
 type Event struct {
 	ActionType ActionType         `json:"actionType"`
@@ -142,11 +236,36 @@ type HearingMessage struct {
 
 // JobConfig defines model for JobConfig.
 type JobConfig struct {
+	ApprovalGates []ApprovalGate `json:"approvalGates"`
+
+	// Budget Limits of a proposal job. 0 means unlimited.
+	Budget Budget `json:"budget"`
+
+	// DocumentScope Documents the internal search of a proposal job may read. All documents are searched when both are empty.
+	DocumentScope        DocumentScope      `json:"documentScope"`
 	EnableInternalSearch bool               `json:"enableInternalSearch"`
 	Id                   openapi_types.UUID `json:"id"`
-	ProblemId            openapi_types.UUID `json:"problemId"`
+
+	// Models Model per action type. The "default" key is used for actions without an entry.
+	Models    ModelMap           `json:"models"`
+	ProblemId openapi_types.UUID `json:"problemId"`
+
+	// Workflow Name of the agent workflow, e.g. standard or quickMemo
+	Workflow WorkflowName `json:"workflow"`
+}
+
+// ModelConfig defines model for ModelConfig.
+type ModelConfig struct {
+	Model    LlmModel    `json:"model"`
+	Provider LlmProvider `json:"provider"`
 }
 
+// ModelMap Model per action type. The "default" key is used for actions without an entry.
+type ModelMap map[string]ModelConfig
+
+// OTLPTrace OTLP/JSON trace (resourceSpans) that can be sent to an OpenTelemetry collector.
+type OTLPTrace map[string]interface{}
+
 // Problem defines model for Problem.
 type Problem struct {
 	CreatedAt   time.Time          `json:"createdAt"`
@@ -164,9 +283,61 @@ type Report struct {
 	ProblemId openapi_types.UUID `json:"problemId"`
 }
 
+// Trace defines model for Trace.
+type Trace struct {
+	ProblemId openapi_types.UUID `json:"problemId"`
+
+	// Spans Root spans. Each run of the proposal job is a root.
+	Spans []TraceSpan `json:"spans"`
+}
+
+// TraceSpan defines model for TraceSpan.
+type TraceSpan struct {
+	Attributes map[string]string   `json:"attributes"`
+	Children   []TraceSpan         `json:"children"`
+	DurationMs int64               `json:"durationMs"`
+	EndedAt    time.Time           `json:"endedAt"`
+	Error      string              `json:"error"`
+	Id         openapi_types.UUID  `json:"id"`
+	Kind       TraceSpanKind       `json:"kind"`
+	Name       string              `json:"name"`
+	ParentId   *openapi_types.UUID `json:"parentId,omitempty"`
+	StartedAt  time.Time           `json:"startedAt"`
+	Status     TraceSpanStatus     `json:"status"`
+}
+
+// TraceSpanKind defines model for TraceSpan.Kind.
+type TraceSpanKind string
+
+// TraceSpanStatus defines model for TraceSpan.Status.
+type TraceSpanStatus string
+
+// Usage defines model for Usage.
+type Usage struct {
+	Actions   []ActionUsage      `json:"actions"`
+	Currency  string             `json:"currency"`
+	ProblemId openapi_types.UUID `json:"problemId"`
+	Total     UsageSummary       `json:"total"`
+}
+
+// UsageSummary defines model for UsageSummary.
+type UsageSummary struct {
+	Calls        int     `json:"calls"`
+	Cost         float64 `json:"cost"`
+	InputTokens  int     `json:"inputTokens"`
+	OutputTokens int     `json:"outputTokens"`
+	TotalTokens  int     `json:"totalTokens"`
+}
+
 // ActionType defines model for actionType.
 type ActionType string
 
+// ApprovalDecision defines model for approvalDecision.
+type ApprovalDecision string
+
+// ApprovalGate Action after which the proposal job waits for a human decision
+type ApprovalGate string
+
 // DocumentStatus defines model for documentStatus.
 type DocumentStatus string
 
@@ -182,9 +353,21 @@ type EventType string
 // HearingMessageRole defines model for hearingMessageRole.
 type HearingMessageRole string
 
+// LlmModel defines model for llmModel.
+type LlmModel string
+
+// LlmProvider defines model for llmProvider.
+type LlmProvider string
+
 // ProblemStatus defines model for problemStatus.
 type ProblemStatus string
 
+// WorkflowName Name of the agent workflow, e.g. standard or quickMemo
+type WorkflowName = string
+
+// ApprovalIdPathParameter defines model for ApprovalIdPathParameter.
+type ApprovalIdPathParameter = openapi_types.UUID
+
 // DocumentIdPathParameter defines model for DocumentIdPathParameter.
 type DocumentIdPathParameter = openapi_types.UUID
 
@@ -209,12 +392,18 @@ type CreateProblemSuccess struct {
 	Id openapi_types.UUID `json:"id"`
 }
 
+// DecideApprovalSuccess defines model for DecideApprovalSuccess.
+type DecideApprovalSuccess = Approval
+
 // Error defines model for Error.
 type Error struct {
 	Code    ErrorCode `json:"code"`
 	Message string    `json:"message"`
 }
 
+// ExportTraceSuccess OTLP/JSON trace (resourceSpans) that can be sent to an OpenTelemetry collector.
+type ExportTraceSuccess = OTLPTrace
+
 // GetDocumentSuccess defines model for GetDocumentSuccess.
 type GetDocumentSuccess = Document
 
@@ -233,6 +422,12 @@ type GetProblemSuccess = Problem
 // GetReportSuccess defines model for GetReportSuccess.
 type GetReportSuccess = Report
 
+// GetTraceSuccess defines model for GetTraceSuccess.
+type GetTraceSuccess = Trace
+
+// GetUsageSuccess defines model for GetUsageSuccess.
+type GetUsageSuccess = Usage
+
 // ListActionsSuccess defines model for ListActionsSuccess.
 type ListActionsSuccess struct {
 	Actions []Action `json:"actions"`
@@ -253,6 +448,11 @@ type ListHearingMessagesSuccess struct {
 	HearingMessages []HearingMessage `json:"hearingMessages"`
 }
 
+// ListPendingApprovalsSuccess defines model for ListPendingApprovalsSuccess.
+type ListPendingApprovalsSuccess struct {
+	Approvals []Approval `json:"approvals"`
+}
+
 // ListProblemsSuccess defines model for ListProblemsSuccess.
 type ListProblemsSuccess struct {
 	Problems []Problem `json:"problems"`
@@ -263,6 +463,9 @@ type UpdateJobConfigSuccess = JobConfig
 
 // CreateDocument defines model for CreateDocument.
 type CreateDocument struct {
+	// Collection Collection the document belongs to
+	Collection *string `json:"collection,omitempty"`
+
 	// Data File data in base64
 	Data         []byte       `json:"data"`
 	DocumentType DocumentType `json:"documentType"`
@@ -274,13 +477,51 @@ type CreateProblem struct {
 	Description string `json:"description"`
 }
 
+// DecideApproval defines model for DecideApproval.
+type DecideApproval struct {
+	Decision ApprovalDecision `json:"decision"`
+
+	// Feedback Guidance added to the history of the proposal job
+	Feedback string `json:"feedback"`
+
+	// Goal Replaces the goal of the proposal job when given
+	Goal *string `json:"goal,omitempty"`
+}
+
 // UpdateJobConfig defines model for UpdateJobConfig.
 type UpdateJobConfig struct {
-	EnableInternalSearch bool `json:"enableInternalSearch"`
+	ApprovalGates *[]ApprovalGate `json:"approvalGates,omitempty"`
+
+	// Budget Limits of a proposal job. 0 means unlimited.
+	Budget *Budget `json:"budget,omitempty"`
+
+	// DocumentScope Documents the internal search of a proposal job may read. All documents are searched when both are empty.
+	DocumentScope        *DocumentScope `json:"documentScope,omitempty"`
+	EnableInternalSearch bool           `json:"enableInternalSearch"`
+
+	// Models Model per action type. The "default" key is used for actions without an entry.
+	Models *ModelMap `json:"models,omitempty"`
+
+	// Workflow Name of the agent workflow, e.g. standard or quickMemo
+	Workflow *WorkflowName `json:"workflow,omitempty"`
+}
+
+// DecideApprovalJSONBody defines parameters for DecideApproval.
+type DecideApprovalJSONBody struct {
+	Decision ApprovalDecision `json:"decision"`
+
+	// Feedback Guidance added to the history of the proposal job
+	Feedback string `json:"feedback"`
+
+	// Goal Replaces the goal of the proposal job when given
+	Goal *string `json:"goal,omitempty"`
 }
 
 // CreateDocumentJSONBody defines parameters for CreateDocument.
 type CreateDocumentJSONBody struct {
+	// Collection Collection the document belongs to
+	Collection *string `json:"collection,omitempty"`
+
 	// Data File data in base64
 	Data         []byte       `json:"data"`
 	DocumentType DocumentType `json:"documentType"`
@@ -289,7 +530,20 @@ type CreateDocumentJSONBody struct {
 
 // UpdateJobConfigJSONBody defines parameters for UpdateJobConfig.
 type UpdateJobConfigJSONBody struct {
-	EnableInternalSearch bool `json:"enableInternalSearch"`
+	ApprovalGates *[]ApprovalGate `json:"approvalGates,omitempty"`
+
+	// Budget Limits of a proposal job. 0 means unlimited.
+	Budget *Budget `json:"budget,omitempty"`
+
+	// DocumentScope Documents the internal search of a proposal job may read. All documents are searched when both are empty.
+	DocumentScope        *DocumentScope `json:"documentScope,omitempty"`
+	EnableInternalSearch bool           `json:"enableInternalSearch"`
+
+	// Models Model per action type. The "default" key is used for actions without an entry.
+	Models *ModelMap `json:"models,omitempty"`
+
+	// Workflow Name of the agent workflow, e.g. standard or quickMemo
+	Workflow *WorkflowName `json:"workflow,omitempty"`
 }
 
 // CreateProblemJSONBody defines parameters for CreateProblem.
@@ -297,6 +551,9 @@ type CreateProblemJSONBody struct {
 	Description string `json:"description"`
 }
 
+// DecideApprovalJSONRequestBody defines body for DecideApproval for application/json ContentType.
+type DecideApprovalJSONRequestBody DecideApprovalJSONBody
+
 // CreateDocumentJSONRequestBody defines body for CreateDocument for application/json ContentType.
 type CreateDocumentJSONRequestBody CreateDocumentJSONBody
 
@@ -311,6 +568,12 @@ type ServerInterface interface {
 	// List actions by problem id
 	// (GET /api/actions/{problemId})
 	ListActions(ctx echo.Context, problemId ProblemIdPathParameter) error
+	// List approvals waiting for a decision
+	// (GET /api/approvals)
+	ListPendingApprovals(ctx echo.Context) error
+	// Submit a decision for an approval and resume the proposal job
+	// (POST /api/approvals/{approvalId}/decision)
+	DecideApproval(ctx echo.Context, approvalId ApprovalIdPathParameter) error
 	// List documents
 	// (GET /api/documents)
 	ListDocuments(ctx echo.Context) error
@@ -356,9 +619,21 @@ type ServerInterface interface {
 	// Get a problem by problem id
 	// (GET /api/problems/{problemId})
 	GetProblem(ctx echo.Context, problemId ProblemIdPathParameter) error
+	// Cancel the running proposal job of a problem
+	// (POST /api/problems/{problemId}/cancel)
+	CancelProblem(ctx echo.Context, problemId ProblemIdPathParameter) error
 	// Get a report by problem id
 	// (GET /api/reports/{problemId})
 	GetReport(ctx echo.Context, problemId ProblemIdPathParameter) error
+	// Get the execution trace of the proposal job as a span tree by problem id
+	// (GET /api/traces/{problemId})
+	GetTrace(ctx echo.Context, problemId ProblemIdPathParameter) error
+	// Export the execution trace of the proposal job in the OTLP/JSON format by problem id
+	// (GET /api/traces/{problemId}/otlp)
+	ExportTrace(ctx echo.Context, problemId ProblemIdPathParameter) error
+	// Get llm usage and cost by problem id
+	// (GET /api/usages/{problemId})
+	GetUsage(ctx echo.Context, problemId ProblemIdPathParameter) error
 }
 
 // ServerInterfaceWrapper converts echo contexts to parameters.
@@ -384,6 +659,35 @@ func (w *ServerInterfaceWrapper) ListActions(ctx echo.Context) error {
 	return err
 }
 
+// ListPendingApprovals converts echo context to params.
+func (w *ServerInterfaceWrapper) ListPendingApprovals(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.ListPendingApprovals(ctx)
+	return err
+}
+
+// DecideApproval converts echo context to params.
+func (w *ServerInterfaceWrapper) DecideApproval(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "approvalId" -------------
+	var approvalId ApprovalIdPathParameter
+
+	err = runtime.BindStyledParameterWithOptions("simple", "approvalId", ctx.Param("approvalId"), &approvalId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter approvalId: %s", err))
+	}
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.DecideApproval(ctx, approvalId)
+	return err
+}
+
 // ListDocuments converts echo context to params.
 func (w *ServerInterfaceWrapper) ListDocuments(ctx echo.Context) error {
 	var err error
@@ -626,6 +930,24 @@ func (w *ServerInterfaceWrapper) GetProblem(ctx echo.Context) error {
 	return err
 }
 
+// CancelProblem converts echo context to params.
+func (w *ServerInterfaceWrapper) CancelProblem(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "problemId" -------------
+	var problemId ProblemIdPathParameter
+
+	err = runtime.BindStyledParameterWithOptions("simple", "problemId", ctx.Param("problemId"), &problemId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter problemId: %s", err))
+	}
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.CancelProblem(ctx, problemId)
+	return err
+}
+
 // GetReport converts echo context to params.
 func (w *ServerInterfaceWrapper) GetReport(ctx echo.Context) error {
 	var err error
@@ -644,6 +966,60 @@ func (w *ServerInterfaceWrapper) GetReport(ctx echo.Context) error {
 	return err
 }
 
+// GetTrace converts echo context to params.
+func (w *ServerInterfaceWrapper) GetTrace(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "problemId" -------------
+	var problemId ProblemIdPathParameter
+
+	err = runtime.BindStyledParameterWithOptions("simple", "problemId", ctx.Param("problemId"), &problemId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter problemId: %s", err))
+	}
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetTrace(ctx, problemId)
+	return err
+}
+
+// ExportTrace converts echo context to params.
+func (w *ServerInterfaceWrapper) ExportTrace(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "problemId" -------------
+	var problemId ProblemIdPathParameter
+
+	err = runtime.BindStyledParameterWithOptions("simple", "problemId", ctx.Param("problemId"), &problemId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter problemId: %s", err))
+	}
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.ExportTrace(ctx, problemId)
+	return err
+}
+
+// GetUsage converts echo context to params.
+func (w *ServerInterfaceWrapper) GetUsage(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "problemId" -------------
+	var problemId ProblemIdPathParameter
+
+	err = runtime.BindStyledParameterWithOptions("simple", "problemId", ctx.Param("problemId"), &problemId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter problemId: %s", err))
+	}
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetUsage(ctx, problemId)
+	return err
+}
+
 // This is a simple interface which specifies echo.Route addition functions which
 // are present on both echo.Echo and echo.Group, since we want to allow using
 // either of them for path registration
@@ -673,6 +1049,8 @@ func RegisterHandlersWithBaseURL(router EchoRouter, si ServerInterface, baseURL
 	}
 
 	router.GET(baseURL+"/api/actions/:problemId", wrapper.ListActions)
+	router.GET(baseURL+"/api/approvals", wrapper.ListPendingApprovals)
+	router.POST(baseURL+"/api/approvals/:approvalId/decision", wrapper.DecideApproval)
 	router.GET(baseURL+"/api/documents", wrapper.ListDocuments)
 	router.POST(baseURL+"/api/documents", wrapper.CreateDocument)
 	router.DELETE(baseURL+"/api/documents/:documentId", wrapper.DeleteDocument)
@@ -688,7 +1066,11 @@ func RegisterHandlersWithBaseURL(router EchoRouter, si ServerInterface, baseURL
 	router.POST(baseURL+"/api/problems", wrapper.CreateProblem)
 	router.DELETE(baseURL+"/api/problems/:problemId", wrapper.DeleteProblem)
 	router.GET(baseURL+"/api/problems/:problemId", wrapper.GetProblem)
+	router.POST(baseURL+"/api/problems/:problemId/cancel", wrapper.CancelProblem)
 	router.GET(baseURL+"/api/reports/:problemId", wrapper.GetReport)
+	router.GET(baseURL+"/api/traces/:problemId", wrapper.GetTrace)
+	router.GET(baseURL+"/api/traces/:problemId/otlp", wrapper.ExportTrace)
+	router.GET(baseURL+"/api/usages/:problemId", wrapper.GetUsage)
 
 }
 
@@ -704,11 +1086,15 @@ type CreateProblemSuccessJSONResponse struct {
 	Id openapi_types.UUID `json:"id"`
 }
 
+type DecideApprovalSuccessJSONResponse Approval
+
 type ErrorJSONResponse struct {
 	Code    ErrorCode `json:"code"`
 	Message string    `json:"message"`
 }
 
+type ExportTraceSuccessJSONResponse OTLPTrace
+
 type GetDocumentSuccessJSONResponse Document
 
 type GetHearingMapSuccessJSONResponse HearingMap
@@ -721,6 +1107,10 @@ type GetProblemSuccessJSONResponse Problem
 
 type GetReportSuccessJSONResponse Report
 
+type GetTraceSuccessJSONResponse Trace
+
+type GetUsageSuccessJSONResponse Usage
+
 type ListActionsSuccessJSONResponse struct {
 	Actions []Action `json:"actions"`
 }
@@ -737,6 +1127,10 @@ type ListHearingMessagesSuccessJSONResponse struct {
 	HearingMessages []HearingMessage `json:"hearingMessages"`
 }
 
+type ListPendingApprovalsSuccessJSONResponse struct {
+	Approvals []Approval `json:"approvals"`
+}
+
 type ListProblemsSuccessJSONResponse struct {
 	Problems []Problem `json:"problems"`
 }
@@ -817,202 +1211,342 @@ func (response ListActions500JSONResponse) VisitListActionsResponse(w http.Respo
 	return json.NewEncoder(w).Encode(response)
 }
 
-type ListDocumentsRequestObject struct {
+type ListPendingApprovalsRequestObject struct {
 }
 
-type ListDocumentsResponseObject interface {
-	VisitListDocumentsResponse(w http.ResponseWriter) error
+type ListPendingApprovalsResponseObject interface {
+	VisitListPendingApprovalsResponse(w http.ResponseWriter) error
 }
 
-type ListDocuments200JSONResponse struct {
-	ListDocumentsSuccessJSONResponse
+type ListPendingApprovals200JSONResponse struct {
+	ListPendingApprovalsSuccessJSONResponse
 }
 
-func (response ListDocuments200JSONResponse) VisitListDocumentsResponse(w http.ResponseWriter) error {
+func (response ListPendingApprovals200JSONResponse) VisitListPendingApprovalsResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(200)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type ListDocuments400JSONResponse struct{ ErrorJSONResponse }
-
-func (response ListDocuments400JSONResponse) VisitListDocumentsResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
-
-	return json.NewEncoder(w).Encode(response)
-}
-
-type ListDocuments401JSONResponse struct {
-	Code    ErrorCode `json:"code"`
-	Message string    `json:"message"`
-}
+type ListPendingApprovals401JSONResponse struct{ ErrorJSONResponse }
 
-func (response ListDocuments401JSONResponse) VisitListDocumentsResponse(w http.ResponseWriter) error {
+func (response ListPendingApprovals401JSONResponse) VisitListPendingApprovalsResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(401)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type ListDocuments403JSONResponse struct {
+type ListPendingApprovals403JSONResponse struct {
 	Code    ErrorCode `json:"code"`
 	Message string    `json:"message"`
 }
 
-func (response ListDocuments403JSONResponse) VisitListDocumentsResponse(w http.ResponseWriter) error {
+func (response ListPendingApprovals403JSONResponse) VisitListPendingApprovalsResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(403)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type ListDocuments500JSONResponse struct {
+type ListPendingApprovals500JSONResponse struct {
 	Code    ErrorCode `json:"code"`
 	Message string    `json:"message"`
 }
 
-func (response ListDocuments500JSONResponse) VisitListDocumentsResponse(w http.ResponseWriter) error {
+func (response ListPendingApprovals500JSONResponse) VisitListPendingApprovalsResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(500)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type CreateDocumentRequestObject struct {
-	Body *CreateDocumentJSONRequestBody
+type DecideApprovalRequestObject struct {
+	ApprovalId ApprovalIdPathParameter `json:"approvalId"`
+	Body       *DecideApprovalJSONRequestBody
 }
 
-type CreateDocumentResponseObject interface {
-	VisitCreateDocumentResponse(w http.ResponseWriter) error
+type DecideApprovalResponseObject interface {
+	VisitDecideApprovalResponse(w http.ResponseWriter) error
 }
 
-type CreateDocument201JSONResponse struct {
-	CreateDocumentSuccessJSONResponse
+type DecideApproval200JSONResponse struct {
+	DecideApprovalSuccessJSONResponse
 }
 
-func (response CreateDocument201JSONResponse) VisitCreateDocumentResponse(w http.ResponseWriter) error {
+func (response DecideApproval200JSONResponse) VisitDecideApprovalResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(201)
+	w.WriteHeader(200)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type CreateDocument400JSONResponse struct{ ErrorJSONResponse }
+type DecideApproval400JSONResponse struct{ ErrorJSONResponse }
 
-func (response CreateDocument400JSONResponse) VisitCreateDocumentResponse(w http.ResponseWriter) error {
+func (response DecideApproval400JSONResponse) VisitDecideApprovalResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(400)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type CreateDocument401JSONResponse struct {
+type DecideApproval401JSONResponse struct {
 	Code    ErrorCode `json:"code"`
 	Message string    `json:"message"`
 }
 
-func (response CreateDocument401JSONResponse) VisitCreateDocumentResponse(w http.ResponseWriter) error {
+func (response DecideApproval401JSONResponse) VisitDecideApprovalResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(401)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type CreateDocument403JSONResponse struct {
+type DecideApproval403JSONResponse struct {
 	Code    ErrorCode `json:"code"`
 	Message string    `json:"message"`
 }
 
-func (response CreateDocument403JSONResponse) VisitCreateDocumentResponse(w http.ResponseWriter) error {
+func (response DecideApproval403JSONResponse) VisitDecideApprovalResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(403)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type CreateDocument409JSONResponse struct {
+type DecideApproval404JSONResponse struct {
 	Code    ErrorCode `json:"code"`
 	Message string    `json:"message"`
 }
 
-func (response CreateDocument409JSONResponse) VisitCreateDocumentResponse(w http.ResponseWriter) error {
+func (response DecideApproval404JSONResponse) VisitDecideApprovalResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DecideApproval409JSONResponse struct {
+	Code    ErrorCode `json:"code"`
+	Message string    `json:"message"`
+}
+
+func (response DecideApproval409JSONResponse) VisitDecideApprovalResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(409)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type CreateDocument500JSONResponse struct {
+type DecideApproval500JSONResponse struct {
 	Code    ErrorCode `json:"code"`
 	Message string    `json:"message"`
 }
 
-func (response CreateDocument500JSONResponse) VisitCreateDocumentResponse(w http.ResponseWriter) error {
+func (response DecideApproval500JSONResponse) VisitDecideApprovalResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(500)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type DeleteDocumentRequestObject struct {
-	DocumentId DocumentIdPathParameter `json:"documentId"`
+type ListDocumentsRequestObject struct {
 }
 
-type DeleteDocumentResponseObject interface {
-	VisitDeleteDocumentResponse(w http.ResponseWriter) error
+type ListDocumentsResponseObject interface {
+	VisitListDocumentsResponse(w http.ResponseWriter) error
 }
 
-type DeleteDocument204Response struct {
+type ListDocuments200JSONResponse struct {
+	ListDocumentsSuccessJSONResponse
 }
 
-func (response DeleteDocument204Response) VisitDeleteDocumentResponse(w http.ResponseWriter) error {
-	w.WriteHeader(204)
-	return nil
+func (response ListDocuments200JSONResponse) VisitListDocumentsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
 }
 
-type DeleteDocument400JSONResponse struct{ ErrorJSONResponse }
+type ListDocuments400JSONResponse struct{ ErrorJSONResponse }
 
-func (response DeleteDocument400JSONResponse) VisitDeleteDocumentResponse(w http.ResponseWriter) error {
+func (response ListDocuments400JSONResponse) VisitListDocumentsResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(400)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type DeleteDocument401JSONResponse struct {
+type ListDocuments401JSONResponse struct {
 	Code    ErrorCode `json:"code"`
 	Message string    `json:"message"`
 }
 
-func (response DeleteDocument401JSONResponse) VisitDeleteDocumentResponse(w http.ResponseWriter) error {
+func (response ListDocuments401JSONResponse) VisitListDocumentsResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(401)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type DeleteDocument403JSONResponse struct {
+type ListDocuments403JSONResponse struct {
 	Code    ErrorCode `json:"code"`
 	Message string    `json:"message"`
 }
 
-func (response DeleteDocument403JSONResponse) VisitDeleteDocumentResponse(w http.ResponseWriter) error {
+func (response ListDocuments403JSONResponse) VisitListDocumentsResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(403)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type DeleteDocument404JSONResponse struct {
+type ListDocuments500JSONResponse struct {
 	Code    ErrorCode `json:"code"`
 	Message string    `json:"message"`
 }
 
-func (response DeleteDocument404JSONResponse) VisitDeleteDocumentResponse(w http.ResponseWriter) error {
+func (response ListDocuments500JSONResponse) VisitListDocumentsResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateDocumentRequestObject struct {
+	Body *CreateDocumentJSONRequestBody
+}
+
+type CreateDocumentResponseObject interface {
+	VisitCreateDocumentResponse(w http.ResponseWriter) error
+}
+
+type CreateDocument201JSONResponse struct {
+	CreateDocumentSuccessJSONResponse
+}
+
+func (response CreateDocument201JSONResponse) VisitCreateDocumentResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateDocument400JSONResponse struct{ ErrorJSONResponse }
+
+func (response CreateDocument400JSONResponse) VisitCreateDocumentResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateDocument401JSONResponse struct {
+	Code    ErrorCode `json:"code"`
+	Message string    `json:"message"`
+}
+
+func (response CreateDocument401JSONResponse) VisitCreateDocumentResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateDocument403JSONResponse struct {
+	Code    ErrorCode `json:"code"`
+	Message string    `json:"message"`
+}
+
+func (response CreateDocument403JSONResponse) VisitCreateDocumentResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateDocument409JSONResponse struct {
+	Code    ErrorCode `json:"code"`
+	Message string    `json:"message"`
+}
+
+func (response CreateDocument409JSONResponse) VisitCreateDocumentResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateDocument500JSONResponse struct {
+	Code    ErrorCode `json:"code"`
+	Message string    `json:"message"`
+}
+
+func (response CreateDocument500JSONResponse) VisitCreateDocumentResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteDocumentRequestObject struct {
+	DocumentId DocumentIdPathParameter `json:"documentId"`
+}
+
+type DeleteDocumentResponseObject interface {
+	VisitDeleteDocumentResponse(w http.ResponseWriter) error
+}
+
+type DeleteDocument204Response struct {
+}
+
+func (response DeleteDocument204Response) VisitDeleteDocumentResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type DeleteDocument400JSONResponse struct{ ErrorJSONResponse }
+
+func (response DeleteDocument400JSONResponse) VisitDeleteDocumentResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteDocument401JSONResponse struct {
+	Code    ErrorCode `json:"code"`
+	Message string    `json:"message"`
+}
+
+func (response DeleteDocument401JSONResponse) VisitDeleteDocumentResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteDocument403JSONResponse struct {
+	Code    ErrorCode `json:"code"`
+	Message string    `json:"message"`
+}
+
+func (response DeleteDocument403JSONResponse) VisitDeleteDocumentResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteDocument404JSONResponse struct {
+	Code    ErrorCode `json:"code"`
+	Message string    `json:"message"`
+}
+
+func (response DeleteDocument404JSONResponse) VisitDeleteDocumentResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
 
 	return json.NewEncoder(w).Encode(response)
 }
@@ -1918,6 +2452,91 @@ func (response GetProblem500JSONResponse) VisitGetProblemResponse(w http.Respons
 	return json.NewEncoder(w).Encode(response)
 }
 
+type CancelProblemRequestObject struct {
+	ProblemId ProblemIdPathParameter `json:"problemId"`
+}
+
+type CancelProblemResponseObject interface {
+	VisitCancelProblemResponse(w http.ResponseWriter) error
+}
+
+type CancelProblem202Response struct {
+}
+
+func (response CancelProblem202Response) VisitCancelProblemResponse(w http.ResponseWriter) error {
+	w.WriteHeader(202)
+	return nil
+}
+
+type CancelProblem400JSONResponse struct{ ErrorJSONResponse }
+
+func (response CancelProblem400JSONResponse) VisitCancelProblemResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CancelProblem401JSONResponse struct {
+	Code    ErrorCode `json:"code"`
+	Message string    `json:"message"`
+}
+
+func (response CancelProblem401JSONResponse) VisitCancelProblemResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CancelProblem403JSONResponse struct {
+	Code    ErrorCode `json:"code"`
+	Message string    `json:"message"`
+}
+
+func (response CancelProblem403JSONResponse) VisitCancelProblemResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CancelProblem404JSONResponse struct {
+	Code    ErrorCode `json:"code"`
+	Message string    `json:"message"`
+}
+
+func (response CancelProblem404JSONResponse) VisitCancelProblemResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CancelProblem409JSONResponse struct {
+	Code    ErrorCode `json:"code"`
+	Message string    `json:"message"`
+}
+
+func (response CancelProblem409JSONResponse) VisitCancelProblemResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CancelProblem500JSONResponse struct {
+	Code    ErrorCode `json:"code"`
+	Message string    `json:"message"`
+}
+
+func (response CancelProblem500JSONResponse) VisitCancelProblemResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
 type GetReportRequestObject struct {
 	ProblemId ProblemIdPathParameter `json:"problemId"`
 }
@@ -1992,11 +2611,239 @@ func (response GetReport500JSONResponse) VisitGetReportResponse(w http.ResponseW
 	return json.NewEncoder(w).Encode(response)
 }
 
+type GetTraceRequestObject struct {
+	ProblemId ProblemIdPathParameter `json:"problemId"`
+}
+
+type GetTraceResponseObject interface {
+	VisitGetTraceResponse(w http.ResponseWriter) error
+}
+
+type GetTrace200JSONResponse struct{ GetTraceSuccessJSONResponse }
+
+func (response GetTrace200JSONResponse) VisitGetTraceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetTrace400JSONResponse struct{ ErrorJSONResponse }
+
+func (response GetTrace400JSONResponse) VisitGetTraceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetTrace401JSONResponse struct {
+	Code    ErrorCode `json:"code"`
+	Message string    `json:"message"`
+}
+
+func (response GetTrace401JSONResponse) VisitGetTraceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetTrace403JSONResponse struct {
+	Code    ErrorCode `json:"code"`
+	Message string    `json:"message"`
+}
+
+func (response GetTrace403JSONResponse) VisitGetTraceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetTrace404JSONResponse struct {
+	Code    ErrorCode `json:"code"`
+	Message string    `json:"message"`
+}
+
+func (response GetTrace404JSONResponse) VisitGetTraceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetTrace500JSONResponse struct {
+	Code    ErrorCode `json:"code"`
+	Message string    `json:"message"`
+}
+
+func (response GetTrace500JSONResponse) VisitGetTraceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ExportTraceRequestObject struct {
+	ProblemId ProblemIdPathParameter `json:"problemId"`
+}
+
+type ExportTraceResponseObject interface {
+	VisitExportTraceResponse(w http.ResponseWriter) error
+}
+
+type ExportTrace200JSONResponse struct{ ExportTraceSuccessJSONResponse }
+
+func (response ExportTrace200JSONResponse) VisitExportTraceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ExportTrace400JSONResponse struct{ ErrorJSONResponse }
+
+func (response ExportTrace400JSONResponse) VisitExportTraceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ExportTrace401JSONResponse struct {
+	Code    ErrorCode `json:"code"`
+	Message string    `json:"message"`
+}
+
+func (response ExportTrace401JSONResponse) VisitExportTraceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ExportTrace403JSONResponse struct {
+	Code    ErrorCode `json:"code"`
+	Message string    `json:"message"`
+}
+
+func (response ExportTrace403JSONResponse) VisitExportTraceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ExportTrace404JSONResponse struct {
+	Code    ErrorCode `json:"code"`
+	Message string    `json:"message"`
+}
+
+func (response ExportTrace404JSONResponse) VisitExportTraceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ExportTrace500JSONResponse struct {
+	Code    ErrorCode `json:"code"`
+	Message string    `json:"message"`
+}
+
+func (response ExportTrace500JSONResponse) VisitExportTraceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetUsageRequestObject struct {
+	ProblemId ProblemIdPathParameter `json:"problemId"`
+}
+
+type GetUsageResponseObject interface {
+	VisitGetUsageResponse(w http.ResponseWriter) error
+}
+
+type GetUsage200JSONResponse struct{ GetUsageSuccessJSONResponse }
+
+func (response GetUsage200JSONResponse) VisitGetUsageResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetUsage400JSONResponse struct{ ErrorJSONResponse }
+
+func (response GetUsage400JSONResponse) VisitGetUsageResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetUsage401JSONResponse struct {
+	Code    ErrorCode `json:"code"`
+	Message string    `json:"message"`
+}
+
+func (response GetUsage401JSONResponse) VisitGetUsageResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetUsage403JSONResponse struct {
+	Code    ErrorCode `json:"code"`
+	Message string    `json:"message"`
+}
+
+func (response GetUsage403JSONResponse) VisitGetUsageResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetUsage404JSONResponse struct {
+	Code    ErrorCode `json:"code"`
+	Message string    `json:"message"`
+}
+
+func (response GetUsage404JSONResponse) VisitGetUsageResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetUsage500JSONResponse struct {
+	Code    ErrorCode `json:"code"`
+	Message string    `json:"message"`
+}
+
+func (response GetUsage500JSONResponse) VisitGetUsageResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
 // StrictServerInterface represents all server handlers.
 type StrictServerInterface interface {
 	// List actions by problem id
 	// (GET /api/actions/{problemId})
 	ListActions(ctx context.Context, request ListActionsRequestObject) (ListActionsResponseObject, error)
+	// List approvals waiting for a decision
+	// (GET /api/approvals)
+	ListPendingApprovals(ctx context.Context, request ListPendingApprovalsRequestObject) (ListPendingApprovalsResponseObject, error)
+	// Submit a decision for an approval and resume the proposal job
+	// (POST /api/approvals/{approvalId}/decision)
+	DecideApproval(ctx context.Context, request DecideApprovalRequestObject) (DecideApprovalResponseObject, error)
 	// List documents
 	// (GET /api/documents)
 	ListDocuments(ctx context.Context, request ListDocumentsRequestObject) (ListDocumentsResponseObject, error)
@@ -2042,9 +2889,21 @@ type StrictServerInterface interface {
 	// Get a problem by problem id
 	// (GET /api/problems/{problemId})
 	GetProblem(ctx context.Context, request GetProblemRequestObject) (GetProblemResponseObject, error)
+	// Cancel the running proposal job of a problem
+	// (POST /api/problems/{problemId}/cancel)
+	CancelProblem(ctx context.Context, request CancelProblemRequestObject) (CancelProblemResponseObject, error)
 	// Get a report by problem id
 	// (GET /api/reports/{problemId})
 	GetReport(ctx context.Context, request GetReportRequestObject) (GetReportResponseObject, error)
+	// Get the execution trace of the proposal job as a span tree by problem id
+	// (GET /api/traces/{problemId})
+	GetTrace(ctx context.Context, request GetTraceRequestObject) (GetTraceResponseObject, error)
+	// Export the execution trace of the proposal job in the OTLP/JSON format by problem id
+	// (GET /api/traces/{problemId}/otlp)
+	ExportTrace(ctx context.Context, request ExportTraceRequestObject) (ExportTraceResponseObject, error)
+	// Get llm usage and cost by problem id
+	// (GET /api/usages/{problemId})
+	GetUsage(ctx context.Context, request GetUsageRequestObject) (GetUsageResponseObject, error)
 }
 
 type StrictHandlerFunc = strictecho.StrictEchoHandlerFunc
@@ -2084,6 +2943,60 @@ func (sh *strictHandler) ListActions(ctx echo.Context, problemId ProblemIdPathPa
 	return nil
 }
 
+// ListPendingApprovals operation middleware
+func (sh *strictHandler) ListPendingApprovals(ctx echo.Context) error {
+	var request ListPendingApprovalsRequestObject
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.ListPendingApprovals(ctx.Request().Context(), request.(ListPendingApprovalsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListPendingApprovals")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(ListPendingApprovalsResponseObject); ok {
+		return validResponse.VisitListPendingApprovalsResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// DecideApproval operation middleware
+func (sh *strictHandler) DecideApproval(ctx echo.Context, approvalId ApprovalIdPathParameter) error {
+	var request DecideApprovalRequestObject
+
+	request.ApprovalId = approvalId
+
+	var body DecideApprovalJSONRequestBody
+	if err := ctx.Bind(&body); err != nil {
+		return err
+	}
+	request.Body = &body
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.DecideApproval(ctx.Request().Context(), request.(DecideApprovalRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DecideApproval")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(DecideApprovalResponseObject); ok {
+		return validResponse.VisitDecideApprovalResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
 // ListDocuments operation middleware
 func (sh *strictHandler) ListDocuments(ctx echo.Context) error {
 	var request ListDocumentsRequestObject
@@ -2469,6 +3382,31 @@ func (sh *strictHandler) GetProblem(ctx echo.Context, problemId ProblemIdPathPar
 	return nil
 }
 
+// CancelProblem operation middleware
+func (sh *strictHandler) CancelProblem(ctx echo.Context, problemId ProblemIdPathParameter) error {
+	var request CancelProblemRequestObject
+
+	request.ProblemId = problemId
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.CancelProblem(ctx.Request().Context(), request.(CancelProblemRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "CancelProblem")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(CancelProblemResponseObject); ok {
+		return validResponse.VisitCancelProblemResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
 // GetReport operation middleware
 func (sh *strictHandler) GetReport(ctx echo.Context, problemId ProblemIdPathParameter) error {
 	var request GetReportRequestObject
@@ -2494,43 +3432,144 @@ func (sh *strictHandler) GetReport(ctx echo.Context, problemId ProblemIdPathPara
 	return nil
 }
 
+// GetTrace operation middleware
+func (sh *strictHandler) GetTrace(ctx echo.Context, problemId ProblemIdPathParameter) error {
+	var request GetTraceRequestObject
+
+	request.ProblemId = problemId
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetTrace(ctx.Request().Context(), request.(GetTraceRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetTrace")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(GetTraceResponseObject); ok {
+		return validResponse.VisitGetTraceResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// ExportTrace operation middleware
+func (sh *strictHandler) ExportTrace(ctx echo.Context, problemId ProblemIdPathParameter) error {
+	var request ExportTraceRequestObject
+
+	request.ProblemId = problemId
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.ExportTrace(ctx.Request().Context(), request.(ExportTraceRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ExportTrace")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(ExportTraceResponseObject); ok {
+		return validResponse.VisitExportTraceResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// GetUsage operation middleware
+func (sh *strictHandler) GetUsage(ctx echo.Context, problemId ProblemIdPathParameter) error {
+	var request GetUsageRequestObject
+
+	request.ProblemId = problemId
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetUsage(ctx.Request().Context(), request.(GetUsageRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetUsage")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(GetUsageResponseObject); ok {
+		return validResponse.VisitGetUsageResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/+xcW2/juBX+KwLbRyX2NN4Cm7dsZiebRbsINlv0YZAHWqJjZSRRJalMXUP/vSBFUqRE",
-	"WpQvkwzWb7HEy7l8/M7hIZUtSHBR4RKVjILrLagggQViiIhfH3FSF6hk9+kDZOsH9Y6/ShFNSFaxDJfg",
-	"WjeM7j+CGGT8UQXZGsSghAUC1yDVI4EYEPSfOiMoBdeM1CgGNFmjAvJRV5gUkIFrUNcZb8k2Fe9NGcnK",
-	"Z9A0MfgFQf73qESynVegtRrnQHkeCF7mqBiVR7bzylOpcQ6Sp2k7I8p+wmmGhBNvCYIMKQ/xJwkumfwT",
-	"VlWeJZALOXuhXNKtMV1FcIUIkwOlkMGhYp+yHEX8VZSV0RJS9PcFiDtBlxuGhoLGGhB/iBdb8FeCVuAa",
-	"/GXW4XHWCkJnVtsmBixjueg0dEdnus+yWW+quFXjSYuEly8oYZ3tOsM3sbSd9N0hpjNNNia32ThQzn9V",
-	"KWToV7y8xeUqez5AUlTCZY7uS4ZICfNHBEmyNkReYpwjWA5kdnYLEl48oRUuqQuuj3WSIEoPUChLwxay",
-	"qU2WemS3od+KGil8RUoPoJEjWehwLTq6mqxM13WCTrKTQyW5GL4Hx0hOtZT4mRBMDpA6wekoXyE+xy1v",
-	"2MSgQJTC5wC6Ug3jdo4QPVtlmhjcIXbIitmljA4cjvnvEHOj/w4xCf1/wurYEnUj+2RS6C1g5RHrRDKN",
-	"CdQTRhP2scXpIoFHoBe8jBLRoi/TAet7l0QqhnrkcS3VO8R+RxUmR4d0O6pPFCLeWpL8I6PsJuFt6OG8",
-	"B9uBBAUyVNAxaduJRd7T8gEkBG4G9KGGDeENrk8kOwwUVQv+CKoqbghXtmObEXW7oYMV1l0GKv/8ehx9",
-	"0eskZcW0o5rKQYPV5CJhCvOo7ThQVtFnG27o0XITNWCw+rYgo3boTxNsEB0NZM+BRSQ3HcEUksbCbaBp",
-	"cUR5PfAUGIgelra9fcI3DTvt3O7I06hNrpBFMp6HOUN2jEbLJgaJSAfTG2YlnFyaC5YVzu1pUHLKN/JV",
-	"zRypXQxwzXyvuq3+PvlvbJUKDEWVNHpuU/EhbGJglgRsQy/r5Ativ4nKhEOBPeypuPeRQVbT0A2/bH1g",
-	"uSDQl61hvEoTxMjmFtel6dSsZOgZkV1FiRjUAvgTzOVyuqeYYXjK0mBgcUsB04WmfC6UtEHqaGtRBKWQ",
-	"bl3DcBcG77VE926G3jpSw7jMoRL94cbwZCRzTL7YzQjGzsqx8dUBYiDhlOrEN1PbrPBaJpCK7DJAh6OD",
-	"nXwS2/iBrnX9lKE8DZyV4Hx0Pdrp1++8R6jZlShyotgsdezEo1XQ3K9O+SarLLAaqk8PjgK03TXmYDvQ",
-	"oAgtte0CdGBN3o5lhsR64jFMyM37JH76TrhZajBmATv0orIuxA4hh9yK6L8W6GKRolgPYAnzzf+40l9J",
-	"Jg5nCHrN0FeRM5Rm1NuVvulpCeZbCN5O9o/BCmY5SncONJA+XXFegORLir9yPRL66hygq612vRfzebyY",
-	"f4gX86t4MV/Ei/mP8Q/zedfdSNOs/EPN3lp0mEE/+fnc5EFjpJoiwm1MaUYZLN1D2IvHtAIq09aScpYW",
-	"IeEG5usXJTXJ2OaRr9R2afyEIEHkpmaCIZfi1yeF1V///QeQuy9BnOJtB941Y7LMmpUrPDz+u7m/uMUl",
-	"rXOubXSTFlkZ3Tzc61W+q8UrIrQd5cPl/HIukvAKlbDKwDW4upxfXnH9IVsLLWawymQySWdbvXAa/u4Z",
-	"iaXN+UDsW/l6NKt2YpzuZPuzm9y6JjPPsW7z1Dut+tt87qNK3W7mKB82MViEdNUV/sX8w6TWV5NaLya0",
-	"/mGC3AYehdVNJH5+4takdVFAsukXJZcbXRJuqRQ+U6vKyYcWiLBqjF4c6KIm2NeBg7Lou3LhSZ2SGsZT",
-	"jjDqr5zRMHUYvnfzwLybsPFLa1xfmPVGaAbOC7Co+0D5nS3AH9+Br+WpKdT+9rh7sPJm2+56T9OGiBwx",
-	"NATER/HcAMQ0UvZdR3Kw8mIYqX7Dkcywola+iLZYWNV5vjkz8hAQrbsMQHBa1n9bvGzTgZOGjTPqU3o+",
-	"wBSO0/Kz9wfev0NssusVNbRHT54srXeHgTeNfskow2QT4VWk9sSxI4y3B3Vvn83ZB4Zn8LjzBnkA6cvl",
-	"1MGmRo3c9FwUsKKzrS4k+TN865rJZFR4rpLuzSnDGy9nYHhYxbygs9zonxY81tqcDoxY+JgVxtGzdwvQ",
-	"O/d+W7jsOIg/g8bNJoNj/DHg6IsCDvAElA+6Ff228WZ4ae2MkBFa8UUc5f/RPevpHR+8cX3fvn9X+9au",
-	"aOpwuOKAF7y8aO+fBNNAdwz11kQwuLlzpgIPFRjXjHxs8KKMKfkAsvYI0fZ//9OKo0JgYjmsL0uzD4w8",
-	"d8DOSBogSd5Ymw4mxTXmdUBvXqpuH+5dme5fX/zzFKarznTKD91FyZEQ35UZ9qxKG1c3943tvbv259ju",
-	"j+2VdpfD0/311g/su+vRHRJOFdzP1ehjVqMV//qo2KIAX053eqeHZXTvmwLeTz43wemKDdoPeYKzfHmx",
-	"6K0BYX/ydMaDBw/yKy0fHKTvORoa/XTb/28E3L7b3hf41jO9fxw+0+Ul45WsaBtPlBzGI3WFwXhkJI+O",
-	"iWBFQfPU/D8AAP//kSBmUqtBAAA=",
+	"H4sIAAAAAAAC/+wd23LbNvZXMNx92J2hJbVxdqZ+c+zUTadpPLEz+5D6ASKPLMQkwQKgbK1H/76DGwmS",
+	"gERdXDtTP8UigYNzB3AuzGOU0LykBRSCRyePUYkZzkEAU79Oy5LRBc4+pJdYzC/tO/kqBZ4wUgpCi+ik",
+	"Hog+nEdxROSjEot5FEcFziE6iXANKYojBn9WhEEanQhWQRzxZA45llBnlOVYRCdRVRE5UixLOZsLRorb",
+	"aLWKo3OaVDkUYiNKdmAQpbSGtCdKvwCWf2/EyIwLIjS3cPbE55LRaQb5RnzMuCA+pYWzFz4rPRm4eEdT",
+	"AkqvzhhgAVZC8klCC2H+xGWZkQRLJMffuMT00VmuZLQEJgyghGYZJJqeLnln9Tsk5oCsuNEUMlrcciRo",
+	"H9s4SrHAfVg/kwyQfIVIgaaYw3+Oo7ghfboU4AVm1rxWLx6jfzKYRSfRP8aN0Y01aXzcGruKI0FEpib1",
+	"BdwI46sZ1lnKkHFTo0Sn3yAR0ao9W4pyFRtpGG3YQxgtlm3C2x08EM9zSEgK1tXshWhCuMFynUis0zq3",
+	"41dxNANIpzi56+vIRUVSXCSAcJpCigRVajcnXFC2RHSmfkpEKMcZ+kanPo25pTjrg/4MZYYT4AqEHOID",
+	"h+7nUKBbsoDC6xba3DcUOQQNlMKXMsUCfqXTM1rMyO0eYrDsvcBCPyACcj5UKHJWtKpxxozhpfw9rdJb",
+	"EJugvNOjHBu9SuhmIz1vDV7FERR4msGHQgArcHYFmCVzR/mnlGaAleLkNIVsI3Uf5aiPuJQz7im7m2X0",
+	"ftMcO+53nENP0l4EB4laPeElLbjPaV9VSQKc7yF+kg7bzlxqSBrAveP6FaqNy7d0RLW3M3vx/lQ0m/bW",
+	"xDRTt6DJTPKQZBz49yAYc7JoEdH27rtQsc5GLFwfUnplZB1LC6v3jFG21wEl3ehUQK5xJgdKNwGc49sB",
+	"G78dGOs1hnBfEyOpeigpE9cMJ3BoRn+6/u1SAfYioNZFQr5vsfkCxD6OZYjL9uFzAcLvJC5AGA/xEZeH",
+	"xqiBHMLJGnmOywBaT4TTJoQ6yNSngEOjUwMOISTPO4ka0cVpDze4DiMDNoSPz6NdgPgMUuEPjYuGGkKF",
+	"qbddTJ7E2IOGfgEBK/8ifdah0VBAQ2hU8mULjd8IF6fqYsj33yyxBjT4+KoX7h9cO97dgh3i1iU9yEzo",
+	"EWrd3wFItZ5yOLGN791AbgN6MMH1lB7J7xeHoRcWWxGrlt1IKSy2I7O+4cHCS6zdTPRpgB/sQGsBDia/",
+	"jchGPnSXGcyQem80M3scuYQiJcWtPfEdwsYtqOFWXh83N9l5DXq4Rmj66vOqhwN6MzoA5WZbG054vU1u",
+	"oLsGvI0hqBktajvBiL/0GKLX9p9EVjZCqsPodYTSt3cMCQ46I1dxlKhbVHoqWvc0ic2RILk3EjnoThdH",
+	"pCgr4bl7xBGtROhVEyfe5doYt+LMDqEWm3ptl/C+2sSGzV/s9Wkdr3s0JDjLuPOGFAJuQV2WEso7jKbV",
+	"NHO4XFT5VA9VCF/TOygCsDQl60YIKnAWHuA9Jhh2aRraWHSWbMM3tHl56URZd1baTqRuB7VN1dV86ymH",
+	"CO4ODM1eyGgspERAiqZLFZRlsCBwD2wPO2ysrb3aJ/XcRn+1KNA9JkJuCDPK6k3BB/TJ7LS2T19YeZPV",
+	"vqvjtV2nnxPBJam4FeYeoQnKARccVUUmx0A6iuKOkub44YxyD9SP+IHkVY6k4stczper8yj2mHZOCjku",
+	"Opl4zDzHD+cVUxvKFSS0SPWa/SmOXef4obHqdUO7YZ56XlxT5cXAx1s3w9Zm0LRK7kCoiLHXHe6VVxuh",
+	"93kpljoh0RpDOCqoQLeMVqWWW3/pHbyEDR0JLCo+NNFmRu+Zphtq0EomQX4zEGx5RqtCBHaFQDIwjqoy",
+	"3Y5dPssOJBEdJWlR0ON4i4CW9rjydJFdp611Jsaf0NdZMGIyGoirlEbfT6AcLxEDnI7QaZY510XMwEyC",
+	"VKvolIq5egxSbfvepKGnfRDuCaObi2pKDNoTN6rL2rOzi057DR9X3y+8DmDXs6e6hg6ZBovtrWRw8FtN",
+	"b1bo7EcWjI8dNtDZj9Q/2aH6kPvu+r3UiSz36WsuRD0Mt0li/WVku+UwLRYYQtYxoNGjvYX8JLwJK3pN",
+	"688EsnTgqoxmG+2xHXD5LGcMZbtFxSwUu7mntfrYqhL4Oyf/h6rF1jUC2xjZgSoKej7Jy4aamFoocUfm",
+	"DjpdQfh0SVEd0ia11iaqsixXQAzfFiQFNmDOpR3qiWHpF4bWMNrGIeM0JXKbwtllC/uNwrZBqG4ISr1E",
+	"JTB7FZTLj9D1HNAfUQozXGXijwjdwVKevCsOqb4kmlzBPRFzWgmECwSFYMtR5CGgSegGKdAlgZ2b6vVv",
+	"l+Nfrz79bvJA/2LAacUSuCpxwf+NxBwLlOACTQFxeTUQVOLxqYTiGjLI5XkSmZMOZV7MnIq1vb38+tq1",
+	"wQbMB91AjPE0F5CBtX7ts7qDcb3wJodsModbHQ6+k4ORoWATB2pV9ga6B/pRLnXYU6ZHqUDq3Qi9x8kc",
+	"sarwVuoRjjBilAqp14M2Pp2+LXExNLKumKLxDLJBwevvzEIwMq3MthzyWaErS7NEMidZyqAYvLmvoTGO",
+	"UhPu+Ni+QJFCqFLc/pUZii0Dh2BLfXZV5ztSqIFQyNDO14hV2jKhbIfGsL0aC0qzKI6yLI9uPPCKULSg",
+	"xEzXrQ90SWxL8228mCWF3kWWQTeDTLPQcQLFEsc7aRCxq2Muho3UWgJ3dMmny2sj/ttm5r/4s5dxlFSM",
+	"QZEs906BmBj8oKKGqyrPMVtv5DViFnC8pnogjlpw+xvB95kHOWTqox0ZsRZQZliaLDx0Trmke+yVlfen",
+	"Bc6WnMhlsPzzfyBPu4wI+a9OD8iRtNC/ZzZa5vMCvTyFi5ROBddHawueg0K1VOxZB1Tdo/rdPPowiWcC",
+	"GLqfk2TuKTbHMjqvTpNoXuW4QI6H6zDNUHwzKHRbz2U0Ac41eYZVM0wySNcC6sktnal4ObtL6b1EJuEL",
+	"L4CmCrOZfTyZxMeTH+LjyZv4eHIcH09+it9OJjfe/WbhWb129b1UZgqZwJHOKn2WIgvJ33NvdxaouLp/",
+	"YM4JF7gQXhD1xceZeFuKo2MqESjF0Vv5L8isxNGPo7dHswzzeQjSpXNzssBoCQUmURwtgAl4wMQ7uX3s",
+	"9aqxITaK14k/jhJcJJDpv0ss7zXeBVvX2J6Wy6d1Ju1WXkHs+BjB6HaEJENTzFJEGfqzIsndR8ipMiyc",
+	"l5leSo/wHlc5JBUjYnklHbr2ru8AM2CnlVBhgqn69bN1p7/+9zoyZQMqeqDeNpDnQph6UVLMqMdoPxyd",
+	"0YJXmVQDdJrmpECnlx/qG8O6EQtg2rVEP4wmo4ny0FKkJYlOojejyeiNYrSYKyrGuCQmKszHj/VWtJLv",
+	"TPSFlqB37w+pqd84rQPWbovhV/8e2AwZB5rZVjed7oQfJ5PQjlqPG3sq/1ZxdDxkal07fTz5YavRb7Ya",
+	"fbzF6Ldb4O3oo+K6q4lfbyQ3uT0StOsJp8u6tlUfZvAtbxUoStBaI9wqqaAedKuzol3lGCrzemIRPS3T",
+	"LTHtnL67tdb8bwrH+hIYPzb9tquxWxFRUu4RTKezblsbDbUJr27c3s9lmG9Oe+i4g8tqF/3w95J8x6Z+",
+	"PPnpBejoVTXNiXD0Uatn0bTO4CJFDHiVg7fbcY3utoqLg96jzgHv7DZ69dAvSiue1LukDvOsKJpnN6s4",
+	"4B063do72HQHQt+mB3DU3374wmz6JVip6bHDtbwD4u5Z3vixqSVY6QNmBgL6CnGunjsKsd12EfqEg+dM",
+	"d+w5tlNkYr1I44e41oVZlWXL1/NcXyG0uByFkIe6+u/Wqa7tDrxu2GnVe0rJD2CFp2nwVfo96cseqG1F",
+	"b12D7jkJ3PE6rZ1yKPql+dqBzc7Fnm38/cJsQ898F2x3Cr0qj//cYDqPQjdB29FUa40J4hzluOTjx7qe",
+	"JBwfaHXbbq0Vgc/v7OxT+o2/r4oR8Cpun/J0Wf9sqce8ZqdHR1r6Mc6dnrPgFaDT8Pa86rKmA+9Vafze",
+	"pNe/t0lx6g5Bj/IMCD42Fv28+02/d/9VQza4ldCOY95vvrM+veAHX1xftuxf1L21SQJ5BG59wDc6PdJt",
+	"l4PdQFON+tyOoNew+uoKAq7A6a4NeYNvlpnGH2CRzPvy73627KAqsGU4rIvLTjHuQOvzqyb1NMk0am+v",
+	"TNbXuF3w4cSWHbRzQqvTtf/3CUyXDeusHOpHm7b4JsywY1Ta+WLBrnt755NDr3t7eG8va3F5JN21t+7G",
+	"vj4e3WjCU23ur9HoQ0ajrf8NueKWCwid6Z5e6MNOdC/bBbyc89wWQl/nDca6Aixc0XCm3j+9dvzoq55M",
+	"oJRfJUDXnRQ44oKW3NRVyvy4Lt21xTYjdIp0NVvDG47qWjdE8hxSggV8347lhexIiq1KCqwqChluaInK",
+	"9pNv3q309/YG30JNC85zO6z2lwlf/VXAX2nhBt2Vfu1qg+p2G6wMuhvpuXWh9W3IV1XwqoJ0FPAASaX7",
+	"LFVPo6+1C8vWLl5iOQYgqDhaTdbqzZiKrAwqj/MJ3+fVH8+3hF9VqKdC9svHA7WI6O/mNE20uulmuDqp",
+	"r54OdkNfTDf/87qh1rdhX3XI64ayLDdftJWlluorViGdqOq02ap++Nj9z28kox87/+FL61kdeu8/qzNz",
+	"zitTDOA8sVuk88jWjjuPnLibZyFcth5XvWWbilLnoTGK1c3q/wMAe07z7uZoAAA=",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
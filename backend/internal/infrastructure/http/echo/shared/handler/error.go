@@ -90,6 +90,8 @@ func usecaseErrToResponse(err *usecaseErrors.UseCaseError) int {
 		return http.StatusConflict
 	case usecaseErrors.NotFoundError:
 		return http.StatusNotFound
+	case usecaseErrors.ConflictError:
+		return http.StatusConflict
 	case usecaseErrors.InternalError:
 		return http.StatusInternalServerError
 	default:
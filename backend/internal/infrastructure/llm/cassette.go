@@ -0,0 +1,224 @@
+package llm
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	stdErrors "errors"
+	"fmt"
+	"iter"
+	"os"
+	"path/filepath"
+
+	llmClient "github.com/goda6565/ai-consultant/backend/internal/domain/llm"
+	"github.com/goda6565/ai-consultant/backend/internal/infrastructure/environment"
+	"github.com/goda6565/ai-consultant/backend/internal/infrastructure/errors"
+	"github.com/goda6565/ai-consultant/backend/internal/pkg/logger"
+)
+
+type CassetteMode string
+
+const (
+	CassetteModeOff    CassetteMode = "off"
+	CassetteModeRecord CassetteMode = "record"
+	CassetteModeReplay CassetteMode = "replay"
+)
+
+type CassetteConfig struct {
+	Mode CassetteMode
+	Dir  string
+}
+
+// ErrCassetteMiss is returned in replay mode when no recorded response matches the request.
+var ErrCassetteMiss = stdErrors.New("llm cassette miss")
+
+func NewCassetteConfig(e *environment.Environment) CassetteConfig {
+	return CassetteConfig{Mode: CassetteMode(e.LLMCassetteMode), Dir: e.LLMCassetteDir}
+}
+
+// CassetteClient records request/response pairs of the wrapped client to disk and replays them offline.
+// Each pair is stored as <dir>/<key>.json where key is a hash of the operation and the whole input.
+type CassetteClient struct {
+	client llmClient.LLMClient
+	config CassetteConfig
+	logger logger.Logger
+}
+
+type cassetteEntry struct {
+	Operation string          `json:"operation"`
+	Request   json.RawMessage `json:"request"`
+	Response  json.RawMessage `json:"response"`
+}
+
+type cassetteStream struct {
+	Chunks []*llmClient.GenerateTextStreamChunk `json:"chunks"`
+}
+
+func NewCassetteClient(client *RetryClient, config CassetteConfig, logger logger.Logger) (*CassetteClient, error) {
+	return newCassetteClient(client, config, logger)
+}
+
+func newCassetteClient(client llmClient.LLMClient, config CassetteConfig, logger logger.Logger) (*CassetteClient, error) {
+	if config.Mode == "" {
+		config.Mode = CassetteModeOff
+	}
+	switch config.Mode {
+	case CassetteModeOff:
+	case CassetteModeRecord, CassetteModeReplay:
+		if config.Dir == "" {
+			return nil, errors.NewInfrastructureError(errors.InternalError, fmt.Sprintf("cassette dir is required in %s mode", config.Mode))
+		}
+	default:
+		return nil, errors.NewInfrastructureError(errors.InternalError, fmt.Sprintf("invalid cassette mode %s", config.Mode))
+	}
+	if config.Mode == CassetteModeRecord {
+		if err := os.MkdirAll(config.Dir, 0o755); err != nil {
+			return nil, errors.NewInfrastructureError(errors.InternalError, fmt.Sprintf("failed to create cassette dir: %v", err))
+		}
+	}
+	if config.Mode != CassetteModeOff {
+		logger.Info("llm cassette enabled", "mode", config.Mode, "dir", config.Dir)
+	}
+	return &CassetteClient{client: client, config: config, logger: logger}, nil
+}
+
+func (c *CassetteClient) GenerateText(ctx context.Context, input llmClient.GenerateTextInput) (*llmClient.GenerateTextOutput, error) {
+	return cassette(c, "GenerateText", input, func() (*llmClient.GenerateTextOutput, error) {
+		return c.client.GenerateText(ctx, input)
+	})
+}
+
+// GenerateTextStream records all chunks of the stream as one response and replays them in order.
+func (c *CassetteClient) GenerateTextStream(ctx context.Context, input llmClient.GenerateTextInput) iter.Seq2[*llmClient.GenerateTextStreamChunk, error] {
+	if c.config.Mode == CassetteModeOff {
+		return c.client.GenerateTextStream(ctx, input)
+	}
+	return func(yield func(*llmClient.GenerateTextStreamChunk, error) bool) {
+		recorded, err := cassette(c, "GenerateTextStream", input, func() (*cassetteStream, error) {
+			chunks := []*llmClient.GenerateTextStreamChunk{}
+			for chunk, err := range c.client.GenerateTextStream(ctx, input) {
+				if err != nil {
+					return nil, err
+				}
+				chunks = append(chunks, chunk)
+			}
+			return &cassetteStream{Chunks: chunks}, nil
+		})
+		if err != nil {
+			yield(nil, err)
+			return
+		}
+		for _, chunk := range recorded.Chunks {
+			if !yield(chunk, nil) {
+				return
+			}
+		}
+	}
+}
+
+func (c *CassetteClient) GenerateStructuredText(ctx context.Context, input llmClient.GenerateStructuredTextInput) (*llmClient.GenerateStructuredTextOutput, error) {
+	return cassette(c, "GenerateStructuredText", input, func() (*llmClient.GenerateStructuredTextOutput, error) {
+		return c.client.GenerateStructuredText(ctx, input)
+	})
+}
+
+func (c *CassetteClient) GenerateFunctionCall(ctx context.Context, input llmClient.GenerateFunctionCallInput) (*llmClient.GenerateFunctionCallOutput, error) {
+	return cassette(c, "GenerateFunctionCall", input, func() (*llmClient.GenerateFunctionCallOutput, error) {
+		return c.client.GenerateFunctionCall(ctx, input)
+	})
+}
+
+func (c *CassetteClient) GenerateEmbedding(ctx context.Context, input llmClient.GenerateEmbeddingInput) (*llmClient.GenerateEmbeddingOutput, error) {
+	return cassette(c, "GenerateEmbedding", input, func() (*llmClient.GenerateEmbeddingOutput, error) {
+		return c.client.GenerateEmbedding(ctx, input)
+	})
+}
+
+func (c *CassetteClient) GenerateEmbeddingBatch(ctx context.Context, input llmClient.GenerateEmbeddingBatchInput) (*llmClient.GenerateEmbeddingBatchOutput, error) {
+	return cassette(c, "GenerateEmbeddingBatch", input, func() (*llmClient.GenerateEmbeddingBatchOutput, error) {
+		return c.client.GenerateEmbeddingBatch(ctx, input)
+	})
+}
+
+func (c *CassetteClient) GetTokenCount(ctx context.Context, input llmClient.CountTokenInput) (*llmClient.CountTokenOutput, error) {
+	return cassette(c, "GetTokenCount", input, func() (*llmClient.CountTokenOutput, error) {
+		return c.client.GetTokenCount(ctx, input)
+	})
+}
+
+func cassette[I any, O any](c *CassetteClient, operation string, input I, call func() (*O, error)) (*O, error) {
+	if c.config.Mode == CassetteModeOff {
+		return call()
+	}
+
+	request, err := json.Marshal(input)
+	if err != nil {
+		return nil, errors.NewInfrastructureError(errors.InternalError, fmt.Sprintf("failed to marshal cassette request: %v", err))
+	}
+	key := cassetteKey(operation, request)
+	path := filepath.Join(c.config.Dir, key+".json")
+
+	if c.config.Mode == CassetteModeReplay {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			if stdErrors.Is(err, os.ErrNotExist) {
+				c.logger.Error("llm cassette miss", "operation", operation, "key", key, "dir", c.config.Dir)
+				return nil, fmt.Errorf("%w: %s (key %s) is not recorded in %s; run in record mode to update the cassette", ErrCassetteMiss, operation, key, c.config.Dir)
+			}
+			return nil, errors.NewInfrastructureError(errors.InternalError, fmt.Sprintf("failed to read cassette: %v", err))
+		}
+		var entry cassetteEntry
+		if err := json.Unmarshal(data, &entry); err != nil {
+			return nil, errors.NewInfrastructureError(errors.InternalError, fmt.Sprintf("failed to unmarshal cassette %s: %v", path, err))
+		}
+		var output O
+		if err := json.Unmarshal(entry.Response, &output); err != nil {
+			return nil, errors.NewInfrastructureError(errors.InternalError, fmt.Sprintf("failed to unmarshal cassette response %s: %v", path, err))
+		}
+		return &output, nil
+	}
+
+	output, err := call()
+	if err != nil {
+		return nil, err
+	}
+	response, err := json.Marshal(output)
+	if err != nil {
+		return nil, errors.NewInfrastructureError(errors.InternalError, fmt.Sprintf("failed to marshal cassette response: %v", err))
+	}
+	data, err := json.MarshalIndent(cassetteEntry{Operation: operation, Request: request, Response: response}, "", "  ")
+	if err != nil {
+		return nil, errors.NewInfrastructureError(errors.InternalError, fmt.Sprintf("failed to marshal cassette: %v", err))
+	}
+	if err := writeFileAtomic(path, data); err != nil {
+		return nil, errors.NewInfrastructureError(errors.InternalError, fmt.Sprintf("failed to write cassette: %v", err))
+	}
+	return output, nil
+}
+
+// cassetteKey hashes the operation and the marshaled input.
+// json.Marshal compacts json.RawMessage, so formatting of schemas does not change the key.
+func cassetteKey(operation string, request []byte) string {
+	hash := sha256.New()
+	hash.Write([]byte(operation))
+	hash.Write([]byte{0})
+	hash.Write(request)
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+func writeFileAtomic(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
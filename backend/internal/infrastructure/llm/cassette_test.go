@@ -0,0 +1,79 @@
+package llm
+
+import (
+	"context"
+	"encoding/json"
+	stdErrors "errors"
+	"testing"
+
+	llmClient "github.com/goda6565/ai-consultant/backend/internal/domain/llm"
+	"github.com/goda6565/ai-consultant/backend/internal/domain/llm/mock"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCassetteClient_RecordAndReplay(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockClient := mock.NewMockLLMClient(ctrl)
+	dir := t.TempDir()
+
+	input := llmClient.GenerateStructuredTextInput{
+		UserPrompt: "decide",
+		Schema:     json.RawMessage(`{"type": "object"}`),
+		Config:     llmClient.LLMConfig{Provider: llmClient.VertexAI, Model: llmClient.Gemini25Flash},
+	}
+	output := &llmClient.GenerateStructuredTextOutput{Text: `{"ok": true}`, Usage: llmClient.Usage{TotalTokens: 10}}
+	mockClient.EXPECT().GenerateStructuredText(gomock.Any(), input).Return(output, nil).Times(1)
+
+	recorder, err := newCassetteClient(mockClient, CassetteConfig{Mode: CassetteModeRecord, Dir: dir}, nopLogger{})
+	if err != nil {
+		t.Fatalf("failed to create recorder: %v", err)
+	}
+	if _, err := recorder.GenerateStructuredText(context.Background(), input); err != nil {
+		t.Fatalf("unexpected error while recording: %v", err)
+	}
+
+	// replay must not call the wrapped client
+	player, err := newCassetteClient(nil, CassetteConfig{Mode: CassetteModeReplay, Dir: dir}, nopLogger{})
+	if err != nil {
+		t.Fatalf("failed to create player: %v", err)
+	}
+	// schema formatting does not change the key
+	reformatted := input
+	reformatted.Schema = json.RawMessage("{\n  \"type\": \"object\"\n}")
+	replayed, err := player.GenerateStructuredText(context.Background(), reformatted)
+	if err != nil {
+		t.Fatalf("unexpected error while replaying: %v", err)
+	}
+	if replayed.Text != output.Text || replayed.Usage != output.Usage {
+		t.Errorf("replayed = %+v, expected %+v", replayed, output)
+	}
+
+	changed := input
+	changed.UserPrompt = "decide again"
+	if _, err := player.GenerateStructuredText(context.Background(), changed); !stdErrors.Is(err, ErrCassetteMiss) {
+		t.Errorf("error = %v, expected %v", err, ErrCassetteMiss)
+	}
+}
+
+func TestNewCassetteClient(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  CassetteConfig
+		wantErr bool
+	}{
+		{name: "off without dir", config: CassetteConfig{Mode: CassetteModeOff}, wantErr: false},
+		{name: "empty mode is off", config: CassetteConfig{}, wantErr: false},
+		{name: "replay without dir", config: CassetteConfig{Mode: CassetteModeReplay}, wantErr: true},
+		{name: "unknown mode", config: CassetteConfig{Mode: "rewind", Dir: t.TempDir()}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := newCassetteClient(nil, tt.config, nopLogger{})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("newCassetteClient() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,106 @@
+package llm
+
+import (
+	"context"
+	"fmt"
+	"iter"
+
+	llmClient "github.com/goda6565/ai-consultant/backend/internal/domain/llm"
+	"github.com/goda6565/ai-consultant/backend/internal/infrastructure/environment"
+	"github.com/goda6565/ai-consultant/backend/internal/infrastructure/errors"
+	"github.com/goda6565/ai-consultant/backend/internal/infrastructure/google/gemini"
+	"github.com/goda6565/ai-consultant/backend/internal/infrastructure/openai"
+)
+
+// ProviderClient routes each call to the client of the provider specified in the input config.
+type ProviderClient struct {
+	clients map[llmClient.Provider]llmClient.LLMClient
+}
+
+// NewProviderClient builds the clients of all providers. In the cassette replay mode every call is answered from the cassettes,
+// so no provider client is built and no credentials are required.
+func NewProviderClient(ctx context.Context, e *environment.Environment, cassetteConfig CassetteConfig) *ProviderClient {
+	if cassetteConfig.Mode == CassetteModeReplay {
+		return &ProviderClient{clients: map[llmClient.Provider]llmClient.LLMClient{}}
+	}
+	return newProviderClient(gemini.NewGeminiClient(ctx, e), openai.NewOpenAIClient(e))
+}
+
+func newProviderClient(geminiClient *gemini.GeminiClient, openaiClient *openai.OpenAIClient) *ProviderClient {
+	return &ProviderClient{
+		clients: map[llmClient.Provider]llmClient.LLMClient{
+			llmClient.VertexAI: geminiClient,
+			llmClient.OpenAI:   openaiClient,
+		},
+	}
+}
+
+func (c *ProviderClient) GenerateText(ctx context.Context, input llmClient.GenerateTextInput) (*llmClient.GenerateTextOutput, error) {
+	client, err := c.client(input.Config.Provider)
+	if err != nil {
+		return nil, err
+	}
+	return client.GenerateText(ctx, input)
+}
+
+func (c *ProviderClient) GenerateTextStream(ctx context.Context, input llmClient.GenerateTextInput) iter.Seq2[*llmClient.GenerateTextStreamChunk, error] {
+	client, err := c.client(input.Config.Provider)
+	if err != nil {
+		return errorStream(err)
+	}
+	return client.GenerateTextStream(ctx, input)
+}
+
+func (c *ProviderClient) GenerateStructuredText(ctx context.Context, input llmClient.GenerateStructuredTextInput) (*llmClient.GenerateStructuredTextOutput, error) {
+	client, err := c.client(input.Config.Provider)
+	if err != nil {
+		return nil, err
+	}
+	return client.GenerateStructuredText(ctx, input)
+}
+
+func (c *ProviderClient) GenerateFunctionCall(ctx context.Context, input llmClient.GenerateFunctionCallInput) (*llmClient.GenerateFunctionCallOutput, error) {
+	client, err := c.client(input.Config.Provider)
+	if err != nil {
+		return nil, err
+	}
+	return client.GenerateFunctionCall(ctx, input)
+}
+
+func (c *ProviderClient) GenerateEmbedding(ctx context.Context, input llmClient.GenerateEmbeddingInput) (*llmClient.GenerateEmbeddingOutput, error) {
+	client, err := c.client(input.Config.Provider)
+	if err != nil {
+		return nil, err
+	}
+	return client.GenerateEmbedding(ctx, input)
+}
+
+func (c *ProviderClient) GenerateEmbeddingBatch(ctx context.Context, input llmClient.GenerateEmbeddingBatchInput) (*llmClient.GenerateEmbeddingBatchOutput, error) {
+	client, err := c.client(input.Config.Provider)
+	if err != nil {
+		return nil, err
+	}
+	return client.GenerateEmbeddingBatch(ctx, input)
+}
+
+func (c *ProviderClient) GetTokenCount(ctx context.Context, input llmClient.CountTokenInput) (*llmClient.CountTokenOutput, error) {
+	client, err := c.client(input.Config.Provider)
+	if err != nil {
+		return nil, err
+	}
+	return client.GetTokenCount(ctx, input)
+}
+
+func (c *ProviderClient) client(provider llmClient.Provider) (llmClient.LLMClient, error) {
+	client, ok := c.clients[provider]
+	if !ok {
+		return nil, errors.NewInfrastructureError(errors.BadRequestError, fmt.Sprintf("unsupported llm provider: %s", provider))
+	}
+	return client, nil
+}
+
+func errorStream(err error) iter.Seq2[*llmClient.GenerateTextStreamChunk, error] {
+	return func(yield func(*llmClient.GenerateTextStreamChunk, error) bool) {
+		yield(nil, err)
+	}
+}
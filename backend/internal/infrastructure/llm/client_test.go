@@ -0,0 +1,21 @@
+package llm
+
+import (
+	"context"
+	"testing"
+
+	llmClient "github.com/goda6565/ai-consultant/backend/internal/domain/llm"
+	"github.com/goda6565/ai-consultant/backend/internal/infrastructure/environment"
+)
+
+func TestNewProviderClient_Replay(t *testing.T) {
+	// replay must work without the credentials of the providers
+	client := NewProviderClient(context.Background(), &environment.Environment{}, CassetteConfig{Mode: CassetteModeReplay, Dir: t.TempDir()})
+
+	_, err := client.GenerateText(context.Background(), llmClient.GenerateTextInput{
+		Config: llmClient.LLMConfig{Provider: llmClient.VertexAI, Model: llmClient.Gemini25Flash},
+	})
+	if err == nil {
+		t.Error("expected an error for a call that reaches the provider in replay mode")
+	}
+}
@@ -0,0 +1,35 @@
+package llm
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+
+	usageValue "github.com/goda6565/ai-consultant/backend/internal/domain/usage/value"
+	"github.com/goda6565/ai-consultant/backend/internal/infrastructure/environment"
+	"github.com/goda6565/ai-consultant/backend/internal/infrastructure/errors"
+)
+
+type priceConfig struct {
+	Input  float64 `json:"input"`
+	Output float64 `json:"output"`
+}
+
+// NewPriceTable creates the price table from the default prices overridden by LLM_PRICE_TABLE.
+func NewPriceTable(e *environment.Environment) (*usageValue.PriceTable, error) {
+	prices := maps.Clone(usageValue.DefaultPrices)
+	if e.LLMPriceTable != "" {
+		var overrides map[string]priceConfig
+		if err := json.Unmarshal([]byte(e.LLMPriceTable), &overrides); err != nil {
+			return nil, errors.NewInfrastructureError(errors.InternalError, fmt.Sprintf("failed to parse LLM_PRICE_TABLE: %v", err))
+		}
+		for model, price := range overrides {
+			prices[model] = usageValue.Price{InputPerMillionTokens: price.Input, OutputPerMillionTokens: price.Output}
+		}
+	}
+	priceTable, err := usageValue.NewPriceTable(prices)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create price table: %w", err)
+	}
+	return priceTable, nil
+}
@@ -0,0 +1,206 @@
+package llm
+
+import (
+	"context"
+	"encoding/json"
+	stdErrors "errors"
+	"fmt"
+	"iter"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	llmClient "github.com/goda6565/ai-consultant/backend/internal/domain/llm"
+	sharedValue "github.com/goda6565/ai-consultant/backend/internal/domain/shared/value"
+	traceEntity "github.com/goda6565/ai-consultant/backend/internal/domain/trace/entity"
+	traceRepository "github.com/goda6565/ai-consultant/backend/internal/domain/trace/repository"
+	traceValue "github.com/goda6565/ai-consultant/backend/internal/domain/trace/value"
+	usageRepository "github.com/goda6565/ai-consultant/backend/internal/domain/usage/repository"
+	"github.com/goda6565/ai-consultant/backend/internal/infrastructure/errors"
+	"github.com/goda6565/ai-consultant/backend/internal/pkg/logger"
+)
+
+type ReplayConfig struct {
+	ProblemID string
+}
+
+// ErrReplayMiss is returned when no recorded span matches the request.
+var ErrReplayMiss = stdErrors.New("llm replay miss")
+
+// ReplayClient answers every call with the response recorded in the trace of a past run.
+// Requests are matched by the operation, the model and the prompts; identical requests
+// receive the recorded responses in the order they were made.
+type ReplayClient struct {
+	mu        sync.Mutex
+	responses map[replayKey][]traceEntity.Span
+}
+
+type replayKey struct {
+	name         string
+	provider     string
+	model        string
+	systemPrompt string
+	userPrompt   string
+	messages     string
+	input        string
+}
+
+func NewReplayClient(ctx context.Context, config ReplayConfig, spanRepository traceRepository.SpanRepository) (*ReplayClient, error) {
+	problemID, err := sharedValue.NewID(config.ProblemID)
+	if err != nil {
+		return nil, err
+	}
+	spans, err := spanRepository.FindByProblemID(ctx, problemID)
+	if err != nil {
+		return nil, err
+	}
+	return newReplayClient(spans), nil
+}
+
+func newReplayClient(spans []traceEntity.Span) *ReplayClient {
+	sorted := append([]traceEntity.Span{}, spans...)
+	sort.SliceStable(sorted, func(a, b int) bool {
+		return sorted[a].GetStartedAt().Before(sorted[b].GetStartedAt())
+	})
+	responses := map[replayKey][]traceEntity.Span{}
+	for _, span := range sorted {
+		if !span.GetKind().Equals(traceValue.SpanKindLLM) {
+			continue
+		}
+		attributes := span.GetAttributes()
+		key := replayKey{
+			name:         span.GetName(),
+			provider:     attributes["llm.provider"],
+			model:        attributes["llm.model"],
+			systemPrompt: attributes["llm.system_prompt"],
+			userPrompt:   attributes["llm.user_prompt"],
+			messages:     attributes["llm.messages"],
+			input:        attributes["llm.input"],
+		}
+		responses[key] = append(responses[key], span)
+	}
+	return &ReplayClient{responses: responses}
+}
+
+func (c *ReplayClient) GenerateText(ctx context.Context, input llmClient.GenerateTextInput) (*llmClient.GenerateTextOutput, error) {
+	span, err := c.next(generateKey("generateText", input.Config, input.SystemPrompt, input.UserPrompt, input.Messages))
+	if err != nil {
+		return nil, err
+	}
+	return &llmClient.GenerateTextOutput{Text: span.GetAttributes()["llm.output"], Usage: replayUsage(span)}, nil
+}
+
+// GenerateTextStream yields the recorded text as one chunk.
+func (c *ReplayClient) GenerateTextStream(ctx context.Context, input llmClient.GenerateTextInput) iter.Seq2[*llmClient.GenerateTextStreamChunk, error] {
+	return func(yield func(*llmClient.GenerateTextStreamChunk, error) bool) {
+		span, err := c.next(generateKey("generateTextStream", input.Config, input.SystemPrompt, input.UserPrompt, input.Messages))
+		if err != nil {
+			yield(nil, err)
+			return
+		}
+		usage := replayUsage(span)
+		yield(&llmClient.GenerateTextStreamChunk{Delta: span.GetAttributes()["llm.output"], Usage: &usage}, nil)
+	}
+}
+
+func (c *ReplayClient) GenerateStructuredText(ctx context.Context, input llmClient.GenerateStructuredTextInput) (*llmClient.GenerateStructuredTextOutput, error) {
+	span, err := c.next(generateKey("generateStructuredText", input.Config, input.SystemPrompt, input.UserPrompt, input.Messages))
+	if err != nil {
+		return nil, err
+	}
+	return &llmClient.GenerateStructuredTextOutput{Text: span.GetAttributes()["llm.output"], Usage: replayUsage(span)}, nil
+}
+
+func (c *ReplayClient) GenerateFunctionCall(ctx context.Context, input llmClient.GenerateFunctionCallInput) (*llmClient.GenerateFunctionCallOutput, error) {
+	span, err := c.next(generateKey("generateFunctionCall", input.Config, input.SystemPrompt, input.UserPrompt, input.Messages))
+	if err != nil {
+		return nil, err
+	}
+	attributes := span.GetAttributes()
+	output := &llmClient.GenerateFunctionCallOutput{Text: attributes["llm.output"], Usage: replayUsage(span)}
+	if calls := attributes["llm.function_calls"]; calls != "" && calls != "null" {
+		if err := json.Unmarshal([]byte(calls), &output.FunctionCalls); err != nil {
+			return nil, errors.NewInfrastructureError(errors.InternalError, fmt.Sprintf("failed to unmarshal recorded function calls: %v", err))
+		}
+	}
+	if len(output.FunctionCalls) > 0 {
+		output.FunctionCall = output.FunctionCalls[0]
+	}
+	return output, nil
+}
+
+// GenerateEmbedding returns an empty vector: the trace does not keep vectors,
+// and the document search that consumes them is replayed from the trace as well.
+func (c *ReplayClient) GenerateEmbedding(ctx context.Context, input llmClient.GenerateEmbeddingInput) (*llmClient.GenerateEmbeddingOutput, error) {
+	span, err := c.next(replayKey{name: "llm.generateEmbedding", provider: string(input.Config.Provider), model: string(input.Config.Model), input: input.Text})
+	if err != nil {
+		return nil, err
+	}
+	return &llmClient.GenerateEmbeddingOutput{Embedding: []float32{}, Usage: replayUsage(span)}, nil
+}
+
+// GenerateEmbeddingBatch is not used by the proposal job, so it is not replayed.
+func (c *ReplayClient) GenerateEmbeddingBatch(ctx context.Context, input llmClient.GenerateEmbeddingBatchInput) (*llmClient.GenerateEmbeddingBatchOutput, error) {
+	return nil, fmt.Errorf("%w: generateEmbeddingBatch is not replayable", ErrReplayMiss)
+}
+
+func (c *ReplayClient) GetTokenCount(ctx context.Context, input llmClient.CountTokenInput) (*llmClient.CountTokenOutput, error) {
+	span, err := c.next(replayKey{name: "llm.getTokenCount", provider: string(input.Config.Provider), model: string(input.Config.Model), input: input.Text})
+	if err != nil {
+		return nil, err
+	}
+	tokenCount, err := strconv.Atoi(span.GetAttributes()["llm.token_count"])
+	if err != nil {
+		return nil, errors.NewInfrastructureError(errors.InternalError, fmt.Sprintf("failed to parse recorded token count: %v", err))
+	}
+	return &llmClient.CountTokenOutput{TokenCount: tokenCount}, nil
+}
+
+// next pops the oldest recorded span of the request. A recorded failure is returned as an error.
+func (c *ReplayClient) next(key replayKey) (*traceEntity.Span, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	spans := c.responses[key]
+	if len(spans) == 0 {
+		return nil, fmt.Errorf("%w: %s (%s) with user prompt %q is not recorded", ErrReplayMiss, key.name, key.model, truncate(key.userPrompt+key.input, 100))
+	}
+	span := spans[0]
+	c.responses[key] = spans[1:]
+	if span.GetStatus().Equals(traceValue.SpanStatusError) {
+		return nil, fmt.Errorf("recorded %s failed: %s", key.name, span.GetError())
+	}
+	return &span, nil
+}
+
+func generateKey(method string, config llmClient.LLMConfig, systemPrompt string, userPrompt string, messages []llmClient.Message) replayKey {
+	return replayKey{
+		name:         "llm." + method,
+		provider:     string(config.Provider),
+		model:        string(config.Model),
+		systemPrompt: systemPrompt,
+		userPrompt:   userPrompt,
+		messages:     marshalMessages(messages),
+	}
+}
+
+func replayUsage(span *traceEntity.Span) llmClient.Usage {
+	attributes := span.GetAttributes()
+	inputTokens, _ := strconv.Atoi(attributes["llm.usage.input_tokens"])
+	outputTokens, _ := strconv.Atoi(attributes["llm.usage.output_tokens"])
+	totalTokens, _ := strconv.Atoi(attributes["llm.usage.total_tokens"])
+	return llmClient.Usage{InputTokens: inputTokens, OutputTokens: outputTokens, TotalTokens: totalTokens}
+}
+
+func truncate(text string, length int) string {
+	runes := []rune(strings.TrimSpace(text))
+	if len(runes) <= length {
+		return string(runes)
+	}
+	return string(runes[:length]) + "..."
+}
+
+// NewReplayUsageClient records the replayed usage to the ledger so that budget checks behave as in the recorded run.
+func NewReplayUsageClient(client *ReplayClient, usageRepository usageRepository.UsageRepository, logger logger.Logger) llmClient.LLMClient {
+	return newUsageClient(client, usageRepository, logger)
+}
@@ -0,0 +1,88 @@
+package llm
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	llmClient "github.com/goda6565/ai-consultant/backend/internal/domain/llm"
+	"github.com/goda6565/ai-consultant/backend/internal/domain/llm/mock"
+	sharedValue "github.com/goda6565/ai-consultant/backend/internal/domain/shared/value"
+	traceEntity "github.com/goda6565/ai-consultant/backend/internal/domain/trace/entity"
+	traceMock "github.com/goda6565/ai-consultant/backend/internal/domain/trace/repository/mock"
+	traceService "github.com/goda6565/ai-consultant/backend/internal/domain/trace/service"
+	"go.uber.org/mock/gomock"
+)
+
+func TestReplayClient(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	usage := llmClient.Usage{InputTokens: 10, OutputTokens: 5, TotalTokens: 15}
+	functionInput := llmClient.GenerateFunctionCallInput{UserPrompt: "search", Config: testInput.Config}
+	call := llmClient.FunctionCall{ID: "call-1", Name: "web_search", Arguments: map[string]any{"query": "DX"}}
+	tokenInput := llmClient.CountTokenInput{Text: "history", Config: testInput.Config}
+
+	// record a run through the trace client
+	mockClient := mock.NewMockLLMClient(ctrl)
+	gomock.InOrder(
+		mockClient.EXPECT().GenerateText(gomock.Any(), testInput).Return(&llmClient.GenerateTextOutput{Text: "first", Usage: usage}, nil),
+		mockClient.EXPECT().GenerateText(gomock.Any(), testInput).Return(&llmClient.GenerateTextOutput{Text: "second", Usage: usage}, nil),
+	)
+	mockClient.EXPECT().GenerateFunctionCall(gomock.Any(), functionInput).Return(&llmClient.GenerateFunctionCallOutput{FunctionCall: call, FunctionCalls: []llmClient.FunctionCall{call}, Usage: usage}, nil)
+	mockClient.EXPECT().GetTokenCount(gomock.Any(), tokenInput).Return(&llmClient.CountTokenOutput{TokenCount: 42}, nil)
+	mockClient.EXPECT().GenerateStructuredText(gomock.Any(), gomock.Any()).Return(nil, errors.New("unavailable"))
+	mockRepo := traceMock.NewMockSpanRepository(ctrl)
+	spans := []traceEntity.Span{}
+	mockRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, span *traceEntity.Span) error {
+		spans = append(spans, *span)
+		return nil
+	}).AnyTimes()
+
+	ctx, run := traceService.NewTracer(mockRepo).StartRun(context.Background(), sharedValue.ID("problem-id"), "proposal")
+	recorder := newTraceClient(mockClient)
+	for range 2 {
+		if _, err := recorder.GenerateText(ctx, testInput); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := recorder.GenerateFunctionCall(ctx, functionInput); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := recorder.GetTokenCount(ctx, tokenInput); err != nil {
+		t.Fatal(err)
+	}
+	structuredInput := llmClient.GenerateStructuredTextInput{UserPrompt: "structured", Config: testInput.Config}
+	if _, err := recorder.GenerateStructuredText(ctx, structuredInput); err == nil {
+		t.Fatal("expected the recorded error")
+	}
+	run.End(nil)
+
+	// replay it
+	replay := newReplayClient(spans)
+	for _, expected := range []string{"first", "second"} {
+		output, err := replay.GenerateText(context.Background(), testInput)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if output.Text != expected || output.Usage != usage {
+			t.Errorf("output = %+v, expected %s with %+v", output, expected, usage)
+		}
+	}
+	if _, err := replay.GenerateText(context.Background(), testInput); !errors.Is(err, ErrReplayMiss) {
+		t.Errorf("error = %v, expected a replay miss after the recorded calls", err)
+	}
+	functionOutput, err := replay.GenerateFunctionCall(context.Background(), functionInput)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if functionOutput.FunctionCall.Name != "web_search" || functionOutput.FunctionCall.Arguments["query"] != "DX" || len(functionOutput.FunctionCalls) != 1 {
+		t.Errorf("function call = %+v", functionOutput.FunctionCall)
+	}
+	tokenOutput, err := replay.GetTokenCount(context.Background(), tokenInput)
+	if err != nil || tokenOutput.TokenCount != 42 {
+		t.Errorf("token count = %v, %v, expected 42", tokenOutput, err)
+	}
+	if _, err := replay.GenerateStructuredText(context.Background(), structuredInput); err == nil || errors.Is(err, ErrReplayMiss) {
+		t.Errorf("error = %v, expected the recorded error", err)
+	}
+}
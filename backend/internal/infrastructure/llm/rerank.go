@@ -0,0 +1,10 @@
+package llm
+
+import (
+	"github.com/goda6565/ai-consultant/backend/internal/domain/search"
+	"github.com/goda6565/ai-consultant/backend/internal/infrastructure/environment"
+)
+
+func NewRerankConfig(e *environment.Environment) search.RerankConfig {
+	return search.RerankConfig{Enabled: e.DocumentSearchRerank}
+}
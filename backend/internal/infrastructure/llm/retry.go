@@ -0,0 +1,251 @@
+package llm
+
+import (
+	"context"
+	stdErrors "errors"
+	"fmt"
+	"iter"
+	"math/rand/v2"
+	"sync"
+	"time"
+
+	llmClient "github.com/goda6565/ai-consultant/backend/internal/domain/llm"
+	"github.com/goda6565/ai-consultant/backend/internal/infrastructure/errors"
+	"github.com/goda6565/ai-consultant/backend/internal/pkg/logger"
+)
+
+const (
+	defaultMaxAttempts             = 4
+	defaultInitialBackoff          = 1 * time.Second
+	defaultMaxBackoff              = 20 * time.Second
+	defaultCircuitFailureThreshold = 5
+	defaultCircuitOpenDuration     = 30 * time.Second
+)
+
+type RetryConfig struct {
+	MaxAttempts    int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	// 連続でこの回数だけ一時的なエラーが発生したらサーキットを開く
+	CircuitFailureThreshold int
+	CircuitOpenDuration     time.Duration
+}
+
+func DefaultRetryConfig() RetryConfig {
+	return RetryConfig{
+		MaxAttempts:             defaultMaxAttempts,
+		InitialBackoff:          defaultInitialBackoff,
+		MaxBackoff:              defaultMaxBackoff,
+		CircuitFailureThreshold: defaultCircuitFailureThreshold,
+		CircuitOpenDuration:     defaultCircuitOpenDuration,
+	}
+}
+
+// RetryClient retries transient errors of the wrapped client with jittered exponential backoff.
+// A circuit breaker per provider fails fast while the provider keeps failing.
+type RetryClient struct {
+	client   llmClient.LLMClient
+	logger   logger.Logger
+	config   RetryConfig
+	mu       sync.Mutex
+	circuits map[llmClient.Provider]*circuit
+	now      func() time.Time
+	sleep    func(ctx context.Context, d time.Duration) error
+}
+
+type circuit struct {
+	consecutiveFailures int
+	openUntil           time.Time
+}
+
+func NewRetryClient(client *ProviderClient, logger logger.Logger) *RetryClient {
+	return newRetryClient(client, logger, DefaultRetryConfig())
+}
+
+func newRetryClient(client llmClient.LLMClient, logger logger.Logger, config RetryConfig) *RetryClient {
+	return &RetryClient{
+		client:   client,
+		logger:   logger,
+		config:   config,
+		circuits: make(map[llmClient.Provider]*circuit),
+		now:      time.Now,
+		sleep:    sleepWithContext,
+	}
+}
+
+func (c *RetryClient) GenerateText(ctx context.Context, input llmClient.GenerateTextInput) (*llmClient.GenerateTextOutput, error) {
+	return retry(ctx, c, input.Config.Provider, "GenerateText", func() (*llmClient.GenerateTextOutput, error) {
+		return c.client.GenerateText(ctx, input)
+	})
+}
+
+// GenerateTextStream retries only until the first chunk arrives.
+// Once a chunk has been yielded a retry would duplicate the text, so later errors are returned as they are.
+func (c *RetryClient) GenerateTextStream(ctx context.Context, input llmClient.GenerateTextInput) iter.Seq2[*llmClient.GenerateTextStreamChunk, error] {
+	return func(yield func(*llmClient.GenerateTextStreamChunk, error) bool) {
+		_, err := retry(ctx, c, input.Config.Provider, "GenerateTextStream", func() (*struct{}, error) {
+			started := false
+			for chunk, err := range c.client.GenerateTextStream(ctx, input) {
+				if err != nil {
+					if started {
+						return nil, errors.NewInfrastructureError(errors.ExternalServiceError, fmt.Sprintf("llm stream interrupted: %v", err))
+					}
+					return nil, err
+				}
+				started = true
+				if !yield(chunk, nil) {
+					break
+				}
+			}
+			return &struct{}{}, nil
+		})
+		if err != nil {
+			yield(nil, err)
+		}
+	}
+}
+
+func (c *RetryClient) GenerateStructuredText(ctx context.Context, input llmClient.GenerateStructuredTextInput) (*llmClient.GenerateStructuredTextOutput, error) {
+	return retry(ctx, c, input.Config.Provider, "GenerateStructuredText", func() (*llmClient.GenerateStructuredTextOutput, error) {
+		return c.client.GenerateStructuredText(ctx, input)
+	})
+}
+
+func (c *RetryClient) GenerateFunctionCall(ctx context.Context, input llmClient.GenerateFunctionCallInput) (*llmClient.GenerateFunctionCallOutput, error) {
+	return retry(ctx, c, input.Config.Provider, "GenerateFunctionCall", func() (*llmClient.GenerateFunctionCallOutput, error) {
+		return c.client.GenerateFunctionCall(ctx, input)
+	})
+}
+
+func (c *RetryClient) GenerateEmbedding(ctx context.Context, input llmClient.GenerateEmbeddingInput) (*llmClient.GenerateEmbeddingOutput, error) {
+	return retry(ctx, c, input.Config.Provider, "GenerateEmbedding", func() (*llmClient.GenerateEmbeddingOutput, error) {
+		return c.client.GenerateEmbedding(ctx, input)
+	})
+}
+
+func (c *RetryClient) GenerateEmbeddingBatch(ctx context.Context, input llmClient.GenerateEmbeddingBatchInput) (*llmClient.GenerateEmbeddingBatchOutput, error) {
+	return retry(ctx, c, input.Config.Provider, "GenerateEmbeddingBatch", func() (*llmClient.GenerateEmbeddingBatchOutput, error) {
+		return c.client.GenerateEmbeddingBatch(ctx, input)
+	})
+}
+
+func (c *RetryClient) GetTokenCount(ctx context.Context, input llmClient.CountTokenInput) (*llmClient.CountTokenOutput, error) {
+	return retry(ctx, c, input.Config.Provider, "GetTokenCount", func() (*llmClient.CountTokenOutput, error) {
+		return c.client.GetTokenCount(ctx, input)
+	})
+}
+
+func retry[T any](ctx context.Context, c *RetryClient, provider llmClient.Provider, operation string, call func() (*T, error)) (*T, error) {
+	var lastErr error
+	for attempt := 1; attempt <= c.config.MaxAttempts; attempt++ {
+		if err := c.allow(provider); err != nil {
+			if lastErr != nil {
+				return nil, fmt.Errorf("%w (last error: %v)", err, lastErr)
+			}
+			return nil, err
+		}
+
+		output, err := call()
+		if err == nil {
+			c.recordSuccess(provider)
+			return output, nil
+		}
+		lastErr = err
+
+		if ctx.Err() != nil || !IsTransientError(err) {
+			return nil, err
+		}
+		c.recordFailure(provider)
+
+		if attempt == c.config.MaxAttempts {
+			break
+		}
+		wait := c.backoff(attempt)
+		if deadline, ok := ctx.Deadline(); ok && c.now().Add(wait).After(deadline) {
+			c.logger.Warn("llm retry aborted by context deadline", "operation", operation, "provider", provider, "attempt", attempt, "error", err)
+			return nil, err
+		}
+		c.logger.Warn("llm call failed, retrying", "operation", operation, "provider", provider, "attempt", attempt, "maxAttempts", c.config.MaxAttempts, "wait", wait.String(), "error", err)
+		if err := c.sleep(ctx, wait); err != nil {
+			return nil, lastErr
+		}
+	}
+	c.logger.Error("llm call failed after retries", "operation", operation, "provider", provider, "attempts", c.config.MaxAttempts, "error", lastErr)
+	return nil, lastErr
+}
+
+// IsTransientError reports whether the error is worth retrying.
+func IsTransientError(err error) bool {
+	if stdErrors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	var infraErr *errors.InfrastructureError
+	if !stdErrors.As(err, &infraErr) {
+		return false
+	}
+	switch infraErr.ErrorType {
+	case errors.RateLimitError, errors.UnavailableError, errors.TimeoutError:
+		return true
+	default:
+		return false
+	}
+}
+
+// backoff returns an exponential backoff with equal jitter for the given attempt.
+func (c *RetryClient) backoff(attempt int) time.Duration {
+	backoff := c.config.InitialBackoff << (attempt - 1)
+	if backoff <= 0 || backoff > c.config.MaxBackoff {
+		backoff = c.config.MaxBackoff
+	}
+	half := backoff / 2
+	return half + rand.N(half+1)
+}
+
+func (c *RetryClient) allow(provider llmClient.Provider) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	cb := c.circuit(provider)
+	if c.now().Before(cb.openUntil) {
+		return errors.NewInfrastructureError(errors.ExternalServiceError, fmt.Sprintf("circuit breaker is open for provider %s", provider))
+	}
+	return nil
+}
+
+func (c *RetryClient) recordSuccess(provider llmClient.Provider) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	cb := c.circuit(provider)
+	cb.consecutiveFailures = 0
+	cb.openUntil = time.Time{}
+}
+
+func (c *RetryClient) recordFailure(provider llmClient.Provider) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	cb := c.circuit(provider)
+	cb.consecutiveFailures++
+	if cb.consecutiveFailures >= c.config.CircuitFailureThreshold {
+		// half-open: 期限後の試行で再び失敗した場合はすぐに開き直す
+		cb.consecutiveFailures = c.config.CircuitFailureThreshold - 1
+		cb.openUntil = c.now().Add(c.config.CircuitOpenDuration)
+		c.logger.Error("llm circuit breaker opened", "provider", provider, "openUntil", cb.openUntil)
+	}
+}
+
+func (c *RetryClient) circuit(provider llmClient.Provider) *circuit {
+	if _, ok := c.circuits[provider]; !ok {
+		c.circuits[provider] = &circuit{}
+	}
+	return c.circuits[provider]
+}
+
+func sleepWithContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,281 @@
+package llm
+
+import (
+	"context"
+	stdErrors "errors"
+	"iter"
+	"testing"
+	"time"
+
+	llmClient "github.com/goda6565/ai-consultant/backend/internal/domain/llm"
+	"github.com/goda6565/ai-consultant/backend/internal/domain/llm/mock"
+	"github.com/goda6565/ai-consultant/backend/internal/infrastructure/errors"
+	"go.uber.org/mock/gomock"
+)
+
+type nopLogger struct{}
+
+func (nopLogger) Info(string, ...interface{})  {}
+func (nopLogger) Debug(string, ...interface{}) {}
+func (nopLogger) Warn(string, ...interface{})  {}
+func (nopLogger) Error(string, ...interface{}) {}
+func (nopLogger) Fatal(string, ...interface{}) {}
+func (nopLogger) Panic(string, ...interface{}) {}
+func (nopLogger) LogUsage(llmClient.Usage)     {}
+func (nopLogger) Sync() error                  { return nil }
+
+var testInput = llmClient.GenerateTextInput{
+	UserPrompt: "hello",
+	Config:     llmClient.LLMConfig{Provider: llmClient.VertexAI, Model: llmClient.Gemini25Flash},
+}
+
+func newTestRetryClient(client llmClient.LLMClient, now *time.Time) (*RetryClient, *[]time.Duration) {
+	retryClient := newRetryClient(client, nopLogger{}, RetryConfig{
+		MaxAttempts:             3,
+		InitialBackoff:          100 * time.Millisecond,
+		MaxBackoff:              time.Second,
+		CircuitFailureThreshold: 5,
+		CircuitOpenDuration:     time.Minute,
+	})
+	waits := []time.Duration{}
+	retryClient.now = func() time.Time { return *now }
+	retryClient.sleep = func(ctx context.Context, d time.Duration) error {
+		waits = append(waits, d)
+		return nil
+	}
+	return retryClient, &waits
+}
+
+func TestRetryClient_GenerateText(t *testing.T) {
+	rateLimitErr := errors.NewInfrastructureError(errors.RateLimitError, "rate limited")
+	unavailableErr := errors.NewInfrastructureError(errors.UnavailableError, "unavailable")
+	badRequestErr := errors.NewInfrastructureError(errors.ExternalServiceError, "bad request")
+	output := &llmClient.GenerateTextOutput{Text: "ok"}
+
+	tests := []struct {
+		name          string
+		mockSetup     func(m *mock.MockLLMClient)
+		expectedErr   error
+		expectedWaits int
+	}{
+		{
+			name: "retries transient errors until success",
+			mockSetup: func(m *mock.MockLLMClient) {
+				gomock.InOrder(
+					m.EXPECT().GenerateText(gomock.Any(), testInput).Return(nil, rateLimitErr),
+					m.EXPECT().GenerateText(gomock.Any(), testInput).Return(nil, unavailableErr),
+					m.EXPECT().GenerateText(gomock.Any(), testInput).Return(output, nil),
+				)
+			},
+			expectedErr:   nil,
+			expectedWaits: 2,
+		},
+		{
+			name: "does not retry non-transient errors",
+			mockSetup: func(m *mock.MockLLMClient) {
+				m.EXPECT().GenerateText(gomock.Any(), testInput).Return(nil, badRequestErr).Times(1)
+			},
+			expectedErr:   badRequestErr,
+			expectedWaits: 0,
+		},
+		{
+			name: "returns last error after max attempts",
+			mockSetup: func(m *mock.MockLLMClient) {
+				gomock.InOrder(
+					m.EXPECT().GenerateText(gomock.Any(), testInput).Return(nil, rateLimitErr),
+					m.EXPECT().GenerateText(gomock.Any(), testInput).Return(nil, rateLimitErr),
+					m.EXPECT().GenerateText(gomock.Any(), testInput).Return(nil, unavailableErr),
+				)
+			},
+			expectedErr:   unavailableErr,
+			expectedWaits: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockClient := mock.NewMockLLMClient(ctrl)
+			tt.mockSetup(mockClient)
+
+			now := time.Now()
+			retryClient, waits := newTestRetryClient(mockClient, &now)
+			result, err := retryClient.GenerateText(context.Background(), testInput)
+
+			if tt.expectedErr != nil {
+				if !stdErrors.Is(err, tt.expectedErr) {
+					t.Errorf("error = %v, expected %v", err, tt.expectedErr)
+				}
+			} else if err != nil || result.Text != output.Text {
+				t.Errorf("result = %v, error = %v", result, err)
+			}
+			if len(*waits) != tt.expectedWaits {
+				t.Errorf("waits = %d, expected %d", len(*waits), tt.expectedWaits)
+			}
+		})
+	}
+}
+
+func testStream(deltas []string, err error) iter.Seq2[*llmClient.GenerateTextStreamChunk, error] {
+	return func(yield func(*llmClient.GenerateTextStreamChunk, error) bool) {
+		for _, delta := range deltas {
+			if !yield(&llmClient.GenerateTextStreamChunk{Delta: delta}, nil) {
+				return
+			}
+		}
+		if err != nil {
+			yield(nil, err)
+			return
+		}
+		yield(&llmClient.GenerateTextStreamChunk{Usage: &llmClient.Usage{TotalTokens: 10}}, nil)
+	}
+}
+
+func TestRetryClient_GenerateTextStream(t *testing.T) {
+	unavailableErr := errors.NewInfrastructureError(errors.UnavailableError, "unavailable")
+
+	tests := []struct {
+		name           string
+		mockSetup      func(m *mock.MockLLMClient)
+		expectedDeltas string
+		expectedError  bool
+		expectedWaits  int
+	}{
+		{
+			name: "retries errors before the first chunk",
+			mockSetup: func(m *mock.MockLLMClient) {
+				gomock.InOrder(
+					m.EXPECT().GenerateTextStream(gomock.Any(), testInput).Return(testStream(nil, unavailableErr)),
+					m.EXPECT().GenerateTextStream(gomock.Any(), testInput).Return(testStream([]string{"he", "llo"}, nil)),
+				)
+			},
+			expectedDeltas: "hello",
+			expectedWaits:  1,
+		},
+		{
+			name: "does not retry after the first chunk",
+			mockSetup: func(m *mock.MockLLMClient) {
+				m.EXPECT().GenerateTextStream(gomock.Any(), testInput).Return(testStream([]string{"he"}, unavailableErr)).Times(1)
+			},
+			expectedDeltas: "he",
+			expectedError:  true,
+			expectedWaits:  0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockClient := mock.NewMockLLMClient(ctrl)
+			tt.mockSetup(mockClient)
+
+			now := time.Now()
+			retryClient, waits := newTestRetryClient(mockClient, &now)
+			deltas := ""
+			var streamErr error
+			for chunk, err := range retryClient.GenerateTextStream(context.Background(), testInput) {
+				if err != nil {
+					streamErr = err
+					break
+				}
+				deltas += chunk.Delta
+			}
+
+			if (streamErr != nil) != tt.expectedError {
+				t.Errorf("error = %v, expectedError %v", streamErr, tt.expectedError)
+			}
+			if deltas != tt.expectedDeltas {
+				t.Errorf("deltas = %s, expected %s", deltas, tt.expectedDeltas)
+			}
+			if len(*waits) != tt.expectedWaits {
+				t.Errorf("waits = %d, expected %d", len(*waits), tt.expectedWaits)
+			}
+		})
+	}
+}
+
+func TestRetryClient_CircuitBreaker(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockClient := mock.NewMockLLMClient(ctrl)
+	unavailableErr := errors.NewInfrastructureError(errors.UnavailableError, "unavailable")
+
+	now := time.Now()
+	retryClient, _ := newTestRetryClient(mockClient, &now)
+
+	// 5 consecutive failures open the circuit: 3 attempts on the first call and 2 on the second
+	mockClient.EXPECT().GenerateText(gomock.Any(), testInput).Return(nil, unavailableErr).Times(5)
+	if _, err := retryClient.GenerateText(context.Background(), testInput); err == nil {
+		t.Fatal("expected error on first call")
+	}
+	_, err := retryClient.GenerateText(context.Background(), testInput)
+	var infraErr *errors.InfrastructureError
+	if !stdErrors.As(err, &infraErr) || infraErr.ErrorType != errors.ExternalServiceError {
+		t.Fatalf("expected circuit breaker error, got %v", err)
+	}
+
+	// the circuit is open, so the client must not be called
+	if _, err := retryClient.GenerateText(context.Background(), testInput); err == nil {
+		t.Fatal("expected error while circuit is open")
+	}
+
+	// other providers are not affected
+	openAIInput := llmClient.GenerateTextInput{Config: llmClient.LLMConfig{Provider: llmClient.OpenAI, Model: llmClient.GPT4o}}
+	mockClient.EXPECT().GenerateText(gomock.Any(), openAIInput).Return(&llmClient.GenerateTextOutput{Text: "ok"}, nil).Times(1)
+	if _, err := retryClient.GenerateText(context.Background(), openAIInput); err != nil {
+		t.Fatalf("unexpected error for other provider: %v", err)
+	}
+
+	// after the open duration a successful call closes the circuit
+	now = now.Add(2 * time.Minute)
+	mockClient.EXPECT().GenerateText(gomock.Any(), testInput).Return(&llmClient.GenerateTextOutput{Text: "ok"}, nil).Times(1)
+	if _, err := retryClient.GenerateText(context.Background(), testInput); err != nil {
+		t.Fatalf("unexpected error after circuit closed: %v", err)
+	}
+}
+
+func TestRetryClient_ContextDeadline(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockClient := mock.NewMockLLMClient(ctrl)
+	rateLimitErr := errors.NewInfrastructureError(errors.RateLimitError, "rate limited")
+	mockClient.EXPECT().GenerateText(gomock.Any(), testInput).Return(nil, rateLimitErr).Times(1)
+
+	now := time.Now()
+	retryClient, waits := newTestRetryClient(mockClient, &now)
+	ctx, cancel := context.WithDeadline(context.Background(), now.Add(10*time.Millisecond))
+	defer cancel()
+
+	_, err := retryClient.GenerateText(ctx, testInput)
+	if !stdErrors.Is(err, rateLimitErr) {
+		t.Errorf("error = %v, expected %v", err, rateLimitErr)
+	}
+	if len(*waits) != 0 {
+		t.Errorf("waits = %d, expected 0", len(*waits))
+	}
+}
+
+func TestRetryClient_Backoff(t *testing.T) {
+	now := time.Now()
+	retryClient, _ := newTestRetryClient(nil, &now)
+
+	tests := []struct {
+		attempt int
+		min     time.Duration
+		max     time.Duration
+	}{
+		{attempt: 1, min: 50 * time.Millisecond, max: 100 * time.Millisecond},
+		{attempt: 2, min: 100 * time.Millisecond, max: 200 * time.Millisecond},
+		{attempt: 10, min: 500 * time.Millisecond, max: time.Second},
+	}
+
+	for _, tt := range tests {
+		for range 20 {
+			if got := retryClient.backoff(tt.attempt); got < tt.min || got > tt.max {
+				t.Errorf("backoff(%d) = %v, expected between %v and %v", tt.attempt, got, tt.min, tt.max)
+			}
+		}
+	}
+}
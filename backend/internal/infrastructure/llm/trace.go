@@ -0,0 +1,177 @@
+package llm
+
+import (
+	"context"
+	"encoding/json"
+	"iter"
+	"strings"
+	"time"
+
+	llmClient "github.com/goda6565/ai-consultant/backend/internal/domain/llm"
+	traceService "github.com/goda6565/ai-consultant/backend/internal/domain/trace/service"
+	traceValue "github.com/goda6565/ai-consultant/backend/internal/domain/trace/value"
+)
+
+// TraceClient records every call as a span of the trace carried by the context:
+// the prompts, the raw output, the usage and the latency.
+type TraceClient struct {
+	client llmClient.LLMClient
+}
+
+func NewTraceClient(client *UsageClient) llmClient.LLMClient {
+	return newTraceClient(client)
+}
+
+func newTraceClient(client llmClient.LLMClient) *TraceClient {
+	return &TraceClient{client: client}
+}
+
+func (c *TraceClient) GenerateText(ctx context.Context, input llmClient.GenerateTextInput) (*llmClient.GenerateTextOutput, error) {
+	ctx, span := c.start(ctx, "generateText", input.Config.Provider, string(input.Config.Model))
+	span.SetAttribute("llm.system_prompt", input.SystemPrompt)
+	span.SetAttribute("llm.user_prompt", input.UserPrompt)
+	setMessages(span, input.Messages)
+	startedAt := time.Now()
+	output, err := c.client.GenerateText(ctx, input)
+	span.SetAttribute("llm.latency_ms", time.Since(startedAt).Milliseconds())
+	if err == nil {
+		span.SetAttribute("llm.output", output.Text)
+		setUsage(span, output.Usage)
+	}
+	span.End(err)
+	return output, err
+}
+
+func (c *TraceClient) GenerateTextStream(ctx context.Context, input llmClient.GenerateTextInput) iter.Seq2[*llmClient.GenerateTextStreamChunk, error] {
+	return func(yield func(*llmClient.GenerateTextStreamChunk, error) bool) {
+		ctx, span := c.start(ctx, "generateTextStream", input.Config.Provider, string(input.Config.Model))
+		span.SetAttribute("llm.system_prompt", input.SystemPrompt)
+		span.SetAttribute("llm.user_prompt", input.UserPrompt)
+		setMessages(span, input.Messages)
+		startedAt := time.Now()
+		var text strings.Builder
+		var streamErr error
+		defer func() {
+			span.SetAttribute("llm.latency_ms", time.Since(startedAt).Milliseconds())
+			span.SetAttribute("llm.output", text.String())
+			span.End(streamErr)
+		}()
+		for chunk, err := range c.client.GenerateTextStream(ctx, input) {
+			if err != nil {
+				streamErr = err
+			} else {
+				text.WriteString(chunk.Delta)
+				if chunk.Usage != nil {
+					setUsage(span, *chunk.Usage)
+				}
+			}
+			if !yield(chunk, err) {
+				return
+			}
+		}
+	}
+}
+
+func (c *TraceClient) GenerateStructuredText(ctx context.Context, input llmClient.GenerateStructuredTextInput) (*llmClient.GenerateStructuredTextOutput, error) {
+	ctx, span := c.start(ctx, "generateStructuredText", input.Config.Provider, string(input.Config.Model))
+	span.SetAttribute("llm.system_prompt", input.SystemPrompt)
+	span.SetAttribute("llm.user_prompt", input.UserPrompt)
+	setMessages(span, input.Messages)
+	startedAt := time.Now()
+	output, err := c.client.GenerateStructuredText(ctx, input)
+	span.SetAttribute("llm.latency_ms", time.Since(startedAt).Milliseconds())
+	if err == nil {
+		span.SetAttribute("llm.output", output.Text)
+		setUsage(span, output.Usage)
+	}
+	span.End(err)
+	return output, err
+}
+
+func (c *TraceClient) GenerateFunctionCall(ctx context.Context, input llmClient.GenerateFunctionCallInput) (*llmClient.GenerateFunctionCallOutput, error) {
+	ctx, span := c.start(ctx, "generateFunctionCall", input.Config.Provider, string(input.Config.Model))
+	span.SetAttribute("llm.system_prompt", input.SystemPrompt)
+	span.SetAttribute("llm.user_prompt", input.UserPrompt)
+	setMessages(span, input.Messages)
+	startedAt := time.Now()
+	output, err := c.client.GenerateFunctionCall(ctx, input)
+	span.SetAttribute("llm.latency_ms", time.Since(startedAt).Milliseconds())
+	if err == nil {
+		span.SetAttribute("llm.output", output.Text)
+		if calls, err := json.Marshal(output.FunctionCalls); err == nil {
+			span.SetAttribute("llm.function_calls", string(calls))
+		}
+		setUsage(span, output.Usage)
+	}
+	span.End(err)
+	return output, err
+}
+
+func (c *TraceClient) GenerateEmbedding(ctx context.Context, input llmClient.GenerateEmbeddingInput) (*llmClient.GenerateEmbeddingOutput, error) {
+	ctx, span := c.start(ctx, "generateEmbedding", input.Config.Provider, string(input.Config.Model))
+	span.SetAttribute("llm.input", input.Text)
+	startedAt := time.Now()
+	output, err := c.client.GenerateEmbedding(ctx, input)
+	span.SetAttribute("llm.latency_ms", time.Since(startedAt).Milliseconds())
+	if err == nil {
+		setUsage(span, output.Usage)
+	}
+	span.End(err)
+	return output, err
+}
+
+func (c *TraceClient) GenerateEmbeddingBatch(ctx context.Context, input llmClient.GenerateEmbeddingBatchInput) (*llmClient.GenerateEmbeddingBatchOutput, error) {
+	ctx, span := c.start(ctx, "generateEmbeddingBatch", input.Config.Provider, string(input.Config.Model))
+	span.SetAttribute("llm.inputs", len(input.Texts))
+	startedAt := time.Now()
+	output, err := c.client.GenerateEmbeddingBatch(ctx, input)
+	span.SetAttribute("llm.latency_ms", time.Since(startedAt).Milliseconds())
+	if err == nil {
+		setUsage(span, output.Usage)
+	}
+	span.End(err)
+	return output, err
+}
+
+// GetTokenCount is traced as well so that a replay takes the same summarize decisions.
+func (c *TraceClient) GetTokenCount(ctx context.Context, input llmClient.CountTokenInput) (*llmClient.CountTokenOutput, error) {
+	ctx, span := c.start(ctx, "getTokenCount", input.Config.Provider, string(input.Config.Model))
+	span.SetAttribute("llm.input", input.Text)
+	output, err := c.client.GetTokenCount(ctx, input)
+	if err == nil {
+		span.SetAttribute("llm.token_count", output.TokenCount)
+	}
+	span.End(err)
+	return output, err
+}
+
+func (c *TraceClient) start(ctx context.Context, method string, provider llmClient.Provider, model string) (context.Context, *traceService.ActiveSpan) {
+	ctx, span := traceService.Start(ctx, "llm."+method, traceValue.SpanKindLLM)
+	span.SetAttribute("llm.provider", provider)
+	span.SetAttribute("llm.model", model)
+	return ctx, span
+}
+
+func setMessages(span *traceService.ActiveSpan, messages []llmClient.Message) {
+	if data := marshalMessages(messages); data != "" {
+		span.SetAttribute("llm.messages", data)
+	}
+}
+
+// marshalMessages returns the messages as JSON, or an empty string when there is none
+func marshalMessages(messages []llmClient.Message) string {
+	if len(messages) == 0 {
+		return ""
+	}
+	data, err := json.Marshal(messages)
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
+
+func setUsage(span *traceService.ActiveSpan, usage llmClient.Usage) {
+	span.SetAttribute("llm.usage.input_tokens", usage.InputTokens)
+	span.SetAttribute("llm.usage.output_tokens", usage.OutputTokens)
+	span.SetAttribute("llm.usage.total_tokens", usage.TotalTokens)
+}
@@ -0,0 +1,88 @@
+package llm
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	llmClient "github.com/goda6565/ai-consultant/backend/internal/domain/llm"
+	"github.com/goda6565/ai-consultant/backend/internal/domain/llm/mock"
+	sharedValue "github.com/goda6565/ai-consultant/backend/internal/domain/shared/value"
+	traceEntity "github.com/goda6565/ai-consultant/backend/internal/domain/trace/entity"
+	traceMock "github.com/goda6565/ai-consultant/backend/internal/domain/trace/repository/mock"
+	traceService "github.com/goda6565/ai-consultant/backend/internal/domain/trace/service"
+	traceValue "github.com/goda6565/ai-consultant/backend/internal/domain/trace/value"
+	"go.uber.org/mock/gomock"
+)
+
+func TestTraceClient_GenerateText(t *testing.T) {
+	usage := llmClient.Usage{InputTokens: 10, OutputTokens: 5, TotalTokens: 15}
+
+	tests := []struct {
+		name           string
+		output         *llmClient.GenerateTextOutput
+		err            error
+		expectedStatus traceValue.SpanStatus
+		expected       map[string]string
+	}{
+		{
+			name:           "records prompt, output and usage",
+			output:         &llmClient.GenerateTextOutput{Text: "ok", Usage: usage},
+			expectedStatus: traceValue.SpanStatusOK,
+			expected: map[string]string{
+				"llm.provider":            "vertexai",
+				"llm.model":               string(llmClient.Gemini25Flash),
+				"llm.user_prompt":         "hello",
+				"llm.output":              "ok",
+				"llm.usage.input_tokens":  "10",
+				"llm.usage.output_tokens": "5",
+				"llm.usage.total_tokens":  "15",
+			},
+		},
+		{
+			name:           "records error",
+			err:            errors.New("unavailable"),
+			expectedStatus: traceValue.SpanStatusError,
+			expected:       map[string]string{"llm.user_prompt": "hello"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockClient := mock.NewMockLLMClient(ctrl)
+			mockRepo := traceMock.NewMockSpanRepository(ctrl)
+			mockClient.EXPECT().GenerateText(gomock.Any(), testInput).Return(tt.output, tt.err).Times(1)
+			var saved *traceEntity.Span
+			mockRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, span *traceEntity.Span) error {
+				if span.GetKind() == traceValue.SpanKindLLM {
+					saved = span
+				}
+				return nil
+			}).Times(2)
+
+			ctx, run := traceService.NewTracer(mockRepo).StartRun(context.Background(), sharedValue.ID("problem-id"), "proposal")
+			_, err := newTraceClient(mockClient).GenerateText(ctx, testInput)
+			run.End(nil)
+			if err != tt.err {
+				t.Fatalf("error = %v, expected %v", err, tt.err)
+			}
+			if saved == nil {
+				t.Fatal("llm span is not saved")
+			}
+			if saved.GetName() != "llm.generateText" || saved.GetStatus() != tt.expectedStatus {
+				t.Errorf("name = %s, status = %s", saved.GetName(), saved.GetStatus())
+			}
+			attributes := saved.GetAttributes()
+			for key, value := range tt.expected {
+				if attributes[key] != value {
+					t.Errorf("%s = %q, expected %q", key, attributes[key], value)
+				}
+			}
+			if _, ok := attributes["llm.latency_ms"]; !ok {
+				t.Error("latency is not recorded")
+			}
+		})
+	}
+}
@@ -0,0 +1,110 @@
+package llm
+
+import (
+	"context"
+	"iter"
+
+	llmClient "github.com/goda6565/ai-consultant/backend/internal/domain/llm"
+	sharedValue "github.com/goda6565/ai-consultant/backend/internal/domain/shared/value"
+	usageEntity "github.com/goda6565/ai-consultant/backend/internal/domain/usage/entity"
+	usageRepository "github.com/goda6565/ai-consultant/backend/internal/domain/usage/repository"
+	usageValue "github.com/goda6565/ai-consultant/backend/internal/domain/usage/value"
+	"github.com/goda6565/ai-consultant/backend/internal/pkg/logger"
+	"github.com/goda6565/ai-consultant/backend/internal/pkg/uuid"
+)
+
+// UsageClient records the usage of every successful call to the ledger.
+// Calls are recorded only when the context carries a usage scope.
+type UsageClient struct {
+	client          llmClient.LLMClient
+	usageRepository usageRepository.UsageRepository
+	logger          logger.Logger
+}
+
+func NewUsageClient(client *CassetteClient, usageRepository usageRepository.UsageRepository, logger logger.Logger) *UsageClient {
+	return newUsageClient(client, usageRepository, logger)
+}
+
+func newUsageClient(client llmClient.LLMClient, usageRepository usageRepository.UsageRepository, logger logger.Logger) *UsageClient {
+	return &UsageClient{client: client, usageRepository: usageRepository, logger: logger}
+}
+
+func (c *UsageClient) GenerateText(ctx context.Context, input llmClient.GenerateTextInput) (*llmClient.GenerateTextOutput, error) {
+	output, err := c.client.GenerateText(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+	c.record(ctx, input.Config.Provider, string(input.Config.Model), output.Usage)
+	return output, nil
+}
+
+func (c *UsageClient) GenerateTextStream(ctx context.Context, input llmClient.GenerateTextInput) iter.Seq2[*llmClient.GenerateTextStreamChunk, error] {
+	return func(yield func(*llmClient.GenerateTextStreamChunk, error) bool) {
+		for chunk, err := range c.client.GenerateTextStream(ctx, input) {
+			if err == nil && chunk.Usage != nil {
+				c.record(ctx, input.Config.Provider, string(input.Config.Model), *chunk.Usage)
+			}
+			if !yield(chunk, err) {
+				return
+			}
+		}
+	}
+}
+
+func (c *UsageClient) GenerateStructuredText(ctx context.Context, input llmClient.GenerateStructuredTextInput) (*llmClient.GenerateStructuredTextOutput, error) {
+	output, err := c.client.GenerateStructuredText(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+	c.record(ctx, input.Config.Provider, string(input.Config.Model), output.Usage)
+	return output, nil
+}
+
+func (c *UsageClient) GenerateFunctionCall(ctx context.Context, input llmClient.GenerateFunctionCallInput) (*llmClient.GenerateFunctionCallOutput, error) {
+	output, err := c.client.GenerateFunctionCall(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+	c.record(ctx, input.Config.Provider, string(input.Config.Model), output.Usage)
+	return output, nil
+}
+
+func (c *UsageClient) GenerateEmbedding(ctx context.Context, input llmClient.GenerateEmbeddingInput) (*llmClient.GenerateEmbeddingOutput, error) {
+	output, err := c.client.GenerateEmbedding(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+	c.record(ctx, input.Config.Provider, string(input.Config.Model), output.Usage)
+	return output, nil
+}
+
+func (c *UsageClient) GenerateEmbeddingBatch(ctx context.Context, input llmClient.GenerateEmbeddingBatchInput) (*llmClient.GenerateEmbeddingBatchOutput, error) {
+	output, err := c.client.GenerateEmbeddingBatch(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+	c.record(ctx, input.Config.Provider, string(input.Config.Model), output.Usage)
+	return output, nil
+}
+
+func (c *UsageClient) GetTokenCount(ctx context.Context, input llmClient.CountTokenInput) (*llmClient.CountTokenOutput, error) {
+	return c.client.GetTokenCount(ctx, input)
+}
+
+// record never fails the call: a missing ledger entry is better than a failed proposal.
+func (c *UsageClient) record(ctx context.Context, provider llmClient.Provider, model string, usage llmClient.Usage) {
+	c.logger.LogUsage(usage)
+	scope, ok := usageValue.ScopeFromContext(ctx)
+	if !ok {
+		return
+	}
+	id, err := sharedValue.NewID(uuid.NewUUID())
+	if err != nil {
+		c.logger.Warn("failed to create usage id", "error", err)
+		return
+	}
+	entity := usageEntity.NewUsage(id, scope.ProblemID, scope.ActionType, provider, model, usage, nil)
+	if err := c.usageRepository.Create(ctx, entity); err != nil {
+		c.logger.Warn("failed to record llm usage", "problemID", scope.ProblemID.Value(), "actionType", scope.ActionType.Value(), "error", err)
+	}
+}
@@ -0,0 +1,63 @@
+package llm
+
+import (
+	"context"
+	"testing"
+
+	actionValue "github.com/goda6565/ai-consultant/backend/internal/domain/action/value"
+	llmClient "github.com/goda6565/ai-consultant/backend/internal/domain/llm"
+	"github.com/goda6565/ai-consultant/backend/internal/domain/llm/mock"
+	sharedValue "github.com/goda6565/ai-consultant/backend/internal/domain/shared/value"
+	usageEntity "github.com/goda6565/ai-consultant/backend/internal/domain/usage/entity"
+	usageMock "github.com/goda6565/ai-consultant/backend/internal/domain/usage/repository/mock"
+	usageValue "github.com/goda6565/ai-consultant/backend/internal/domain/usage/value"
+	"go.uber.org/mock/gomock"
+)
+
+func TestUsageClient_GenerateText(t *testing.T) {
+	problemID := sharedValue.ID("problem-id")
+	usage := llmClient.Usage{InputTokens: 10, OutputTokens: 5, TotalTokens: 15}
+	isPlanUsage := gomock.Cond(func(x any) bool {
+		u, ok := x.(*usageEntity.Usage)
+		return ok && u.GetProblemID() == problemID && u.GetActionType() == actionValue.ActionTypePlan &&
+			u.GetProvider() == llmClient.VertexAI && u.GetModel() == string(llmClient.Gemini25Flash) && u.GetUsage() == usage
+	})
+
+	tests := []struct {
+		name      string
+		ctx       context.Context
+		mockSetup func(m *mock.MockLLMClient, r *usageMock.MockUsageRepository)
+	}{
+		{
+			name: "records usage with scope",
+			ctx:  usageValue.WithScope(context.Background(), problemID, actionValue.ActionTypePlan),
+			mockSetup: func(m *mock.MockLLMClient, r *usageMock.MockUsageRepository) {
+				m.EXPECT().GenerateText(gomock.Any(), testInput).Return(&llmClient.GenerateTextOutput{Text: "ok", Usage: usage}, nil).Times(1)
+				r.EXPECT().Create(gomock.Any(), isPlanUsage).Return(nil).Times(1)
+			},
+		},
+		{
+			name: "does not record usage without scope",
+			ctx:  context.Background(),
+			mockSetup: func(m *mock.MockLLMClient, r *usageMock.MockUsageRepository) {
+				m.EXPECT().GenerateText(gomock.Any(), testInput).Return(&llmClient.GenerateTextOutput{Text: "ok", Usage: usage}, nil).Times(1)
+				r.EXPECT().Create(gomock.Any(), gomock.Any()).Times(0)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockClient := mock.NewMockLLMClient(ctrl)
+			mockRepo := usageMock.NewMockUsageRepository(ctrl)
+			tt.mockSetup(mockClient, mockRepo)
+
+			result, err := newUsageClient(mockClient, mockRepo, nopLogger{}).GenerateText(tt.ctx, testInput)
+			if err != nil || result.Text != "ok" {
+				t.Errorf("result = %v, error = %v", result, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,28 @@
+package llm
+
+import (
+	"github.com/google/wire"
+)
+
+// ClientSet requires a CassetteConfig so that commands can choose the cassette mode.
+var ClientSet = wire.NewSet(
+	NewProviderClient,
+	NewRetryClient,
+	NewCassetteClient,
+	NewUsageClient,
+	NewTraceClient,
+	NewPriceTable,
+	NewRerankConfig,
+)
+
+var Set = wire.NewSet(
+	ClientSet,
+	NewCassetteConfig,
+)
+
+// ReplaySet answers every call from the trace of a past run. It requires a ReplayConfig.
+var ReplaySet = wire.NewSet(
+	NewReplayClient,
+	NewReplayUsageClient,
+	NewPriceTable,
+)
@@ -0,0 +1,426 @@
+package openai
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	stdErrors "errors"
+	"fmt"
+	"io"
+	"iter"
+	"net"
+	"net/http"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/goda6565/ai-consultant/backend/internal/domain/llm"
+	"github.com/goda6565/ai-consultant/backend/internal/infrastructure/environment"
+	"github.com/goda6565/ai-consultant/backend/internal/infrastructure/errors"
+)
+
+const (
+	chatCompletionsPath = "/chat/completions"
+	embeddingsPath      = "/embeddings"
+	structuredOutputKey = "response"
+	streamDoneData      = "[DONE]"
+	maxStreamLineSize   = 1024 * 1024
+)
+
+type OpenAIClient struct {
+	httpClient *http.Client
+	baseURL    string
+	apiKey     string
+}
+
+func NewOpenAIClient(e *environment.Environment) *OpenAIClient {
+	return &OpenAIClient{
+		httpClient: &http.Client{},
+		baseURL:    strings.TrimRight(e.OpenAIBaseURL, "/"),
+		apiKey:     e.OpenAIAPIKey,
+	}
+}
+
+func (c *OpenAIClient) GenerateText(ctx context.Context, input llm.GenerateTextInput) (*llm.GenerateTextOutput, error) {
+	request, err := newChatCompletionRequest(input.Config, input.Conversation, input.Temperature)
+	if err != nil {
+		return nil, err
+	}
+	response, err := c.createChatCompletion(ctx, request)
+	if err != nil {
+		return nil, wrapError("failed to generate text", err)
+	}
+	message, err := response.firstMessage()
+	if err != nil {
+		return nil, err
+	}
+	return &llm.GenerateTextOutput{Text: message.Content, Usage: response.Usage.toUsage()}, nil
+}
+
+func (c *OpenAIClient) GenerateTextStream(ctx context.Context, input llm.GenerateTextInput) iter.Seq2[*llm.GenerateTextStreamChunk, error] {
+	return func(yield func(*llm.GenerateTextStreamChunk, error) bool) {
+		request, err := newChatCompletionRequest(input.Config, input.Conversation, input.Temperature)
+		if err != nil {
+			yield(nil, err)
+			return
+		}
+		request.Stream = true
+		request.StreamOptions = &streamOptions{IncludeUsage: true}
+		body, err := c.postStream(ctx, chatCompletionsPath, request)
+		if err != nil {
+			yield(nil, wrapError("failed to generate text stream", err))
+			return
+		}
+		defer func() {
+			_ = body.Close()
+		}()
+
+		usage := llm.Usage{}
+		scanner := bufio.NewScanner(body)
+		scanner.Buffer(make([]byte, 0, 64*1024), maxStreamLineSize)
+		for scanner.Scan() {
+			data, ok := strings.CutPrefix(scanner.Text(), "data:")
+			if !ok {
+				continue
+			}
+			data = strings.TrimSpace(data)
+			if data == streamDoneData {
+				break
+			}
+			var chunk chatCompletionChunk
+			if err := json.Unmarshal([]byte(data), &chunk); err != nil {
+				yield(nil, errors.NewInfrastructureError(errors.BadResponseError, fmt.Sprintf("failed to unmarshal stream chunk: %v", err)))
+				return
+			}
+			// usage is sent in the last chunk without choices
+			if chunk.Usage != nil {
+				usage = chunk.Usage.toUsage()
+			}
+			if len(chunk.Choices) == 0 || chunk.Choices[0].Delta.Content == "" {
+				continue
+			}
+			if !yield(&llm.GenerateTextStreamChunk{Delta: chunk.Choices[0].Delta.Content}, nil) {
+				return
+			}
+		}
+		if err := scanner.Err(); err != nil {
+			yield(nil, wrapError("failed to read text stream", err))
+			return
+		}
+		yield(&llm.GenerateTextStreamChunk{Usage: &usage}, nil)
+	}
+}
+
+func (c *OpenAIClient) GenerateStructuredText(ctx context.Context, input llm.GenerateStructuredTextInput) (*llm.GenerateStructuredTextOutput, error) {
+	if !json.Valid(input.Schema) {
+		return nil, errors.NewInfrastructureError(errors.ExternalServiceError, "failed to unmarshal schema: invalid json")
+	}
+	request, err := newChatCompletionRequest(input.Config, input.Conversation, input.Temperature)
+	if err != nil {
+		return nil, err
+	}
+	request.ResponseFormat = &responseFormat{
+		Type: "json_schema",
+		JSONSchema: &jsonSchemaFormat{
+			Name:   structuredOutputKey,
+			Schema: input.Schema,
+		},
+	}
+	response, err := c.createChatCompletion(ctx, request)
+	if err != nil {
+		return nil, wrapError("failed to generate structured text", err)
+	}
+	message, err := response.firstMessage()
+	if err != nil {
+		return nil, err
+	}
+	return &llm.GenerateStructuredTextOutput{Text: message.Content, Usage: response.Usage.toUsage()}, nil
+}
+
+func (c *OpenAIClient) GenerateFunctionCall(ctx context.Context, input llm.GenerateFunctionCallInput) (*llm.GenerateFunctionCallOutput, error) {
+	request, err := newChatCompletionRequest(input.Config, input.Conversation, input.Temperature)
+	if err != nil {
+		return nil, err
+	}
+	for _, fn := range input.Functions {
+		if !json.Valid(fn.Parameters) {
+			return nil, errors.NewInfrastructureError(errors.ExternalServiceError, fmt.Sprintf("failed to unmarshal function schema for %s: invalid json", fn.Name))
+		}
+		request.Tools = append(request.Tools, tool{
+			Type: "function",
+			Function: functionDefinition{
+				Name:        fn.Name,
+				Description: fn.Description,
+				Parameters:  fn.Parameters,
+			},
+		})
+	}
+	if len(request.Tools) > 0 {
+		request.ToolChoice = toolChoice(input.Mode)
+	}
+
+	response, err := c.createChatCompletion(ctx, request)
+	if err != nil {
+		return nil, wrapError("failed to generate function call", err)
+	}
+	message, err := response.firstMessage()
+	if err != nil {
+		return nil, err
+	}
+	if len(message.ToolCalls) == 0 && input.Mode == llm.FunctionCallingModeAny {
+		return nil, errors.NewInfrastructureError(errors.ExternalServiceError, "no function call found")
+	}
+
+	functionCalls := make([]llm.FunctionCall, 0, len(message.ToolCalls))
+	for _, toolCall := range message.ToolCalls {
+		arguments := map[string]any{}
+		if toolCall.Function.Arguments != "" {
+			if err := json.Unmarshal([]byte(toolCall.Function.Arguments), &arguments); err != nil {
+				return nil, errors.NewInfrastructureError(errors.BadResponseError, fmt.Sprintf("failed to unmarshal function arguments for %s: %v", toolCall.Function.Name, err))
+			}
+		}
+		functionCalls = append(functionCalls, llm.FunctionCall{ID: toolCall.ID, Name: toolCall.Function.Name, Arguments: arguments})
+	}
+
+	output := &llm.GenerateFunctionCallOutput{
+		FunctionCalls: functionCalls,
+		Text:          message.Content,
+		Usage:         response.Usage.toUsage(),
+	}
+	if len(functionCalls) > 0 {
+		output.FunctionCall = functionCalls[0]
+	}
+	return output, nil
+}
+
+func toolChoice(mode llm.FunctionCallingMode) string {
+	switch mode {
+	case llm.FunctionCallingModeAuto:
+		return "auto"
+	case llm.FunctionCallingModeNone:
+		return "none"
+	default:
+		// required forces the model to predict only function calls
+		return "required"
+	}
+}
+
+func (c *OpenAIClient) GenerateEmbedding(ctx context.Context, input llm.GenerateEmbeddingInput) (*llm.GenerateEmbeddingOutput, error) {
+	output, err := c.GenerateEmbeddingBatch(ctx, llm.GenerateEmbeddingBatchInput{
+		Texts:  []string{input.Text},
+		Config: input.Config,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &llm.GenerateEmbeddingOutput{Embedding: output.Embeddings[0], Usage: output.Usage}, nil
+}
+
+func (c *OpenAIClient) GenerateEmbeddingBatch(ctx context.Context, input llm.GenerateEmbeddingBatchInput) (*llm.GenerateEmbeddingBatchOutput, error) {
+	if len(input.Texts) == 0 {
+		return &llm.GenerateEmbeddingBatchOutput{Embeddings: [][]float32{}}, nil
+	}
+	request := embeddingRequest{
+		Model:      string(input.Config.Model),
+		Input:      input.Texts,
+		Dimensions: llm.EmbeddingDimensions,
+	}
+	var response embeddingResponse
+	if err := c.post(ctx, embeddingsPath, request, &response); err != nil {
+		return nil, wrapError("failed to generate embedding batch", err)
+	}
+	if len(response.Data) != len(input.Texts) {
+		return nil, errors.NewInfrastructureError(errors.BadResponseError, fmt.Sprintf("unexpected number of embeddings: expected %d, got %d", len(input.Texts), len(response.Data)))
+	}
+
+	// レスポンスの順序は保証されないため index で並べ直す
+	embeddings := make([][]float32, len(input.Texts))
+	for _, data := range response.Data {
+		if data.Index < 0 || data.Index >= len(embeddings) {
+			return nil, errors.NewInfrastructureError(errors.BadResponseError, fmt.Sprintf("unexpected embedding index: %d", data.Index))
+		}
+		embeddings[data.Index] = data.Embedding
+	}
+
+	usage := llm.Usage{
+		InputTokens:  response.Usage.PromptTokens,
+		OutputTokens: 0,
+		TotalTokens:  response.Usage.TotalTokens,
+	}
+	return &llm.GenerateEmbeddingBatchOutput{Embeddings: embeddings, Usage: usage}, nil
+}
+
+// OpenAI has no token counting endpoint, so the count is estimated locally.
+// ASCII text averages about four characters per token and Japanese text about one.
+func (c *OpenAIClient) GetTokenCount(ctx context.Context, input llm.CountTokenInput) (*llm.CountTokenOutput, error) {
+	return &llm.CountTokenOutput{TokenCount: estimateTokenCount(input.Text)}, nil
+}
+
+func estimateTokenCount(text string) int {
+	asciiCount := 0
+	otherCount := 0
+	for _, r := range text {
+		if r < utf8.RuneSelf {
+			asciiCount++
+		} else {
+			otherCount++
+		}
+	}
+	return (asciiCount+3)/4 + otherCount
+}
+
+func newChatCompletionRequest(config llm.LLMConfig, conversation func() ([]llm.Message, error), temperature float32) (chatCompletionRequest, error) {
+	messages, err := conversation()
+	if err != nil {
+		return chatCompletionRequest{}, errors.NewInfrastructureError(errors.BadRequestError, fmt.Sprintf("invalid messages: %v", err))
+	}
+	chatMessages, err := toChatMessages(messages)
+	if err != nil {
+		return chatCompletionRequest{}, err
+	}
+	request := chatCompletionRequest{
+		Model:    string(config.Model),
+		Messages: chatMessages,
+	}
+	// reasoning models only accept the default temperature
+	if config.Model != llm.GPT5 {
+		request.Temperature = &temperature
+	}
+	return request, nil
+}
+
+func toChatMessages(messages []llm.Message) ([]chatMessage, error) {
+	chatMessages := make([]chatMessage, 0, len(messages))
+	for _, message := range messages {
+		switch message.Role {
+		case llm.RoleSystem, llm.RoleUser:
+			chatMessages = append(chatMessages, chatMessage{Role: string(message.Role), Content: message.Content})
+		case llm.RoleAssistant:
+			chatMessage := chatMessage{Role: string(message.Role), Content: message.Content}
+			for _, call := range message.FunctionCalls {
+				arguments, err := json.Marshal(call.Arguments)
+				if err != nil {
+					return nil, errors.NewInfrastructureError(errors.BadRequestError, fmt.Sprintf("failed to marshal function arguments for %s: %v", call.Name, err))
+				}
+				chatMessage.ToolCalls = append(chatMessage.ToolCalls, toolCall{
+					ID:       toolCallID(call.ID, call.Name),
+					Type:     "function",
+					Function: functionCall{Name: call.Name, Arguments: string(arguments)},
+				})
+			}
+			chatMessages = append(chatMessages, chatMessage)
+		case llm.RoleTool:
+			chatMessages = append(chatMessages, chatMessage{
+				Role:       string(message.Role),
+				Content:    message.Content,
+				ToolCallID: toolCallID(message.ToolCallID, message.Name),
+			})
+		default:
+			return nil, errors.NewInfrastructureError(errors.BadRequestError, fmt.Sprintf("unsupported message role: %s", message.Role))
+		}
+	}
+	return chatMessages, nil
+}
+
+// toolCallID falls back to the function name for calls made by providers without call ids.
+func toolCallID(id string, name string) string {
+	if id != "" {
+		return id
+	}
+	return "call_" + name
+}
+
+func (c *OpenAIClient) createChatCompletion(ctx context.Context, request chatCompletionRequest) (*chatCompletionResponse, error) {
+	var response chatCompletionResponse
+	if err := c.post(ctx, chatCompletionsPath, request, &response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+func (c *OpenAIClient) post(ctx context.Context, path string, request any, response any) error {
+	body, err := c.postStream(ctx, path, request)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = body.Close()
+	}()
+
+	responseBody, err := io.ReadAll(body)
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+	if err := json.Unmarshal(responseBody, response); err != nil {
+		return &apiError{statusCode: http.StatusOK, message: fmt.Sprintf("failed to unmarshal response: %v", err), badResponse: true}
+	}
+	return nil
+}
+
+// postStream sends the request and returns the response body on success.
+// The caller must close the body.
+func (c *OpenAIClient) postStream(ctx context.Context, path string, request any) (io.ReadCloser, error) {
+	body, err := json.Marshal(request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+	httpRequest, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+path, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	httpRequest.Header.Set("Content-Type", "application/json")
+	httpRequest.Header.Set("Authorization", "Bearer "+c.apiKey)
+
+	httpResponse, err := c.httpClient.Do(httpRequest)
+	if err != nil {
+		return nil, err
+	}
+	if httpResponse.StatusCode < 200 || httpResponse.StatusCode >= 300 {
+		defer func() {
+			_ = httpResponse.Body.Close()
+		}()
+		responseBody, err := io.ReadAll(httpResponse.Body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read response: %w", err)
+		}
+		return nil, newAPIError(httpResponse.StatusCode, responseBody)
+	}
+	return httpResponse.Body, nil
+}
+
+type apiError struct {
+	statusCode  int
+	message     string
+	badResponse bool
+}
+
+func newAPIError(statusCode int, body []byte) *apiError {
+	var errorBody errorResponse
+	message := strings.TrimSpace(string(body))
+	if err := json.Unmarshal(body, &errorBody); err == nil && errorBody.Error.Message != "" {
+		message = errorBody.Error.Message
+	}
+	return &apiError{statusCode: statusCode, message: message}
+}
+
+func (e *apiError) Error() string {
+	return fmt.Sprintf("status %d: %s", e.statusCode, e.message)
+}
+
+// wrapError classifies the error the same way as the Gemini client.
+// A 400, 401 or 403 from OpenAI is a fault of this service's request or credentials, not of the caller,
+// so it stays ExternalServiceError instead of being reported as the caller's 400, 401 or 403.
+func wrapError(message string, err error) error {
+	errorType := errors.ExternalServiceError
+	var apiErr *apiError
+	var netErr net.Error
+	switch {
+	case stdErrors.As(err, &apiErr) && apiErr.badResponse:
+		errorType = errors.BadResponseError
+	case stdErrors.As(err, &apiErr):
+		errorType = errors.ExternalServiceErrorType(apiErr.statusCode)
+	case stdErrors.Is(err, context.DeadlineExceeded), stdErrors.As(err, &netErr) && netErr.Timeout():
+		errorType = errors.TimeoutError
+	}
+	return errors.NewInfrastructureError(errorType, fmt.Sprintf("%s: %v", message, err))
+}
@@ -0,0 +1,329 @@
+package openai
+
+import (
+	"context"
+	"encoding/json"
+	stdErrors "errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/goda6565/ai-consultant/backend/internal/domain/llm"
+	"github.com/goda6565/ai-consultant/backend/internal/infrastructure/environment"
+	"github.com/goda6565/ai-consultant/backend/internal/infrastructure/errors"
+)
+
+func newTestClient(t *testing.T, handler http.HandlerFunc) *OpenAIClient {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	return NewOpenAIClient(&environment.Environment{
+		OpenAIEnvironment: environment.OpenAIEnvironment{
+			OpenAIAPIKey:  "test-key",
+			OpenAIBaseURL: server.URL + "/",
+		},
+	})
+}
+
+func decodeRequest(t *testing.T, r *http.Request, v any) {
+	t.Helper()
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		t.Fatalf("failed to decode request: %v", err)
+	}
+}
+
+func writeJSON(t *testing.T, w http.ResponseWriter, status int, body string) {
+	t.Helper()
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if _, err := w.Write([]byte(body)); err != nil {
+		t.Fatalf("failed to write response: %v", err)
+	}
+}
+
+func TestOpenAIClient_GenerateText(t *testing.T) {
+	tests := []struct {
+		name            string
+		model           llm.LLMModel
+		wantTemperature bool
+	}{
+		{name: "gpt-4o sends temperature", model: llm.GPT4o, wantTemperature: true},
+		{name: "gpt-5 omits temperature", model: llm.GPT5, wantTemperature: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != chatCompletionsPath {
+					t.Errorf("path = %s, expected %s", r.URL.Path, chatCompletionsPath)
+				}
+				if got := r.Header.Get("Authorization"); got != "Bearer test-key" {
+					t.Errorf("Authorization = %s, expected Bearer test-key", got)
+				}
+				var request map[string]any
+				decodeRequest(t, r, &request)
+				if request["model"] != string(tt.model) {
+					t.Errorf("model = %v, expected %s", request["model"], tt.model)
+				}
+				if _, ok := request["temperature"]; ok != tt.wantTemperature {
+					t.Errorf("temperature present = %v, expected %v", ok, tt.wantTemperature)
+				}
+				messages := request["messages"].([]any)
+				if len(messages) != 2 || messages[0].(map[string]any)["role"] != "system" || messages[1].(map[string]any)["role"] != "user" {
+					t.Errorf("unexpected messages: %v", messages)
+				}
+				writeJSON(t, w, http.StatusOK, `{
+					"choices": [{"index": 0, "message": {"role": "assistant", "content": "Paris"}, "finish_reason": "stop"}],
+					"usage": {"prompt_tokens": 10, "completion_tokens": 2, "total_tokens": 12}
+				}`)
+			})
+
+			output, err := client.GenerateText(context.Background(), llm.GenerateTextInput{
+				SystemPrompt: "You are a helpful assistant.",
+				UserPrompt:   "What is the capital of France?",
+				Temperature:  0.2,
+				Config:       llm.LLMConfig{Provider: llm.OpenAI, Model: tt.model},
+			})
+			if err != nil {
+				t.Fatalf("failed to generate text: %v", err)
+			}
+			if output.Text != "Paris" {
+				t.Errorf("Text = %s, expected Paris", output.Text)
+			}
+			expectedUsage := llm.Usage{InputTokens: 10, OutputTokens: 2, TotalTokens: 12}
+			if output.Usage != expectedUsage {
+				t.Errorf("Usage = %+v, expected %+v", output.Usage, expectedUsage)
+			}
+		})
+	}
+}
+
+func TestOpenAIClient_GenerateTextStream(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		var request chatCompletionRequest
+		decodeRequest(t, r, &request)
+		if !request.Stream || request.StreamOptions == nil || !request.StreamOptions.IncludeUsage {
+			t.Errorf("unexpected stream options: stream=%v, stream_options=%+v", request.Stream, request.StreamOptions)
+		}
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.WriteHeader(http.StatusOK)
+		body := "data: {\"choices\": [{\"index\": 0, \"delta\": {\"role\": \"assistant\", \"content\": \"\"}}]}\n\n" +
+			"data: {\"choices\": [{\"index\": 0, \"delta\": {\"content\": \"Par\"}}]}\n\n" +
+			": keep-alive\n\n" +
+			"data: {\"choices\": [{\"index\": 0, \"delta\": {\"content\": \"is\"}, \"finish_reason\": \"stop\"}]}\n\n" +
+			"data: {\"choices\": [], \"usage\": {\"prompt_tokens\": 10, \"completion_tokens\": 2, \"total_tokens\": 12}}\n\n" +
+			"data: [DONE]\n\n"
+		if _, err := w.Write([]byte(body)); err != nil {
+			t.Fatalf("failed to write response: %v", err)
+		}
+	})
+
+	deltas := []string{}
+	var usage *llm.Usage
+	for chunk, err := range client.GenerateTextStream(context.Background(), llm.GenerateTextInput{
+		UserPrompt: "What is the capital of France?",
+		Config:     llm.LLMConfig{Provider: llm.OpenAI, Model: llm.GPT4o},
+	}) {
+		if err != nil {
+			t.Fatalf("failed to generate text stream: %v", err)
+		}
+		if chunk.Delta != "" {
+			deltas = append(deltas, chunk.Delta)
+		}
+		if chunk.Usage != nil {
+			usage = chunk.Usage
+		}
+	}
+	if len(deltas) != 2 || deltas[0] != "Par" || deltas[1] != "is" {
+		t.Errorf("deltas = %v, expected [Par is]", deltas)
+	}
+	expectedUsage := llm.Usage{InputTokens: 10, OutputTokens: 2, TotalTokens: 12}
+	if usage == nil || *usage != expectedUsage {
+		t.Errorf("Usage = %+v, expected %+v", usage, expectedUsage)
+	}
+}
+
+func TestOpenAIClient_GenerateStructuredText(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		var request chatCompletionRequest
+		decodeRequest(t, r, &request)
+		if request.ResponseFormat == nil || request.ResponseFormat.Type != "json_schema" || request.ResponseFormat.JSONSchema == nil {
+			t.Fatalf("unexpected response_format: %+v", request.ResponseFormat)
+		}
+		var schema map[string]any
+		if err := json.Unmarshal(request.ResponseFormat.JSONSchema.Schema, &schema); err != nil || schema["type"] != "object" {
+			t.Errorf("unexpected schema: %s", request.ResponseFormat.JSONSchema.Schema)
+		}
+		writeJSON(t, w, http.StatusOK, `{
+			"choices": [{"index": 0, "message": {"role": "assistant", "content": "{\"capital\":\"Paris\"}"}}],
+			"usage": {"prompt_tokens": 10, "completion_tokens": 5, "total_tokens": 15}
+		}`)
+	})
+
+	output, err := client.GenerateStructuredText(context.Background(), llm.GenerateStructuredTextInput{
+		SystemPrompt: "You are a helpful assistant.",
+		UserPrompt:   "What is the capital of France?",
+		Schema:       json.RawMessage(`{"type": "object", "properties": {"capital": {"type": "string"}}, "required": ["capital"]}`),
+		Config:       llm.LLMConfig{Provider: llm.OpenAI, Model: llm.GPT4o},
+	})
+	if err != nil {
+		t.Fatalf("failed to generate structured text: %v", err)
+	}
+	if output.Text != `{"capital":"Paris"}` {
+		t.Errorf("Text = %s, expected {\"capital\":\"Paris\"}", output.Text)
+	}
+}
+
+func TestOpenAIClient_GenerateFunctionCall(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		var request chatCompletionRequest
+		decodeRequest(t, r, &request)
+		if request.ToolChoice != "required" {
+			t.Errorf("tool_choice = %s, expected required", request.ToolChoice)
+		}
+		if len(request.Tools) != 1 || request.Tools[0].Function.Name != "web_search" {
+			t.Errorf("unexpected tools: %+v", request.Tools)
+		}
+		writeJSON(t, w, http.StatusOK, `{
+			"choices": [{"index": 0, "message": {"role": "assistant", "content": "", "tool_calls": [
+				{"id": "call_1", "type": "function", "function": {"name": "web_search", "arguments": "{\"query\":\"golang\"}"}}
+			]}}],
+			"usage": {"prompt_tokens": 20, "completion_tokens": 5, "total_tokens": 25}
+		}`)
+	})
+
+	output, err := client.GenerateFunctionCall(context.Background(), llm.GenerateFunctionCallInput{
+		SystemPrompt: "You are a helpful assistant.",
+		UserPrompt:   "Search golang.",
+		Config:       llm.LLMConfig{Provider: llm.OpenAI, Model: llm.GPT4o},
+		Functions: []llm.Function{
+			{
+				Name:        "web_search",
+				Description: "search the web",
+				Parameters:  json.RawMessage(`{"type": "object", "properties": {"query": {"type": "string"}}, "required": ["query"]}`),
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("failed to generate function call: %v", err)
+	}
+	if output.FunctionCall.Name != "web_search" || output.FunctionCall.Arguments["query"] != "golang" {
+		t.Errorf("unexpected function call: %+v", output.FunctionCall)
+	}
+}
+
+func TestOpenAIClient_GenerateEmbeddingBatch(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != embeddingsPath {
+			t.Errorf("path = %s, expected %s", r.URL.Path, embeddingsPath)
+		}
+		var request embeddingRequest
+		decodeRequest(t, r, &request)
+		if request.Dimensions != llm.EmbeddingDimensions {
+			t.Errorf("dimensions = %d, expected %d", request.Dimensions, llm.EmbeddingDimensions)
+		}
+		// index 順ではないレスポンスを返す
+		writeJSON(t, w, http.StatusOK, `{
+			"data": [{"index": 1, "embedding": [0.2]}, {"index": 0, "embedding": [0.1]}],
+			"usage": {"prompt_tokens": 4, "total_tokens": 4}
+		}`)
+	})
+
+	output, err := client.GenerateEmbeddingBatch(context.Background(), llm.GenerateEmbeddingBatchInput{
+		Texts:  []string{"first", "second"},
+		Config: llm.EmbeddingConfig{Provider: llm.OpenAI, Model: llm.EmbeddingModelOpenAIEmbeddings},
+	})
+	if err != nil {
+		t.Fatalf("failed to generate embedding batch: %v", err)
+	}
+	if len(output.Embeddings) != 2 || output.Embeddings[0][0] != 0.1 || output.Embeddings[1][0] != 0.2 {
+		t.Errorf("unexpected embeddings: %v", output.Embeddings)
+	}
+	if output.Usage.TotalTokens != 4 {
+		t.Errorf("TotalTokens = %d, expected 4", output.Usage.TotalTokens)
+	}
+}
+
+func TestOpenAIClient_Error(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   int
+		expected errors.InfrastructureErrorType
+	}{
+		// provider 4xx errors must not be reported as the caller's 400, 401 or 403 by the HTTP error handler
+		{name: "bad request is not the caller's bad request", status: http.StatusBadRequest, expected: errors.ExternalServiceError},
+		{name: "unauthorized is not the caller's unauthorized", status: http.StatusUnauthorized, expected: errors.ExternalServiceError},
+		{name: "forbidden is not the caller's forbidden", status: http.StatusForbidden, expected: errors.ExternalServiceError},
+		{name: "rate limit", status: http.StatusTooManyRequests, expected: errors.RateLimitError},
+		{name: "server error", status: http.StatusInternalServerError, expected: errors.UnavailableError},
+		{name: "gateway timeout", status: http.StatusGatewayTimeout, expected: errors.TimeoutError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				writeJSON(t, w, tt.status, `{"error": {"message": "something went wrong", "type": "error"}}`)
+			})
+
+			_, err := client.GenerateText(context.Background(), llm.GenerateTextInput{
+				Config: llm.LLMConfig{Provider: llm.OpenAI, Model: llm.GPT4o},
+			})
+			var infraErr *errors.InfrastructureError
+			if !stdErrors.As(err, &infraErr) {
+				t.Fatalf("expected InfrastructureError, got %v", err)
+			}
+			if infraErr.ErrorType != tt.expected {
+				t.Errorf("ErrorType = %s, expected %s", infraErr.ErrorType, tt.expected)
+			}
+		})
+	}
+}
+
+func TestOpenAIClient_GetTokenCount(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Error("token count should not call the api")
+	})
+
+	output, err := client.GetTokenCount(context.Background(), llm.CountTokenInput{
+		Text:   "abcdefgh日本語",
+		Config: llm.LLMConfig{Provider: llm.OpenAI, Model: llm.GPT4o},
+	})
+	if err != nil {
+		t.Fatalf("failed to get token count: %v", err)
+	}
+	if output.TokenCount != 5 {
+		t.Errorf("TokenCount = %d, expected 5", output.TokenCount)
+	}
+}
+
+func TestToChatMessages(t *testing.T) {
+	call := llm.FunctionCall{Name: "web_search", Arguments: map[string]any{"query": "golang"}}
+	messages, err := toChatMessages([]llm.Message{
+		llm.NewSystemMessage("You are a helpful assistant."),
+		llm.NewUserMessage("search golang"),
+		llm.NewFunctionCallMessage("", call),
+		llm.NewToolMessage(call, "result"),
+		llm.NewAssistantMessage("done"),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expectedRoles := []string{"system", "user", "assistant", "tool", "assistant"}
+	if len(messages) != len(expectedRoles) {
+		t.Fatalf("len(messages) = %d, expected %d", len(messages), len(expectedRoles))
+	}
+	for i, role := range expectedRoles {
+		if messages[i].Role != role {
+			t.Errorf("messages[%d].Role = %s, expected %s", i, messages[i].Role, role)
+		}
+	}
+	toolCalls := messages[2].ToolCalls
+	if len(toolCalls) != 1 || toolCalls[0].Function.Name != "web_search" || toolCalls[0].Function.Arguments != `{"query":"golang"}` {
+		t.Errorf("unexpected tool calls: %+v", toolCalls)
+	}
+	// calls without id are matched by the fallback id
+	if toolCalls[0].ID == "" || messages[3].ToolCallID != toolCalls[0].ID {
+		t.Errorf("tool_call_id = %s, expected %s", messages[3].ToolCallID, toolCalls[0].ID)
+	}
+}
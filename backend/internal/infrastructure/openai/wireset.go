@@ -0,0 +1,7 @@
+package openai
+
+import "github.com/google/wire"
+
+var Set = wire.NewSet(
+	NewOpenAIClient,
+)
@@ -0,0 +1,11 @@
+package scraper
+
+import (
+	"github.com/goda6565/ai-consultant/backend/internal/domain/search"
+	"github.com/google/wire"
+)
+
+var Set = wire.NewSet(
+	NewScraperClient,
+	wire.Bind(new(search.ScraperClient), new(*ScraperClient)),
+)
@@ -0,0 +1,28 @@
+package proposaljob
+
+import (
+	actionEntity "github.com/goda6565/ai-consultant/backend/internal/domain/action/entity"
+)
+
+// ActionDivergence is the first replayed action that differs from the recorded run.
+// Recorded is nil when the replay ran more actions than the recorded run.
+type ActionDivergence struct {
+	Index    int
+	Recorded *actionEntity.Action
+	Replayed *actionEntity.Action
+}
+
+// CompareActions compares the replayed actions with the recorded ones in order by the action type and the output.
+// The replay stops at the first approval gate, so recorded actions after the replayed ones are not a divergence.
+func CompareActions(recorded []actionEntity.Action, replayed []actionEntity.Action) *ActionDivergence {
+	for n := range replayed {
+		if n >= len(recorded) {
+			return &ActionDivergence{Index: n, Replayed: &replayed[n]}
+		}
+		recordedOutput, replayedOutput := recorded[n].GetOutput(), replayed[n].GetOutput()
+		if !recorded[n].GetActionType().Equals(replayed[n].GetActionType()) || !recordedOutput.Equals(replayedOutput) {
+			return &ActionDivergence{Index: n, Recorded: &recorded[n], Replayed: &replayed[n]}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+package proposaljob
+
+import (
+	"testing"
+
+	actionEntity "github.com/goda6565/ai-consultant/backend/internal/domain/action/entity"
+	actionValue "github.com/goda6565/ai-consultant/backend/internal/domain/action/value"
+	sharedValue "github.com/goda6565/ai-consultant/backend/internal/domain/shared/value"
+)
+
+func newTestAction(t *testing.T, actionType actionValue.ActionType, output string) actionEntity.Action {
+	t.Helper()
+	input, err := actionValue.NewActionInput("input")
+	if err != nil {
+		t.Fatal(err)
+	}
+	actionOutput, err := actionValue.NewActionOutput(output)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return *actionEntity.NewAction(sharedValue.ID("action-id"), sharedValue.ID("problem-id"), actionType, *input, *actionOutput, nil)
+}
+
+func TestCompareActions(t *testing.T) {
+	plan := newTestAction(t, actionValue.ActionTypePlan, "plan")
+	search := newTestAction(t, actionValue.ActionTypeExternalSearch, "results")
+	write := newTestAction(t, actionValue.ActionTypeWrite, "report")
+
+	tests := []struct {
+		name          string
+		recorded      []actionEntity.Action
+		replayed      []actionEntity.Action
+		expectedIndex int
+		hasRecorded   bool
+	}{
+		{name: "same actions", recorded: []actionEntity.Action{plan, search}, replayed: []actionEntity.Action{plan, search}, expectedIndex: -1},
+		{name: "replay stopped at an approval gate", recorded: []actionEntity.Action{plan, search, write}, replayed: []actionEntity.Action{plan}, expectedIndex: -1},
+		{name: "different action type", recorded: []actionEntity.Action{plan, search}, replayed: []actionEntity.Action{plan, write}, expectedIndex: 1, hasRecorded: true},
+		{name: "different output", recorded: []actionEntity.Action{plan}, replayed: []actionEntity.Action{newTestAction(t, actionValue.ActionTypePlan, "another plan")}, expectedIndex: 0, hasRecorded: true},
+		{name: "more actions than recorded", recorded: []actionEntity.Action{plan}, replayed: []actionEntity.Action{plan, search}, expectedIndex: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			divergence := CompareActions(tt.recorded, tt.replayed)
+			if tt.expectedIndex < 0 {
+				if divergence != nil {
+					t.Fatalf("divergence at %d, expected none", divergence.Index)
+				}
+				return
+			}
+			if divergence == nil {
+				t.Fatalf("no divergence, expected at %d", tt.expectedIndex)
+			}
+			if divergence.Index != tt.expectedIndex {
+				t.Errorf("index = %d, expected %d", divergence.Index, tt.expectedIndex)
+			}
+			if (divergence.Recorded != nil) != tt.hasRecorded {
+				t.Errorf("recorded = %v, expected %v", divergence.Recorded != nil, tt.hasRecorded)
+			}
+		})
+	}
+}
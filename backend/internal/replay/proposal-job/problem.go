@@ -0,0 +1,31 @@
+package proposaljob
+
+import (
+	"context"
+
+	problemRepository "github.com/goda6565/ai-consultant/backend/internal/domain/problem/repository"
+	problemValue "github.com/goda6565/ai-consultant/backend/internal/domain/problem/value"
+	sharedValue "github.com/goda6565/ai-consultant/backend/internal/domain/shared/value"
+)
+
+// readOnlyProblemRepository keeps the status of the recorded problem as it is during the replay.
+type readOnlyProblemRepository struct {
+	problemRepository.ProblemRepository
+}
+
+func (r *readOnlyProblemRepository) UpdateStatus(ctx context.Context, id sharedValue.ID, status problemValue.Status) error {
+	return nil
+}
+
+func (r *readOnlyProblemRepository) TransitionStatus(ctx context.Context, id sharedValue.ID, from problemValue.Status, to problemValue.Status) (bool, error) {
+	return true, nil
+}
+
+func (r *readOnlyProblemRepository) ClearCancelRequest(ctx context.Context, id sharedValue.ID) error {
+	return nil
+}
+
+// IsCancelRequested ignores the cancellation of the recorded run: the replay always runs to the end.
+func (r *readOnlyProblemRepository) IsCancelRequested(ctx context.Context, id sharedValue.ID) (bool, error) {
+	return false, nil
+}
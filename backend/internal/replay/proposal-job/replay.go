@@ -0,0 +1,179 @@
+package proposaljob
+
+import (
+	"context"
+	"fmt"
+
+	actionRepository "github.com/goda6565/ai-consultant/backend/internal/domain/action/repository"
+	actionService "github.com/goda6565/ai-consultant/backend/internal/domain/action/service"
+	agentService "github.com/goda6565/ai-consultant/backend/internal/domain/agent/service"
+	mockApprovalRepository "github.com/goda6565/ai-consultant/backend/internal/domain/approval/repository/mock"
+	mockCheckpointRepository "github.com/goda6565/ai-consultant/backend/internal/domain/checkpoint/repository/mock"
+	documentRepository "github.com/goda6565/ai-consultant/backend/internal/domain/document/repository"
+	mockEventRepository "github.com/goda6565/ai-consultant/backend/internal/domain/event/repository/mock"
+	mockGoalRevisionRepository "github.com/goda6565/ai-consultant/backend/internal/domain/goal_revision/repository/mock"
+	hearingRepository "github.com/goda6565/ai-consultant/backend/internal/domain/hearing/repository"
+	hearingMessageRepository "github.com/goda6565/ai-consultant/backend/internal/domain/hearing_message/repository"
+	jobConfigRepository "github.com/goda6565/ai-consultant/backend/internal/domain/job_config/repository"
+	problemRepository "github.com/goda6565/ai-consultant/backend/internal/domain/problem/repository"
+	problemFieldRepository "github.com/goda6565/ai-consultant/backend/internal/domain/problem_field/repository"
+	reportRepository "github.com/goda6565/ai-consultant/backend/internal/domain/report/repository"
+	sharedValue "github.com/goda6565/ai-consultant/backend/internal/domain/shared/value"
+	mockSpanRepository "github.com/goda6565/ai-consultant/backend/internal/domain/trace/repository/mock"
+	traceService "github.com/goda6565/ai-consultant/backend/internal/domain/trace/service"
+	usageService "github.com/goda6565/ai-consultant/backend/internal/domain/usage/service"
+	"github.com/goda6565/ai-consultant/backend/internal/evaluate/proposal-job/memory"
+	"github.com/goda6565/ai-consultant/backend/internal/infrastructure/job"
+	llmClient "github.com/goda6565/ai-consultant/backend/internal/infrastructure/llm"
+	"github.com/goda6565/ai-consultant/backend/internal/pkg/logger"
+	"github.com/goda6565/ai-consultant/backend/internal/usecase/proposal"
+	"go.uber.org/mock/gomock"
+)
+
+// ProposalJobReplay re-runs a recorded problem with the LLM responses and the search results of its trace.
+// The problem, the hearing and the job config are read from the database; nothing is written back.
+// The replay starts from the beginning and stops at the first approval gate.
+type ProposalJobReplay struct {
+	config                   llmClient.ReplayConfig
+	problemRepository        problemRepository.ProblemRepository
+	problemFieldRepository   problemFieldRepository.ProblemFieldRepository
+	hearingRepository        hearingRepository.HearingRepository
+	hearingMessageRepository hearingMessageRepository.HearingMessageRepository
+	jobConfigRepository      jobConfigRepository.JobConfigRepository
+	documentRepository       documentRepository.DocumentRepository
+	recordedActionRepository actionRepository.ActionRepository
+	orchestrator             *agentService.Orchestrator
+	summarizeService         *agentService.SummarizeService
+	goalService              *agentService.GoalService
+	terminator               *agentService.Terminator
+	skipper                  *agentService.Skipper
+	reflection               *agentService.Reflection
+	goalRevisionService      *agentService.GoalRevisionService
+	actionFactory            *actionService.ActionFactory
+	reportRepository         reportRepository.ReportRepository
+	ledgerService            *usageService.LedgerService
+}
+
+func NewProposalJobReplay(
+	config llmClient.ReplayConfig,
+	problemRepository problemRepository.ProblemRepository,
+	problemFieldRepository problemFieldRepository.ProblemFieldRepository,
+	hearingRepository hearingRepository.HearingRepository,
+	hearingMessageRepository hearingMessageRepository.HearingMessageRepository,
+	jobConfigRepository jobConfigRepository.JobConfigRepository,
+	documentRepository documentRepository.DocumentRepository,
+	recordedActionRepository actionRepository.ActionRepository,
+	orchestrator *agentService.Orchestrator,
+	summarizeService *agentService.SummarizeService,
+	goalService *agentService.GoalService,
+	terminator *agentService.Terminator,
+	skipper *agentService.Skipper,
+	reflection *agentService.Reflection,
+	goalRevisionService *agentService.GoalRevisionService,
+	actionFactory *actionService.ActionFactory,
+	reportRepository reportRepository.ReportRepository,
+	ledgerService *usageService.LedgerService,
+) job.JobApplication {
+	return &ProposalJobReplay{
+		config:                   config,
+		problemRepository:        problemRepository,
+		problemFieldRepository:   problemFieldRepository,
+		hearingRepository:        hearingRepository,
+		hearingMessageRepository: hearingMessageRepository,
+		jobConfigRepository:      jobConfigRepository,
+		documentRepository:       documentRepository,
+		recordedActionRepository: recordedActionRepository,
+		orchestrator:             orchestrator,
+		summarizeService:         summarizeService,
+		goalService:              goalService,
+		terminator:               terminator,
+		skipper:                  skipper,
+		reflection:               reflection,
+		goalRevisionService:      goalRevisionService,
+		actionFactory:            actionFactory,
+		reportRepository:         reportRepository,
+		ledgerService:            ledgerService,
+	}
+}
+
+func (r *ProposalJobReplay) Execute(ctx context.Context) error {
+	logger := logger.GetLogger(ctx)
+	problemID, err := sharedValue.NewID(r.config.ProblemID)
+	if err != nil {
+		return fmt.Errorf("failed to create problem id: %w", err)
+	}
+	recorded, err := r.recordedActionRepository.FindByProblemID(ctx, problemID)
+	if err != nil {
+		return fmt.Errorf("failed to find recorded actions: %w", err)
+	}
+
+	ctrl := gomock.NewController(nil)
+	defer ctrl.Finish()
+
+	eventRepository := mockEventRepository.NewMockEventRepository(ctrl)
+	eventRepository.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+	// リプレイは毎回最初から実行する
+	checkpointRepository := mockCheckpointRepository.NewMockCheckpointRepository(ctrl)
+	checkpointRepository.EXPECT().FindLatestByProblemID(gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+	checkpointRepository.EXPECT().Save(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	checkpointRepository.EXPECT().DeleteByProblemID(gomock.Any(), gomock.Any()).Return(int64(0), nil).AnyTimes()
+
+	// 承認ゲートに達したら一時停止してリプレイを終える
+	approvalRepository := mockApprovalRepository.NewMockApprovalRepository(ctrl)
+	approvalRepository.EXPECT().FindLatestByProblemID(gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+	approvalRepository.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+	goalRevisionRepository := mockGoalRevisionRepository.NewMockGoalRevisionRepository(ctrl)
+	goalRevisionRepository.EXPECT().Save(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+	// 記録済みのトレースを上書きしない
+	spanRepository := mockSpanRepository.NewMockSpanRepository(ctrl)
+	spanRepository.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+	replayedActionRepository := memory.NewMemoryActionRepository()
+	executeProposalUseCase := proposal.NewExecuteProposalUseCase(
+		&readOnlyProblemRepository{ProblemRepository: r.problemRepository},
+		r.problemFieldRepository,
+		r.hearingRepository,
+		r.hearingMessageRepository,
+		replayedActionRepository,
+		eventRepository,
+		r.orchestrator,
+		r.summarizeService,
+		r.goalService,
+		r.terminator,
+		r.skipper,
+		r.reflection,
+		r.goalRevisionService,
+		r.actionFactory,
+		r.reportRepository,
+		r.jobConfigRepository,
+		r.documentRepository,
+		r.ledgerService,
+		checkpointRepository,
+		approvalRepository,
+		goalRevisionRepository,
+		traceService.NewTracer(spanRepository),
+	)
+	err = executeProposalUseCase.Execute(ctx, proposal.ExecuteProposalUseCaseInput{ProblemID: r.config.ProblemID})
+	if err != nil {
+		return fmt.Errorf("failed to replay proposal: %w", err)
+	}
+
+	replayed, err := replayedActionRepository.FindByProblemID(ctx, problemID)
+	if err != nil {
+		return fmt.Errorf("failed to find replayed actions: %w", err)
+	}
+	divergence := CompareActions(recorded, replayed)
+	if divergence != nil {
+		args := []any{"index", divergence.Index, "replayedActionType", divergence.Replayed.GetActionType().Value()}
+		if divergence.Recorded != nil {
+			args = append(args, "recordedActionType", divergence.Recorded.GetActionType().Value())
+		}
+		logger.Warn("replay diverged from the recorded run", args...)
+		return nil
+	}
+	logger.Info("replay matched the recorded run", "replayedActions", len(replayed), "recordedActions", len(recorded))
+	return nil
+}
@@ -0,0 +1,176 @@
+package proposaljob
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/goda6565/ai-consultant/backend/internal/domain/action/tools"
+	"github.com/goda6565/ai-consultant/backend/internal/domain/search"
+	sharedValue "github.com/goda6565/ai-consultant/backend/internal/domain/shared/value"
+	traceEntity "github.com/goda6565/ai-consultant/backend/internal/domain/trace/entity"
+	traceRepository "github.com/goda6565/ai-consultant/backend/internal/domain/trace/repository"
+	traceValue "github.com/goda6565/ai-consultant/backend/internal/domain/trace/value"
+	llmClient "github.com/goda6565/ai-consultant/backend/internal/infrastructure/llm"
+)
+
+// SearchRecording holds the search results recorded in the tool spans of a past run.
+// Identical queries receive the recorded results in the order they were searched.
+type SearchRecording struct {
+	mu       sync.Mutex
+	searches map[searchKey][]recordedSearch
+	contents map[string]string
+}
+
+type searchKey struct {
+	function string
+	query    string
+}
+
+type recordedSearch struct {
+	results []tools.SearchResult
+	err     string
+}
+
+func NewSearchRecording(ctx context.Context, config llmClient.ReplayConfig, spanRepository traceRepository.SpanRepository) (*SearchRecording, error) {
+	problemID, err := sharedValue.NewID(config.ProblemID)
+	if err != nil {
+		return nil, err
+	}
+	spans, err := spanRepository.FindByProblemID(ctx, problemID)
+	if err != nil {
+		return nil, err
+	}
+	return newSearchRecording(spans)
+}
+
+func newSearchRecording(spans []traceEntity.Span) (*SearchRecording, error) {
+	sorted := append([]traceEntity.Span{}, spans...)
+	sort.SliceStable(sorted, func(a, b int) bool {
+		return sorted[a].GetStartedAt().Before(sorted[b].GetStartedAt())
+	})
+	recording := &SearchRecording{searches: map[searchKey][]recordedSearch{}, contents: map[string]string{}}
+	for _, span := range sorted {
+		if !span.GetKind().Equals(traceValue.SpanKindTool) {
+			continue
+		}
+		if span.GetName() != string(tools.FunctionNameWebSearch) && span.GetName() != string(tools.FunctionNameDocumentSearch) {
+			continue
+		}
+		attributes := span.GetAttributes()
+		key := searchKey{function: span.GetName(), query: attributes[tools.QueryAttributeKey]}
+		if span.GetStatus().Equals(traceValue.SpanStatusError) {
+			recording.searches[key] = append(recording.searches[key], recordedSearch{err: span.GetError()})
+			continue
+		}
+		data, ok := attributes[tools.SearchResultsAttributeKey]
+		if !ok {
+			// 結果を記録する前のトレースは再生できないため取り違えないよう登録しない
+			continue
+		}
+		var results []tools.SearchResult
+		if err := json.Unmarshal([]byte(data), &results); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal recorded results of %s: %w", span.GetName(), err)
+		}
+		for _, result := range results {
+			recording.contents[result.URL] = result.Content
+		}
+		recording.searches[key] = append(recording.searches[key], recordedSearch{results: results})
+	}
+	return recording, nil
+}
+
+func (r *SearchRecording) next(function tools.FunctionName, query string) ([]tools.SearchResult, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	key := searchKey{function: string(function), query: query}
+	searches := r.searches[key]
+	if len(searches) == 0 {
+		return nil, fmt.Errorf("%w: %s %q is not recorded", llmClient.ErrReplayMiss, function, query)
+	}
+	r.searches[key] = searches[1:]
+	if searches[0].err != "" {
+		return nil, fmt.Errorf("recorded %s failed: %s", function, searches[0].err)
+	}
+	return searches[0].results, nil
+}
+
+type webSearchClient struct {
+	recording *SearchRecording
+}
+
+func NewWebSearchClient(recording *SearchRecording) search.WebSearchClient {
+	return &webSearchClient{recording: recording}
+}
+
+// Search returns the pages that were scraped in the recorded run; their content is returned by the scraper.
+func (c *webSearchClient) Search(ctx context.Context, input search.WebSearchInput) (*search.WebSearchOutput, error) {
+	results, err := c.recording.next(tools.FunctionNameWebSearch, input.Query)
+	if err != nil {
+		return nil, err
+	}
+	output := &search.WebSearchOutput{Results: []search.WebSearchResult{}}
+	for _, result := range results {
+		output.Results = append(output.Results, search.WebSearchResult{Title: result.Title, URL: result.URL})
+	}
+	return output, nil
+}
+
+type scraperClient struct {
+	recording *SearchRecording
+}
+
+func NewScraperClient(recording *SearchRecording) search.ScraperClient {
+	return &scraperClient{recording: recording}
+}
+
+func (c *scraperClient) Scrape(ctx context.Context, url string) (string, error) {
+	c.recording.mu.Lock()
+	defer c.recording.mu.Unlock()
+	content, ok := c.recording.contents[url]
+	if !ok {
+		return "", fmt.Errorf("%w: content of %s is not recorded", llmClient.ErrReplayMiss, url)
+	}
+	return content, nil
+}
+
+type documentSearchClient struct {
+	recording *SearchRecording
+}
+
+func NewDocumentSearchClient(recording *SearchRecording) search.DocumentSearchClient {
+	return &documentSearchClient{recording: recording}
+}
+
+func (c *documentSearchClient) Search(ctx context.Context, input search.DocumentSearchInput) (*search.DocumentSearchOutput, error) {
+	results, err := c.recording.next(tools.FunctionNameDocumentSearch, input.Query)
+	if err != nil {
+		return nil, err
+	}
+	output := &search.DocumentSearchOutput{Results: []search.DocumentSearchResult{}}
+	for _, result := range results {
+		output.Results = append(output.Results, search.DocumentSearchResult{
+			DocumentID:    result.DocumentID,
+			ChunkID:       result.ChunkID,
+			ChunkIndex:    result.ChunkIndex,
+			Title:         result.Title,
+			Content:       result.Content,
+			Similarity:    result.Similarity,
+			QueryVariants: result.QueryVariants,
+			RerankScore:   result.Score,
+		})
+	}
+	return output, nil
+}
+
+// NewReranker disables the reranking: the recorded document search results are already reranked.
+func NewReranker() search.Reranker {
+	return nil
+}
+
+// NewQueryExpander disables the query expansion: the recorded document search results are already merged.
+func NewQueryExpander() search.QueryExpander {
+	return nil
+}
@@ -0,0 +1,15 @@
+package proposaljob
+
+import (
+	"github.com/google/wire"
+)
+
+var Set = wire.NewSet(
+	NewProposalJobReplay,
+	NewSearchRecording,
+	NewWebSearchClient,
+	NewDocumentSearchClient,
+	NewScraperClient,
+	NewReranker,
+	NewQueryExpander,
+)
@@ -0,0 +1,142 @@
+package approval
+
+import (
+	"context"
+	"fmt"
+
+	agentValue "github.com/goda6565/ai-consultant/backend/internal/domain/agent/value"
+	"github.com/goda6565/ai-consultant/backend/internal/domain/approval/entity"
+	"github.com/goda6565/ai-consultant/backend/internal/domain/approval/repository"
+	"github.com/goda6565/ai-consultant/backend/internal/domain/approval/value"
+	problemRepository "github.com/goda6565/ai-consultant/backend/internal/domain/problem/repository"
+	problemValue "github.com/goda6565/ai-consultant/backend/internal/domain/problem/value"
+	sharedValue "github.com/goda6565/ai-consultant/backend/internal/domain/shared/value"
+	"github.com/goda6565/ai-consultant/backend/internal/infrastructure/environment"
+	"github.com/goda6565/ai-consultant/backend/internal/pkg/logger"
+	"github.com/goda6565/ai-consultant/backend/internal/usecase/errors"
+	"github.com/goda6565/ai-consultant/backend/internal/usecase/ports/job"
+)
+
+type DecideApprovalInputPort interface {
+	Execute(ctx context.Context, input DecideApprovalUseCaseInput) (*DecideApprovalOutput, error)
+}
+
+type DecideApprovalUseCaseInput struct {
+	ApprovalID string
+	Decision   string
+	Feedback   string
+	// nil の場合はゴールを変更しない
+	Goal *string
+}
+
+type DecideApprovalOutput struct {
+	Approval *entity.Approval
+}
+
+type DecideApprovalInteractor struct {
+	approvalRepository repository.ApprovalRepository
+	problemRepository  problemRepository.ProblemRepository
+	jobClient          job.Job
+	env                *environment.Environment
+}
+
+func NewDecideApprovalUseCase(approvalRepository repository.ApprovalRepository, problemRepository problemRepository.ProblemRepository, jobClient job.Job, env *environment.Environment) DecideApprovalInputPort {
+	return &DecideApprovalInteractor{approvalRepository: approvalRepository, problemRepository: problemRepository, jobClient: jobClient, env: env}
+}
+
+// Execute saves the decision and starts the proposal job again. The job resumes from the checkpoint of the approval.
+func (i *DecideApprovalInteractor) Execute(ctx context.Context, input DecideApprovalUseCaseInput) (*DecideApprovalOutput, error) {
+	approvalID, err := sharedValue.NewID(input.ApprovalID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create approval id: %w", err)
+	}
+	decision, err := value.NewDecision(input.Decision)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create decision: %w", err)
+	}
+	var goal *agentValue.Goal
+	if input.Goal != nil && *input.Goal != "" {
+		goal = agentValue.NewGoal(*input.Goal)
+	}
+
+	approval, err := i.approvalRepository.FindByID(ctx, approvalID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find approval: %w", err)
+	}
+	if approval == nil {
+		return nil, errors.NewUseCaseError(errors.NotFoundError, "approval not found")
+	}
+	if !approval.IsPending() {
+		return nil, errors.NewUseCaseError(errors.ConflictError, fmt.Sprintf("approval is already decided: %s", approval.GetDecision().Value()))
+	}
+
+	// a problem cancelled while waiting for the decision must not be started again
+	problem, err := i.problemRepository.FindById(ctx, approval.GetProblemID())
+	if err != nil {
+		return nil, fmt.Errorf("failed to find problem: %w", err)
+	}
+	if problem == nil {
+		return nil, errors.NewUseCaseError(errors.NotFoundError, "problem not found")
+	}
+	if !problem.GetStatus().Equals(problemValue.StatusPaused) {
+		return nil, errors.NewUseCaseError(errors.ConflictError, fmt.Sprintf("problem is not paused: %s", problem.GetStatus().Value()))
+	}
+
+	err = approval.Decide(decision, input.Feedback, goal)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decide approval: %w", err)
+	}
+
+	// only one of a decision and a cancellation of the paused problem wins the status
+	problemID := approval.GetProblemID()
+	resumed, err := i.problemRepository.TransitionStatus(ctx, problemID, problemValue.StatusPaused, problemValue.StatusProcessing)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update problem status: %w", err)
+	}
+	if !resumed {
+		return nil, errors.NewUseCaseError(errors.ConflictError, "problem is no longer paused")
+	}
+	decided, err := i.approvalRepository.Decide(ctx, approval)
+	if err != nil {
+		i.restorePaused(ctx, problemID)
+		return nil, fmt.Errorf("failed to save decision: %w", err)
+	}
+	if !decided {
+		// the approval was closed in the meantime, so the job must not be started for it
+		i.restorePaused(ctx, problemID)
+		return nil, errors.NewUseCaseError(errors.ConflictError, "approval is already decided")
+	}
+
+	err = i.jobClient.CallJob(ctx, job.JobInput{
+		JobName:   i.env.JobName,
+		ProblemID: problemID.Value(),
+	})
+	if err != nil {
+		// leave the approval pending so that the reviewer can decide again
+		reopenErr := i.approvalRepository.Reopen(ctx, approvalID)
+		if reopenErr != nil {
+			logger.GetLogger(ctx).Error("failed to reopen approval", "error", reopenErr)
+		}
+		i.restorePaused(ctx, problemID)
+		return nil, fmt.Errorf("failed to call job: %w", err)
+	}
+
+	// reload to return the decided time set by the database
+	decidedApproval, err := i.approvalRepository.FindByID(ctx, approvalID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find approval: %w", err)
+	}
+	if decidedApproval == nil {
+		return nil, errors.NewUseCaseError(errors.NotFoundError, "approval not found")
+	}
+
+	return &DecideApprovalOutput{Approval: decidedApproval}, nil
+}
+
+// restorePaused returns a problem whose job could not be started to paused. A failure is only logged.
+func (i *DecideApprovalInteractor) restorePaused(ctx context.Context, problemID sharedValue.ID) {
+	_, err := i.problemRepository.TransitionStatus(ctx, problemID, problemValue.StatusProcessing, problemValue.StatusPaused)
+	if err != nil {
+		logger.GetLogger(ctx).Error("failed to restore paused status", "error", err)
+	}
+}
@@ -0,0 +1,184 @@
+package approval
+
+import (
+	"context"
+	stdErrors "errors"
+	"testing"
+
+	actionValue "github.com/goda6565/ai-consultant/backend/internal/domain/action/value"
+	"github.com/goda6565/ai-consultant/backend/internal/domain/approval/entity"
+	approvalMock "github.com/goda6565/ai-consultant/backend/internal/domain/approval/repository/mock"
+	"github.com/goda6565/ai-consultant/backend/internal/domain/approval/value"
+	"github.com/goda6565/ai-consultant/backend/internal/domain/llm"
+	problemEntity "github.com/goda6565/ai-consultant/backend/internal/domain/problem/entity"
+	problemMock "github.com/goda6565/ai-consultant/backend/internal/domain/problem/repository/mock"
+	problemValue "github.com/goda6565/ai-consultant/backend/internal/domain/problem/value"
+	sharedValue "github.com/goda6565/ai-consultant/backend/internal/domain/shared/value"
+	"github.com/goda6565/ai-consultant/backend/internal/infrastructure/environment"
+	"github.com/goda6565/ai-consultant/backend/internal/pkg/logger"
+	"github.com/goda6565/ai-consultant/backend/internal/usecase/errors"
+	"github.com/goda6565/ai-consultant/backend/internal/usecase/ports/job"
+	jobMock "github.com/goda6565/ai-consultant/backend/internal/usecase/ports/job/mock"
+	"go.uber.org/mock/gomock"
+)
+
+var (
+	testApprovalID = sharedValue.ID("aaaaaaaa-bbbb-cccc-dddd-eeeeeeeeeeee")
+	testProblemID  = sharedValue.ID("11111111-2222-3333-4444-555555555555")
+)
+
+type nopLogger struct{}
+
+func (nopLogger) Info(string, ...interface{})  {}
+func (nopLogger) Debug(string, ...interface{}) {}
+func (nopLogger) Warn(string, ...interface{})  {}
+func (nopLogger) Error(string, ...interface{}) {}
+func (nopLogger) Fatal(string, ...interface{}) {}
+func (nopLogger) Panic(string, ...interface{}) {}
+func (nopLogger) LogUsage(llm.Usage)           {}
+func (nopLogger) Sync() error                  { return nil }
+
+func newTestApproval(decision value.Decision) *entity.Approval {
+	return entity.NewApproval(testApprovalID, testProblemID, actionValue.ActionTypeAnalyze, 3, "分析結果", decision, "", nil, nil, nil, nil)
+}
+
+func newTestProblem(status problemValue.Status) *problemEntity.Problem {
+	title, _ := problemValue.NewTitle("売上改善")
+	description, _ := problemValue.NewDescription("来店数が減っている")
+	return problemEntity.NewProblem(testProblemID, *title, *description, status, nil)
+}
+
+func TestDecideApprovalInteractor_Execute(t *testing.T) {
+	goal := "来店数を回復させる施策を提案する"
+	jobError := stdErrors.New("job unavailable")
+	tests := []struct {
+		name              string
+		input             DecideApprovalUseCaseInput
+		mockSetup         func(approvalRepo *approvalMock.MockApprovalRepository, problemRepo *problemMock.MockProblemRepository, jobClient *jobMock.MockJob)
+		expectedErrorType errors.UseCaseErrorType
+		expectedError     error
+	}{
+		{
+			name:  "decision is saved and the job is started again",
+			input: DecideApprovalUseCaseInput{ApprovalID: testApprovalID.Value(), Decision: "revise", Feedback: "競合の調査が足りない", Goal: &goal},
+			mockSetup: func(approvalRepo *approvalMock.MockApprovalRepository, problemRepo *problemMock.MockProblemRepository, jobClient *jobMock.MockJob) {
+				decided := newTestApproval(value.DecisionRevise)
+				gomock.InOrder(
+					approvalRepo.EXPECT().FindByID(gomock.Any(), testApprovalID).Return(newTestApproval(value.DecisionPending), nil),
+					problemRepo.EXPECT().FindById(gomock.Any(), testProblemID).Return(newTestProblem(problemValue.StatusPaused), nil),
+					problemRepo.EXPECT().TransitionStatus(gomock.Any(), testProblemID, problemValue.StatusPaused, problemValue.StatusProcessing).Return(true, nil),
+					approvalRepo.EXPECT().Decide(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, approval *entity.Approval) (bool, error) {
+						if !approval.GetDecision().Equals(value.DecisionRevise) || approval.GetFeedback() != "競合の調査が足りない" {
+							t.Errorf("unexpected decision: %s %s", approval.GetDecision().Value(), approval.GetFeedback())
+						}
+						if approval.GetGoal() == nil || approval.GetGoal().Value() != goal {
+							t.Errorf("goal = %v, expected %s", approval.GetGoal(), goal)
+						}
+						return true, nil
+					}),
+					jobClient.EXPECT().CallJob(gomock.Any(), job.JobInput{JobName: "proposal-job", ProblemID: testProblemID.Value()}).Return(nil),
+					approvalRepo.EXPECT().FindByID(gomock.Any(), testApprovalID).Return(decided, nil),
+				)
+			},
+		},
+		{
+			name:  "approval not found",
+			input: DecideApprovalUseCaseInput{ApprovalID: testApprovalID.Value(), Decision: "approve"},
+			mockSetup: func(approvalRepo *approvalMock.MockApprovalRepository, problemRepo *problemMock.MockProblemRepository, jobClient *jobMock.MockJob) {
+				approvalRepo.EXPECT().FindByID(gomock.Any(), testApprovalID).Return(nil, nil)
+			},
+			expectedErrorType: errors.NotFoundError,
+		},
+		{
+			name:  "decided approval cannot be decided again",
+			input: DecideApprovalUseCaseInput{ApprovalID: testApprovalID.Value(), Decision: "approve"},
+			mockSetup: func(approvalRepo *approvalMock.MockApprovalRepository, problemRepo *problemMock.MockProblemRepository, jobClient *jobMock.MockJob) {
+				approvalRepo.EXPECT().FindByID(gomock.Any(), testApprovalID).Return(newTestApproval(value.DecisionApprove), nil)
+			},
+			expectedErrorType: errors.ConflictError,
+		},
+		{
+			name:  "cancelled problem is not started again",
+			input: DecideApprovalUseCaseInput{ApprovalID: testApprovalID.Value(), Decision: "approve"},
+			mockSetup: func(approvalRepo *approvalMock.MockApprovalRepository, problemRepo *problemMock.MockProblemRepository, jobClient *jobMock.MockJob) {
+				approvalRepo.EXPECT().FindByID(gomock.Any(), testApprovalID).Return(newTestApproval(value.DecisionPending), nil)
+				problemRepo.EXPECT().FindById(gomock.Any(), testProblemID).Return(newTestProblem(problemValue.StatusCancelled), nil)
+			},
+			expectedErrorType: errors.ConflictError,
+		},
+		{
+			name:  "problem cancelled or resumed by another reviewer in the meantime",
+			input: DecideApprovalUseCaseInput{ApprovalID: testApprovalID.Value(), Decision: "approve"},
+			mockSetup: func(approvalRepo *approvalMock.MockApprovalRepository, problemRepo *problemMock.MockProblemRepository, jobClient *jobMock.MockJob) {
+				approvalRepo.EXPECT().FindByID(gomock.Any(), testApprovalID).Return(newTestApproval(value.DecisionPending), nil)
+				problemRepo.EXPECT().FindById(gomock.Any(), testProblemID).Return(newTestProblem(problemValue.StatusPaused), nil)
+				problemRepo.EXPECT().TransitionStatus(gomock.Any(), testProblemID, problemValue.StatusPaused, problemValue.StatusProcessing).Return(false, nil)
+			},
+			expectedErrorType: errors.ConflictError,
+		},
+		{
+			name:  "approval closed in the meantime restores the paused status",
+			input: DecideApprovalUseCaseInput{ApprovalID: testApprovalID.Value(), Decision: "approve"},
+			mockSetup: func(approvalRepo *approvalMock.MockApprovalRepository, problemRepo *problemMock.MockProblemRepository, jobClient *jobMock.MockJob) {
+				gomock.InOrder(
+					approvalRepo.EXPECT().FindByID(gomock.Any(), testApprovalID).Return(newTestApproval(value.DecisionPending), nil),
+					problemRepo.EXPECT().FindById(gomock.Any(), testProblemID).Return(newTestProblem(problemValue.StatusPaused), nil),
+					problemRepo.EXPECT().TransitionStatus(gomock.Any(), testProblemID, problemValue.StatusPaused, problemValue.StatusProcessing).Return(true, nil),
+					approvalRepo.EXPECT().Decide(gomock.Any(), gomock.Any()).Return(false, nil),
+					problemRepo.EXPECT().TransitionStatus(gomock.Any(), testProblemID, problemValue.StatusProcessing, problemValue.StatusPaused).Return(true, nil),
+				)
+			},
+			expectedErrorType: errors.ConflictError,
+		},
+		{
+			name:  "job that cannot be started leaves the approval pending and the problem paused",
+			input: DecideApprovalUseCaseInput{ApprovalID: testApprovalID.Value(), Decision: "approve"},
+			mockSetup: func(approvalRepo *approvalMock.MockApprovalRepository, problemRepo *problemMock.MockProblemRepository, jobClient *jobMock.MockJob) {
+				gomock.InOrder(
+					approvalRepo.EXPECT().FindByID(gomock.Any(), testApprovalID).Return(newTestApproval(value.DecisionPending), nil),
+					problemRepo.EXPECT().FindById(gomock.Any(), testProblemID).Return(newTestProblem(problemValue.StatusPaused), nil),
+					problemRepo.EXPECT().TransitionStatus(gomock.Any(), testProblemID, problemValue.StatusPaused, problemValue.StatusProcessing).Return(true, nil),
+					approvalRepo.EXPECT().Decide(gomock.Any(), gomock.Any()).Return(true, nil),
+					jobClient.EXPECT().CallJob(gomock.Any(), gomock.Any()).Return(jobError),
+					approvalRepo.EXPECT().Reopen(gomock.Any(), testApprovalID).Return(nil),
+					problemRepo.EXPECT().TransitionStatus(gomock.Any(), testProblemID, problemValue.StatusProcessing, problemValue.StatusPaused).Return(true, nil),
+				)
+			},
+			expectedError: jobError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			approvalRepo := approvalMock.NewMockApprovalRepository(ctrl)
+			problemRepo := problemMock.NewMockProblemRepository(ctrl)
+			jobClient := jobMock.NewMockJob(ctrl)
+			tt.mockSetup(approvalRepo, problemRepo, jobClient)
+
+			ctx := logger.WithLogger(context.Background(), nopLogger{})
+			env := &environment.Environment{CloudRunJobEnvironment: environment.CloudRunJobEnvironment{JobName: "proposal-job"}}
+			output, err := NewDecideApprovalUseCase(approvalRepo, problemRepo, jobClient, env).Execute(ctx, tt.input)
+			if tt.expectedError != nil {
+				if !stdErrors.Is(err, tt.expectedError) {
+					t.Errorf("error = %v, expected %v", err, tt.expectedError)
+				}
+				return
+			}
+			if tt.expectedErrorType == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if output.Approval.GetID() != testApprovalID {
+					t.Errorf("approval id = %s, expected %s", output.Approval.GetID().Value(), testApprovalID.Value())
+				}
+				return
+			}
+			var useCaseError *errors.UseCaseError
+			if !stdErrors.As(err, &useCaseError) || useCaseError.ErrorType != tt.expectedErrorType {
+				t.Errorf("error = %v, expected %s", err, tt.expectedErrorType)
+			}
+		})
+	}
+}
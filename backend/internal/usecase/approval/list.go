@@ -0,0 +1,33 @@
+package approval
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/goda6565/ai-consultant/backend/internal/domain/approval/entity"
+	"github.com/goda6565/ai-consultant/backend/internal/domain/approval/repository"
+)
+
+type ListPendingApprovalInputPort interface {
+	Execute(ctx context.Context) (*ListPendingApprovalOutput, error)
+}
+
+type ListPendingApprovalOutput struct {
+	Approvals []entity.Approval
+}
+
+type ListPendingApprovalInteractor struct {
+	approvalRepository repository.ApprovalRepository
+}
+
+func NewListPendingApprovalUseCase(approvalRepository repository.ApprovalRepository) ListPendingApprovalInputPort {
+	return &ListPendingApprovalInteractor{approvalRepository: approvalRepository}
+}
+
+func (i *ListPendingApprovalInteractor) Execute(ctx context.Context) (*ListPendingApprovalOutput, error) {
+	approvals, err := i.approvalRepository.FindPending(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find pending approvals: %w", err)
+	}
+	return &ListPendingApprovalOutput{Approvals: approvals}, nil
+}
@@ -0,0 +1,8 @@
+package approval
+
+import "github.com/google/wire"
+
+var Set = wire.NewSet(
+	NewListPendingApprovalUseCase,
+	NewDecideApprovalUseCase,
+)
@@ -189,7 +189,7 @@ func (i *CreateChunkInteractor) Execute(ctx context.Context, input CreateChunkUs
 		if err != nil {
 			return nil, fmt.Errorf("failed to create embedding: %w", err)
 		}
-		chunks[i] = chunkEntity.NewChunk(id, document.GetID(), content, parentContent, embeddingValue)
+		chunks[i] = chunkEntity.NewChunk(id, document.GetID(), i, content, parentContent, embeddingValue)
 	}
 
 	// create chunks
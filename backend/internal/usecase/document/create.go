@@ -25,6 +25,7 @@ type CreateDocumentUseCaseInput struct {
 	Title        string
 	DocumentType string
 	File         io.Reader
+	Collection   string
 }
 
 type CreateDocumentOutput struct {
@@ -58,6 +59,10 @@ func (i *CreateDocumentInteractor) Execute(ctx context.Context, input CreateDocu
 	if err != nil {
 		return nil, fmt.Errorf("failed to create document type: %w", err)
 	}
+	collection, err := value.NewCollection(input.Collection)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create collection: %w", err)
+	}
 
 	// check duplicate
 	isDuplicate, err := i.duplicateChecker.Execute(ctx, title)
@@ -92,6 +97,7 @@ func (i *CreateDocumentInteractor) Execute(ctx context.Context, input CreateDocu
 		storagePath,
 		value.DocumentStatusPending, // initial document status is pending
 		value.NewRetryCount(0),      // initial retry count is 0
+		collection,
 		nil,
 		nil,
 	)
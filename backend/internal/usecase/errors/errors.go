@@ -5,6 +5,7 @@ type UseCaseErrorType string
 const (
 	DuplicateError UseCaseErrorType = "duplicate_error"
 	NotFoundError  UseCaseErrorType = "not_found_error"
+	ConflictError  UseCaseErrorType = "conflict_error"
 	InternalError  UseCaseErrorType = "internal_error"
 )
 
@@ -5,6 +5,7 @@ import (
 
 	"github.com/goda6565/ai-consultant/backend/internal/domain/event/entity"
 	repository "github.com/goda6565/ai-consultant/backend/internal/domain/event/repository"
+	eventValue "github.com/goda6565/ai-consultant/backend/internal/domain/event/value"
 	sharedValue "github.com/goda6565/ai-consultant/backend/internal/domain/shared/value"
 )
 
@@ -37,5 +38,13 @@ func (i *ListEventInteractor) Execute(ctx context.Context, input ListEventUseCas
 	if err != nil {
 		return nil, err
 	}
-	return &ListEventOutput{Events: events}, nil
+	// deltas are only useful while streaming, the history keeps the completed events
+	history := make([]entity.Event, 0, len(events))
+	for _, event := range events {
+		if event.GetEventType().Equals(eventValue.EventTypeDelta) {
+			continue
+		}
+		history = append(history, event)
+	}
+	return &ListEventOutput{Events: history}, nil
 }
@@ -4,6 +4,10 @@ import (
 	"context"
 	"fmt"
 
+	actionValue "github.com/goda6565/ai-consultant/backend/internal/domain/action/value"
+	eventEntity "github.com/goda6565/ai-consultant/backend/internal/domain/event/entity"
+	eventRepository "github.com/goda6565/ai-consultant/backend/internal/domain/event/repository"
+	eventValue "github.com/goda6565/ai-consultant/backend/internal/domain/event/value"
 	hearingEntity "github.com/goda6565/ai-consultant/backend/internal/domain/hearing/entity"
 	"github.com/goda6565/ai-consultant/backend/internal/domain/hearing/repository"
 	hearingService "github.com/goda6565/ai-consultant/backend/internal/domain/hearing/service"
@@ -14,6 +18,7 @@ import (
 	hearingMessageRepository "github.com/goda6565/ai-consultant/backend/internal/domain/hearing_message/repository"
 	hearingMessageService "github.com/goda6565/ai-consultant/backend/internal/domain/hearing_message/service"
 	"github.com/goda6565/ai-consultant/backend/internal/domain/hearing_message/value"
+	"github.com/goda6565/ai-consultant/backend/internal/domain/llm"
 	problemEntity "github.com/goda6565/ai-consultant/backend/internal/domain/problem/entity"
 	problemRepository "github.com/goda6565/ai-consultant/backend/internal/domain/problem/repository"
 	problemValue "github.com/goda6565/ai-consultant/backend/internal/domain/problem/value"
@@ -22,6 +27,7 @@ import (
 	problemFieldService "github.com/goda6565/ai-consultant/backend/internal/domain/problem_field/service"
 	problemFieldValue "github.com/goda6565/ai-consultant/backend/internal/domain/problem_field/value"
 	sharedValue "github.com/goda6565/ai-consultant/backend/internal/domain/shared/value"
+	usageValue "github.com/goda6565/ai-consultant/backend/internal/domain/usage/value"
 	"github.com/goda6565/ai-consultant/backend/internal/infrastructure/environment"
 	"github.com/goda6565/ai-consultant/backend/internal/pkg/logger"
 	"github.com/goda6565/ai-consultant/backend/internal/pkg/uuid"
@@ -56,6 +62,7 @@ type ExecuteHearingInteractor struct {
 	generateHearingMessageService      *hearingMessageService.GenerateHearingMessageService
 	generateHearingMapService          *hearingMapService.GenerateHearingMapService
 	judgeProblemFieldCompletionService *problemFieldService.JudgeProblemFieldCompletionService
+	eventRepository                    eventRepository.EventRepository
 	adminUnitOfWork                    transaction.AdminUnitOfWork
 	jobClient                          job.Job
 	env                                *environment.Environment
@@ -70,6 +77,7 @@ func NewExecuteHearingUseCase(
 	generateHearingMessageService *hearingMessageService.GenerateHearingMessageService,
 	generateHearingMapService *hearingMapService.GenerateHearingMapService,
 	judgeProblemFieldCompletionService *problemFieldService.JudgeProblemFieldCompletionService,
+	eventRepository eventRepository.EventRepository,
 	adminUnitOfWork transaction.AdminUnitOfWork,
 	jobClient job.Job,
 	env *environment.Environment,
@@ -83,6 +91,7 @@ func NewExecuteHearingUseCase(
 		generateHearingMessageService:      generateHearingMessageService,
 		generateHearingMapService:          generateHearingMapService,
 		judgeProblemFieldCompletionService: judgeProblemFieldCompletionService,
+		eventRepository:                    eventRepository,
 		adminUnitOfWork:                    adminUnitOfWork,
 		jobClient:                          jobClient,
 		env:                                env,
@@ -96,6 +105,9 @@ func (i *ExecuteHearingInteractor) Execute(ctx context.Context, input ExecuteHea
 		return nil, fmt.Errorf("failed to create problem id: %w", err)
 	}
 
+	// attribute llm usage during the hearing to the problem
+	ctx = usageValue.WithScope(ctx, problemID, actionValue.SelfActionTypeHearing)
+
 	// validate and create hearing ID
 	hearingID, err := sharedValue.NewID(input.HearingID)
 	if err != nil {
@@ -232,12 +244,15 @@ func (i *ExecuteHearingInteractor) Execute(ctx context.Context, input ExecuteHea
 	}
 
 	// create hearing message
+	deltas := i.deltaBuffer(problemID, logger)
 	generateHearingMessageOutput, err := i.generateHearingMessageService.Execute(ctx, hearingMessageService.GenerateHearingMessageInput{
 		Problem:              problem,
 		HearingMessages:      hearingMessages,
 		TargetProblemFieldID: targetProblemFieldID,
 		ProblemFields:        problemFields,
+		OnDelta:              deltas.Handle,
 	})
+	deltas.Flush(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate hearing message: %w", err)
 	}
@@ -307,3 +322,25 @@ func (i *ExecuteHearingInteractor) saveHearingMessage(ctx context.Context, heari
 	}
 	return hearingMessage, nil
 }
+
+// deltaBuffer pushes the assistant message being generated to the event stream of the problem.
+// The deltas are buffered so that an event is not saved for every token; Flush must be called after the generation.
+func (i *ExecuteHearingInteractor) deltaBuffer(problemID sharedValue.ID, logger logger.Logger) *llm.DeltaBuffer {
+	return llm.NewDeltaBuffer(func(ctx context.Context, delta string) {
+		id, err := sharedValue.NewID(uuid.NewUUID())
+		if err != nil {
+			logger.Error("failed to create id", "error", err)
+			return
+		}
+		message, err := eventValue.NewMessage(delta)
+		if err != nil {
+			logger.Error("failed to create message", "error", err)
+			return
+		}
+		event := eventEntity.NewEvent(id, problemID, eventValue.EventTypeDelta, actionValue.SelfActionTypeHearing, *message)
+		if err := i.eventRepository.Create(ctx, event); err != nil {
+			// streaming is best effort, the complete message is returned in the response
+			logger.Error("failed to create event", "error", err)
+		}
+	}, llm.DefaultDeltaFlushSize, llm.DefaultDeltaFlushInterval)
+}
@@ -3,10 +3,14 @@ package jobconfig
 import (
 	"context"
 	"fmt"
+	"time"
 
 	jobConfigEntity "github.com/goda6565/ai-consultant/backend/internal/domain/job_config/entity"
 	jobConfigRepository "github.com/goda6565/ai-consultant/backend/internal/domain/job_config/repository"
+	jobConfigValue "github.com/goda6565/ai-consultant/backend/internal/domain/job_config/value"
+	"github.com/goda6565/ai-consultant/backend/internal/domain/llm"
 	sharedValue "github.com/goda6565/ai-consultant/backend/internal/domain/shared/value"
+	workflowValue "github.com/goda6565/ai-consultant/backend/internal/domain/workflow/value"
 	"github.com/goda6565/ai-consultant/backend/internal/usecase/errors"
 )
 
@@ -17,6 +21,27 @@ type UpdateJobConfigInputPort interface {
 type UpdateJobConfigUseCaseInput struct {
 	ProblemID            string
 	EnableInternalSearch bool
+	// nil の場合は既存のモデル設定を維持する
+	Models map[string]llm.LLMConfig
+	// nil の場合は既存の予算を維持する
+	Budget *UpdateJobConfigBudgetInput
+	// nil の場合は既存の承認ゲートを維持する
+	ApprovalGates []string
+	// nil の場合は既存のワークフローを維持する
+	Workflow *string
+	// nil の場合は既存の検索対象ドキュメントを維持する
+	DocumentScope *UpdateJobConfigDocumentScopeInput
+}
+
+type UpdateJobConfigDocumentScopeInput struct {
+	Collections []string
+	DocumentIDs []string
+}
+
+type UpdateJobConfigBudgetInput struct {
+	MaxTokens   int
+	MaxCost     float64
+	MaxDuration time.Duration
 }
 
 type UpdateJobConfigOutput struct {
@@ -56,6 +81,46 @@ func (u *UpdateJobConfigInteractor) Execute(ctx context.Context, input UpdateJob
 		existingJobConfig.DisableInternalSearch()
 	}
 
+	if input.Models != nil {
+		modelMap, err := jobConfigValue.NewModelMap(input.Models)
+		if err != nil {
+			return nil, fmt.Errorf("invalid model map: %w", err)
+		}
+		existingJobConfig.SetModelMap(*modelMap)
+	}
+
+	if input.Budget != nil {
+		budget, err := jobConfigValue.NewBudget(input.Budget.MaxTokens, input.Budget.MaxCost, input.Budget.MaxDuration)
+		if err != nil {
+			return nil, fmt.Errorf("invalid budget: %w", err)
+		}
+		existingJobConfig.SetBudget(*budget)
+	}
+
+	if input.ApprovalGates != nil {
+		approvalGates, err := jobConfigValue.NewApprovalGates(input.ApprovalGates)
+		if err != nil {
+			return nil, fmt.Errorf("invalid approval gates: %w", err)
+		}
+		existingJobConfig.SetApprovalGates(*approvalGates)
+	}
+
+	if input.Workflow != nil {
+		workflow, err := workflowValue.NewName(*input.Workflow)
+		if err != nil {
+			return nil, fmt.Errorf("invalid workflow: %w", err)
+		}
+		existingJobConfig.SetWorkflowName(workflow)
+	}
+
+	if input.DocumentScope != nil {
+		documentScope, err := jobConfigValue.NewDocumentScope(input.DocumentScope.Collections, input.DocumentScope.DocumentIDs)
+		if err != nil {
+			return nil, fmt.Errorf("invalid document scope: %w", err)
+		}
+		existingJobConfig.SetDocumentScope(*documentScope)
+	}
+
 	err = u.jobConfigRepository.Update(ctx, existingJobConfig)
 	if err != nil {
 		return nil, fmt.Errorf("failed to update job config: %w", err)
@@ -7,6 +7,7 @@ type JobInput struct {
 	ProblemID string
 }
 
+//go:generate go tool mockgen -source=$GOFILE -destination=mock/$GOFILE -package=mock
 type Job interface {
 	CallJob(ctx context.Context, input JobInput) error
 }
@@ -0,0 +1,56 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: job.go
+//
+// Generated by this command:
+//
+//	mockgen -source=job.go -destination=mock/job.go -package=mock
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	job "github.com/goda6565/ai-consultant/backend/internal/usecase/ports/job"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockJob is a mock of Job interface.
+type MockJob struct {
+	ctrl     *gomock.Controller
+	recorder *MockJobMockRecorder
+	isgomock struct{}
+}
+
+// MockJobMockRecorder is the mock recorder for MockJob.
+type MockJobMockRecorder struct {
+	mock *MockJob
+}
+
+// NewMockJob creates a new mock instance.
+func NewMockJob(ctrl *gomock.Controller) *MockJob {
+	mock := &MockJob{ctrl: ctrl}
+	mock.recorder = &MockJobMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockJob) EXPECT() *MockJobMockRecorder {
+	return m.recorder
+}
+
+// CallJob mocks base method.
+func (m *MockJob) CallJob(ctx context.Context, input job.JobInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CallJob", ctx, input)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CallJob indicates an expected call of CallJob.
+func (mr *MockJobMockRecorder) CallJob(ctx, input any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CallJob", reflect.TypeOf((*MockJob)(nil).CallJob), ctx, input)
+}
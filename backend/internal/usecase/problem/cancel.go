@@ -0,0 +1,163 @@
+package problem
+
+import (
+	"context"
+	"fmt"
+
+	actionValue "github.com/goda6565/ai-consultant/backend/internal/domain/action/value"
+	approvalRepository "github.com/goda6565/ai-consultant/backend/internal/domain/approval/repository"
+	checkpointRepository "github.com/goda6565/ai-consultant/backend/internal/domain/checkpoint/repository"
+	eventEntity "github.com/goda6565/ai-consultant/backend/internal/domain/event/entity"
+	eventRepository "github.com/goda6565/ai-consultant/backend/internal/domain/event/repository"
+	eventValue "github.com/goda6565/ai-consultant/backend/internal/domain/event/value"
+	"github.com/goda6565/ai-consultant/backend/internal/domain/problem/repository"
+	"github.com/goda6565/ai-consultant/backend/internal/domain/problem/value"
+	reportEntity "github.com/goda6565/ai-consultant/backend/internal/domain/report/entity"
+	reportRepository "github.com/goda6565/ai-consultant/backend/internal/domain/report/repository"
+	reportValue "github.com/goda6565/ai-consultant/backend/internal/domain/report/value"
+	sharedValue "github.com/goda6565/ai-consultant/backend/internal/domain/shared/value"
+	"github.com/goda6565/ai-consultant/backend/internal/pkg/logger"
+	"github.com/goda6565/ai-consultant/backend/internal/pkg/uuid"
+	"github.com/goda6565/ai-consultant/backend/internal/usecase/errors"
+)
+
+type CancelProblemInputPort interface {
+	Execute(ctx context.Context, input CancelProblemUseCaseInput) error
+}
+
+type CancelProblemUseCaseInput struct {
+	ProblemID string
+}
+
+type CancelProblemInteractor struct {
+	problemRepository    repository.ProblemRepository
+	approvalRepository   approvalRepository.ApprovalRepository
+	checkpointRepository checkpointRepository.CheckpointRepository
+	reportRepository     reportRepository.ReportRepository
+	eventRepository      eventRepository.EventRepository
+}
+
+func NewCancelProblemUseCase(
+	problemRepository repository.ProblemRepository,
+	approvalRepository approvalRepository.ApprovalRepository,
+	checkpointRepository checkpointRepository.CheckpointRepository,
+	reportRepository reportRepository.ReportRepository,
+	eventRepository eventRepository.EventRepository,
+) CancelProblemInputPort {
+	return &CancelProblemInteractor{
+		problemRepository:    problemRepository,
+		approvalRepository:   approvalRepository,
+		checkpointRepository: checkpointRepository,
+		reportRepository:     reportRepository,
+		eventRepository:      eventRepository,
+	}
+}
+
+// Execute only requests the cancellation of a processing problem. The proposal job stops after the current action and sets the cancelled status.
+// A paused problem has no running job to observe the request, so it is finished here in the same way as a cancelled run.
+func (i *CancelProblemInteractor) Execute(ctx context.Context, input CancelProblemUseCaseInput) error {
+	// validate and create problem ID
+	problemID, err := sharedValue.NewID(input.ProblemID)
+	if err != nil {
+		return fmt.Errorf("failed to create problem id: %w", err)
+	}
+
+	// check if problem exists
+	problem, err := i.problemRepository.FindById(ctx, problemID)
+	if err != nil {
+		return fmt.Errorf("failed to find problem: %w", err)
+	}
+	if problem == nil {
+		return errors.NewUseCaseError(errors.NotFoundError, "problem not found")
+	}
+	if problem.GetStatus().Equals(value.StatusPaused) {
+		return i.cancelPaused(ctx, problemID)
+	}
+	if !problem.GetStatus().Equals(value.StatusProcessing) {
+		return errors.NewUseCaseError(errors.ConflictError, fmt.Sprintf("problem is not processing: %s", problem.GetStatus().Value()))
+	}
+
+	err = i.problemRepository.RequestCancel(ctx, problemID)
+	if err != nil {
+		return fmt.Errorf("failed to request cancel: %w", err)
+	}
+	return nil
+}
+
+// cancelPaused saves the content of the latest checkpoint as the report, expires the pending approval and clears the run state.
+// The status only changes while the problem is still paused, so a decision made at the same time either starts the job or loses to the cancellation.
+func (i *CancelProblemInteractor) cancelPaused(ctx context.Context, problemID sharedValue.ID) error {
+	logger := logger.GetLogger(ctx)
+	cancelled, err := i.problemRepository.TransitionStatus(ctx, problemID, value.StatusPaused, value.StatusCancelled)
+	if err != nil {
+		return fmt.Errorf("failed to update problem status: %w", err)
+	}
+	if !cancelled {
+		return errors.NewUseCaseError(errors.ConflictError, "problem is no longer paused")
+	}
+
+	err = i.approvalRepository.ExpirePendingByProblemID(ctx, problemID)
+	if err != nil {
+		return fmt.Errorf("failed to expire approval: %w", err)
+	}
+
+	checkpoint, err := i.checkpointRepository.FindLatestByProblemID(ctx, problemID)
+	if err != nil {
+		return fmt.Errorf("failed to find checkpoint: %w", err)
+	}
+	if checkpoint != nil {
+		content, err := checkpoint.GetSnapshot().ToReport()
+		if err != nil {
+			return fmt.Errorf("failed to create report: %w", err)
+		}
+		if content.Value() != "" {
+			err = i.saveReport(ctx, problemID, content.Value())
+			if err != nil {
+				return fmt.Errorf("failed to save report: %w", err)
+			}
+		}
+	}
+
+	// the status is already saved, so a failure is only logged
+	_, err = i.checkpointRepository.DeleteByProblemID(ctx, problemID)
+	if err != nil {
+		logger.Warn("failed to delete checkpoints", "error", err)
+	}
+	err = i.problemRepository.ClearCancelRequest(ctx, problemID)
+	if err != nil {
+		logger.Warn("failed to clear cancel request", "error", err)
+	}
+
+	err = i.createEvent(ctx, problemID)
+	if err != nil {
+		logger.Error("failed to create event", "error", err)
+	}
+	return nil
+}
+
+func (i *CancelProblemInteractor) saveReport(ctx context.Context, problemID sharedValue.ID, content string) error {
+	reportID, err := sharedValue.NewID(uuid.NewUUID())
+	if err != nil {
+		return fmt.Errorf("failed to create report id: %w", err)
+	}
+	report := reportEntity.NewReport(reportID, problemID, *reportValue.NewContent(content), nil)
+	return i.reportRepository.Create(ctx, report)
+}
+
+func (i *CancelProblemInteractor) createEvent(ctx context.Context, problemID sharedValue.ID) error {
+	id, err := sharedValue.NewID(uuid.NewUUID())
+	if err != nil {
+		return fmt.Errorf("failed to create id: %w", err)
+	}
+	message, err := eventValue.NewMessage(eventValue.CancelledMessage)
+	if err != nil {
+		return fmt.Errorf("failed to create message: %w", err)
+	}
+	return i.eventRepository.Create(ctx, &eventEntity.Event{
+		ID:         id,
+		ProblemID:  problemID,
+		EventType:  eventValue.EventTypeAction,
+		ActionType: actionValue.ActionTypeDone,
+		Message:    *message,
+	})
+}
@@ -0,0 +1,173 @@
+package problem
+
+import (
+	"context"
+	stdErrors "errors"
+	"testing"
+	"time"
+
+	"github.com/goda6565/ai-consultant/backend/internal/domain/agent/state"
+	agentValue "github.com/goda6565/ai-consultant/backend/internal/domain/agent/value"
+	approvalMock "github.com/goda6565/ai-consultant/backend/internal/domain/approval/repository/mock"
+	checkpointEntity "github.com/goda6565/ai-consultant/backend/internal/domain/checkpoint/entity"
+	checkpointMock "github.com/goda6565/ai-consultant/backend/internal/domain/checkpoint/repository/mock"
+	eventEntity "github.com/goda6565/ai-consultant/backend/internal/domain/event/entity"
+	eventMock "github.com/goda6565/ai-consultant/backend/internal/domain/event/repository/mock"
+	eventValue "github.com/goda6565/ai-consultant/backend/internal/domain/event/value"
+	jobConfigValue "github.com/goda6565/ai-consultant/backend/internal/domain/job_config/value"
+	"github.com/goda6565/ai-consultant/backend/internal/domain/llm"
+	"github.com/goda6565/ai-consultant/backend/internal/domain/problem/entity"
+	"github.com/goda6565/ai-consultant/backend/internal/domain/problem/repository/mock"
+	"github.com/goda6565/ai-consultant/backend/internal/domain/problem/value"
+	reportEntity "github.com/goda6565/ai-consultant/backend/internal/domain/report/entity"
+	reportMock "github.com/goda6565/ai-consultant/backend/internal/domain/report/repository/mock"
+	sharedValue "github.com/goda6565/ai-consultant/backend/internal/domain/shared/value"
+	workflowValue "github.com/goda6565/ai-consultant/backend/internal/domain/workflow/value"
+	"github.com/goda6565/ai-consultant/backend/internal/pkg/logger"
+	"github.com/goda6565/ai-consultant/backend/internal/usecase/errors"
+	"go.uber.org/mock/gomock"
+)
+
+var testProblemID = sharedValue.ID("11111111-2222-3333-4444-555555555555")
+
+type nopLogger struct{}
+
+func (nopLogger) Info(string, ...interface{})  {}
+func (nopLogger) Debug(string, ...interface{}) {}
+func (nopLogger) Warn(string, ...interface{})  {}
+func (nopLogger) Error(string, ...interface{}) {}
+func (nopLogger) Fatal(string, ...interface{}) {}
+func (nopLogger) Panic(string, ...interface{}) {}
+func (nopLogger) LogUsage(llm.Usage)           {}
+func (nopLogger) Sync() error                  { return nil }
+
+func newTestProblem(status value.Status) *entity.Problem {
+	title, _ := value.NewTitle("売上改善")
+	description, _ := value.NewDescription("来店数が減っている")
+	return entity.NewProblem(testProblemID, *title, *description, status, nil)
+}
+
+type cancelMocks struct {
+	problemRepo    *mock.MockProblemRepository
+	approvalRepo   *approvalMock.MockApprovalRepository
+	checkpointRepo *checkpointMock.MockCheckpointRepository
+	reportRepo     *reportMock.MockReportRepository
+	eventRepo      *eventMock.MockEventRepository
+}
+
+func TestCancelProblemInteractor_Execute(t *testing.T) {
+	workflow, err := workflowValue.GetWorkflow(workflowValue.DefaultName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pausedState := state.NewState(*newTestProblem(value.StatusPaused), *agentValue.NewContent("# 分析\n来店数は前年比で減少"), nil, nil, *agentValue.NewHistory(""), nil, false, workflow, jobConfigValue.ModelMap{})
+	checkpoint := checkpointEntity.NewCheckpoint(sharedValue.ID("checkpoint-id"), testProblemID, 3, pausedState.Snapshot(), 0, 0, time.Now(), nil)
+	expectedReport := pausedState.ToReport()
+
+	tests := []struct {
+		name              string
+		problem           *entity.Problem
+		mockSetup         func(m cancelMocks)
+		expectedErrorType errors.UseCaseErrorType
+	}{
+		{
+			name:    "processing problem is requested to cancel",
+			problem: newTestProblem(value.StatusProcessing),
+			mockSetup: func(m cancelMocks) {
+				m.problemRepo.EXPECT().RequestCancel(gomock.Any(), testProblemID).Return(nil).Times(1)
+			},
+		},
+		{
+			name:    "paused problem is finished with the report of the checkpoint",
+			problem: newTestProblem(value.StatusPaused),
+			mockSetup: func(m cancelMocks) {
+				gomock.InOrder(
+					m.problemRepo.EXPECT().TransitionStatus(gomock.Any(), testProblemID, value.StatusPaused, value.StatusCancelled).Return(true, nil),
+					m.approvalRepo.EXPECT().ExpirePendingByProblemID(gomock.Any(), testProblemID).Return(nil),
+					m.checkpointRepo.EXPECT().FindLatestByProblemID(gomock.Any(), testProblemID).Return(checkpoint, nil),
+					m.reportRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, report *reportEntity.Report) error {
+						content := report.GetContent()
+						if content.Value() != expectedReport.Value() {
+							t.Errorf("report = %q, expected %q", content.Value(), expectedReport.Value())
+						}
+						return nil
+					}),
+					m.checkpointRepo.EXPECT().DeleteByProblemID(gomock.Any(), testProblemID).Return(int64(1), nil),
+					m.problemRepo.EXPECT().ClearCancelRequest(gomock.Any(), testProblemID).Return(nil),
+					m.eventRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, event *eventEntity.Event) error {
+						if event.Message.Value() != eventValue.CancelledMessage {
+							t.Errorf("message = %q, expected %q", event.Message.Value(), eventValue.CancelledMessage)
+						}
+						return nil
+					}),
+				)
+			},
+		},
+		{
+			name:    "paused problem without checkpoint is cancelled without report",
+			problem: newTestProblem(value.StatusPaused),
+			mockSetup: func(m cancelMocks) {
+				m.problemRepo.EXPECT().TransitionStatus(gomock.Any(), testProblemID, value.StatusPaused, value.StatusCancelled).Return(true, nil)
+				m.approvalRepo.EXPECT().ExpirePendingByProblemID(gomock.Any(), testProblemID).Return(nil)
+				m.checkpointRepo.EXPECT().FindLatestByProblemID(gomock.Any(), testProblemID).Return(nil, nil)
+				m.checkpointRepo.EXPECT().DeleteByProblemID(gomock.Any(), testProblemID).Return(int64(0), nil)
+				m.problemRepo.EXPECT().ClearCancelRequest(gomock.Any(), testProblemID).Return(nil)
+				m.eventRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name:    "paused problem resumed by a decision in the meantime",
+			problem: newTestProblem(value.StatusPaused),
+			mockSetup: func(m cancelMocks) {
+				m.problemRepo.EXPECT().TransitionStatus(gomock.Any(), testProblemID, value.StatusPaused, value.StatusCancelled).Return(false, nil)
+			},
+			expectedErrorType: errors.ConflictError,
+		},
+		{
+			name:              "done problem cannot be cancelled",
+			problem:           newTestProblem(value.StatusDone),
+			mockSetup:         func(m cancelMocks) {},
+			expectedErrorType: errors.ConflictError,
+		},
+		{
+			name:              "cancelled problem cannot be cancelled again",
+			problem:           newTestProblem(value.StatusCancelled),
+			mockSetup:         func(m cancelMocks) {},
+			expectedErrorType: errors.ConflictError,
+		},
+		{
+			name:              "problem not found",
+			mockSetup:         func(m cancelMocks) {},
+			expectedErrorType: errors.NotFoundError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			m := cancelMocks{
+				problemRepo:    mock.NewMockProblemRepository(ctrl),
+				approvalRepo:   approvalMock.NewMockApprovalRepository(ctrl),
+				checkpointRepo: checkpointMock.NewMockCheckpointRepository(ctrl),
+				reportRepo:     reportMock.NewMockReportRepository(ctrl),
+				eventRepo:      eventMock.NewMockEventRepository(ctrl),
+			}
+			m.problemRepo.EXPECT().FindById(gomock.Any(), testProblemID).Return(tt.problem, nil).Times(1)
+			tt.mockSetup(m)
+
+			ctx := logger.WithLogger(context.Background(), nopLogger{})
+			err := NewCancelProblemUseCase(m.problemRepo, m.approvalRepo, m.checkpointRepo, m.reportRepo, m.eventRepo).Execute(ctx, CancelProblemUseCaseInput{ProblemID: testProblemID.Value()})
+			if tt.expectedErrorType == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			var useCaseError *errors.UseCaseError
+			if !stdErrors.As(err, &useCaseError) || useCaseError.ErrorType != tt.expectedErrorType {
+				t.Errorf("error = %v, expected %s", err, tt.expectedErrorType)
+			}
+		})
+	}
+}
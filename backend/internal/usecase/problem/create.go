@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	jobConfigEntity "github.com/goda6565/ai-consultant/backend/internal/domain/job_config/entity"
+	jobConfigValue "github.com/goda6565/ai-consultant/backend/internal/domain/job_config/value"
 	"github.com/goda6565/ai-consultant/backend/internal/domain/problem/entity"
 	"github.com/goda6565/ai-consultant/backend/internal/domain/problem/repository"
 	"github.com/goda6565/ai-consultant/backend/internal/domain/problem/service"
@@ -14,6 +15,7 @@ import (
 	problemFieldService "github.com/goda6565/ai-consultant/backend/internal/domain/problem_field/service"
 	problemFieldValue "github.com/goda6565/ai-consultant/backend/internal/domain/problem_field/value"
 	sharedValue "github.com/goda6565/ai-consultant/backend/internal/domain/shared/value"
+	workflowValue "github.com/goda6565/ai-consultant/backend/internal/domain/workflow/value"
 	"github.com/goda6565/ai-consultant/backend/internal/pkg/uuid"
 	transaction "github.com/goda6565/ai-consultant/backend/internal/usecase/ports/transaction"
 )
@@ -92,7 +94,7 @@ func (i *CreateProblemInteractor) Execute(ctx context.Context, input CreateProbl
 	if err != nil {
 		return nil, fmt.Errorf("failed to create job config id: %w", err)
 	}
-	jobConfig := jobConfigEntity.NewJobConfig(jobConfigID, problem.GetID(), false)
+	jobConfig := jobConfigEntity.NewJobConfig(jobConfigID, problem.GetID(), false, jobConfigValue.ModelMap{}, jobConfigValue.Budget{}, jobConfigValue.ApprovalGates{}, workflowValue.DefaultName, jobConfigValue.DocumentScope{})
 
 	// save problem and problem fields in transaction
 	err = i.adminUnitOfWork.WithTx(ctx, func(ctx context.Context) error {
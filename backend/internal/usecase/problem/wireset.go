@@ -9,4 +9,5 @@ var Set = wire.NewSet(
 	NewListProblemUseCase,
 	NewDeleteProblemUseCase,
 	NewGetProblemUseCase,
+	NewCancelProblemUseCase,
 )
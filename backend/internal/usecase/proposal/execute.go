@@ -2,23 +2,36 @@ package proposal
 
 import (
 	"context"
+	stdErrors "errors"
 	"fmt"
+	"strings"
+	"sync"
+	"time"
 
 	actionEntity "github.com/goda6565/ai-consultant/backend/internal/domain/action/entity"
 	actionRepository "github.com/goda6565/ai-consultant/backend/internal/domain/action/repository"
 	actionService "github.com/goda6565/ai-consultant/backend/internal/domain/action/service"
 	actionValue "github.com/goda6565/ai-consultant/backend/internal/domain/action/value"
 	agentService "github.com/goda6565/ai-consultant/backend/internal/domain/agent/service"
-	"github.com/goda6565/ai-consultant/backend/internal/domain/agent/state"
+	agentState "github.com/goda6565/ai-consultant/backend/internal/domain/agent/state"
 	"github.com/goda6565/ai-consultant/backend/internal/domain/agent/value"
+	approvalEntity "github.com/goda6565/ai-consultant/backend/internal/domain/approval/entity"
+	approvalRepository "github.com/goda6565/ai-consultant/backend/internal/domain/approval/repository"
+	approvalValue "github.com/goda6565/ai-consultant/backend/internal/domain/approval/value"
+	checkpointEntity "github.com/goda6565/ai-consultant/backend/internal/domain/checkpoint/entity"
+	checkpointRepository "github.com/goda6565/ai-consultant/backend/internal/domain/checkpoint/repository"
+	documentRepository "github.com/goda6565/ai-consultant/backend/internal/domain/document/repository"
 	eventEntity "github.com/goda6565/ai-consultant/backend/internal/domain/event/entity"
 	eventRepository "github.com/goda6565/ai-consultant/backend/internal/domain/event/repository"
 	eventValue "github.com/goda6565/ai-consultant/backend/internal/domain/event/value"
+	goalRevisionEntity "github.com/goda6565/ai-consultant/backend/internal/domain/goal_revision/entity"
+	goalRevisionRepository "github.com/goda6565/ai-consultant/backend/internal/domain/goal_revision/repository"
 	hearingRepository "github.com/goda6565/ai-consultant/backend/internal/domain/hearing/repository"
 	hearingMessageEntity "github.com/goda6565/ai-consultant/backend/internal/domain/hearing_message/entity"
 	hearingMessageRepository "github.com/goda6565/ai-consultant/backend/internal/domain/hearing_message/repository"
 	jobConfigEntity "github.com/goda6565/ai-consultant/backend/internal/domain/job_config/entity"
 	jobConfigRepository "github.com/goda6565/ai-consultant/backend/internal/domain/job_config/repository"
+	jobConfigValue "github.com/goda6565/ai-consultant/backend/internal/domain/job_config/value"
 	"github.com/goda6565/ai-consultant/backend/internal/domain/llm"
 	problemEntity "github.com/goda6565/ai-consultant/backend/internal/domain/problem/entity"
 	problemRepository "github.com/goda6565/ai-consultant/backend/internal/domain/problem/repository"
@@ -28,7 +41,13 @@ import (
 	reportEntity "github.com/goda6565/ai-consultant/backend/internal/domain/report/entity"
 	reportRepository "github.com/goda6565/ai-consultant/backend/internal/domain/report/repository"
 	reportValue "github.com/goda6565/ai-consultant/backend/internal/domain/report/value"
+	"github.com/goda6565/ai-consultant/backend/internal/domain/search"
 	sharedValue "github.com/goda6565/ai-consultant/backend/internal/domain/shared/value"
+	traceService "github.com/goda6565/ai-consultant/backend/internal/domain/trace/service"
+	traceValue "github.com/goda6565/ai-consultant/backend/internal/domain/trace/value"
+	usageService "github.com/goda6565/ai-consultant/backend/internal/domain/usage/service"
+	usageValue "github.com/goda6565/ai-consultant/backend/internal/domain/usage/value"
+	workflowValue "github.com/goda6565/ai-consultant/backend/internal/domain/workflow/value"
 	"github.com/goda6565/ai-consultant/backend/internal/pkg/logger"
 	"github.com/goda6565/ai-consultant/backend/internal/pkg/uuid"
 	"github.com/goda6565/ai-consultant/backend/internal/usecase/errors"
@@ -38,6 +57,40 @@ var ActionMessage = "%sを実行開始"
 
 const MaxAllowedFailures = 3
 
+var (
+	BudgetNearLimitMessage = "予算の上限に近づいたため（%s）、現在までの内容で最終レポートを作成して終了します。"
+	BudgetExceededMessage  = "予算の上限を超えたため（%s）、現在までの内容で終了します。"
+)
+
+var ResumeMessage = "中断された実行をステップ%dから再開します。"
+
+// CancelPollInterval はキャンセル要求を確認する間隔
+const CancelPollInterval = 5 * time.Second
+
+// ErrProposalCancelled is the cause of the context cancelled by a cancel request from the admin API.
+var ErrProposalCancelled = stdErrors.New("proposal job is cancelled")
+
+var (
+	PausedMessage   = "%sの結果の承認待ちのため一時停止しました。"
+	ApprovedMessage = "%sの結果が承認されました。"
+	RevisedMessage  = "%sの結果が差し戻されました。"
+)
+
+// ErrProposalPaused is returned when the run stops at an approval gate. The job exits and is started again by the decision.
+var ErrProposalPaused = stdErrors.New("proposal job is paused for approval")
+
+var ReflectionMessage = "振り返りを行いました。\n%s"
+
+// RunSpanName はジョブ1回の実行を表すトレースのルート区間の名前
+const RunSpanName = "proposal"
+
+var (
+	InitialGoalReason        = "ヒアリング内容をもとに設定"
+	ApprovalGoalReason       = "レビュアーがゴールを変更"
+	GoalRevisionMessage      = "ゴールを第%d版に見直しました。\n理由: %s\n\n%s"
+	GoalRevisionHistoryEntry = "ゴールを第%d版に見直しました。理由: %s"
+)
+
 type ExecuteProposalInputPort interface {
 	Execute(ctx context.Context, input ExecuteProposalUseCaseInput) error
 }
@@ -58,9 +111,17 @@ type ExecuteProposalInteractor struct {
 	goalService              *agentService.GoalService
 	terminator               *agentService.Terminator
 	skipper                  *agentService.Skipper
+	reflection               *agentService.Reflection
+	goalRevisionService      *agentService.GoalRevisionService
 	actionFactory            *actionService.ActionFactory
 	reportRepository         reportRepository.ReportRepository
 	jobConfigRepository      jobConfigRepository.JobConfigRepository
+	documentRepository       documentRepository.DocumentRepository
+	ledgerService            *usageService.LedgerService
+	checkpointRepository     checkpointRepository.CheckpointRepository
+	approvalRepository       approvalRepository.ApprovalRepository
+	goalRevisionRepository   goalRevisionRepository.GoalRevisionRepository
+	tracer                   *traceService.Tracer
 }
 
 func NewExecuteProposalUseCase(
@@ -75,9 +136,17 @@ func NewExecuteProposalUseCase(
 	goalService *agentService.GoalService,
 	terminator *agentService.Terminator,
 	skipper *agentService.Skipper,
+	reflection *agentService.Reflection,
+	goalRevisionService *agentService.GoalRevisionService,
 	actionFactory *actionService.ActionFactory,
 	reportRepository reportRepository.ReportRepository,
 	jobConfigRepository jobConfigRepository.JobConfigRepository,
+	documentRepository documentRepository.DocumentRepository,
+	ledgerService *usageService.LedgerService,
+	checkpointRepository checkpointRepository.CheckpointRepository,
+	approvalRepository approvalRepository.ApprovalRepository,
+	goalRevisionRepository goalRevisionRepository.GoalRevisionRepository,
+	tracer *traceService.Tracer,
 ) ExecuteProposalInputPort {
 	return &ExecuteProposalInteractor{
 		problemRepository:        problemRepository,
@@ -91,9 +160,17 @@ func NewExecuteProposalUseCase(
 		goalService:              goalService,
 		terminator:               terminator,
 		skipper:                  skipper,
+		reflection:               reflection,
+		goalRevisionService:      goalRevisionService,
 		actionFactory:            actionFactory,
 		reportRepository:         reportRepository,
 		jobConfigRepository:      jobConfigRepository,
+		documentRepository:       documentRepository,
+		ledgerService:            ledgerService,
+		checkpointRepository:     checkpointRepository,
+		approvalRepository:       approvalRepository,
+		goalRevisionRepository:   goalRevisionRepository,
+		tracer:                   tracer,
 	}
 }
 
@@ -117,43 +194,178 @@ func (i *ExecuteProposalInteractor) Execute(ctx context.Context, input ExecutePr
 			if err != nil {
 				logger.Error("failed to update problem status", "error", err)
 			}
+			i.clearRunState(ctx, problemID)
 		}
 	}()
+	runCtx, stopWatch := i.watchCancel(ctx, problemID)
+	defer stopWatch()
+	traceCtx, runSpan := i.tracer.StartRun(runCtx, problemID, RunSpanName)
+	state, err := i.run(traceCtx, problemID)
+	// a pause is not a failure of the run
+	if stdErrors.Is(err, ErrProposalPaused) {
+		runSpan.SetAttribute("paused", true)
+		runSpan.End(nil)
+	} else {
+		runSpan.End(err)
+	}
+	if stdErrors.Is(context.Cause(runCtx), ErrProposalCancelled) {
+		return i.finishCancelled(ctx, problemID, state)
+	}
+	if stdErrors.Is(err, ErrProposalPaused) {
+		err = i.problemRepository.UpdateStatus(ctx, problemID, problemValue.StatusPaused)
+		if err != nil {
+			return fmt.Errorf("failed to update problem status: %w", err)
+		}
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	// debug
+	logger.Info("state", "state", state)
+
+	// save report
+	err = i.saveReport(ctx, problemID, state.ToReport())
+	if err != nil {
+		return fmt.Errorf("failed to save report: %w", err)
+	}
+
+	// update problem status
+	err = i.problemRepository.UpdateStatus(ctx, problemID, problemValue.StatusDone)
+	if err != nil {
+		return fmt.Errorf("failed to update problem status: %w", err)
+	}
+	i.clearRunState(ctx, problemID)
+	return nil
+}
+
+// run executes the agent loop and returns the state so far even when it fails.
+func (i *ExecuteProposalInteractor) run(ctx context.Context, problemID sharedValue.ID) (*agentState.State, error) {
+	logger := logger.GetLogger(ctx)
+	var state *agentState.State
 	preFetchOutput, err := i.preFetch(ctx, problemID)
 	if err != nil {
-		return fmt.Errorf("failed to pre-fetch: %w", err)
+		return state, fmt.Errorf("failed to pre-fetch: %w", err)
 	}
 	problem := preFetchOutput.Problem
 	problemFields := preFetchOutput.ProblemFields
 	hearingMessages := preFetchOutput.HearingMessages
 	jobConfig := preFetchOutput.JobConfig
-	state := state.NewState(*problem, *value.NewContent(""), problemFields, hearingMessages, *value.NewHistory(""), []actionValue.ActionType{}, jobConfig.GetEnableInternalSearch())
-	// goal
-	goal, err := i.goalService.Execute(ctx, agentService.GoalServiceInput{State: *state})
+	workflow := preFetchOutput.Workflow
+
+	// internal search
+	ctx, err = i.scopeDocuments(ctx, jobConfig.GetDocumentScope())
+	if err != nil {
+		return state, fmt.Errorf("failed to scope documents: %w", err)
+	}
+
+	checkpoint, err := i.findResumeCheckpoint(ctx, problem)
 	if err != nil {
-		return fmt.Errorf("failed to execute goal: %w", err)
+		return state, err
+	}
+	step, failCount, startedAt := 0, 0, time.Now()
+	// the budget only counts the usage of this run, including the attempts before a restart
+	runStartedAt := startedAt
+	if checkpoint != nil {
+		// resume the run that was killed in the middle
+		state, err = agentState.RestoreState(*problem, problemFields, hearingMessages, jobConfig.GetEnableInternalSearch(), workflow, jobConfig.GetModelMap(), checkpoint.GetSnapshot())
+		if err != nil {
+			return state, fmt.Errorf("failed to restore state: %w", err)
+		}
+		step = checkpoint.GetStep()
+		failCount = checkpoint.GetFailCount()
+		startedAt = startedAt.Add(-checkpoint.GetElapsed())
+		runStartedAt = checkpoint.GetRunStartedAt()
+		logger.Info("resume from checkpoint", "step", step)
+		err = i.createEvent(ctx, problemID, eventValue.EventTypeAction, state.GetCurrentAction(), fmt.Sprintf(ResumeMessage, step))
+		if err != nil {
+			return state, fmt.Errorf("failed to create event: %w", err)
+		}
+		// approval
+		err = i.applyApproval(ctx, problemID, state, step, failCount, time.Since(startedAt), runStartedAt)
+		if err != nil {
+			return state, err
+		}
+	} else {
+		state = agentState.NewState(*problem, *value.NewContent(""), problemFields, hearingMessages, *value.NewHistory(""), []actionValue.ActionType{}, jobConfig.GetEnableInternalSearch(), workflow, jobConfig.GetModelMap())
+		// goal
+		goalCtx, span := traceService.Start(usageValue.WithScope(ctx, problemID, actionValue.SelfActionTypeGoal), actionValue.SelfActionTypeGoal.Value(), traceValue.SpanKindDecision)
+		goal, err := i.goalService.Execute(goalCtx, agentService.GoalServiceInput{State: *state})
+		if err != nil {
+			span.End(err)
+			return state, fmt.Errorf("failed to execute goal: %w", err)
+		}
+		span.SetAttribute("goal", goal.Goal.Value())
+		span.End(nil)
+		logger.Debug("goal", "goal", goal.Goal.Value())
+		revision := state.ReviseGoal(goal.Goal, InitialGoalReason, actionValue.SelfActionTypeGoal)
+		err = i.saveGoalRevision(ctx, problemID, revision)
+		if err != nil {
+			return state, fmt.Errorf("failed to save goal revision: %w", err)
+		}
 	}
-	logger.Debug("goal", "goal", goal.Goal.Value())
-	state.SetGoal(goal.Goal)
 
-	var failCount int
+	// every step is traced as a child of the run span
+	traceCtx := ctx
+	var stepSpan *traceService.ActiveSpan
+	defer func() {
+		stepSpan.End(nil)
+	}()
+	for ; ; step++ {
+		stepSpan.End(nil)
+		ctx, stepSpan = traceService.Start(traceCtx, fmt.Sprintf("step %d", step), traceValue.SpanKindStep)
+		stepSpan.SetAttribute("step", step)
+		stepSpan.SetAttribute("currentAction", state.GetCurrentAction().Value())
+
+		// cancel
+		if ctx.Err() != nil {
+			return state, context.Cause(ctx)
+		}
+
+		// checkpoint
+		err = i.saveCheckpoint(ctx, problemID, step, state, failCount, time.Since(startedAt), runStartedAt)
+		if err != nil {
+			return state, fmt.Errorf("failed to save checkpoint: %w", err)
+		}
+
+		// budget
+		budgetCheck, err := i.checkBudget(ctx, problemID, jobConfig.GetBudget(), startedAt, runStartedAt)
+		if err != nil {
+			return state, fmt.Errorf("failed to check budget: %w", err)
+		}
+		if budgetCheck.Status != jobConfigValue.BudgetStatusWithin {
+			err = i.terminateByBudget(ctx, problemID, state, *budgetCheck)
+			if err != nil {
+				return state, fmt.Errorf("failed to terminate by budget: %w", err)
+			}
+			break
+		}
 
-	for {
 		// orchestrator
-		decision, err := i.orchestrator.Execute(ctx, agentService.OrchestratorInput{State: *state})
-		logger.Debug("nextAction", "canProceed", decision.CanProceed)
-		logger.Debug("nextAction", "reason", decision.Reason)
+		orchestratorCtx, span := traceService.Start(usageValue.WithScope(ctx, problemID, actionValue.SelfActionTypeOrchestrator), actionValue.SelfActionTypeOrchestrator.Value(), traceValue.SpanKindDecision)
+		decision, err := i.orchestrator.Execute(orchestratorCtx, agentService.OrchestratorInput{State: *state})
 		if err != nil {
-			return fmt.Errorf("failed to execute orchestrator: %w", err)
+			span.End(err)
+			return state, fmt.Errorf("failed to execute orchestrator: %w", err)
 		}
+		span.SetAttribute("canProceed", decision.CanProceed)
+		span.SetAttribute("reason", decision.Reason)
+		span.End(nil)
+		logger.Debug("nextAction", "canProceed", decision.CanProceed)
+		logger.Debug("nextAction", "reason", decision.Reason)
 		state.AddHistory(actionValue.SelfActionTypeOrchestrator, decision.Reason)
 
-		if decision.CanProceed && state.GetCurrentAction() == actionValue.ActionTypeReview {
+		if decision.CanProceed && state.IsTerminalAction() {
 			state.IncrementActionLoopCount()
-			terminatorOutput, err := i.terminator.Execute(ctx, agentService.TerminatorInput{State: *state})
+			terminatorCtx, span := traceService.Start(usageValue.WithScope(ctx, problemID, actionValue.SelfActionTypeTerminator), actionValue.SelfActionTypeTerminator.Value(), traceValue.SpanKindDecision)
+			terminatorOutput, err := i.terminator.Execute(terminatorCtx, agentService.TerminatorInput{State: *state})
 			if err != nil {
-				return fmt.Errorf("failed to execute terminator: %w", err)
+				span.End(err)
+				return state, fmt.Errorf("failed to execute terminator: %w", err)
 			}
+			span.SetAttribute("shouldTerminate", terminatorOutput.ShouldTerminate)
+			span.SetAttribute("reason", terminatorOutput.Reason)
+			span.End(nil)
 			logger.Debug("terminatorOutput", "shouldTerminate", terminatorOutput.ShouldTerminate)
 			logger.Debug("terminatorOutput", "reason", terminatorOutput.Reason)
 			state.AddHistory(actionValue.SelfActionTypeTerminator, terminatorOutput.Reason)
@@ -161,7 +373,7 @@ func (i *ExecuteProposalInteractor) Execute(ctx context.Context, input ExecutePr
 				state.Done()
 				err = i.createEvent(ctx, problemID, eventValue.EventTypeAction, state.GetCurrentAction(), "提案作成が完了しました。")
 				if err != nil {
-					return fmt.Errorf("failed to create event: %w", err)
+					return state, fmt.Errorf("failed to create event: %w", err)
 				}
 				break
 			}
@@ -170,10 +382,24 @@ func (i *ExecuteProposalInteractor) Execute(ctx context.Context, input ExecutePr
 		// action
 		state.ToNextAction(decision.CanProceed)
 
-		skipperOutput, err := i.skipper.Execute(ctx, agentService.SkipperInput{State: *state})
+		// reflection
+		if !decision.CanProceed && agentService.IsStalled(*state) {
+			err = i.reflect(ctx, problemID, state, agentService.ReflectionTriggerStalled, state.GetCurrentAction(), decision.Reason)
+			if err != nil {
+				return state, fmt.Errorf("failed to reflect: %w", err)
+			}
+		}
+
+		skipperCtx, span := traceService.Start(usageValue.WithScope(ctx, problemID, actionValue.SelfActionTypeSkipper), actionValue.SelfActionTypeSkipper.Value(), traceValue.SpanKindDecision)
+		skipperOutput, err := i.skipper.Execute(skipperCtx, agentService.SkipperInput{State: *state})
 		if err != nil {
-			return fmt.Errorf("failed to execute skipper: %w", err)
+			span.End(err)
+			return state, fmt.Errorf("failed to execute skipper: %w", err)
 		}
+		span.SetAttribute("action", state.GetCurrentAction().Value())
+		span.SetAttribute("shouldSkip", skipperOutput.ShouldSkip)
+		span.SetAttribute("reason", skipperOutput.Reason)
+		span.End(nil)
 		logger.Debug("skipperOutput", "shouldSkip", skipperOutput.ShouldSkip)
 		logger.Debug("skipperOutput", "reason", skipperOutput.Reason)
 		state.AddHistory(actionValue.SelfActionTypeSkipper, skipperOutput.Reason)
@@ -181,96 +407,166 @@ func (i *ExecuteProposalInteractor) Execute(ctx context.Context, input ExecutePr
 			continue
 		}
 
-		err = i.createEvent(ctx, problemID, eventValue.EventTypeAction, state.GetCurrentAction(), fmt.Sprintf(ActionMessage, state.GetCurrentAction().Value()))
+		// independent actions of the workflow run concurrently
+		branches := state.GetBranches()
+		results, err := i.executeBranches(ctx, problemID, state, branches)
 		if err != nil {
-			return fmt.Errorf("failed to create event: %w", err)
+			return state, fmt.Errorf("failed to execute branches: %w", err)
 		}
-		tmpl, err := i.actionFactory.GetActionTemplate(state.GetCurrentAction())
+		var outputs []*actionService.ActionTemplateOutput
+		outputs, failCount, err = i.mergeBranches(ctx, problemID, state, results, failCount)
 		if err != nil {
-			return fmt.Errorf("failed to get action: %w", err)
+			return state, err
 		}
-		output, err := tmpl.Execute(ctx, actionService.ActionTemplateInput{
-			State: *state,
-		})
-		if err != nil {
-			// agent must be alive even if some actions fail
-			logger.Error("failed to execute action", "error", err)
-			failCount++
-			if failCount >= MaxAllowedFailures {
-				logger.Error("failed to execute action", "error", err)
-				return fmt.Errorf("failed to execute action: %w", err)
-			}
+		state.JoinBranches(branches)
+		if len(outputs) == 0 {
 			continue
 		}
-		// save action
-		err = i.saveAction(ctx, output.Action)
-		if err != nil {
-			return fmt.Errorf("failed to save action: %w", err)
-		}
-		// save to state
-		inputValue := output.Action.GetInput()
-		outputValue := output.Action.GetOutput()
-		logger.Debug("action", "action", output.Action.GetActionType().Value())
-		logger.Debug("inputValue", "inputValue", inputValue.Value())
-		logger.Debug("outputValue", "outputValue", outputValue.Value())
-		if inputValue.Value() != "" {
-			err = i.createEvent(ctx, problemID, eventValue.EventTypeInput, state.GetCurrentAction(), inputValue.Value())
-			if err != nil {
-				return fmt.Errorf("failed to create event: %w", err)
-			}
-		}
-		if outputValue.Value() != "" {
-			err = i.createEvent(ctx, problemID, eventValue.EventTypeOutput, state.GetCurrentAction(), outputValue.Value())
-			if err != nil {
-				return fmt.Errorf("failed to create event: %w", err)
-			}
-		}
-		state.SetContent(output.Content)
-		state.AddHistory(state.GetCurrentAction(), output.Action.ToHistory())
 		// summarize
 		history := state.GetHistory()
-		summarizeNeeded, err := i.summarizeService.IsSummarizeNeeded(ctx, agentService.SummarizeServiceInput{
+		summarizeCtx := usageValue.WithScope(ctx, problemID, actionValue.SelfActionTypeSummarize)
+		summarizeNeeded, err := i.summarizeService.IsSummarizeNeeded(summarizeCtx, agentService.SummarizeServiceInput{
 			History:   history.GetValue(),
-			LLMConfig: llm.LLMConfig{Provider: llm.VertexAI, Model: llm.Gemini25Flash},
+			LLMConfig: state.GetModelConfig(actionValue.SelfActionTypeSummarize),
 		})
 		if err != nil {
-			return fmt.Errorf("failed to check if summarize is needed: %w", err)
+			return state, fmt.Errorf("failed to check if summarize is needed: %w", err)
 		}
 		if summarizeNeeded {
 			// summarize
-			summarizedHistory, err := i.summarizeService.Summarize(ctx, agentService.SummarizeServiceInput{
+			summarizedHistory, err := i.summarizeService.Summarize(summarizeCtx, agentService.SummarizeServiceInput{
 				History:   history.GetValue(),
-				LLMConfig: llm.LLMConfig{Provider: llm.VertexAI, Model: llm.Gemini25Flash},
+				LLMConfig: state.GetModelConfig(actionValue.SelfActionTypeSummarize),
 			})
-			logger.Debug("summarizedHistory", "summarizedHistory", summarizedHistory.SummarizedHistory)
 			if err != nil {
-				return fmt.Errorf("failed to summarize history: %w", err)
+				return state, fmt.Errorf("failed to summarize history: %w", err)
 			}
+			logger.Debug("summarizedHistory", "summarizedHistory", summarizedHistory.SummarizedHistory)
 			state.SetHistory(*value.NewHistory(summarizedHistory.SummarizedHistory))
 		}
+
+		// approval gate
+		for _, output := range outputs {
+			if !jobConfig.GetApprovalGates().Contains(output.Action.GetActionType()) {
+				continue
+			}
+			err = i.pauseForApproval(ctx, problemID, step+1, state, output.Action, failCount, time.Since(startedAt), runStartedAt)
+			if err != nil {
+				return state, fmt.Errorf("failed to pause for approval: %w", err)
+			}
+			return state, ErrProposalPaused
+		}
 	}
-	// debug
-	logger.Info("state", "state", state)
+	return state, nil
+}
 
-	// save report
-	reportID, err := sharedValue.NewID(uuid.NewUUID())
-	if err != nil {
-		return fmt.Errorf("failed to create report id: %w", err)
+type BranchResult struct {
+	ActionType actionValue.ActionType
+	Output     *actionService.ActionTemplateOutput
+	Err        error
+}
+
+// mergeBranches applies the results of the branches to the state in the order of the workflow, not in the order of completion,
+// and returns the outputs of the succeeded actions with the updated failure count.
+// All branches start from the same content, so a branch that returns it unchanged must not overwrite the content written by another branch.
+func (i *ExecuteProposalInteractor) mergeBranches(ctx context.Context, problemID sharedValue.ID, state *agentState.State, results []BranchResult, failCount int) ([]*actionService.ActionTemplateOutput, int, error) {
+	logger := logger.GetLogger(ctx)
+	baseContent := state.GetContent()
+	outputs := []*actionService.ActionTemplateOutput{}
+	for _, result := range results {
+		if result.Err != nil {
+			// agent must be alive even if some actions fail
+			logger.Error("failed to execute action", "action", result.ActionType.Value(), "error", result.Err)
+			failCount++
+			if failCount >= MaxAllowedFailures {
+				return outputs, failCount, fmt.Errorf("failed to execute action: %w", result.Err)
+			}
+			err := i.reflect(ctx, problemID, state, agentService.ReflectionTriggerFailed, result.ActionType, fmt.Sprintf("%s: %s", result.ActionType.Value(), result.Err.Error()))
+			if err != nil {
+				return outputs, failCount, fmt.Errorf("failed to reflect: %w", err)
+			}
+			continue
+		}
+		output := *result.Output
+		if output.Content.Equals(baseContent) {
+			output.Content = state.GetContent()
+		}
+		err := i.applyActionOutput(ctx, problemID, state, &output)
+		if err != nil {
+			return outputs, failCount, fmt.Errorf("failed to apply action output: %w", err)
+		}
+		outputs = append(outputs, &output)
+
+		// goal revision
+		if agentService.CanReviseGoal(*state, result.ActionType) {
+			err = i.reviseGoal(ctx, problemID, state, result.ActionType)
+			if err != nil {
+				return outputs, failCount, fmt.Errorf("failed to revise goal: %w", err)
+			}
+		}
 	}
-	content := state.GetContent()
-	reportContent := reportValue.NewContent(content.Value())
-	reportEntity := reportEntity.NewReport(reportID, problemID, *reportContent, nil)
-	err = i.reportRepository.Create(ctx, reportEntity)
-	if err != nil {
-		return fmt.Errorf("failed to save report: %w", err)
+	return outputs, failCount, nil
+}
+
+// executeBranches runs the actions concurrently against the same state and returns the results in the order of the actions.
+// A single action runs in the calling goroutine.
+func (i *ExecuteProposalInteractor) executeBranches(ctx context.Context, problemID sharedValue.ID, state *agentState.State, branches []actionValue.ActionType) ([]BranchResult, error) {
+	templates := make([]actionService.ActionTemplate, len(branches))
+	for n, actionType := range branches {
+		err := i.createEvent(ctx, problemID, eventValue.EventTypeAction, actionType, fmt.Sprintf(ActionMessage, actionType.Value()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to create event: %w", err)
+		}
+		tmpl, err := i.actionFactory.GetActionTemplate(actionType)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get action: %w", err)
+		}
+		templates[n] = tmpl
 	}
 
-	// update problem status
-	err = i.problemRepository.UpdateStatus(ctx, problemID, problemValue.StatusDone)
-	if err != nil {
-		return fmt.Errorf("failed to update problem status: %w", err)
+	results := make([]BranchResult, len(branches))
+	execute := func(n int) {
+		actionType := branches[n]
+		actionCtx, span := traceService.Start(usageValue.WithScope(ctx, problemID, actionType), actionType.Value(), traceValue.SpanKindAction)
+		deltas := i.deltaBuffer(problemID, actionType)
+		output, err := templates[n].Execute(actionCtx, actionService.ActionTemplateInput{
+			State:   *state,
+			OnDelta: deltas.Handle,
+		})
+		deltas.Flush(actionCtx)
+		if output != nil {
+			actionInput, actionOutput := output.Action.GetInput(), output.Action.GetOutput()
+			span.SetAttribute("input", actionInput.Value())
+			span.SetAttribute("output", actionOutput.Value())
+		}
+		span.End(err)
+		results[n] = BranchResult{ActionType: actionType, Output: output, Err: err}
 	}
-	return nil
+	if len(branches) == 1 {
+		execute(0)
+		return results, nil
+	}
+
+	var wg sync.WaitGroup
+	panics := make([]any, len(branches))
+	for n := range branches {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() {
+				panics[n] = recover()
+			}()
+			execute(n)
+		}()
+	}
+	wg.Wait()
+	// re-panic in the calling goroutine so that Execute marks the problem as failed as in the serial run
+	for _, r := range panics {
+		if r != nil {
+			panic(r)
+		}
+	}
+	return results, nil
 }
 
 type PreFetchOutput struct {
@@ -278,6 +574,7 @@ type PreFetchOutput struct {
 	ProblemFields   []problemFieldEntity.ProblemField
 	HearingMessages []hearingMessageEntity.HearingMessage
 	JobConfig       *jobConfigEntity.JobConfig
+	Workflow        *workflowValue.Workflow
 }
 
 func (i *ExecuteProposalInteractor) preFetch(ctx context.Context, problemID sharedValue.ID) (*PreFetchOutput, error) {
@@ -313,14 +610,423 @@ func (i *ExecuteProposalInteractor) preFetch(ctx context.Context, problemID shar
 	if jobConfig == nil {
 		return nil, errors.NewUseCaseError(errors.NotFoundError, "job config not found")
 	}
+	workflow, err := workflowValue.GetWorkflow(jobConfig.GetWorkflowName())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get workflow: %w", err)
+	}
 	return &PreFetchOutput{
 		Problem:         problem,
 		ProblemFields:   problemFields,
 		HearingMessages: hearingMessages,
 		JobConfig:       jobConfig,
+		Workflow:        workflow,
 	}, nil
 }
 
+// scopeDocuments restricts the internal search and the datasets to the documents allowed by the job config.
+// The collections are resolved once per run, so documents added to them later are searched from the next run.
+func (i *ExecuteProposalInteractor) scopeDocuments(ctx context.Context, documentScope jobConfigValue.DocumentScope) (context.Context, error) {
+	if !documentScope.IsRestricted() {
+		return ctx, nil
+	}
+	documentIDs := documentScope.DocumentIDValues()
+	if collections := documentScope.GetCollections(); len(collections) > 0 {
+		ids, err := i.documentRepository.FindIDsByCollections(ctx, collections)
+		if err != nil {
+			return ctx, fmt.Errorf("failed to find documents by collections: %w", err)
+		}
+		for _, id := range ids {
+			documentIDs = append(documentIDs, id.Value())
+		}
+	}
+	return search.WithAllowedDocumentIDs(ctx, documentIDs), nil
+}
+
+func (i *ExecuteProposalInteractor) applyActionOutput(ctx context.Context, problemID sharedValue.ID, state *agentState.State, output *actionService.ActionTemplateOutput) error {
+	logger := logger.GetLogger(ctx)
+	// save action
+	err := i.saveAction(ctx, output.Action)
+	if err != nil {
+		return fmt.Errorf("failed to save action: %w", err)
+	}
+	// save to state
+	inputValue := output.Action.GetInput()
+	outputValue := output.Action.GetOutput()
+	logger.Debug("action", "action", output.Action.GetActionType().Value())
+	logger.Debug("inputValue", "inputValue", inputValue.Value())
+	logger.Debug("outputValue", "outputValue", outputValue.Value())
+	actionType := output.Action.GetActionType()
+	if inputValue.Value() != "" {
+		err = i.createEvent(ctx, problemID, eventValue.EventTypeInput, actionType, inputValue.Value())
+		if err != nil {
+			return fmt.Errorf("failed to create event: %w", err)
+		}
+	}
+	if outputValue.Value() != "" {
+		err = i.createEvent(ctx, problemID, eventValue.EventTypeOutput, actionType, outputValue.Value())
+		if err != nil {
+			return fmt.Errorf("failed to create event: %w", err)
+		}
+	}
+	state.SetContent(output.Content)
+	state.AddHistory(actionType, output.Action.ToHistory())
+	return nil
+}
+
+// checkBudget compares the usage recorded since the run started and the running time with the budget.
+// The usage of earlier runs of the problem, e.g. before a restart from scratch, is not counted.
+func (i *ExecuteProposalInteractor) checkBudget(ctx context.Context, problemID sharedValue.ID, budget jobConfigValue.Budget, startedAt time.Time, runStartedAt time.Time) (*jobConfigValue.BudgetCheck, error) {
+	if budget.IsUnlimited() {
+		return &jobConfigValue.BudgetCheck{Status: jobConfigValue.BudgetStatusWithin}, nil
+	}
+	summary, err := i.ledgerService.SummarizeSince(ctx, problemID, runStartedAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to summarize usage: %w", err)
+	}
+	// ヒアリング中の使用量は提案ジョブの予算に含めない
+	total := summary.TotalExcept(actionValue.SelfActionTypeHearing)
+	check := budget.Check(jobConfigValue.BudgetConsumption{
+		Tokens:  total.Usage.TotalTokens,
+		Cost:    total.Cost,
+		Elapsed: time.Since(startedAt),
+	})
+	return &check, nil
+}
+
+// terminateByBudget finishes the run with the best content so far.
+// When the budget is only close to the limit, a final write is forced unless the last action was already write.
+func (i *ExecuteProposalInteractor) terminateByBudget(ctx context.Context, problemID sharedValue.ID, state *agentState.State, budgetCheck jobConfigValue.BudgetCheck) error {
+	logger := logger.GetLogger(ctx)
+	logger.Warn("budget limit reached", "status", budgetCheck.Status, "reason", budgetCheck.Reason)
+
+	if budgetCheck.Status == jobConfigValue.BudgetStatusNearLimit && !state.GetCurrentAction().Equals(actionValue.ActionTypeWrite) {
+		err := i.createEvent(ctx, problemID, eventValue.EventTypeAction, actionValue.ActionTypeWrite, fmt.Sprintf(BudgetNearLimitMessage, budgetCheck.Reason))
+		if err != nil {
+			return fmt.Errorf("failed to create event: %w", err)
+		}
+		state.ForceAction(actionValue.ActionTypeWrite)
+		tmpl, err := i.actionFactory.GetActionTemplate(actionValue.ActionTypeWrite)
+		if err != nil {
+			return fmt.Errorf("failed to get action: %w", err)
+		}
+		actionCtx, span := traceService.Start(usageValue.WithScope(ctx, problemID, actionValue.ActionTypeWrite), actionValue.ActionTypeWrite.Value(), traceValue.SpanKindAction)
+		deltas := i.deltaBuffer(problemID, actionValue.ActionTypeWrite)
+		output, err := tmpl.Execute(actionCtx, actionService.ActionTemplateInput{
+			State:   *state,
+			OnDelta: deltas.Handle,
+		})
+		deltas.Flush(actionCtx)
+		if output != nil {
+			actionInput, actionOutput := output.Action.GetInput(), output.Action.GetOutput()
+			span.SetAttribute("input", actionInput.Value())
+			span.SetAttribute("output", actionOutput.Value())
+		}
+		span.End(err)
+		if err != nil {
+			// keep the content so far
+			logger.Error("failed to execute final write", "error", err)
+		} else {
+			err = i.applyActionOutput(ctx, problemID, state, output)
+			if err != nil {
+				return fmt.Errorf("failed to apply action output: %w", err)
+			}
+		}
+	} else {
+		err := i.createEvent(ctx, problemID, eventValue.EventTypeAction, actionValue.ActionTypeDone, fmt.Sprintf(BudgetExceededMessage, budgetCheck.Reason))
+		if err != nil {
+			return fmt.Errorf("failed to create event: %w", err)
+		}
+	}
+
+	state.Done()
+	err := i.createEvent(ctx, problemID, eventValue.EventTypeAction, state.GetCurrentAction(), "提案作成が完了しました。")
+	if err != nil {
+		return fmt.Errorf("failed to create event: %w", err)
+	}
+	return nil
+}
+
+// pauseForApproval saves the state after the action as the checkpoint to resume from and creates a pending approval.
+func (i *ExecuteProposalInteractor) pauseForApproval(ctx context.Context, problemID sharedValue.ID, resumeStep int, state *agentState.State, action actionEntity.Action, failCount int, elapsed time.Duration, runStartedAt time.Time) error {
+	err := i.saveCheckpoint(ctx, problemID, resumeStep, state, failCount, elapsed, runStartedAt)
+	if err != nil {
+		return fmt.Errorf("failed to save checkpoint: %w", err)
+	}
+	id, err := sharedValue.NewID(uuid.NewUUID())
+	if err != nil {
+		return fmt.Errorf("failed to create approval id: %w", err)
+	}
+	output := action.GetOutput()
+	actionType := action.GetActionType()
+	approval := approvalEntity.NewApproval(id, problemID, actionType, resumeStep, output.Value(), approvalValue.DecisionPending, "", nil, nil, nil, nil)
+	err = i.approvalRepository.Create(ctx, approval)
+	if err != nil {
+		return fmt.Errorf("failed to create approval: %w", err)
+	}
+	err = i.createEvent(ctx, problemID, eventValue.EventTypeAction, actionType, fmt.Sprintf(PausedMessage, actionType.Value()))
+	if err != nil {
+		return fmt.Errorf("failed to create event: %w", err)
+	}
+	return nil
+}
+
+// applyApproval adds the decision of the approval the run was paused for to the state.
+// It returns ErrProposalPaused while the approval is still pending.
+func (i *ExecuteProposalInteractor) applyApproval(ctx context.Context, problemID sharedValue.ID, state *agentState.State, step int, failCount int, elapsed time.Duration, runStartedAt time.Time) error {
+	approval, err := i.approvalRepository.FindLatestByProblemID(ctx, problemID)
+	if err != nil {
+		return fmt.Errorf("failed to find approval: %w", err)
+	}
+	if approval == nil || approval.IsApplied() {
+		return nil
+	}
+	if approval.IsPending() {
+		return ErrProposalPaused
+	}
+
+	if approval.GetGoal() != nil {
+		reason := ApprovalGoalReason
+		if approval.GetFeedback() != "" {
+			reason = fmt.Sprintf("%s: %s", ApprovalGoalReason, approval.GetFeedback())
+		}
+		err = i.applyGoalRevision(ctx, problemID, state, *approval.GetGoal(), reason, approval.GetActionType())
+		if err != nil {
+			return fmt.Errorf("failed to apply goal revision: %w", err)
+		}
+	}
+	state.AddHistory(actionValue.SelfActionTypeApproval, approval.ToHistory())
+	message := fmt.Sprintf(ApprovedMessage, approval.GetActionType().Value())
+	if approval.GetDecision().Equals(approvalValue.DecisionRevise) {
+		message = fmt.Sprintf(RevisedMessage, approval.GetActionType().Value())
+	}
+	if approval.GetFeedback() != "" {
+		message += "\n" + approval.GetFeedback()
+	}
+	err = i.createEvent(ctx, problemID, eventValue.EventTypeAction, approval.GetActionType(), message)
+	if err != nil {
+		return fmt.Errorf("failed to create event: %w", err)
+	}
+
+	// keep the decision in the checkpoint before marking it applied so that it is not lost on restart
+	err = i.saveCheckpoint(ctx, problemID, step, state, failCount, elapsed, runStartedAt)
+	if err != nil {
+		return fmt.Errorf("failed to save checkpoint: %w", err)
+	}
+	err = i.approvalRepository.MarkApplied(ctx, approval.GetID())
+	if err != nil {
+		return fmt.Errorf("failed to mark approval applied: %w", err)
+	}
+	return nil
+}
+
+// watchCancel polls the cancellation flag and cancels the returned context with ErrProposalCancelled.
+// The cancellation also stops the in-flight LLM calls.
+func (i *ExecuteProposalInteractor) watchCancel(ctx context.Context, problemID sharedValue.ID) (context.Context, func()) {
+	logger := logger.GetLogger(ctx)
+	ctx, cancel := context.WithCancelCause(ctx)
+	go func() {
+		ticker := time.NewTicker(CancelPollInterval)
+		defer ticker.Stop()
+		for {
+			cancelRequested, err := i.problemRepository.IsCancelRequested(ctx, problemID)
+			if err != nil {
+				logger.Warn("failed to check cancel request", "error", err)
+			} else if cancelRequested {
+				logger.Info("proposal cancel requested", "problemID", problemID.Value())
+				cancel(ErrProposalCancelled)
+				return
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+	return ctx, func() { cancel(nil) }
+}
+
+// finishCancelled saves the content so far as the report and sets the cancelled status.
+func (i *ExecuteProposalInteractor) finishCancelled(ctx context.Context, problemID sharedValue.ID, state *agentState.State) error {
+	if state != nil {
+		content := state.GetContent()
+		if content.Value() != "" {
+			err := i.saveReport(ctx, problemID, state.ToReport())
+			if err != nil {
+				return fmt.Errorf("failed to save report: %w", err)
+			}
+		}
+	}
+	err := i.problemRepository.UpdateStatus(ctx, problemID, problemValue.StatusCancelled)
+	if err != nil {
+		return fmt.Errorf("failed to update problem status: %w", err)
+	}
+	i.clearRunState(ctx, problemID)
+	err = i.createEvent(ctx, problemID, eventValue.EventTypeAction, actionValue.ActionTypeDone, eventValue.CancelledMessage)
+	if err != nil {
+		return fmt.Errorf("failed to create event: %w", err)
+	}
+	return nil
+}
+
+func (i *ExecuteProposalInteractor) saveReport(ctx context.Context, problemID sharedValue.ID, content value.Content) error {
+	reportID, err := sharedValue.NewID(uuid.NewUUID())
+	if err != nil {
+		return fmt.Errorf("failed to create report id: %w", err)
+	}
+	reportContent := reportValue.NewContent(content.Value())
+	report := reportEntity.NewReport(reportID, problemID, *reportContent, nil)
+	err = i.reportRepository.Create(ctx, report)
+	if err != nil {
+		return fmt.Errorf("failed to save report: %w", err)
+	}
+	return nil
+}
+
+// findResumeCheckpoint returns the checkpoint to resume from, or nil to start over.
+// Only a run that was interrupted while processing or paused for approval is resumed;
+// a finished, cancelled or failed problem starts from the beginning.
+func (i *ExecuteProposalInteractor) findResumeCheckpoint(ctx context.Context, problem *problemEntity.Problem) (*checkpointEntity.Checkpoint, error) {
+	status := problem.GetStatus()
+	if !status.Equals(problemValue.StatusProcessing) && !status.Equals(problemValue.StatusPaused) {
+		return nil, nil
+	}
+	checkpoint, err := i.checkpointRepository.FindLatestByProblemID(ctx, problem.GetID())
+	if err != nil {
+		return nil, fmt.Errorf("failed to find checkpoint: %w", err)
+	}
+	return checkpoint, nil
+}
+
+// clearRunState deletes the checkpoints and the cancel request of a run that reached a terminal status,
+// so that a later run starts over and is not cancelled by a stale request.
+// The status is already saved, so a failure is only logged.
+func (i *ExecuteProposalInteractor) clearRunState(ctx context.Context, problemID sharedValue.ID) {
+	logger := logger.GetLogger(ctx)
+	_, err := i.checkpointRepository.DeleteByProblemID(ctx, problemID)
+	if err != nil {
+		logger.Warn("failed to delete checkpoints", "error", err)
+	}
+	err = i.problemRepository.ClearCancelRequest(ctx, problemID)
+	if err != nil {
+		logger.Warn("failed to clear cancel request", "error", err)
+	}
+}
+
+func (i *ExecuteProposalInteractor) saveCheckpoint(ctx context.Context, problemID sharedValue.ID, step int, state *agentState.State, failCount int, elapsed time.Duration, runStartedAt time.Time) error {
+	id, err := sharedValue.NewID(uuid.NewUUID())
+	if err != nil {
+		return fmt.Errorf("failed to create checkpoint id: %w", err)
+	}
+	checkpoint := checkpointEntity.NewCheckpoint(id, problemID, step, state.Snapshot(), failCount, elapsed, runStartedAt, nil)
+	err = i.checkpointRepository.Save(ctx, checkpoint)
+	if err != nil {
+		return fmt.Errorf("failed to save checkpoint: %w", err)
+	}
+	return nil
+}
+
+// reviseGoal lets the agent revise the goal when the evidence contradicts it.
+// A failure of the revision itself does not stop the run.
+func (i *ExecuteProposalInteractor) reviseGoal(ctx context.Context, problemID sharedValue.ID, state *agentState.State, actionType actionValue.ActionType) error {
+	logger := logger.GetLogger(ctx)
+	goalCtx, span := traceService.Start(usageValue.WithScope(ctx, problemID, actionValue.SelfActionTypeGoal), "goalRevision", traceValue.SpanKindDecision)
+	output, err := i.goalRevisionService.Execute(goalCtx, agentService.GoalRevisionServiceInput{State: *state})
+	if err != nil {
+		span.End(err)
+		if ctx.Err() != nil {
+			return err
+		}
+		logger.Error("failed to execute goal revision", "error", err)
+		return nil
+	}
+	logger.Debug("goalRevision", "shouldRevise", output.ShouldRevise)
+	logger.Debug("goalRevision", "reason", output.Reason)
+	span.SetAttribute("shouldRevise", output.ShouldRevise)
+	span.SetAttribute("reason", output.Reason)
+	span.End(nil)
+	if !output.ShouldRevise {
+		return nil
+	}
+	return i.applyGoalRevision(ctx, problemID, state, output.Goal, output.Reason, actionType)
+}
+
+// applyGoalRevision sets the new version of the goal, persists it and notifies it as a goal revision event.
+func (i *ExecuteProposalInteractor) applyGoalRevision(ctx context.Context, problemID sharedValue.ID, state *agentState.State, goal value.Goal, reason string, actionType actionValue.ActionType) error {
+	revision := state.ReviseGoal(goal, reason, actionType)
+	err := i.saveGoalRevision(ctx, problemID, revision)
+	if err != nil {
+		return fmt.Errorf("failed to save goal revision: %w", err)
+	}
+	state.AddHistory(actionValue.SelfActionTypeGoal, fmt.Sprintf(GoalRevisionHistoryEntry, revision.Version, reason))
+	err = i.createEvent(ctx, problemID, eventValue.EventTypeGoalRevision, actionType, fmt.Sprintf(GoalRevisionMessage, revision.Version, reason, goal.Value()))
+	if err != nil {
+		return fmt.Errorf("failed to create event: %w", err)
+	}
+	return nil
+}
+
+func (i *ExecuteProposalInteractor) saveGoalRevision(ctx context.Context, problemID sharedValue.ID, revision value.GoalRevision) error {
+	id, err := sharedValue.NewID(uuid.NewUUID())
+	if err != nil {
+		return fmt.Errorf("failed to create goal revision id: %w", err)
+	}
+	goalRevision := goalRevisionEntity.NewGoalRevision(id, problemID, revision.Version, revision.Goal, revision.Reason, revision.ActionType, nil)
+	err = i.goalRevisionRepository.Save(ctx, goalRevision)
+	if err != nil {
+		return fmt.Errorf("failed to save goal revision: %w", err)
+	}
+	return nil
+}
+
+// reflect analyzes the failed or stalled action and adds the lessons to the state.
+// A failure of the reflection itself does not stop the run.
+func (i *ExecuteProposalInteractor) reflect(ctx context.Context, problemID sharedValue.ID, state *agentState.State, trigger agentService.ReflectionTrigger, actionType actionValue.ActionType, detail string) error {
+	logger := logger.GetLogger(ctx)
+	reflectionCtx, span := traceService.Start(usageValue.WithScope(ctx, problemID, actionValue.SelfActionTypeReflection), actionValue.SelfActionTypeReflection.Value(), traceValue.SpanKindDecision)
+	span.SetAttribute("trigger", trigger.Value())
+	span.SetAttribute("detail", detail)
+	reflection, err := i.reflection.Execute(reflectionCtx, agentService.ReflectionInput{
+		State:      *state,
+		Trigger:    trigger,
+		ActionType: actionType,
+		Detail:     detail,
+	})
+	span.End(err)
+	if err != nil {
+		if ctx.Err() != nil {
+			return err
+		}
+		logger.Error("failed to execute reflection", "error", err)
+		return nil
+	}
+	logger.Debug("reflection", "analysis", reflection.Analysis)
+	logger.Debug("reflection", "lessons", reflection.Lessons)
+	state.AddLessons(reflection.Lessons...)
+
+	var b strings.Builder
+	b.WriteString(reflection.Analysis)
+	for _, lesson := range reflection.Lessons {
+		b.WriteString(fmt.Sprintf("\n- %s", lesson))
+	}
+	output := b.String()
+	state.AddHistory(actionValue.SelfActionTypeReflection, output)
+
+	action, err := actionService.CreateAction(*state, actionValue.SelfActionTypeReflection, fmt.Sprintf("%s: %s", trigger.Value(), detail), output)
+	if err != nil {
+		return fmt.Errorf("failed to create action: %w", err)
+	}
+	err = i.saveAction(ctx, *action)
+	if err != nil {
+		return fmt.Errorf("failed to save action: %w", err)
+	}
+	err = i.createEvent(ctx, problemID, eventValue.EventTypeAction, actionValue.SelfActionTypeReflection, fmt.Sprintf(ReflectionMessage, output))
+	if err != nil {
+		return fmt.Errorf("failed to create event: %w", err)
+	}
+	return nil
+}
+
 func (i *ExecuteProposalInteractor) saveAction(ctx context.Context, action actionEntity.Action) error {
 	err := i.actionRepository.Create(ctx, &action)
 	if err != nil {
@@ -329,6 +1035,18 @@ func (i *ExecuteProposalInteractor) saveAction(ctx context.Context, action actio
 	return nil
 }
 
+// deltaBuffer pushes partial output of the action to the event stream.
+// The deltas are buffered so that an event is not saved for every token; Flush must be called after the action.
+func (i *ExecuteProposalInteractor) deltaBuffer(problemID sharedValue.ID, actionType actionValue.ActionType) *llm.DeltaBuffer {
+	return llm.NewDeltaBuffer(func(ctx context.Context, delta string) {
+		err := i.createEvent(ctx, problemID, eventValue.EventTypeDelta, actionType, delta)
+		if err != nil {
+			// streaming is best effort, the whole output is saved as the output event
+			logger.GetLogger(ctx).Warn("failed to create delta event", "error", err)
+		}
+	}, llm.DefaultDeltaFlushSize, llm.DefaultDeltaFlushInterval)
+}
+
 func (i *ExecuteProposalInteractor) createEvent(ctx context.Context, problemID sharedValue.ID, eventType eventValue.EventType, actionType actionValue.ActionType, message string) error {
 	logger := logger.GetLogger(ctx)
 	id, err := sharedValue.NewID(uuid.NewUUID())
@@ -0,0 +1,553 @@
+package proposal
+
+import (
+	"context"
+	stdErrors "errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	actionEntity "github.com/goda6565/ai-consultant/backend/internal/domain/action/entity"
+	actionMock "github.com/goda6565/ai-consultant/backend/internal/domain/action/repository/mock"
+	actionService "github.com/goda6565/ai-consultant/backend/internal/domain/action/service"
+	actionValue "github.com/goda6565/ai-consultant/backend/internal/domain/action/value"
+	agentService "github.com/goda6565/ai-consultant/backend/internal/domain/agent/service"
+	"github.com/goda6565/ai-consultant/backend/internal/domain/agent/state"
+	agentValue "github.com/goda6565/ai-consultant/backend/internal/domain/agent/value"
+	approvalEntity "github.com/goda6565/ai-consultant/backend/internal/domain/approval/entity"
+	approvalMock "github.com/goda6565/ai-consultant/backend/internal/domain/approval/repository/mock"
+	approvalValue "github.com/goda6565/ai-consultant/backend/internal/domain/approval/value"
+	checkpointEntity "github.com/goda6565/ai-consultant/backend/internal/domain/checkpoint/entity"
+	checkpointMock "github.com/goda6565/ai-consultant/backend/internal/domain/checkpoint/repository/mock"
+	eventEntity "github.com/goda6565/ai-consultant/backend/internal/domain/event/entity"
+	eventMock "github.com/goda6565/ai-consultant/backend/internal/domain/event/repository/mock"
+	eventValue "github.com/goda6565/ai-consultant/backend/internal/domain/event/value"
+	goalRevisionEntity "github.com/goda6565/ai-consultant/backend/internal/domain/goal_revision/entity"
+	goalRevisionMock "github.com/goda6565/ai-consultant/backend/internal/domain/goal_revision/repository/mock"
+	jobConfigValue "github.com/goda6565/ai-consultant/backend/internal/domain/job_config/value"
+	"github.com/goda6565/ai-consultant/backend/internal/domain/llm"
+	llmMock "github.com/goda6565/ai-consultant/backend/internal/domain/llm/mock"
+	problemEntity "github.com/goda6565/ai-consultant/backend/internal/domain/problem/entity"
+	problemMock "github.com/goda6565/ai-consultant/backend/internal/domain/problem/repository/mock"
+	problemValue "github.com/goda6565/ai-consultant/backend/internal/domain/problem/value"
+	reportEntity "github.com/goda6565/ai-consultant/backend/internal/domain/report/entity"
+	reportMock "github.com/goda6565/ai-consultant/backend/internal/domain/report/repository/mock"
+	sharedValue "github.com/goda6565/ai-consultant/backend/internal/domain/shared/value"
+	traceEntity "github.com/goda6565/ai-consultant/backend/internal/domain/trace/entity"
+	traceMock "github.com/goda6565/ai-consultant/backend/internal/domain/trace/repository/mock"
+	traceService "github.com/goda6565/ai-consultant/backend/internal/domain/trace/service"
+	traceValue "github.com/goda6565/ai-consultant/backend/internal/domain/trace/value"
+	usageEntity "github.com/goda6565/ai-consultant/backend/internal/domain/usage/entity"
+	usageMock "github.com/goda6565/ai-consultant/backend/internal/domain/usage/repository/mock"
+	usageService "github.com/goda6565/ai-consultant/backend/internal/domain/usage/service"
+	usageValue "github.com/goda6565/ai-consultant/backend/internal/domain/usage/value"
+	workflowValue "github.com/goda6565/ai-consultant/backend/internal/domain/workflow/value"
+	"github.com/goda6565/ai-consultant/backend/internal/pkg/logger"
+	"go.uber.org/mock/gomock"
+)
+
+var testProblemID = sharedValue.ID("11111111-2222-3333-4444-555555555555")
+
+type nopLogger struct{}
+
+func (nopLogger) Info(string, ...interface{})  {}
+func (nopLogger) Debug(string, ...interface{}) {}
+func (nopLogger) Warn(string, ...interface{})  {}
+func (nopLogger) Error(string, ...interface{}) {}
+func (nopLogger) Fatal(string, ...interface{}) {}
+func (nopLogger) Panic(string, ...interface{}) {}
+func (nopLogger) LogUsage(llm.Usage)           {}
+func (nopLogger) Sync() error                  { return nil }
+
+func testContext() context.Context {
+	return logger.WithLogger(context.Background(), nopLogger{})
+}
+
+func newTestState(t *testing.T, content string) *state.State {
+	workflow, err := workflowValue.GetWorkflow(workflowValue.DefaultName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return state.NewState(*newTestProblem(problemValue.StatusProcessing), *agentValue.NewContent(content), nil, nil, *agentValue.NewHistory(""), nil, false, workflow, jobConfigValue.ModelMap{})
+}
+
+func newTestProblem(status problemValue.Status) *problemEntity.Problem {
+	title, _ := problemValue.NewTitle("売上改善")
+	description, _ := problemValue.NewDescription("来店数が減っている")
+	return problemEntity.NewProblem(testProblemID, *title, *description, status, nil)
+}
+
+func TestExecuteProposalInteractor_FindResumeCheckpoint(t *testing.T) {
+	checkpoint := checkpointEntity.NewCheckpoint(sharedValue.ID("checkpoint-id"), testProblemID, 3, state.Snapshot{}, 1, 0, time.Now(), nil)
+
+	tests := []struct {
+		name     string
+		status   problemValue.Status
+		resume   bool
+		expected *checkpointEntity.Checkpoint
+	}{
+		{name: "interrupted run is resumed", status: problemValue.StatusProcessing, resume: true, expected: checkpoint},
+		{name: "paused run is resumed", status: problemValue.StatusPaused, resume: true, expected: checkpoint},
+		{name: "done problem starts over", status: problemValue.StatusDone},
+		{name: "cancelled problem starts over", status: problemValue.StatusCancelled},
+		{name: "failed problem starts over", status: problemValue.StatusFailed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockCheckpointRepo := checkpointMock.NewMockCheckpointRepository(ctrl)
+			if tt.resume {
+				mockCheckpointRepo.EXPECT().FindLatestByProblemID(gomock.Any(), testProblemID).Return(checkpoint, nil).Times(1)
+			}
+			interactor := &ExecuteProposalInteractor{checkpointRepository: mockCheckpointRepo}
+
+			got, err := interactor.findResumeCheckpoint(context.Background(), newTestProblem(tt.status))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.expected {
+				t.Errorf("checkpoint = %v, expected %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestExecuteProposalInteractor_WatchCancel(t *testing.T) {
+	t.Run("cancel request cancels the run", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		mockProblemRepo := problemMock.NewMockProblemRepository(ctrl)
+		// the flag is checked as soon as the run starts
+		mockProblemRepo.EXPECT().IsCancelRequested(gomock.Any(), testProblemID).Return(true, nil).Times(1)
+		interactor := &ExecuteProposalInteractor{problemRepository: mockProblemRepo}
+
+		ctx, stop := interactor.watchCancel(testContext(), testProblemID)
+		defer stop()
+		select {
+		case <-ctx.Done():
+		case <-time.After(time.Second):
+			t.Fatal("the run is not cancelled")
+		}
+		if !stdErrors.Is(context.Cause(ctx), ErrProposalCancelled) {
+			t.Errorf("cause = %v, expected %v", context.Cause(ctx), ErrProposalCancelled)
+		}
+	})
+
+	t.Run("stopping the watch is not a cancellation", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		mockProblemRepo := problemMock.NewMockProblemRepository(ctrl)
+		checked := make(chan struct{}, 1)
+		mockProblemRepo.EXPECT().IsCancelRequested(gomock.Any(), testProblemID).DoAndReturn(func(context.Context, sharedValue.ID) (bool, error) {
+			checked <- struct{}{}
+			return false, nil
+		}).Times(1)
+		interactor := &ExecuteProposalInteractor{problemRepository: mockProblemRepo}
+
+		ctx, stop := interactor.watchCancel(testContext(), testProblemID)
+		<-checked
+		if ctx.Err() != nil {
+			t.Fatal("the run is cancelled without a request")
+		}
+		stop()
+		if stdErrors.Is(context.Cause(ctx), ErrProposalCancelled) {
+			t.Error("stop must not be reported as a cancellation")
+		}
+	})
+}
+
+func TestExecuteProposalInteractor_FinishCancelled(t *testing.T) {
+	tests := []struct {
+		name         string
+		state        *state.State
+		expectReport bool
+	}{
+		{name: "content so far is saved as the report", state: newTestState(t, "# 提案\n途中まで"), expectReport: true},
+		{name: "no report without content", state: newTestState(t, "")},
+		{name: "no report when cancelled before the state is built"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockProblemRepo := problemMock.NewMockProblemRepository(ctrl)
+			mockCheckpointRepo := checkpointMock.NewMockCheckpointRepository(ctrl)
+			mockReportRepo := reportMock.NewMockReportRepository(ctrl)
+			mockEventRepo := eventMock.NewMockEventRepository(ctrl)
+			if tt.expectReport {
+				mockReportRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, report *reportEntity.Report) error {
+					content := report.GetContent()
+					if content.Value() != "# 提案\n途中まで" {
+						t.Errorf("report = %q", content.Value())
+					}
+					return nil
+				}).Times(1)
+			}
+			gomock.InOrder(
+				mockProblemRepo.EXPECT().UpdateStatus(gomock.Any(), testProblemID, problemValue.StatusCancelled).Return(nil).Times(1),
+				// the stale request must not cancel a later run
+				mockProblemRepo.EXPECT().ClearCancelRequest(gomock.Any(), testProblemID).Return(nil).Times(1),
+			)
+			mockCheckpointRepo.EXPECT().DeleteByProblemID(gomock.Any(), testProblemID).Return(int64(1), nil).Times(1)
+			mockEventRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, event *eventEntity.Event) error {
+				if event.Message.Value() != eventValue.CancelledMessage {
+					t.Errorf("message = %q, expected %q", event.Message.Value(), eventValue.CancelledMessage)
+				}
+				return nil
+			}).Times(1)
+			interactor := &ExecuteProposalInteractor{
+				problemRepository:    mockProblemRepo,
+				checkpointRepository: mockCheckpointRepo,
+				reportRepository:     mockReportRepo,
+				eventRepository:      mockEventRepo,
+			}
+
+			if err := interactor.finishCancelled(testContext(), testProblemID, tt.state); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+func TestExecuteProposalInteractor_PauseForApproval(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockCheckpointRepo := checkpointMock.NewMockCheckpointRepository(ctrl)
+	mockApprovalRepo := approvalMock.NewMockApprovalRepository(ctrl)
+	mockEventRepo := eventMock.NewMockEventRepository(ctrl)
+
+	// the state has already moved on, so the approval must take the action that produced the output
+	s := newTestState(t, "# 分析\n来店数は前年比で減少")
+	s.ForceAction(actionValue.ActionTypeWrite)
+	input, _ := actionValue.NewActionInput("分析して")
+	output, _ := actionValue.NewActionOutput("分析結果")
+	action := actionEntity.NewAction(sharedValue.ID("action-id"), testProblemID, actionValue.ActionTypeAnalyze, *input, *output, nil)
+
+	mockCheckpointRepo.EXPECT().Save(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+	mockApprovalRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, approval *approvalEntity.Approval) error {
+		if !approval.GetActionType().Equals(actionValue.ActionTypeAnalyze) {
+			t.Errorf("action type = %s, expected %s", approval.GetActionType().Value(), actionValue.ActionTypeAnalyze.Value())
+		}
+		return nil
+	}).Times(1)
+	mockEventRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, event *eventEntity.Event) error {
+		expected := fmt.Sprintf(PausedMessage, actionValue.ActionTypeAnalyze.Value())
+		if event.ActionType != actionValue.ActionTypeAnalyze || event.Message.Value() != expected {
+			t.Errorf("event = %s %q, expected %s %q", event.ActionType.Value(), event.Message.Value(), actionValue.ActionTypeAnalyze.Value(), expected)
+		}
+		return nil
+	}).Times(1)
+	interactor := &ExecuteProposalInteractor{
+		checkpointRepository: mockCheckpointRepo,
+		approvalRepository:   mockApprovalRepo,
+		eventRepository:      mockEventRepo,
+	}
+
+	if err := interactor.pauseForApproval(testContext(), testProblemID, 4, s, *action, 0, 0, time.Now()); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestExecuteProposalInteractor_ApplyApproval(t *testing.T) {
+	approvalID := sharedValue.ID("aaaaaaaa-bbbb-cccc-dddd-eeeeeeeeeeee")
+	newApproval := func(decision approvalValue.Decision, feedback string, goal *agentValue.Goal, appliedAt *time.Time) *approvalEntity.Approval {
+		return approvalEntity.NewApproval(approvalID, testProblemID, actionValue.ActionTypeAnalyze, 3, "分析結果", decision, feedback, goal, nil, nil, appliedAt)
+	}
+	appliedAt := time.Now()
+
+	tests := []struct {
+		name          string
+		approval      *approvalEntity.Approval
+		expectedError error
+		expectApply   bool
+		expectGoal    bool
+		expectMessage string
+	}{
+		{name: "run without approval continues"},
+		{name: "applied approval is not applied again", approval: newApproval(approvalValue.DecisionApprove, "", nil, &appliedAt)},
+		{name: "pending approval pauses the run", approval: newApproval(approvalValue.DecisionPending, "", nil, nil), expectedError: ErrProposalPaused},
+		{
+			name:          "approval is added to the history",
+			approval:      newApproval(approvalValue.DecisionApprove, "", nil, nil),
+			expectApply:   true,
+			expectMessage: "analyzeの結果が承認されました。",
+		},
+		{
+			name:          "revision with a new goal revises the goal",
+			approval:      newApproval(approvalValue.DecisionRevise, "競合の調査が足りない", agentValue.NewGoal("競合との差別化策を提案する"), nil),
+			expectApply:   true,
+			expectGoal:    true,
+			expectMessage: "analyzeの結果が差し戻されました。\n競合の調査が足りない",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockApprovalRepo := approvalMock.NewMockApprovalRepository(ctrl)
+			mockCheckpointRepo := checkpointMock.NewMockCheckpointRepository(ctrl)
+			mockGoalRevisionRepo := goalRevisionMock.NewMockGoalRevisionRepository(ctrl)
+			mockEventRepo := eventMock.NewMockEventRepository(ctrl)
+			mockApprovalRepo.EXPECT().FindLatestByProblemID(gomock.Any(), testProblemID).Return(tt.approval, nil).Times(1)
+			if tt.expectGoal {
+				mockGoalRevisionRepo.EXPECT().Save(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, revision *goalRevisionEntity.GoalRevision) error {
+					if revision.GetReason() != ApprovalGoalReason+": 競合の調査が足りない" {
+						t.Errorf("reason = %q", revision.GetReason())
+					}
+					return nil
+				}).Times(1)
+				// the goal revision event comes before the decision event
+				mockEventRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+			}
+			if tt.expectApply {
+				gomock.InOrder(
+					mockEventRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, event *eventEntity.Event) error {
+						if event.Message.Value() != tt.expectMessage {
+							t.Errorf("message = %q, expected %q", event.Message.Value(), tt.expectMessage)
+						}
+						return nil
+					}).Times(1),
+					// the decision is kept in the checkpoint before it is marked applied
+					mockCheckpointRepo.EXPECT().Save(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, checkpoint *checkpointEntity.Checkpoint) error {
+						if checkpoint.GetStep() != 3 {
+							t.Errorf("step = %d, expected 3", checkpoint.GetStep())
+						}
+						return nil
+					}).Times(1),
+					mockApprovalRepo.EXPECT().MarkApplied(gomock.Any(), approvalID).Return(nil).Times(1),
+				)
+			}
+			interactor := &ExecuteProposalInteractor{
+				approvalRepository:     mockApprovalRepo,
+				checkpointRepository:   mockCheckpointRepo,
+				goalRevisionRepository: mockGoalRevisionRepo,
+				eventRepository:        mockEventRepo,
+			}
+			agentState := newTestState(t, "")
+
+			err := interactor.applyApproval(testContext(), testProblemID, agentState, 3, 0, time.Minute, time.Now())
+			if !stdErrors.Is(err, tt.expectedError) {
+				t.Fatalf("error = %v, expected %v", err, tt.expectedError)
+			}
+			history := agentState.GetHistory()
+			if tt.expectApply && !strings.Contains(history.GetValue(), tt.approval.ToHistory()) {
+				t.Errorf("history = %q", history.GetValue())
+			}
+			if !tt.expectApply && history.GetValue() != "" {
+				t.Errorf("history = %q, expected empty", history.GetValue())
+			}
+			goal := agentState.GetGoal()
+			if tt.expectGoal && goal.Value() != "競合との差別化策を提案する" {
+				t.Errorf("goal = %q", goal.Value())
+			}
+		})
+	}
+}
+
+func TestExecuteProposalInteractor_MergeBranches(t *testing.T) {
+	const baseContent = "# 提案\n前のステップまで"
+	newOutput := func(t *testing.T, agentState *state.State, actionType actionValue.ActionType, content string) *actionService.ActionTemplateOutput {
+		action, err := actionService.CreateAction(*agentState, actionType, "", "結果")
+		if err != nil {
+			t.Fatal(err)
+		}
+		return &actionService.ActionTemplateOutput{Content: *agentValue.NewContent(content), Action: *action}
+	}
+
+	tests := []struct {
+		name            string
+		results         func(t *testing.T, agentState *state.State) []BranchResult
+		expectedContent string
+		expectedOutputs int
+		expectedFailed  actionValue.ActionType
+	}{
+		{
+			name: "content written by a branch is kept when a later branch does not change it",
+			results: func(t *testing.T, agentState *state.State) []BranchResult {
+				return []BranchResult{
+					{ActionType: actionValue.ActionTypeWrite, Output: newOutput(t, agentState, actionValue.ActionTypeWrite, "# 提案\n書き直した")},
+					{ActionType: actionValue.ActionTypeAnalyze, Output: newOutput(t, agentState, actionValue.ActionTypeAnalyze, baseContent)},
+				}
+			},
+			expectedContent: "# 提案\n書き直した",
+			expectedOutputs: 2,
+		},
+		{
+			name: "content written by a later branch is applied",
+			results: func(t *testing.T, agentState *state.State) []BranchResult {
+				return []BranchResult{
+					{ActionType: actionValue.ActionTypeAnalyze, Output: newOutput(t, agentState, actionValue.ActionTypeAnalyze, baseContent)},
+					{ActionType: actionValue.ActionTypeWrite, Output: newOutput(t, agentState, actionValue.ActionTypeWrite, "# 提案\n書き直した")},
+				}
+			},
+			expectedContent: "# 提案\n書き直した",
+			expectedOutputs: 2,
+		},
+		{
+			name: "reflection is about the failed branch",
+			results: func(t *testing.T, agentState *state.State) []BranchResult {
+				return []BranchResult{
+					{ActionType: actionValue.ActionTypeAnalyze, Output: newOutput(t, agentState, actionValue.ActionTypeAnalyze, baseContent)},
+					{ActionType: actionValue.ActionTypeDataAnalysis, Err: stdErrors.New("dataset not found")},
+				}
+			},
+			expectedContent: baseContent,
+			expectedOutputs: 1,
+			expectedFailed:  actionValue.ActionTypeDataAnalysis,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockActionRepo := actionMock.NewMockActionRepository(ctrl)
+			mockEventRepo := eventMock.NewMockEventRepository(ctrl)
+			mockLLMClient := llmMock.NewMockLLMClient(ctrl)
+			mockActionRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+			mockEventRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+			if tt.expectedFailed != "" {
+				mockLLMClient.EXPECT().GenerateStructuredText(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, input llm.GenerateStructuredTextInput) (*llm.GenerateStructuredTextOutput, error) {
+					if !strings.Contains(input.UserPrompt, fmt.Sprintf("アクション「%s」の実行が失敗しました。", tt.expectedFailed.Value())) {
+						t.Errorf("prompt does not name the failed action: %s", input.UserPrompt)
+					}
+					return &llm.GenerateStructuredTextOutput{Text: `{"analysis": "データが無い", "lessons": []}`}, nil
+				}).Times(1)
+			}
+			interactor := &ExecuteProposalInteractor{
+				actionRepository: mockActionRepo,
+				eventRepository:  mockEventRepo,
+				reflection:       agentService.NewReflection(mockLLMClient),
+			}
+			agentState := newTestState(t, baseContent)
+
+			outputs, failCount, err := interactor.mergeBranches(testContext(), testProblemID, agentState, tt.results(t, agentState), 0)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(outputs) != tt.expectedOutputs {
+				t.Errorf("outputs = %d, expected %d", len(outputs), tt.expectedOutputs)
+			}
+			if tt.expectedFailed != "" && failCount != 1 {
+				t.Errorf("failCount = %d, expected 1", failCount)
+			}
+			content := agentState.GetContent()
+			if content.Value() != tt.expectedContent {
+				t.Errorf("content = %q, expected %q", content.Value(), tt.expectedContent)
+			}
+		})
+	}
+}
+
+func TestExecuteProposalInteractor_DeltaBuffer(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockEventRepo := eventMock.NewMockEventRepository(ctrl)
+	// the tokens of a short output are saved as one event
+	mockEventRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, event *eventEntity.Event) error {
+		if event.EventType != eventValue.EventTypeDelta || event.ActionType != actionValue.ActionTypeWrite {
+			t.Errorf("event = %s %s", event.EventType.Value(), event.ActionType.Value())
+		}
+		if event.Message.Value() != "# 提案\n本文" {
+			t.Errorf("message = %q", event.Message.Value())
+		}
+		return nil
+	}).Times(1)
+	interactor := &ExecuteProposalInteractor{eventRepository: mockEventRepo}
+
+	ctx := testContext()
+	deltas := interactor.deltaBuffer(testProblemID, actionValue.ActionTypeWrite)
+	for _, delta := range []string{"# 提案", "\n", "本文"} {
+		deltas.Handle(ctx, delta)
+	}
+	deltas.Flush(ctx)
+}
+
+func TestExecuteProposalInteractor_CheckBudget(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockUsageRepo := usageMock.NewMockUsageRepository(ctrl)
+	priceTable, err := usageValue.NewPriceTable(map[string]usageValue.Price{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	budget, err := jobConfigValue.NewBudget(1000, 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	runStartedAt := time.Now().Add(-time.Hour)
+	newUsage := func(actionType actionValue.ActionType, tokens int) usageEntity.Usage {
+		return *usageEntity.NewUsage(sharedValue.ID("usage-id"), testProblemID, actionType, llm.OpenAI, "gpt-4o", llm.Usage{TotalTokens: tokens}, &runStartedAt)
+	}
+	// only the usage of the current run is counted, not the usage of earlier runs of the problem
+	mockUsageRepo.EXPECT().FindByProblemIDSince(gomock.Any(), testProblemID, runStartedAt).Return([]usageEntity.Usage{
+		newUsage(actionValue.SelfActionTypeHearing, 5000),
+		newUsage(actionValue.ActionTypePlan, 300),
+	}, nil).Times(1)
+	interactor := &ExecuteProposalInteractor{ledgerService: usageService.NewLedgerService(mockUsageRepo, priceTable)}
+
+	check, err := interactor.checkBudget(testContext(), testProblemID, *budget, time.Now(), runStartedAt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if check.Status != jobConfigValue.BudgetStatusWithin {
+		t.Errorf("status = %s, expected %s: %s", check.Status, jobConfigValue.BudgetStatusWithin, check.Reason)
+	}
+}
+
+type stubWriteAction struct {
+	output *actionService.ActionTemplateOutput
+}
+
+func (s stubWriteAction) Execute(context.Context, actionService.ActionTemplateInput) (*actionService.ActionTemplateOutput, error) {
+	return s.output, nil
+}
+
+func TestExecuteProposalInteractor_TerminateByBudget(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockSpanRepo := traceMock.NewMockSpanRepository(ctrl)
+	mockActionRepo := actionMock.NewMockActionRepository(ctrl)
+	mockEventRepo := eventMock.NewMockEventRepository(ctrl)
+
+	input, _ := actionValue.NewActionInput("まとめて")
+	output, _ := actionValue.NewActionOutput("最終レポート")
+	write := stubWriteAction{output: &actionService.ActionTemplateOutput{
+		Content: *agentValue.NewContent("# 提案\n最終レポート"),
+		Action:  *actionEntity.NewAction(sharedValue.ID("action-id"), testProblemID, actionValue.ActionTypeWrite, *input, *output, nil),
+	}}
+	interactor := &ExecuteProposalInteractor{
+		actionRepository: mockActionRepo,
+		eventRepository:  mockEventRepo,
+		actionFactory:    actionService.NewActionFactory(nil, nil, nil, nil, nil, write, nil),
+	}
+
+	ctx, _ := traceService.NewTracer(mockSpanRepo).StartRun(testContext(), testProblemID, "proposal")
+	// the forced write is traced like any other action
+	mockSpanRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, span *traceEntity.Span) error {
+		if span.GetName() != actionValue.ActionTypeWrite.Value() || span.GetKind() != traceValue.SpanKindAction {
+			t.Errorf("span = %s %s, expected %s %s", span.GetName(), span.GetKind(), actionValue.ActionTypeWrite.Value(), traceValue.SpanKindAction)
+		}
+		if span.GetAttributes()["output"] != "最終レポート" {
+			t.Errorf("output attribute = %q", span.GetAttributes()["output"])
+		}
+		return nil
+	}).Times(1)
+	mockActionRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+	mockEventRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+	s := newTestState(t, "# 提案\n途中まで")
+	err := interactor.terminateByBudget(ctx, testProblemID, s, jobConfigValue.BudgetCheck{Status: jobConfigValue.BudgetStatusNearLimit, Reason: "トークン"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	content := s.GetContent()
+	if content.Value() != "# 提案\n最終レポート" {
+		t.Errorf("content = %q", content.Value())
+	}
+}
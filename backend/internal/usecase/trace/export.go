@@ -0,0 +1,52 @@
+package trace
+
+import (
+	"context"
+	"fmt"
+
+	problemRepository "github.com/goda6565/ai-consultant/backend/internal/domain/problem/repository"
+	sharedValue "github.com/goda6565/ai-consultant/backend/internal/domain/shared/value"
+	traceRepository "github.com/goda6565/ai-consultant/backend/internal/domain/trace/repository"
+	traceService "github.com/goda6565/ai-consultant/backend/internal/domain/trace/service"
+	"github.com/goda6565/ai-consultant/backend/internal/usecase/errors"
+)
+
+type ExportTraceInputPort interface {
+	Execute(ctx context.Context, input ExportTraceUseCaseInput) (*ExportTraceUseCaseOutput, error)
+}
+
+type ExportTraceUseCaseInput struct {
+	ProblemID string
+}
+
+type ExportTraceUseCaseOutput struct {
+	Trace traceService.OTLPTrace
+}
+
+type ExportTraceInteractor struct {
+	problemRepository problemRepository.ProblemRepository
+	spanRepository    traceRepository.SpanRepository
+}
+
+func NewExportTraceUseCase(problemRepository problemRepository.ProblemRepository, spanRepository traceRepository.SpanRepository) ExportTraceInputPort {
+	return &ExportTraceInteractor{problemRepository: problemRepository, spanRepository: spanRepository}
+}
+
+func (i *ExportTraceInteractor) Execute(ctx context.Context, input ExportTraceUseCaseInput) (*ExportTraceUseCaseOutput, error) {
+	problemID, err := sharedValue.NewID(input.ProblemID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create problem id: %w", err)
+	}
+	problem, err := i.problemRepository.FindById(ctx, problemID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find problem: %w", err)
+	}
+	if problem == nil {
+		return nil, errors.NewUseCaseError(errors.NotFoundError, "problem not found")
+	}
+	spans, err := i.spanRepository.FindByProblemID(ctx, problemID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find spans: %w", err)
+	}
+	return &ExportTraceUseCaseOutput{Trace: traceService.ExportOTLP(spans)}, nil
+}
@@ -0,0 +1,54 @@
+package trace
+
+import (
+	"context"
+	"fmt"
+
+	problemRepository "github.com/goda6565/ai-consultant/backend/internal/domain/problem/repository"
+	sharedValue "github.com/goda6565/ai-consultant/backend/internal/domain/shared/value"
+	traceRepository "github.com/goda6565/ai-consultant/backend/internal/domain/trace/repository"
+	traceService "github.com/goda6565/ai-consultant/backend/internal/domain/trace/service"
+	"github.com/goda6565/ai-consultant/backend/internal/usecase/errors"
+)
+
+type GetTraceInputPort interface {
+	Execute(ctx context.Context, input GetTraceUseCaseInput) (*GetTraceUseCaseOutput, error)
+}
+
+type GetTraceUseCaseInput struct {
+	ProblemID string
+}
+
+type GetTraceUseCaseOutput struct {
+	ProblemID sharedValue.ID
+	// Spans are the root spans, one for each run of the proposal job
+	Spans []*traceService.SpanNode
+}
+
+type GetTraceInteractor struct {
+	problemRepository problemRepository.ProblemRepository
+	spanRepository    traceRepository.SpanRepository
+}
+
+func NewGetTraceUseCase(problemRepository problemRepository.ProblemRepository, spanRepository traceRepository.SpanRepository) GetTraceInputPort {
+	return &GetTraceInteractor{problemRepository: problemRepository, spanRepository: spanRepository}
+}
+
+func (i *GetTraceInteractor) Execute(ctx context.Context, input GetTraceUseCaseInput) (*GetTraceUseCaseOutput, error) {
+	problemID, err := sharedValue.NewID(input.ProblemID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create problem id: %w", err)
+	}
+	problem, err := i.problemRepository.FindById(ctx, problemID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find problem: %w", err)
+	}
+	if problem == nil {
+		return nil, errors.NewUseCaseError(errors.NotFoundError, "problem not found")
+	}
+	spans, err := i.spanRepository.FindByProblemID(ctx, problemID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find spans: %w", err)
+	}
+	return &GetTraceUseCaseOutput{ProblemID: problemID, Spans: traceService.BuildSpanTree(spans)}, nil
+}
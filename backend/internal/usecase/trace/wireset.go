@@ -0,0 +1,8 @@
+package trace
+
+import "github.com/google/wire"
+
+var Set = wire.NewSet(
+	NewGetTraceUseCase,
+	NewExportTraceUseCase,
+)
@@ -0,0 +1,52 @@
+package usage
+
+import (
+	"context"
+	"fmt"
+
+	problemRepository "github.com/goda6565/ai-consultant/backend/internal/domain/problem/repository"
+	sharedValue "github.com/goda6565/ai-consultant/backend/internal/domain/shared/value"
+	usageService "github.com/goda6565/ai-consultant/backend/internal/domain/usage/service"
+	"github.com/goda6565/ai-consultant/backend/internal/usecase/errors"
+)
+
+type GetUsageInputPort interface {
+	Execute(ctx context.Context, input GetUsageUseCaseInput) (*GetUsageUseCaseOutput, error)
+}
+
+type GetUsageUseCaseInput struct {
+	ProblemID string
+}
+
+type GetUsageUseCaseOutput struct {
+	ProblemID sharedValue.ID
+	Summary   *usageService.LedgerSummary
+}
+
+type GetUsageInteractor struct {
+	problemRepository problemRepository.ProblemRepository
+	ledgerService     *usageService.LedgerService
+}
+
+func NewGetUsageUseCase(problemRepository problemRepository.ProblemRepository, ledgerService *usageService.LedgerService) GetUsageInputPort {
+	return &GetUsageInteractor{problemRepository: problemRepository, ledgerService: ledgerService}
+}
+
+func (i *GetUsageInteractor) Execute(ctx context.Context, input GetUsageUseCaseInput) (*GetUsageUseCaseOutput, error) {
+	problemID, err := sharedValue.NewID(input.ProblemID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create problem id: %w", err)
+	}
+	problem, err := i.problemRepository.FindById(ctx, problemID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find problem: %w", err)
+	}
+	if problem == nil {
+		return nil, errors.NewUseCaseError(errors.NotFoundError, "problem not found")
+	}
+	summary, err := i.ledgerService.Summarize(ctx, problemID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to summarize usage: %w", err)
+	}
+	return &GetUsageUseCaseOutput{ProblemID: problemID, Summary: summary}, nil
+}
@@ -0,0 +1,7 @@
+package usage
+
+import "github.com/google/wire"
+
+var Set = wire.NewSet(
+	NewGetUsageUseCase,
+)
@@ -39,7 +39,7 @@ export const useEventSse = ({ problemId, enabled }: UseEventSseProps) => {
             };
             const id = (e as MessageEvent).lastEventId ?? crypto.randomUUID();
             const event = EventSchema.parse({ id, eventType, ...base });
-            setEvents((prev) => [...prev, event]);
+            setEvents((prev) => appendEvent(prev, event));
           } catch {
             toast.error("failed to parse event");
           }
@@ -48,6 +48,8 @@ export const useEventSse = ({ problemId, enabled }: UseEventSseProps) => {
       es.addEventListener("action", handle("action"));
       es.addEventListener("input", handle("input"));
       es.addEventListener("output", handle("output"));
+      es.addEventListener("delta", handle("delta"));
+      es.addEventListener("goalRevision", handle("goalRevision"));
 
       es.onerror = () => {
         toast.error("event stream connection closed. reconnecting...");
@@ -65,3 +67,18 @@ export const useEventSse = ({ problemId, enabled }: UseEventSseProps) => {
 
   return { events, isLoading, error };
 };
+
+// deltas of the same action are merged into one draft, which is replaced by the next event
+const appendEvent = (prev: Event[], event: Event): Event[] => {
+  const last = prev.at(-1);
+  if (last?.eventType !== "delta") {
+    return [...prev, event];
+  }
+  if (event.eventType === "delta" && last.actionType === event.actionType) {
+    return [
+      ...prev.slice(0, -1),
+      { ...last, message: last.message + event.message },
+    ];
+  }
+  return [...prev.slice(0, -1), event];
+};
@@ -13,15 +13,17 @@ export type Message = z.infer<typeof MessageSchema>;
 
 export const EventSchema = z.object({
   id: z.string(),
-  eventType: z.enum(["action", "input", "output"]),
+  eventType: z.enum(["action", "input", "output", "delta", "goalRevision"]),
   actionType: z.enum([
     "plan",
     "externalSearch",
     "internalSearch",
+    "dataAnalysis",
     "analyze",
     "write",
     "review",
     "done",
+    "reflection",
   ]),
   message: z.string(),
 });
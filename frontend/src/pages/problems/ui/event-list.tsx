@@ -1,9 +1,12 @@
 import {
   LucideBrain,
+  LucideChartColumn,
   LucideCheck,
   LucideChevronDown,
   LucideDatabase,
   LucideEye,
+  LucideFlag,
+  LucideLightbulb,
   LucidePenTool,
   LucideSearch,
   LucideTarget,
@@ -48,6 +51,10 @@ export function EventList({ events }: { events: Event[] }) {
             <ActionItem event={event} />
           ) : event.eventType === "input" ? (
             <InputItem event={event} />
+          ) : event.eventType === "delta" ? (
+            <DeltaItem event={event} />
+          ) : event.eventType === "goalRevision" ? (
+            <GoalRevisionItem event={event} />
           ) : (
             <OutputItem event={event} />
           )}
@@ -81,6 +88,36 @@ const InputItem = ({ event }: { event: Event }) => {
   );
 };
 
+const DeltaItem = ({ event }: { event: Event }) => {
+  return (
+    <Item variant="outline" size="sm">
+      <ItemMedia>{actionTypeIconMap[event.actionType]}</ItemMedia>
+      <ItemContent className="min-w-0">
+        <ItemTitle>{actionTypeLabels[event.actionType]}（生成中）</ItemTitle>
+        <div className="max-h-60 min-w-0 overflow-y-auto overflow-x-clip break-words text-sm text-muted-foreground">
+          <Markdown>{event.message}</Markdown>
+        </div>
+      </ItemContent>
+    </Item>
+  );
+};
+
+const GoalRevisionItem = ({ event }: { event: Event }) => {
+  return (
+    <Item variant="outline" size="sm">
+      <ItemMedia>
+        <LucideFlag className="h-4 w-4 text-orange-600" />
+      </ItemMedia>
+      <ItemContent className="min-w-0">
+        <ItemTitle>ゴールの見直し（{actionTypeLabels[event.actionType]}）</ItemTitle>
+        <div className="max-h-60 min-w-0 overflow-y-auto overflow-x-clip break-words text-sm text-muted-foreground">
+          <Markdown>{event.message}</Markdown>
+        </div>
+      </ItemContent>
+    </Item>
+  );
+};
+
 const OutputItem = ({ event }: { event: Event }) => {
   const [isOpen, setIsOpen] = useState(false);
   return (
@@ -116,18 +153,22 @@ const actionTypeIconMap: Record<string, React.ReactNode> = {
   plan: <LucideTarget className="h-4 w-4 text-blue-600" />,
   externalSearch: <LucideSearch className="h-4 w-4 text-blue-600" />,
   internalSearch: <LucideDatabase className="h-4 w-4 text-blue-600" />,
+  dataAnalysis: <LucideChartColumn className="h-4 w-4 text-blue-600" />,
   analyze: <LucideBrain className="h-4 w-4 text-blue-600" />,
   write: <LucidePenTool className="h-4 w-4 text-blue-600" />,
   review: <LucideEye className="h-4 w-4 text-blue-600" />,
   done: <LucideCheck className="h-4 w-4 text-blue-600" />,
+  reflection: <LucideLightbulb className="h-4 w-4 text-blue-600" />,
 };
 
 const actionTypeLabels: Record<string, string> = {
   plan: "計画立案",
   externalSearch: "外部情報検索",
   internalSearch: "内部情報検索",
+  dataAnalysis: "データ分析",
   analyze: "分析",
   write: "執筆",
   review: "レビュー",
   done: "完了",
+  reflection: "振り返り",
 };
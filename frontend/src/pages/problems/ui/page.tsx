@@ -5,6 +5,7 @@ import { use, useEffect, useRef } from "react";
 import { toast } from "sonner";
 import type { z } from "zod";
 import {
+  useCancelProblem,
   useExecuteHearing,
   useGetHearingMap,
   useGetReport,
@@ -16,6 +17,7 @@ import {
   AccordionItem,
   AccordionTrigger,
   Badge,
+  Button,
   Heading,
   Label,
   Loading,
@@ -66,7 +68,14 @@ export function ProblemPage({ params }: ProblemPageProps) {
   });
 
   const isHearingMapEnabled =
-    problem?.status === "processing" || problem?.status === "done";
+    problem?.status === "processing" ||
+    problem?.status === "done" ||
+    problem?.status === "cancelled" ||
+    problem?.status === "paused";
+
+  // キャンセル時は途中までの内容がレポートとして保存される
+  const isReportEnabled =
+    problem?.status === "done" || problem?.status === "cancelled";
 
   const {
     data: hearingMap,
@@ -84,7 +93,7 @@ export function ProblemPage({ params }: ProblemPageProps) {
     error: isReportError,
   } = useGetReport(id, {
     swr: {
-      enabled: problem?.status === "done",
+      enabled: isReportEnabled,
     },
   });
 
@@ -113,6 +122,9 @@ export function ProblemPage({ params }: ProblemPageProps) {
 
   const { trigger: updateJobConfig } = useUpdateJobConfig(problem?.id ?? "");
 
+  const { trigger: cancelProblem, isMutating: isCancelProblemMutating } =
+    useCancelProblem(problem?.id ?? "");
+
   if (isChatLoading || isHearingMessagesLoading) {
     return (
       <div className="flex items-center justify-center h-full">
@@ -189,6 +201,17 @@ export function ProblemPage({ params }: ProblemPageProps) {
     }
   };
 
+  const onCancelProblem = async () => {
+    try {
+      await cancelProblem();
+      // 承認待ちの場合はその場でキャンセルされるためステータスを再取得する
+      mutateChat();
+      toast.success("キャンセルを受け付けました");
+    } catch (_err) {
+      toast.error("キャンセルに失敗しました");
+    }
+  };
+
   const onToggleInternalSearch = async () => {
     try {
       await updateJobConfig({
@@ -213,6 +236,17 @@ export function ProblemPage({ params }: ProblemPageProps) {
         </Accordion>
         <div className="flex gap-6 justify-between items-center">
           <Badge variant="outline">{problem.status}</Badge>
+          {(problem.status === "processing" ||
+            problem.status === "paused") && (
+            <Button
+              variant="outline"
+              size="sm"
+              onClick={onCancelProblem}
+              disabled={isCancelProblemMutating}
+            >
+              キャンセル
+            </Button>
+          )}
           {problem.status === "hearing" && (
             <div className="flex w-30 items-center space-x-2">
               <Switch
@@ -239,7 +273,7 @@ export function ProblemPage({ params }: ProblemPageProps) {
               {problem.status === "processing" && (
                 <Monitor events={events} onCopyEvents={onCopyEvents} />
               )}
-              {problem.status === "done" && (
+              {isReportEnabled && (
                 <ReportView
                   report={report}
                   isLoading={isReportLoading}
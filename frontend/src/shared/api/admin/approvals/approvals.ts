@@ -0,0 +1,144 @@
+/**
+ * Generated by orval v7.11.2 🍺
+ * Do not edit manually.
+ * AI-Consultant Admin API
+ * AI-Consultant Admin API
+ * OpenAPI spec version: 1.0.0
+ */
+
+import type { Key, SWRConfiguration } from "swr";
+import useSwr from "swr";
+import type { SWRMutationConfiguration } from "swr/mutation";
+import useSWRMutation from "swr/mutation";
+import { adminApiClient } from "../../client";
+import type {
+  DecideApprovalBody,
+  DecideApprovalSuccessResponse,
+  ErrorResponse,
+  ListPendingApprovalsSuccessResponse,
+} from ".././model";
+
+/**
+ * @summary List approvals waiting for a decision
+ */
+export const listPendingApprovals = () => {
+  return adminApiClient<ListPendingApprovalsSuccessResponse>({
+    url: `/api/approvals`,
+    method: "GET",
+  });
+};
+
+export const getListPendingApprovalsKey = () => [`/api/approvals`] as const;
+
+export type ListPendingApprovalsQueryResult = NonNullable<
+  Awaited<ReturnType<typeof listPendingApprovals>>
+>;
+export type ListPendingApprovalsQueryError =
+  | ErrorResponse
+  | ErrorResponse
+  | ErrorResponse;
+
+/**
+ * @summary List approvals waiting for a decision
+ */
+export const useListPendingApprovals = <
+  TError = ErrorResponse | ErrorResponse | ErrorResponse,
+>(options?: {
+  swr?: SWRConfiguration<
+    Awaited<ReturnType<typeof listPendingApprovals>>,
+    TError
+  > & { swrKey?: Key; enabled?: boolean };
+}) => {
+  const { swr: swrOptions } = options ?? {};
+
+  const isEnabled = swrOptions?.enabled !== false;
+  const swrKey =
+    swrOptions?.swrKey ??
+    (() => (isEnabled ? getListPendingApprovalsKey() : null));
+  const swrFn = () => listPendingApprovals();
+
+  const query = useSwr<Awaited<ReturnType<typeof swrFn>>, TError>(
+    swrKey,
+    swrFn,
+    swrOptions,
+  );
+
+  return {
+    swrKey,
+    ...query,
+  };
+};
+
+/**
+ * @summary Submit a decision for an approval and resume the proposal job
+ */
+export const decideApproval = (
+  approvalId: string,
+  decideApprovalBody: DecideApprovalBody,
+) => {
+  return adminApiClient<DecideApprovalSuccessResponse>({
+    url: `/api/approvals/${approvalId}/decision`,
+    method: "POST",
+    headers: { "Content-Type": "application/json" },
+    data: decideApprovalBody,
+  });
+};
+
+export const getDecideApprovalMutationFetcher = (approvalId: string) => {
+  return (
+    _: Key,
+    { arg }: { arg: DecideApprovalBody },
+  ): Promise<DecideApprovalSuccessResponse> => {
+    return decideApproval(approvalId, arg);
+  };
+};
+export const getDecideApprovalMutationKey = (approvalId: string) =>
+  [`/api/approvals/${approvalId}/decision`] as const;
+
+export type DecideApprovalMutationResult = NonNullable<
+  Awaited<ReturnType<typeof decideApproval>>
+>;
+export type DecideApprovalMutationError =
+  | ErrorResponse
+  | ErrorResponse
+  | ErrorResponse
+  | ErrorResponse
+  | ErrorResponse
+  | ErrorResponse;
+
+/**
+ * @summary Submit a decision for an approval and resume the proposal job
+ */
+export const useDecideApproval = <
+  TError =
+    | ErrorResponse
+    | ErrorResponse
+    | ErrorResponse
+    | ErrorResponse
+    | ErrorResponse
+    | ErrorResponse,
+>(
+  approvalId: string,
+  options?: {
+    swr?: SWRMutationConfiguration<
+      Awaited<ReturnType<typeof decideApproval>>,
+      TError,
+      Key,
+      DecideApprovalBody,
+      Awaited<ReturnType<typeof decideApproval>>
+    > & { swrKey?: string };
+  },
+) => {
+  const { swr: swrOptions } = options ?? {};
+
+  const swrKey =
+    swrOptions?.swrKey ?? getDecideApprovalMutationKey(approvalId);
+  const swrFn = getDecideApprovalMutationFetcher(approvalId);
+
+  const query = useSWRMutation(swrKey, swrFn, swrOptions);
+
+  return {
+    swrKey,
+    ...query,
+  };
+};
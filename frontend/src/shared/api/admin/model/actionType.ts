@@ -13,8 +13,10 @@ export const ActionType = {
   plan: "plan",
   externalSearch: "externalSearch",
   internalSearch: "internalSearch",
+  dataAnalysis: "dataAnalysis",
   analyze: "analyze",
   write: "write",
   review: "review",
   done: "done",
+  reflection: "reflection",
 } as const;
@@ -0,0 +1,16 @@
+/**
+ * Generated by orval v7.11.2 🍺
+ * Do not edit manually.
+ * AI-Consultant Admin API
+ * AI-Consultant Admin API
+ * OpenAPI spec version: 1.0.0
+ */
+
+export interface ActionUsage {
+  actionType: string;
+  calls: number;
+  inputTokens: number;
+  outputTokens: number;
+  totalTokens: number;
+  cost: number;
+}
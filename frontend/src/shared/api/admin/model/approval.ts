@@ -0,0 +1,25 @@
+/**
+ * Generated by orval v7.11.2 🍺
+ * Do not edit manually.
+ * AI-Consultant Admin API
+ * AI-Consultant Admin API
+ * OpenAPI spec version: 1.0.0
+ */
+
+import type { ApprovalGate } from "./approvalGate";
+import type { ApprovalDecision } from "./approvalDecision";
+
+export interface Approval {
+  id: string;
+  problemId: string;
+  /** Action after which the proposal job waits for a human decision */
+  actionType: ApprovalGate;
+  /** Output of the action waiting for approval */
+  output: string;
+  decision: ApprovalDecision;
+  feedback: string;
+  /** Goal edited by the reviewer */
+  goal?: string;
+  createdAt: string;
+  decidedAt?: string;
+}
@@ -0,0 +1,18 @@
+/**
+ * Generated by orval v7.11.2 🍺
+ * Do not edit manually.
+ * AI-Consultant Admin API
+ * AI-Consultant Admin API
+ * OpenAPI spec version: 1.0.0
+ */
+
+export type ApprovalDecision =
+  (typeof ApprovalDecision)[keyof typeof ApprovalDecision];
+
+// eslint-disable-next-line @typescript-eslint/no-redeclare
+export const ApprovalDecision = {
+  pending: "pending",
+  approve: "approve",
+  revise: "revise",
+  expired: "expired",
+} as const;
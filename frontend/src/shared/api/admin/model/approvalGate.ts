@@ -0,0 +1,18 @@
+/**
+ * Generated by orval v7.11.2 🍺
+ * Do not edit manually.
+ * AI-Consultant Admin API
+ * AI-Consultant Admin API
+ * OpenAPI spec version: 1.0.0
+ */
+
+/**
+ * Action after which the proposal job waits for a human decision
+ */
+export type ApprovalGate = (typeof ApprovalGate)[keyof typeof ApprovalGate];
+
+// eslint-disable-next-line @typescript-eslint/no-redeclare
+export const ApprovalGate = {
+  plan: "plan",
+  review: "review",
+} as const;
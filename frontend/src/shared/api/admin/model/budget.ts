@@ -0,0 +1,22 @@
+/**
+ * Generated by orval v7.11.2 🍺
+ * Do not edit manually.
+ * AI-Consultant Admin API
+ * AI-Consultant Admin API
+ * OpenAPI spec version: 1.0.0
+ */
+
+/**
+ * Limits of a proposal job. 0 means unlimited.
+ */
+export interface Budget {
+  /** @minimum 0 */
+  maxTokens: number;
+  /**
+   * Maximum cost in USD
+   * @minimum 0
+   */
+  maxCost: number;
+  /** @minimum 0 */
+  maxDurationSeconds: number;
+}
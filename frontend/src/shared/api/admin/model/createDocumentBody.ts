@@ -12,4 +12,6 @@ export type CreateDocumentBody = {
   documentType: DocumentType;
   /** File data in base64 */
   data: string;
+  /** Collection the document belongs to */
+  collection?: string;
 };
@@ -0,0 +1,17 @@
+/**
+ * Generated by orval v7.11.2 🍺
+ * Do not edit manually.
+ * AI-Consultant Admin API
+ * AI-Consultant Admin API
+ * OpenAPI spec version: 1.0.0
+ */
+
+import type { ApprovalDecision } from "./approvalDecision";
+
+export type DecideApprovalBody = {
+  decision: ApprovalDecision;
+  /** Guidance added to the history of the proposal job */
+  feedback: string;
+  /** Replaces the goal of the proposal job when given */
+  goal?: string;
+};
@@ -17,6 +17,8 @@ export interface Document {
   objectName: string;
   documentStatus: DocumentStatus;
   retryCount: number;
+  /** Collection the document belongs to. Empty when the document is not grouped. */
+  collection: string;
   createdAt: string;
   updatedAt: string;
 }
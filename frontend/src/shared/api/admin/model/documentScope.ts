@@ -0,0 +1,15 @@
+/**
+ * Generated by orval v7.11.2 🍺
+ * Do not edit manually.
+ * AI-Consultant Admin API
+ * AI-Consultant Admin API
+ * OpenAPI spec version: 1.0.0
+ */
+
+/**
+ * Documents the internal search of a proposal job may read. All documents are searched when both are empty.
+ */
+export interface DocumentScope {
+  collections: string[];
+  documentIds: string[];
+}
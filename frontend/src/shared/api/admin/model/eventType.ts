@@ -13,4 +13,6 @@ export const EventType = {
   action: "action",
   input: "input",
   output: "output",
+  delta: "delta",
+  goalRevision: "goalRevision",
 } as const;
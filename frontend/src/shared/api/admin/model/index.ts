@@ -8,24 +8,35 @@
 
 export * from "./action";
 export * from "./actionType";
+export * from "./actionUsage";
+export * from "./approval";
+export * from "./approvalDecision";
+export * from "./approvalGate";
+export * from "./budget";
 export * from "./createDocumentBody";
 export * from "./createDocumentSuccessResponse";
 export * from "./createHearingSuccessResponse";
 export * from "./createProblemBody";
 export * from "./createProblemSuccessResponse";
+export * from "./decideApprovalBody";
+export * from "./decideApprovalSuccessResponse";
 export * from "./document";
+export * from "./documentScope";
 export * from "./documentStatus";
 export * from "./documentType";
 export * from "./errorCode";
 export * from "./errorResponse";
 export * from "./event";
 export * from "./eventType";
+export * from "./exportTraceSuccessResponse";
 export * from "./getDocumentSuccessResponse";
 export * from "./getHearingMapSuccessResponse";
 export * from "./getHearingSuccessResponse";
 export * from "./getJobConfigSuccessResponse";
 export * from "./getProblemSuccessResponse";
 export * from "./getReportSuccessResponse";
+export * from "./getTraceSuccessResponse";
+export * from "./getUsageSuccessResponse";
 export * from "./hearing";
 export * from "./hearingMap";
 export * from "./hearingMessage";
@@ -35,9 +46,23 @@ export * from "./listActionsSuccessResponse";
 export * from "./listDocumentsSuccessResponse";
 export * from "./listEventsSuccessResponse";
 export * from "./listHearingMessagesSuccessResponse";
+export * from "./listPendingApprovalsSuccessResponse";
 export * from "./listProblemsSuccessResponse";
+export * from "./llmModel";
+export * from "./llmProvider";
+export * from "./modelConfig";
+export * from "./modelMap";
+export * from "./oTLPTrace";
 export * from "./problem";
 export * from "./problemStatus";
 export * from "./report";
+export * from "./trace";
+export * from "./traceSpan";
+export * from "./traceSpanAttributes";
+export * from "./traceSpanKind";
+export * from "./traceSpanStatus";
 export * from "./updateJobConfigBody";
 export * from "./updateJobConfigSuccessResponse";
+export * from "./usage";
+export * from "./usageSummary";
+export * from "./workflowName";
@@ -6,8 +6,19 @@
  * OpenAPI spec version: 1.0.0
  */
 
+import type { ModelMap } from "./modelMap";
+import type { Budget } from "./budget";
+import type { ApprovalGate } from "./approvalGate";
+import type { WorkflowName } from "./workflowName";
+import type { DocumentScope } from "./documentScope";
+
 export interface JobConfig {
   id: string;
   problemId: string;
   enableInternalSearch: boolean;
+  models: ModelMap;
+  budget: Budget;
+  approvalGates: ApprovalGate[];
+  workflow: WorkflowName;
+  documentScope: DocumentScope;
 }
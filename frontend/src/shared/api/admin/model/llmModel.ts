@@ -0,0 +1,16 @@
+/**
+ * Generated by orval v7.11.2 🍺
+ * Do not edit manually.
+ * AI-Consultant Admin API
+ * AI-Consultant Admin API
+ * OpenAPI spec version: 1.0.0
+ */
+
+export type LlmModel = (typeof LlmModel)[keyof typeof LlmModel];
+
+// eslint-disable-next-line @typescript-eslint/no-redeclare
+export const LlmModel = {
+  "gpt-4o": "gpt-4o",
+  "gpt-5": "gpt-5",
+  "gemini-25-flash": "gemini-2.5-flash",
+} as const;
@@ -0,0 +1,15 @@
+/**
+ * Generated by orval v7.11.2 🍺
+ * Do not edit manually.
+ * AI-Consultant Admin API
+ * AI-Consultant Admin API
+ * OpenAPI spec version: 1.0.0
+ */
+
+export type LlmProvider = (typeof LlmProvider)[keyof typeof LlmProvider];
+
+// eslint-disable-next-line @typescript-eslint/no-redeclare
+export const LlmProvider = {
+  openai: "openai",
+  vertexai: "vertexai",
+} as const;
@@ -0,0 +1,15 @@
+/**
+ * Generated by orval v7.11.2 🍺
+ * Do not edit manually.
+ * AI-Consultant Admin API
+ * AI-Consultant Admin API
+ * OpenAPI spec version: 1.0.0
+ */
+
+import type { LlmModel } from "./llmModel";
+import type { LlmProvider } from "./llmProvider";
+
+export interface ModelConfig {
+  provider: LlmProvider;
+  model: LlmModel;
+}
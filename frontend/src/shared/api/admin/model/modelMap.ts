@@ -0,0 +1,16 @@
+/**
+ * Generated by orval v7.11.2 🍺
+ * Do not edit manually.
+ * AI-Consultant Admin API
+ * AI-Consultant Admin API
+ * OpenAPI spec version: 1.0.0
+ */
+
+import type { ModelConfig } from "./modelConfig";
+
+/**
+ * Model per action type. The "default" key is used for actions without an entry.
+ */
+export interface ModelMap {
+  [key: string]: ModelConfig;
+}
@@ -0,0 +1,14 @@
+/**
+ * Generated by orval v7.11.2 🍺
+ * Do not edit manually.
+ * AI-Consultant Admin API
+ * AI-Consultant Admin API
+ * OpenAPI spec version: 1.0.0
+ */
+
+/**
+ * OTLP/JSON trace (resourceSpans) that can be sent to an OpenTelemetry collector.
+ */
+export interface OTLPTrace {
+  [key: string]: unknown;
+}
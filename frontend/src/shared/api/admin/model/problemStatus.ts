@@ -15,4 +15,6 @@ export const ProblemStatus = {
   processing: "processing",
   done: "done",
   failed: "failed",
+  cancelled: "cancelled",
+  paused: "paused",
 } as const;
@@ -0,0 +1,15 @@
+/**
+ * Generated by orval v7.11.2 🍺
+ * Do not edit manually.
+ * AI-Consultant Admin API
+ * AI-Consultant Admin API
+ * OpenAPI spec version: 1.0.0
+ */
+
+import type { TraceSpan } from "./traceSpan";
+
+export interface Trace {
+  problemId: string;
+  /** Root spans. Each run of the proposal job is a root. */
+  spans: TraceSpan[];
+}
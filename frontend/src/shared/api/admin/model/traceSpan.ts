@@ -0,0 +1,25 @@
+/**
+ * Generated by orval v7.11.2 🍺
+ * Do not edit manually.
+ * AI-Consultant Admin API
+ * AI-Consultant Admin API
+ * OpenAPI spec version: 1.0.0
+ */
+
+import type { TraceSpanKind } from "./traceSpanKind";
+import type { TraceSpanStatus } from "./traceSpanStatus";
+import type { TraceSpanAttributes } from "./traceSpanAttributes";
+
+export interface TraceSpan {
+  id: string;
+  parentId?: string;
+  name: string;
+  kind: TraceSpanKind;
+  status: TraceSpanStatus;
+  error: string;
+  attributes: TraceSpanAttributes;
+  startedAt: string;
+  endedAt: string;
+  durationMs: number;
+  children: TraceSpan[];
+}
@@ -0,0 +1,20 @@
+/**
+ * Generated by orval v7.11.2 🍺
+ * Do not edit manually.
+ * AI-Consultant Admin API
+ * AI-Consultant Admin API
+ * OpenAPI spec version: 1.0.0
+ */
+
+export type TraceSpanKind =
+  (typeof TraceSpanKind)[keyof typeof TraceSpanKind];
+
+// eslint-disable-next-line @typescript-eslint/no-redeclare
+export const TraceSpanKind = {
+  run: "run",
+  step: "step",
+  decision: "decision",
+  action: "action",
+  tool: "tool",
+  llm: "llm",
+} as const;
@@ -0,0 +1,16 @@
+/**
+ * Generated by orval v7.11.2 🍺
+ * Do not edit manually.
+ * AI-Consultant Admin API
+ * AI-Consultant Admin API
+ * OpenAPI spec version: 1.0.0
+ */
+
+export type TraceSpanStatus =
+  (typeof TraceSpanStatus)[keyof typeof TraceSpanStatus];
+
+// eslint-disable-next-line @typescript-eslint/no-redeclare
+export const TraceSpanStatus = {
+  ok: "ok",
+  error: "error",
+} as const;
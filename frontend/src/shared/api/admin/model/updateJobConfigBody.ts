@@ -6,6 +6,17 @@
  * OpenAPI spec version: 1.0.0
  */
 
+import type { ModelMap } from "./modelMap";
+import type { Budget } from "./budget";
+import type { ApprovalGate } from "./approvalGate";
+import type { WorkflowName } from "./workflowName";
+import type { DocumentScope } from "./documentScope";
+
 export type UpdateJobConfigBody = {
   enableInternalSearch: boolean;
+  models?: ModelMap;
+  budget?: Budget;
+  approvalGates?: ApprovalGate[];
+  workflow?: WorkflowName;
+  documentScope?: DocumentScope;
 };
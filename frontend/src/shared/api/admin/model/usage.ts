@@ -0,0 +1,17 @@
+/**
+ * Generated by orval v7.11.2 🍺
+ * Do not edit manually.
+ * AI-Consultant Admin API
+ * AI-Consultant Admin API
+ * OpenAPI spec version: 1.0.0
+ */
+
+import type { UsageSummary } from "./usageSummary";
+import type { ActionUsage } from "./actionUsage";
+
+export interface Usage {
+  problemId: string;
+  currency: string;
+  total: UsageSummary;
+  actions: ActionUsage[];
+}
@@ -0,0 +1,12 @@
+/**
+ * Generated by orval v7.11.2 🍺
+ * Do not edit manually.
+ * AI-Consultant Admin API
+ * AI-Consultant Admin API
+ * OpenAPI spec version: 1.0.0
+ */
+
+/**
+ * Name of the agent workflow, e.g. standard or quickMemo
+ */
+export type WorkflowName = string;
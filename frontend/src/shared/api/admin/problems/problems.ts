@@ -255,3 +255,67 @@ export const useDeleteProblem = <
     ...query,
   };
 };
+/**
+ * @summary Cancel the running proposal job of a problem
+ */
+export const cancelProblem = (problemId: string) => {
+  return adminApiClient<null>({
+    url: `/api/problems/${problemId}/cancel`,
+    method: "POST",
+  });
+};
+
+export const getCancelProblemMutationFetcher = (problemId: string) => {
+  return (_: Key, __: { arg: Arguments }): Promise<null> => {
+    return cancelProblem(problemId);
+  };
+};
+export const getCancelProblemMutationKey = (problemId: string) =>
+  [`/api/problems/${problemId}/cancel`] as const;
+
+export type CancelProblemMutationResult = NonNullable<
+  Awaited<ReturnType<typeof cancelProblem>>
+>;
+export type CancelProblemMutationError =
+  | ErrorResponse
+  | ErrorResponse
+  | ErrorResponse
+  | ErrorResponse
+  | ErrorResponse
+  | ErrorResponse;
+
+/**
+ * @summary Cancel the running proposal job of a problem
+ */
+export const useCancelProblem = <
+  TError =
+    | ErrorResponse
+    | ErrorResponse
+    | ErrorResponse
+    | ErrorResponse
+    | ErrorResponse
+    | ErrorResponse,
+>(
+  problemId: string,
+  options?: {
+    swr?: SWRMutationConfiguration<
+      Awaited<ReturnType<typeof cancelProblem>>,
+      TError,
+      Key,
+      Arguments,
+      Awaited<ReturnType<typeof cancelProblem>>
+    > & { swrKey?: string };
+  },
+) => {
+  const { swr: swrOptions } = options ?? {};
+
+  const swrKey = swrOptions?.swrKey ?? getCancelProblemMutationKey(problemId);
+  const swrFn = getCancelProblemMutationFetcher(problemId);
+
+  const query = useSWRMutation(swrKey, swrFn, swrOptions);
+
+  return {
+    swrKey,
+    ...query,
+  };
+};
@@ -0,0 +1,140 @@
+/**
+ * Generated by orval v7.11.2 🍺
+ * Do not edit manually.
+ * AI-Consultant Admin API
+ * AI-Consultant Admin API
+ * OpenAPI spec version: 1.0.0
+ */
+
+import type { Key, SWRConfiguration } from "swr";
+import useSwr from "swr";
+import { adminApiClient } from "../../client";
+import type {
+  ErrorResponse,
+  ExportTraceSuccessResponse,
+  GetTraceSuccessResponse,
+} from ".././model";
+
+/**
+ * @summary Get the execution trace of the proposal job as a span tree by problem id
+ */
+export const getTrace = (problemId: string) => {
+  return adminApiClient<GetTraceSuccessResponse>({
+    url: `/api/traces/${problemId}`,
+    method: "GET",
+  });
+};
+
+export const getGetTraceKey = (problemId: string) =>
+  [`/api/traces/${problemId}`] as const;
+
+export type GetTraceQueryResult = NonNullable<
+  Awaited<ReturnType<typeof getTrace>>
+>;
+export type GetTraceQueryError =
+  | ErrorResponse
+  | ErrorResponse
+  | ErrorResponse
+  | ErrorResponse
+  | ErrorResponse;
+
+/**
+ * @summary Get the execution trace of the proposal job as a span tree by problem id
+ */
+export const useGetTrace = <
+  TError =
+    | ErrorResponse
+    | ErrorResponse
+    | ErrorResponse
+    | ErrorResponse
+    | ErrorResponse,
+>(
+  problemId: string,
+  options?: {
+    swr?: SWRConfiguration<Awaited<ReturnType<typeof getTrace>>, TError> & {
+      swrKey?: Key;
+      enabled?: boolean;
+    };
+  },
+) => {
+  const { swr: swrOptions } = options ?? {};
+
+  const isEnabled = swrOptions?.enabled !== false && !!problemId;
+  const swrKey =
+    swrOptions?.swrKey ??
+    (() => (isEnabled ? getGetTraceKey(problemId) : null));
+  const swrFn = () => getTrace(problemId);
+
+  const query = useSwr<Awaited<ReturnType<typeof swrFn>>, TError>(
+    swrKey,
+    swrFn,
+    swrOptions,
+  );
+
+  return {
+    swrKey,
+    ...query,
+  };
+};
+
+/**
+ * @summary Export the execution trace of the proposal job in the OTLP/JSON format by problem id
+ */
+export const exportTrace = (problemId: string) => {
+  return adminApiClient<ExportTraceSuccessResponse>({
+    url: `/api/traces/${problemId}/otlp`,
+    method: "GET",
+  });
+};
+
+export const getExportTraceKey = (problemId: string) =>
+  [`/api/traces/${problemId}/otlp`] as const;
+
+export type ExportTraceQueryResult = NonNullable<
+  Awaited<ReturnType<typeof exportTrace>>
+>;
+export type ExportTraceQueryError =
+  | ErrorResponse
+  | ErrorResponse
+  | ErrorResponse
+  | ErrorResponse
+  | ErrorResponse;
+
+/**
+ * @summary Export the execution trace of the proposal job in the OTLP/JSON format by problem id
+ */
+export const useExportTrace = <
+  TError =
+    | ErrorResponse
+    | ErrorResponse
+    | ErrorResponse
+    | ErrorResponse
+    | ErrorResponse,
+>(
+  problemId: string,
+  options?: {
+    swr?: SWRConfiguration<Awaited<ReturnType<typeof exportTrace>>, TError> & {
+      swrKey?: Key;
+      enabled?: boolean;
+    };
+  },
+) => {
+  const { swr: swrOptions } = options ?? {};
+
+  const isEnabled = swrOptions?.enabled !== false && !!problemId;
+  const swrKey =
+    swrOptions?.swrKey ??
+    (() => (isEnabled ? getExportTraceKey(problemId) : null));
+  const swrFn = () => exportTrace(problemId);
+
+  const query = useSwr<Awaited<ReturnType<typeof swrFn>>, TError>(
+    swrKey,
+    swrFn,
+    swrOptions,
+  );
+
+  return {
+    swrKey,
+    ...query,
+  };
+};
@@ -0,0 +1,74 @@
+/**
+ * Generated by orval v7.11.2 🍺
+ * Do not edit manually.
+ * AI-Consultant Admin API
+ * AI-Consultant Admin API
+ * OpenAPI spec version: 1.0.0
+ */
+
+import type { Key, SWRConfiguration } from "swr";
+import useSwr from "swr";
+import { adminApiClient } from "../../client";
+import type { ErrorResponse, GetUsageSuccessResponse } from ".././model";
+
+/**
+ * @summary Get llm usage and cost by problem id
+ */
+export const getUsage = (problemId: string) => {
+  return adminApiClient<GetUsageSuccessResponse>({
+    url: `/api/usages/${problemId}`,
+    method: "GET",
+  });
+};
+
+export const getGetUsageKey = (problemId: string) =>
+  [`/api/usages/${problemId}`] as const;
+
+export type GetUsageQueryResult = NonNullable<
+  Awaited<ReturnType<typeof getUsage>>
+>;
+export type GetUsageQueryError =
+  | ErrorResponse
+  | ErrorResponse
+  | ErrorResponse
+  | ErrorResponse
+  | ErrorResponse;
+
+/**
+ * @summary Get llm usage and cost by problem id
+ */
+export const useGetUsage = <
+  TError =
+    | ErrorResponse
+    | ErrorResponse
+    | ErrorResponse
+    | ErrorResponse
+    | ErrorResponse,
+>(
+  problemId: string,
+  options?: {
+    swr?: SWRConfiguration<Awaited<ReturnType<typeof getUsage>>, TError> & {
+      swrKey?: Key;
+      enabled?: boolean;
+    };
+  },
+) => {
+  const { swr: swrOptions } = options ?? {};
+
+  const isEnabled = swrOptions?.enabled !== false && !!problemId;
+  const swrKey =
+    swrOptions?.swrKey ??
+    (() => (isEnabled ? getGetUsageKey(problemId) : null));
+  const swrFn = () => getUsage(problemId);
+
+  const query = useSwr<Awaited<ReturnType<typeof swrFn>>, TError>(
+    swrKey,
+    swrFn,
+    swrOptions,
+  );
+
+  return {
+    swrKey,
+    ...query,
+  };
+};
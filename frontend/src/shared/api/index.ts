@@ -1,3 +1,4 @@
+export * from "./admin/approvals/approvals";
 export * from "./admin/documents/documents";
 export * from "./admin/events/events";
 export * from "./admin/hearing-maps/hearing-maps";
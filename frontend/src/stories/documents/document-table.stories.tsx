@@ -23,6 +23,7 @@ const sampleDocuments: DocumentResponse[] = [
     objectName: "a/req.md",
     documentStatus: DocumentStatus.processing,
     retryCount: 0,
+    collection: "",
     createdAt: now,
     updatedAt: now,
   },
@@ -34,6 +35,7 @@ const sampleDocuments: DocumentResponse[] = [
     objectName: "b/design.pdf",
     documentStatus: DocumentStatus.done,
     retryCount: 0,
+    collection: "",
     createdAt: now,
     updatedAt: now,
   },
@@ -45,6 +47,7 @@ const sampleDocuments: DocumentResponse[] = [
     objectName: "c/schema.csv",
     documentStatus: DocumentStatus.failed,
     retryCount: 1,
+    collection: "",
     createdAt: now,
     updatedAt: now,
   },
@@ -56,6 +59,7 @@ const sampleDocuments: DocumentResponse[] = [
     objectName: "c/schema.csv",
     documentStatus: DocumentStatus.failed,
     retryCount: 1,
+    collection: "",
     createdAt: now,
     updatedAt: now,
   },
@@ -67,6 +71,7 @@ const sampleDocuments: DocumentResponse[] = [
     objectName: "c/schema.csv",
     documentStatus: DocumentStatus.failed,
     retryCount: 1,
+    collection: "",
     createdAt: now,
     updatedAt: now,
   },
@@ -78,6 +83,7 @@ const sampleDocuments: DocumentResponse[] = [
     objectName: "c/schema.csv",
     documentStatus: DocumentStatus.failed,
     retryCount: 1,
+    collection: "",
     createdAt: now,
     updatedAt: now,
   },
@@ -89,6 +95,7 @@ const sampleDocuments: DocumentResponse[] = [
     objectName: "c/schema.pdf",
     documentStatus: DocumentStatus.processing,
     retryCount: 1,
+    collection: "",
     createdAt: now,
     updatedAt: now,
   },
@@ -100,6 +107,7 @@ const sampleDocuments: DocumentResponse[] = [
     objectName: "c/schema.csv",
     documentStatus: DocumentStatus.processing,
     retryCount: 1,
+    collection: "",
     createdAt: now,
     updatedAt: now,
   },
@@ -111,6 +119,7 @@ const sampleDocuments: DocumentResponse[] = [
     objectName: "c/schema.pdf",
     documentStatus: DocumentStatus.processing,
     retryCount: 1,
+    collection: "",
     createdAt: now,
     updatedAt: now,
   },
@@ -122,6 +131,7 @@ const sampleDocuments: DocumentResponse[] = [
     objectName: "c/schema.pdf",
     documentStatus: DocumentStatus.done,
     retryCount: 1,
+    collection: "",
     createdAt: now,
     updatedAt: now,
   },
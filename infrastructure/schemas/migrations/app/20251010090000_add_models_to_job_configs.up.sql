@@ -0,0 +1 @@
+ALTER TABLE job_configs ADD COLUMN models JSONB NOT NULL DEFAULT '{}'::jsonb;
@@ -0,0 +1,13 @@
+CREATE TABLE llm_usages (
+    id UUID PRIMARY KEY,
+    problem_id UUID NOT NULL REFERENCES problems(id) ON DELETE CASCADE,
+    action_type TEXT NOT NULL,
+    provider TEXT NOT NULL,
+    model TEXT NOT NULL,
+    input_tokens INTEGER NOT NULL,
+    output_tokens INTEGER NOT NULL,
+    total_tokens INTEGER NOT NULL,
+    created_at timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_llm_usages_problem_id ON llm_usages(problem_id);
@@ -0,0 +1,4 @@
+ALTER TABLE job_configs
+    DROP COLUMN budget_max_tokens,
+    DROP COLUMN budget_max_cost,
+    DROP COLUMN budget_max_duration_seconds;
@@ -0,0 +1,4 @@
+ALTER TABLE job_configs
+    ADD COLUMN budget_max_tokens INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN budget_max_cost DOUBLE PRECISION NOT NULL DEFAULT 0,
+    ADD COLUMN budget_max_duration_seconds INTEGER NOT NULL DEFAULT 0;
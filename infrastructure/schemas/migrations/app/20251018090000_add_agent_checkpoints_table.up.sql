@@ -0,0 +1,10 @@
+CREATE TABLE agent_checkpoints (
+    id UUID PRIMARY KEY,
+    problem_id UUID NOT NULL REFERENCES problems(id) ON DELETE CASCADE,
+    step INTEGER NOT NULL,
+    state JSONB NOT NULL,
+    fail_count INTEGER NOT NULL DEFAULT 0,
+    elapsed_ms BIGINT NOT NULL DEFAULT 0,
+    created_at timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (problem_id, step)
+);
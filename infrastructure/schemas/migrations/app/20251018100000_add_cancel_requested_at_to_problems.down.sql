@@ -0,0 +1 @@
+ALTER TABLE problems DROP COLUMN IF EXISTS cancel_requested_at;
@@ -0,0 +1 @@
+ALTER TABLE problems ADD COLUMN cancel_requested_at timestamptz;
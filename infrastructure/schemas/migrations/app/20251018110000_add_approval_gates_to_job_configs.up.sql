@@ -0,0 +1 @@
+ALTER TABLE job_configs ADD COLUMN approval_gates TEXT[] NOT NULL DEFAULT '{}';
@@ -0,0 +1,15 @@
+CREATE TABLE approvals (
+    id UUID PRIMARY KEY,
+    problem_id UUID NOT NULL REFERENCES problems(id) ON DELETE CASCADE,
+    action_type TEXT NOT NULL,
+    step INTEGER NOT NULL,
+    output TEXT NOT NULL DEFAULT '',
+    decision TEXT NOT NULL DEFAULT 'pending',
+    feedback TEXT NOT NULL DEFAULT '',
+    goal TEXT,
+    created_at timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    decided_at timestamptz,
+    applied_at timestamptz
+);
+
+CREATE INDEX idx_approvals_problem_id ON approvals(problem_id);
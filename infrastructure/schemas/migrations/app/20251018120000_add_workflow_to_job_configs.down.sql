@@ -0,0 +1 @@
+ALTER TABLE job_configs DROP COLUMN IF EXISTS workflow;
@@ -0,0 +1 @@
+ALTER TABLE job_configs ADD COLUMN workflow TEXT NOT NULL DEFAULT 'standard';
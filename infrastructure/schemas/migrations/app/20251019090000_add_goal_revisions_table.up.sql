@@ -0,0 +1,10 @@
+CREATE TABLE goal_revisions (
+    id UUID PRIMARY KEY,
+    problem_id UUID NOT NULL REFERENCES problems(id) ON DELETE CASCADE,
+    version INTEGER NOT NULL,
+    goal TEXT NOT NULL,
+    reason TEXT NOT NULL DEFAULT '',
+    action_type TEXT NOT NULL,
+    created_at timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (problem_id, version)
+);
@@ -0,0 +1,14 @@
+CREATE TABLE trace_spans (
+    id UUID PRIMARY KEY,
+    problem_id UUID NOT NULL REFERENCES problems(id) ON DELETE CASCADE,
+    parent_id UUID,
+    name TEXT NOT NULL,
+    kind TEXT NOT NULL,
+    status TEXT NOT NULL,
+    error TEXT NOT NULL DEFAULT '',
+    attributes JSONB NOT NULL DEFAULT '{}'::jsonb,
+    started_at timestamptz NOT NULL,
+    ended_at timestamptz NOT NULL
+);
+
+CREATE INDEX idx_trace_spans_problem_id ON trace_spans(problem_id);
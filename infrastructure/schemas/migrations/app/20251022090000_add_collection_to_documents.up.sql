@@ -0,0 +1,3 @@
+ALTER TABLE documents ADD COLUMN collection TEXT NOT NULL DEFAULT '';
+
+CREATE INDEX idx_documents_collection ON documents(collection);
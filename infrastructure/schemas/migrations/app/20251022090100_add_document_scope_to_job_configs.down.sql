@@ -0,0 +1,2 @@
+ALTER TABLE job_configs DROP COLUMN IF EXISTS allowed_document_ids;
+ALTER TABLE job_configs DROP COLUMN IF EXISTS allowed_collections;
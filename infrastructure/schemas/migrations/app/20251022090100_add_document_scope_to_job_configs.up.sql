@@ -0,0 +1,2 @@
+ALTER TABLE job_configs ADD COLUMN allowed_collections TEXT[] NOT NULL DEFAULT '{}';
+ALTER TABLE job_configs ADD COLUMN allowed_document_ids TEXT[] NOT NULL DEFAULT '{}';
@@ -0,0 +1 @@
+ALTER TABLE agent_checkpoints DROP COLUMN IF EXISTS run_started_at;
@@ -0,0 +1 @@
+ALTER TABLE agent_checkpoints ADD COLUMN run_started_at timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP;
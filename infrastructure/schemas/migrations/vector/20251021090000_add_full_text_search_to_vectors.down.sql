@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_vectors_content_tsv;
+ALTER TABLE vectors DROP COLUMN IF EXISTS content_tsv;
+DROP FUNCTION IF EXISTS bigram_tsquery(TEXT);
+DROP FUNCTION IF EXISTS bigram_tokens(TEXT);
@@ -0,0 +1,29 @@
+-- 日本語は単語の区切りがないため、英数字は単語単位、それ以外は2文字ずつ (bigram) に分割して索引を作る
+CREATE OR REPLACE FUNCTION bigram_tokens(input TEXT) RETURNS TEXT[]
+LANGUAGE sql IMMUTABLE PARALLEL SAFE AS $$
+    SELECT COALESCE(array_agg(DISTINCT token), '{}')
+    FROM (
+        SELECT word[1] AS token
+        FROM regexp_matches(lower(input), '[a-z0-9]+', 'g') AS word
+        UNION ALL
+        SELECT substr(run[1], n, 2) AS token
+        FROM regexp_matches(lower(input), '[^[:space:][:punct:]a-z0-9]+', 'g') AS run,
+            generate_series(1, greatest(char_length(run[1]) - 1, 1)) AS n
+    ) AS tokens
+$$;
+
+-- 検索語のトークンをいずれか含む行に一致する tsquery を作る
+CREATE OR REPLACE FUNCTION bigram_tsquery(input TEXT) RETURNS TSQUERY
+LANGUAGE sql IMMUTABLE PARALLEL SAFE AS $$
+    SELECT COALESCE(
+        NULLIF(array_to_string(array(
+            SELECT '''' || replace(replace(token, '\', '\\'), '''', '''''') || ''''
+            FROM unnest(bigram_tokens(input)) AS token
+        ), ' | '), ''),
+        ''
+    )::tsquery
+$$;
+
+ALTER TABLE vectors ADD COLUMN content_tsv TSVECTOR GENERATED ALWAYS AS (array_to_tsvector(bigram_tokens(content))) STORED;
+
+CREATE INDEX IF NOT EXISTS idx_vectors_content_tsv ON vectors USING gin(content_tsv);
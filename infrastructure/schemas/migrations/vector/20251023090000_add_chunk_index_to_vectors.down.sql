@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_vectors_document_id;
+ALTER TABLE vectors DROP COLUMN IF EXISTS chunk_index;
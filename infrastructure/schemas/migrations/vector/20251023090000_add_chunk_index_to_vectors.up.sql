@@ -0,0 +1,14 @@
+ALTER TABLE vectors ADD COLUMN chunk_index INTEGER NOT NULL DEFAULT 0;
+
+-- 既存のチャンクにドキュメントごとの連番を振り、引用 (document://<id>#chunk-<n>) が重複しないようにする。
+-- vectors には作成日時がなく id もランダムなため、挿入順に近い物理順 (ctid) を使う。
+-- 正確な順序が必要な場合はドキュメントを再同期する
+UPDATE vectors
+SET chunk_index = numbered.chunk_index
+FROM (
+    SELECT ctid, row_number() OVER (PARTITION BY document_id ORDER BY ctid) - 1 AS chunk_index
+    FROM vectors
+) AS numbered
+WHERE vectors.ctid = numbered.ctid;
+
+CREATE INDEX IF NOT EXISTS idx_vectors_document_id ON vectors(document_id);